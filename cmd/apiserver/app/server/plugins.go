@@ -25,9 +25,12 @@ import (
 	// Admission controllers
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/broker/authsarcheck"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/namespace/lifecycle"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/namespace/quota"
 	siclifecycle "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/servicebindings/lifecycle"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/changevalidator"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/defaultserviceplan"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/parameterspolicy"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/plansarcheck"
 )
 
 // registerAllAdmissionPlugins registers all admission plugins
@@ -37,4 +40,7 @@ func registerAllAdmissionPlugins(plugins *admission.Plugins) {
 	siclifecycle.Register(plugins)
 	changevalidator.Register(plugins)
 	authsarcheck.Register(plugins)
+	quota.Register(plugins)
+	plansarcheck.Register(plugins)
+	parameterspolicy.Register(plugins)
 }
@@ -25,8 +25,10 @@ import (
 	"os"
 	goruntime "runtime"
 	"strconv"
+	"strings"
 	"time"
 
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -365,6 +367,21 @@ func StartControllers(s *options.ControllerManagerServer,
 	)
 	// All shared informers are v1beta1 API level
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
+	// Informers of Kubernetes resources are only started for the resources
+	// the controller watches.
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(coreClient, s.ResyncInterval)
+	kubeSharedInformers := kubeInformerFactory.Core().V1()
+
+	osbclientproxy.SetDefaultRequestLimits(osbclientproxy.RequestLimits{
+		QPS:                   s.BrokerRequestQPS,
+		Burst:                 s.BrokerRequestBurst,
+		MaxConcurrentRequests: s.BrokerMaxConcurrentRequests,
+	})
+
+	defaultRequestContext, err := newDefaultRequestContext(s)
+	if err != nil {
+		return err
+	}
 
 	glog.V(5).Infof("Creating controller; broker relist interval: %v", s.ServiceBrokerRelistInterval)
 	serviceCatalogController, err := controller.NewController(
@@ -378,12 +395,19 @@ func StartControllers(s *options.ControllerManagerServer,
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceGrants(),
+		kubeSharedInformers.Secrets(),
+		kubeSharedInformers.ConfigMaps(),
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Apps().V1().StatefulSets(),
 		osbclientproxy.NewClient,
 		s.ServiceBrokerRelistInterval,
 		s.OSBAPIPreferredVersion,
 		recorder,
 		s.ReconciliationRetryDuration,
 		s.OperationPollingMaximumBackoffDuration,
+		s.BindingRotationGracePeriod,
+		defaultRequestContext,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
 	)
@@ -393,9 +417,11 @@ func StartControllers(s *options.ControllerManagerServer,
 
 	glog.V(1).Info("Starting shared informers")
 	informerFactory.Start(stop)
+	kubeInformerFactory.Start(stop)
 
 	glog.V(5).Info("Waiting for caches to sync")
 	informerFactory.WaitForCacheSync(stop)
+	kubeInformerFactory.WaitForCacheSync(stop)
 
 	glog.V(5).Info("Running controller")
 	go serviceCatalogController.Run(s.ConcurrentSyncs, stop)
@@ -403,6 +429,33 @@ func StartControllers(s *options.ControllerManagerServer,
 	select {}
 }
 
+// newDefaultRequestContext returns the extra fields of the context of the
+// requests to brokers selected by the flags of the controller manager, or
+// nil if none are selected.
+func newDefaultRequestContext(s *options.ControllerManagerServer) (*servicecatalogv1beta1.ServiceBrokerRequestContext, error) {
+	if !s.RequestContextInstance && len(s.RequestContextNamespaceLabels) == 0 &&
+		len(s.RequestContextNamespaceAnnotations) == 0 && len(s.RequestContextStaticFields) == 0 {
+		return nil, nil
+	}
+
+	requestContext := &servicecatalogv1beta1.ServiceBrokerRequestContext{
+		Instance:             s.RequestContextInstance,
+		NamespaceLabels:      s.RequestContextNamespaceLabels,
+		NamespaceAnnotations: s.RequestContextNamespaceAnnotations,
+	}
+	for _, staticField := range s.RequestContextStaticFields {
+		kv := strings.SplitN(staticField, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid request context static field %q, expected key=value", staticField)
+		}
+		if requestContext.StaticFields == nil {
+			requestContext.StaticFields = map[string]string{}
+		}
+		requestContext.StaticFields[kv[0]] = kv[1]
+	}
+	return requestContext, nil
+}
+
 // checkAPIAvailableResourcesServer is a HealthzChecker that makes sure the
 // Service-Catalog APIServer is contactable.
 type checkAPIAvailableResources struct {
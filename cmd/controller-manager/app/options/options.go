@@ -60,6 +60,7 @@ const (
 	defaultLeaderElectionNamespace                = "kube-system"
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
+	defaultBindingRotationGracePeriod             = 10 * time.Minute
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -85,6 +86,7 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			EnableContentionProfiling:              false,
 			ReconciliationRetryDuration:            defaultReconciliationRetryDuration,
 			OperationPollingMaximumBackoffDuration: defaultOperationPollingMaximumBackoffDuration,
+			BindingRotationGracePeriod:             defaultBindingRotationGracePeriod,
 			SecureServingOptions:                   genericoptions.NewSecureServingOptions(),
 		},
 	}
@@ -118,6 +120,14 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.LeaderElectionNamespace, "leader-election-namespace", s.LeaderElectionNamespace, "Namespace to use for leader election lock")
 	fs.DurationVar(&s.ReconciliationRetryDuration, "reconciliation-retry-duration", s.ReconciliationRetryDuration, "The maximum amount of time to retry reconciliations on a resource before failing")
 	fs.DurationVar(&s.OperationPollingMaximumBackoffDuration, "operation-polling-maximum-backoff-duration", s.OperationPollingMaximumBackoffDuration, "The maximum amount of time to back-off while polling an OSB API operation")
+	fs.DurationVar(&s.BindingRotationGracePeriod, "binding-rotation-grace-period", s.BindingRotationGracePeriod, "The amount of time that retired credentials of a rotated binding remain valid before they are unbound at the broker")
+	fs.Float32Var(&s.BrokerRequestQPS, "broker-request-qps", s.BrokerRequestQPS, "The default maximum average number of requests per second sent to each broker; 0 means no limit")
+	fs.IntVar(&s.BrokerRequestBurst, "broker-request-burst", s.BrokerRequestBurst, "The default maximum number of requests sent to each broker at once above --broker-request-qps; defaults to --broker-request-qps")
+	fs.IntVar(&s.BrokerMaxConcurrentRequests, "broker-max-concurrent-requests", s.BrokerMaxConcurrentRequests, "The default maximum number of requests in flight to each broker at the same time; 0 means no limit")
+	fs.BoolVar(&s.RequestContextInstance, "request-context-instance", s.RequestContextInstance, "Add the name and UID of the instance to the context of the requests sent to brokers that select no context fields of their own")
+	fs.StringSliceVar(&s.RequestContextNamespaceLabels, "request-context-namespace-labels", s.RequestContextNamespaceLabels, "The keys of the labels of the namespace of the instance added to the context of the requests sent to brokers that select no context fields of their own")
+	fs.StringSliceVar(&s.RequestContextNamespaceAnnotations, "request-context-namespace-annotations", s.RequestContextNamespaceAnnotations, "The keys of the annotations of the namespace of the instance added to the context of the requests sent to brokers that select no context fields of their own")
+	fs.StringSliceVar(&s.RequestContextStaticFields, "request-context-static-fields", s.RequestContextStaticFields, "The key=value fields added to the context of the requests sent to brokers that select no context fields of their own")
 	s.SecureServingOptions.AddFlags(fs)
 	utilfeature.DefaultFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
@@ -0,0 +1,842 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	v1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	"github.com/kubernetes-incubator/service-catalog/pkg/jsonschema"
+	util "github.com/kubernetes-incubator/service-catalog/test/util"
+)
+
+const (
+	// conformanceGenerateName prefixes the names of the instances and
+	// bindings created by the conformance tests
+	conformanceGenerateName = "conformance-"
+
+	// draft04SchemaURI is the JSON Schema draft that the schemas of plans
+	// must be written in
+	draft04SchemaURI = "http://json-schema.org/draft-04/schema#"
+)
+
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "conformance verifies that a broker behaves as its catalog says",
+	Long: "conformance runs the Open Service Broker API operations against every " +
+		"plan of the ClusterServiceBroker named by --broker-name through Service Catalog, " +
+		"and writes a JUnit or JSON report of the results.  For each plan, it checks that " +
+		"the schemas are valid, and provisions, binds to, unbinds from, updates, changes " +
+		"the plan of and deprovisions an instance, as far as the catalog of the broker says " +
+		"these operations are supported.  The bindings of classes that are bindings " +
+		"retrievable are fetched from the broker directly.  It exits with a non-zero " +
+		"status if any test failed, so it can gate the releases of a broker in CI.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := NewConformanceCheck(options)
+		if err != nil {
+			glog.Errorf("Error initialzing: %v", err)
+			os.Exit(1)
+		}
+
+		report := c.Run()
+		err = writeReport(report, options)
+		if err != nil {
+			glog.Errorf("Error writing report: %v", err)
+			os.Exit(1)
+		}
+
+		if failures := report.Failures(); failures > 0 {
+			glog.Errorf("%d of %d conformance tests failed", failures, len(report.Tests))
+			glog.Flush()
+			os.Exit(1)
+		}
+		glog.Infof("All %d conformance tests passed or were skipped", len(report.Tests))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(conformanceCmd)
+}
+
+// ConformanceCheck runs the conformance tests against the plans of a
+// broker.
+type ConformanceCheck struct {
+	kubeClientSet           kubernetes.Interface
+	serviceCatalogClientSet clientset.Interface
+	brokerName              string
+	timeout                 time.Duration
+	namespace               *corev1.Namespace // ns where we create instances and bindings
+	report                  *Report
+}
+
+// NewConformanceCheck creates a new ConformanceCheck object and initializes
+// the kube and catalog client sets.
+func NewConformanceCheck(s *HealthCheckServer) (*ConformanceCheck, error) {
+	if s.ConformanceReportFormat != reportFormatJUnit && s.ConformanceReportFormat != reportFormatJSON {
+		return nil, fmt.Errorf("invalid conformance-report-format specified: %v.  Valid options are %v and %v", s.ConformanceReportFormat, reportFormatJUnit, reportFormatJSON)
+	}
+
+	c := &ConformanceCheck{
+		brokerName: s.TestBrokerName,
+		timeout:    s.ConformanceTimeout,
+	}
+	var err error
+	c.kubeClientSet, c.serviceCatalogClientSet, err = newClientSets(s)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// writeReport writes the report to the configured file, or to standard
+// output if there is none.
+func writeReport(report *Report, s *HealthCheckServer) error {
+	if s.ConformanceReport == "" {
+		return report.Write(os.Stdout, s.ConformanceReportFormat)
+	}
+	f, err := os.Create(s.ConformanceReport)
+	if err != nil {
+		return err
+	}
+	if err := report.Write(f, s.ConformanceReportFormat); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Run verifies that the broker is ready and runs the conformance tests
+// against each of its plans in a new namespace, which is deleted
+// afterwards.
+func (c *ConformanceCheck) Run() *Report {
+	c.report = NewReport(c.brokerName)
+	startTime := time.Now()
+
+	broker, classes, plans, err := c.getCatalog()
+	if err != nil {
+		c.report.Add("", "", "catalog", TestFailed, err.Error(), startTime)
+		return c.report
+	}
+	c.report.Add("", "", "catalog", TestPassed, fmt.Sprintf("the catalog has %d classes and %d plans", len(classes), len(plans)), startTime)
+
+	c.namespace, err = CreateKubeNamespace(c.kubeClientSet)
+	if err != nil {
+		c.report.Add("", "", "namespace", TestFailed, err.Error(), startTime)
+		return c.report
+	}
+	defer func() {
+		glog.V(4).Infof("Deleting test namespace %v", c.namespace.Name)
+		if err := DeleteKubeNamespace(c.kubeClientSet, c.namespace.Name); err != nil {
+			glog.Errorf("Error deleting namespace %v: %v", c.namespace.Name, err)
+		}
+		c.namespace = nil
+	}()
+
+	for _, class := range classes {
+		var classPlans []*v1beta1.ClusterServicePlan
+		for _, plan := range plans {
+			if plan.Spec.ClusterServiceClassRef.Name == class.Name {
+				classPlans = append(classPlans, plan)
+			}
+		}
+		for _, plan := range classPlans {
+			p := &planCheck{
+				ConformanceCheck: c,
+				broker:           broker,
+				class:            class,
+				plan:             plan,
+			}
+			for _, other := range classPlans {
+				if other != plan {
+					p.otherPlan = other
+					break
+				}
+			}
+			p.run()
+		}
+	}
+	return c.report
+}
+
+// getCatalog waits for the broker to be ready and returns it along with the
+// classes and plans of its catalog, sorted by their external names.
+func (c *ConformanceCheck) getCatalog() (*v1beta1.ClusterServiceBroker, []*v1beta1.ClusterServiceClass, []*v1beta1.ClusterServicePlan, error) {
+	client := c.serviceCatalogClientSet.ServicecatalogV1beta1()
+
+	glog.V(4).Infof("checking for Broker %v to be ready", c.brokerName)
+	err := util.WaitForBrokerCondition(client,
+		c.brokerName,
+		v1beta1.ServiceBrokerCondition{
+			Type:   v1beta1.ServiceBrokerConditionReady,
+			Status: v1beta1.ConditionTrue,
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("broker not ready: %v", err)
+	}
+	broker, err := client.ClusterServiceBrokers().Get(c.brokerName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting broker: %v", err)
+	}
+
+	listOptions := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.clusterServiceBrokerName", c.brokerName).String(),
+	}
+	classList, err := client.ClusterServiceClasses().List(listOptions)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error listing classes: %v", err)
+	}
+	planList, err := client.ClusterServicePlans().List(listOptions)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error listing plans: %v", err)
+	}
+
+	var classes []*v1beta1.ClusterServiceClass
+	for i := range classList.Items {
+		if !classList.Items[i].Status.RemovedFromBrokerCatalog {
+			classes = append(classes, &classList.Items[i])
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Spec.ExternalName < classes[j].Spec.ExternalName
+	})
+	var plans []*v1beta1.ClusterServicePlan
+	for i := range planList.Items {
+		if !planList.Items[i].Status.RemovedFromBrokerCatalog {
+			plans = append(plans, &planList.Items[i])
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Spec.ExternalName < plans[j].Spec.ExternalName
+	})
+
+	if len(classes) == 0 {
+		return nil, nil, nil, fmt.Errorf("the catalog of the broker has no classes")
+	}
+	return broker, classes, plans, nil
+}
+
+// planCheck runs the conformance tests against a single plan.
+type planCheck struct {
+	*ConformanceCheck
+	broker    *v1beta1.ClusterServiceBroker
+	class     *v1beta1.ClusterServiceClass
+	plan      *v1beta1.ClusterServicePlan
+	otherPlan *v1beta1.ClusterServicePlan // another plan of the class, if any
+
+	instanceName string
+	bindingName  string
+}
+
+// run runs the tests in the order of the lifecycle of an instance. Tests
+// are skipped if the catalog says they are not supported, or if a test
+// they depend on failed.
+func (p *planCheck) run() {
+	glog.V(4).Infof("Running conformance tests for plan %v/%v", p.class.Spec.ExternalName, p.plan.Spec.ExternalName)
+	defer p.cleanup()
+
+	p.test("schemas", "", p.checkSchemas)
+
+	provisioned := p.test("provision",
+		requiresNoParameters(p.plan.Spec.ServiceInstanceCreateParameterSchema),
+		p.provision)
+	notProvisioned := ""
+	if !provisioned {
+		notProvisioned = "the instance was not provisioned"
+	}
+
+	bound := p.test("bind",
+		firstReason(notProvisioned, p.bindableReason(), requiresNoParameters(p.plan.Spec.ServiceBindingCreateParameterSchema)),
+		p.bind)
+	notBound := ""
+	if !bound {
+		notBound = "the binding was not created"
+	}
+	p.test("getBinding",
+		firstReason(notBound, p.bindingRetrievableReason()),
+		p.getBinding)
+	p.test("unbind", notBound, p.unbind)
+
+	p.test("update",
+		firstReason(notProvisioned, p.updatableReason(), requiresNoParameters(p.plan.Spec.ServiceInstanceUpdateParameterSchema)),
+		p.update)
+	p.test("planChange",
+		firstReason(notProvisioned, p.planChangeReason()),
+		p.changePlan)
+
+	p.test("deprovision", notProvisioned, p.deprovision)
+}
+
+// test runs the named test and adds its result to the report, unless it is
+// skipped for the given reason. It returns whether the test passed.
+func (p *planCheck) test(name, skipReason string, test func() (string, error)) bool {
+	startTime := time.Now()
+	class, plan := p.class.Spec.ExternalName, p.plan.Spec.ExternalName
+	if skipReason != "" {
+		glog.V(4).Infof("Skipping %v for plan %v/%v: %v", name, class, plan, skipReason)
+		p.report.Add(class, plan, name, TestSkipped, skipReason, startTime)
+		return false
+	}
+
+	glog.V(4).Infof("Running %v for plan %v/%v", name, class, plan)
+	message, err := test()
+	if err != nil {
+		glog.Infof("%v failed for plan %v/%v: %v", name, class, plan, err)
+		p.report.Add(class, plan, name, TestFailed, err.Error(), startTime)
+		return false
+	}
+	p.report.Add(class, plan, name, TestPassed, message, startTime)
+	return true
+}
+
+// firstReason returns the first of the given skip reasons that is not
+// empty.
+func firstReason(reasons ...string) string {
+	for _, reason := range reasons {
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// requiresNoParameters returns a skip reason if the given parameter schema
+// does not accept empty parameters, as the tests cannot make up valid ones.
+func requiresNoParameters(schema *runtime.RawExtension) string {
+	if schema == nil || len(schema.Raw) == 0 {
+		return ""
+	}
+	errs, err := jsonschema.Validate(schema.Raw, map[string]interface{}{}, field.NewPath("parameters"))
+	if err != nil || len(errs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("the plan requires parameters: %v", errs.ToAggregate())
+}
+
+func (p *planCheck) bindableReason() string {
+	bindable := p.class.Spec.Bindable
+	if p.plan.Spec.Bindable != nil {
+		bindable = *p.plan.Spec.Bindable
+	}
+	if !bindable {
+		return "the plan is not bindable"
+	}
+	return ""
+}
+
+func (p *planCheck) bindingRetrievableReason() string {
+	if !p.class.Spec.BindingRetrievable {
+		return "the class is not bindings retrievable"
+	}
+	return ""
+}
+
+func (p *planCheck) updatableReason() string {
+	if !p.class.Spec.PlanUpdatable && p.plan.Spec.ServiceInstanceUpdateParameterSchema == nil {
+		return "the class is not plan updatable and the plan has no update parameter schema"
+	}
+	return ""
+}
+
+func (p *planCheck) planChangeReason() string {
+	if !p.class.Spec.PlanUpdatable {
+		return "the class is not plan updatable"
+	}
+	if p.otherPlan == nil {
+		return "the class has no other plan"
+	}
+	return ""
+}
+
+// checkSchemas checks that the schemas of the plan are valid.
+func (p *planCheck) checkSchemas() (string, error) {
+	schemas := []struct {
+		name   string
+		schema *runtime.RawExtension
+	}{
+		{"instanceCreateParameterSchema", p.plan.Spec.ServiceInstanceCreateParameterSchema},
+		{"instanceUpdateParameterSchema", p.plan.Spec.ServiceInstanceUpdateParameterSchema},
+		{"serviceBindingCreateParameterSchema", p.plan.Spec.ServiceBindingCreateParameterSchema},
+		{"serviceBindingCreateResponseSchema", p.plan.Spec.ServiceBindingCreateResponseSchema},
+	}
+	var checked []string
+	for _, s := range schemas {
+		if s.schema == nil || len(s.schema.Raw) == 0 {
+			continue
+		}
+		if err := checkSchema(s.schema.Raw); err != nil {
+			return "", fmt.Errorf("invalid %v: %v", s.name, err)
+		}
+		checked = append(checked, s.name)
+	}
+	if len(checked) == 0 {
+		return "the plan has no schemas", nil
+	}
+	return fmt.Sprintf("valid schemas: %v", strings.Join(checked, ", ")), nil
+}
+
+// checkSchema checks that a schema of a plan is a JSON Schema draft-04
+// schema for an object, as the Open Service Broker API requires.
+func checkSchema(raw []byte) error {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return fmt.Errorf("the schema is not a JSON object: %v", err)
+	}
+	if uri, ok := schema["$schema"]; ok && uri != draft04SchemaURI && uri != strings.TrimSuffix(draft04SchemaURI, "#") {
+		return fmt.Errorf("the $schema %v is not JSON Schema draft-04", uri)
+	}
+	if t, ok := schema["type"]; ok && t != "object" {
+		return fmt.Errorf("the type of the schema is %v instead of object", t)
+	}
+	return nil
+}
+
+// provision creates an instance of the plan and waits for it to be ready.
+func (p *planCheck) provision() (string, error) {
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: conformanceGenerateName,
+			Namespace:    p.namespace.Name,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassName: p.class.Name,
+				ClusterServicePlanName:  p.plan.Name,
+			},
+		},
+	}
+	instance, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Create(instance)
+	if err != nil {
+		return "", fmt.Errorf("error creating instance: %v", err)
+	}
+	p.instanceName = instance.Name
+
+	instance, async, err := p.waitForInstance(instance.Generation)
+	if err != nil {
+		return "", err
+	}
+	if instance.Spec.ClusterServicePlanRef == nil || instance.Spec.ClusterServicePlanRef.Name != p.plan.Name {
+		return "", fmt.Errorf("ClusterServicePlanRef of the instance does not refer to plan %v", p.plan.Name)
+	}
+	return operationMessage("provisioned", async), nil
+}
+
+// bind creates a binding to the instance, waits for it to be ready and
+// validates the credentials in its secret against the response schema of
+// the plan.
+func (p *planCheck) bind() (string, error) {
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: conformanceGenerateName,
+			Namespace:    p.namespace.Name,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{
+				Name: p.instanceName,
+			},
+		},
+	}
+	binding, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Create(binding)
+	if err != nil {
+		return "", fmt.Errorf("error creating binding: %v", err)
+	}
+	p.bindingName = binding.Name
+
+	binding, async, err := p.waitForBinding(binding.Generation)
+	if err != nil {
+		return "", err
+	}
+	secret, err := p.kubeClientSet.CoreV1().Secrets(p.namespace.Name).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting secret: %v", err)
+	}
+	if schema := p.plan.Spec.ServiceBindingCreateResponseSchema; schema != nil && len(schema.Raw) > 0 {
+		if err := validateCredentials(schema.Raw, secret.Data); err != nil {
+			return "", fmt.Errorf("the credentials do not match the response schema of the plan: %v", err)
+		}
+	}
+	return operationMessage("bound", async), nil
+}
+
+// validateCredentials validates the credentials in the secret of a binding
+// against the response schema of its plan. The secret does not record the
+// types of the credentials, so values are decoded from JSON unless the
+// schema declares them strings.
+func validateCredentials(schema []byte, data map[string][]byte) error {
+	var s struct {
+		Properties map[string]struct {
+			Type interface{} `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal schema: %v", err)
+	}
+
+	credentials := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		credentials[key] = string(value)
+		if property, ok := s.Properties[key]; ok && property.Type == "string" {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err == nil {
+			credentials[key] = decoded
+		}
+	}
+
+	errs, err := jsonschema.Validate(schema, credentials, field.NewPath("credentials"))
+	if err != nil {
+		return err
+	}
+	return errs.ToAggregate()
+}
+
+// getBinding fetches the binding from the broker and checks that it has the
+// credentials that are in its secret.
+func (p *planCheck) getBinding() (string, error) {
+	instance, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Get(p.instanceName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting instance: %v", err)
+	}
+	binding, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Get(p.bindingName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting binding: %v", err)
+	}
+	secret, err := p.kubeClientSet.CoreV1().Secrets(p.namespace.Name).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting secret: %v", err)
+	}
+
+	client, err := p.brokerClient()
+	if err != nil {
+		return "", fmt.Errorf("error creating broker client: %v", err)
+	}
+	response, err := client.GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error fetching binding from broker: %v", err)
+	}
+
+	for key := range secret.Data {
+		if _, ok := response.Credentials[key]; !ok {
+			return "", fmt.Errorf("the fetched binding does not have the credential %q", key)
+		}
+	}
+	if len(response.Credentials) != len(secret.Data) {
+		return "", fmt.Errorf("the fetched binding has %d credentials instead of %d", len(response.Credentials), len(secret.Data))
+	}
+	return fmt.Sprintf("fetched %d credentials", len(response.Credentials)), nil
+}
+
+// brokerClient creates a client to talk to the broker directly, with the
+// auth and TLS configuration of the broker.
+func (p *planCheck) brokerClient() (osb.Client, error) {
+	config := osb.DefaultClientConfiguration()
+	config.Name = p.broker.Name
+	config.URL = p.broker.Spec.URL
+	config.EnableAlphaFeatures = true
+	config.Insecure = p.broker.Spec.InsecureSkipTLSVerify
+	config.CAData = p.broker.Spec.CABundle
+
+	if authInfo := p.broker.Spec.AuthInfo; authInfo != nil {
+		config.AuthConfig = &osb.AuthConfig{}
+		if authInfo.Basic != nil {
+			secret, err := p.kubeClientSet.CoreV1().Secrets(authInfo.Basic.SecretRef.Namespace).Get(authInfo.Basic.SecretRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			config.AuthConfig.BasicAuthConfig = &osb.BasicAuthConfig{
+				Username: string(secret.Data["username"]),
+				Password: string(secret.Data["password"]),
+			}
+		} else if authInfo.Bearer != nil {
+			secret, err := p.kubeClientSet.CoreV1().Secrets(authInfo.Bearer.SecretRef.Namespace).Get(authInfo.Bearer.SecretRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			config.AuthConfig.BearerConfig = &osb.BearerConfig{
+				Token: string(secret.Data["token"]),
+			}
+		}
+	}
+	return osb.NewClient(config)
+}
+
+// unbind deletes the binding and verifies that it and its secret are
+// removed.
+func (p *planCheck) unbind() (string, error) {
+	binding, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Get(p.bindingName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting binding: %v", err)
+	}
+	err = p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Delete(p.bindingName, nil)
+	if err != nil {
+		return "", fmt.Errorf("error deleting binding: %v", err)
+	}
+
+	async := false
+	err = p.waitForDeletion(func() (string, error) {
+		b, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Get(p.bindingName, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		async = async || b.Status.AsyncOpInProgress
+		return bindingConditionMessage(b, v1beta1.ServiceBindingConditionReady), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("binding not removed: %v", err)
+	}
+	p.bindingName = ""
+
+	_, err = p.kubeClientSet.CoreV1().Secrets(p.namespace.Name).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err == nil {
+		return "", fmt.Errorf("secret not deleted")
+	}
+	return operationMessage("unbound", async), nil
+}
+
+// update requests an update of the instance without changing its spec, and
+// waits for it to be ready again.
+func (p *planCheck) update() (string, error) {
+	instance, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Get(p.instanceName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting instance: %v", err)
+	}
+	instance.Spec.UpdateRequests++
+	instance, err = p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Update(instance)
+	if err != nil {
+		return "", fmt.Errorf("error updating instance: %v", err)
+	}
+
+	_, async, err := p.waitForInstance(instance.Generation)
+	if err != nil {
+		return "", err
+	}
+	return operationMessage("updated", async), nil
+}
+
+// changePlan changes the plan of the instance to another plan of the class,
+// and waits for the broker to have changed it.
+func (p *planCheck) changePlan() (string, error) {
+	instance, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Get(p.instanceName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting instance: %v", err)
+	}
+	instance.Spec.ClusterServicePlanName = p.otherPlan.Name
+	instance, err = p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Update(instance)
+	if err != nil {
+		return "", fmt.Errorf("error updating instance: %v", err)
+	}
+
+	instance, async, err := p.waitForInstance(instance.Generation)
+	if err != nil {
+		return "", err
+	}
+	if instance.Status.ExternalProperties == nil || instance.Status.ExternalProperties.ClusterServicePlanExternalID != p.otherPlan.Spec.ExternalID {
+		return "", fmt.Errorf("the instance is not on plan %v after the update", p.otherPlan.Spec.ExternalName)
+	}
+	return operationMessage(fmt.Sprintf("changed to plan %v", p.otherPlan.Spec.ExternalName), async), nil
+}
+
+// deprovision deletes the instance and waits for it to be removed.
+func (p *planCheck) deprovision() (string, error) {
+	err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Delete(p.instanceName, nil)
+	if err != nil {
+		return "", fmt.Errorf("error deleting instance: %v", err)
+	}
+
+	async := false
+	err = p.waitForDeletion(func() (string, error) {
+		instance, err := p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Get(p.instanceName, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		async = async || instance.Status.AsyncOpInProgress
+		return instanceConditionMessage(instance, v1beta1.ServiceInstanceConditionReady), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("instance not removed: %v", err)
+	}
+	p.instanceName = ""
+	return operationMessage("deprovisioned", async), nil
+}
+
+// cleanup deletes the binding and instance if a test failed before deleting
+// them. Whatever is left is removed along with the namespace.
+func (p *planCheck) cleanup() {
+	if p.bindingName != "" {
+		p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Delete(p.bindingName, nil)
+	}
+	if p.instanceName != "" {
+		p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Delete(p.instanceName, nil)
+	}
+}
+
+// waitForInstance waits for the controller to have completed the operation
+// for the given generation of the instance, and returns the instance and
+// whether the broker performed the operation asynchronously.
+func (p *planCheck) waitForInstance(generation int64) (*v1beta1.ServiceInstance, bool, error) {
+	var instance *v1beta1.ServiceInstance
+	async := false
+	err := wait.PollImmediate(poll, p.timeout, func() (bool, error) {
+		var err error
+		instance, err = p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceInstances(p.namespace.Name).Get(p.instanceName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting instance: %v", err)
+		}
+		async = async || instance.Status.AsyncOpInProgress
+
+		if instance.Status.ObservedGeneration < generation ||
+			instance.Status.CurrentOperation != "" ||
+			instance.Status.OrphanMitigationInProgress {
+			return false, nil
+		}
+		if cond := instanceCondition(instance, v1beta1.ServiceInstanceConditionFailed); cond != nil && cond.Status == v1beta1.ConditionTrue {
+			return false, fmt.Errorf("the operation failed: %v", cond.Message)
+		}
+		cond := instanceCondition(instance, v1beta1.ServiceInstanceConditionReady)
+		return cond != nil && cond.Status == v1beta1.ConditionTrue, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		err = p.timeoutError(instanceConditionMessage(instance, v1beta1.ServiceInstanceConditionReady))
+	}
+	return instance, async, err
+}
+
+// waitForBinding waits for the controller to have completed the operation
+// for the given generation of the binding, and returns the binding and
+// whether the broker performed the operation asynchronously.
+func (p *planCheck) waitForBinding(generation int64) (*v1beta1.ServiceBinding, bool, error) {
+	var binding *v1beta1.ServiceBinding
+	async := false
+	err := wait.PollImmediate(poll, p.timeout, func() (bool, error) {
+		var err error
+		binding, err = p.serviceCatalogClientSet.ServicecatalogV1beta1().ServiceBindings(p.namespace.Name).Get(p.bindingName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting binding: %v", err)
+		}
+		async = async || binding.Status.AsyncOpInProgress
+
+		if binding.Status.ReconciledGeneration < generation ||
+			binding.Status.CurrentOperation != "" ||
+			binding.Status.OrphanMitigationInProgress {
+			return false, nil
+		}
+		if cond := bindingCondition(binding, v1beta1.ServiceBindingConditionFailed); cond != nil && cond.Status == v1beta1.ConditionTrue {
+			return false, fmt.Errorf("the operation failed: %v", cond.Message)
+		}
+		cond := bindingCondition(binding, v1beta1.ServiceBindingConditionReady)
+		return cond != nil && cond.Status == v1beta1.ConditionTrue, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		err = p.timeoutError(bindingConditionMessage(binding, v1beta1.ServiceBindingConditionReady))
+	}
+	return binding, async, err
+}
+
+// waitForDeletion waits for get to return a not found error. Otherwise get
+// returns the message of the Ready condition of the object, for the error if
+// it is not removed in time.
+func (p *planCheck) waitForDeletion(get func() (string, error)) error {
+	message := ""
+	err := wait.PollImmediate(poll, p.timeout, func() (bool, error) {
+		var err error
+		message, err = get()
+		if apierrs.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err == wait.ErrWaitTimeout {
+		err = p.timeoutError(message)
+	}
+	return err
+}
+
+func (p *planCheck) timeoutError(message string) error {
+	if message == "" {
+		return fmt.Errorf("the operation did not complete in %v", p.timeout)
+	}
+	return fmt.Errorf("the operation did not complete in %v: %v", p.timeout, message)
+}
+
+func instanceCondition(instance *v1beta1.ServiceInstance, conditionType v1beta1.ServiceInstanceConditionType) *v1beta1.ServiceInstanceCondition {
+	for i, cond := range instance.Status.Conditions {
+		if cond.Type == conditionType {
+			return &instance.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func instanceConditionMessage(instance *v1beta1.ServiceInstance, conditionType v1beta1.ServiceInstanceConditionType) string {
+	if instance == nil {
+		return ""
+	}
+	if cond := instanceCondition(instance, conditionType); cond != nil {
+		return cond.Message
+	}
+	return ""
+}
+
+func bindingCondition(binding *v1beta1.ServiceBinding, conditionType v1beta1.ServiceBindingConditionType) *v1beta1.ServiceBindingCondition {
+	for i, cond := range binding.Status.Conditions {
+		if cond.Type == conditionType {
+			return &binding.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func bindingConditionMessage(binding *v1beta1.ServiceBinding, conditionType v1beta1.ServiceBindingConditionType) string {
+	if binding == nil {
+		return ""
+	}
+	if cond := bindingCondition(binding, conditionType); cond != nil {
+		return cond.Message
+	}
+	return ""
+}
+
+// operationMessage returns the message of a test that passed, which records
+// whether the broker performed the operation asynchronously.
+func operationMessage(operation string, async bool) string {
+	if async {
+		return operation + " asynchronously"
+	}
+	return operation + " synchronously"
+}
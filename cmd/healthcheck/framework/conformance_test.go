@@ -0,0 +1,788 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	v1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalogfake "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+)
+
+const (
+	testConformanceBrokerName = "test-broker"
+	testConformanceNamespace  = "test-ns"
+	testConformanceClassID    = "db"
+	testConformancePlanID     = "small"
+	testConformanceOtherID    = "large"
+)
+
+// fakeConformanceBroker is an Open Service Broker served by httptest, which
+// fails the operations named in failures.
+type fakeConformanceBroker struct {
+	t           *testing.T
+	server      *httptest.Server
+	failures    map[string]bool
+	credentials map[string]interface{}
+	// fetchedCredentials are returned when fetching a binding, instead of
+	// credentials, if set
+	fetchedCredentials map[string]interface{}
+	username           string
+	password           string
+	plans              map[string]string // plan IDs by instance ID
+}
+
+func newFakeConformanceBroker(t *testing.T) *fakeConformanceBroker {
+	b := &fakeConformanceBroker{
+		t:        t,
+		failures: map[string]bool{},
+		credentials: map[string]interface{}{
+			"host": "db.example.com",
+			"port": 5432,
+		},
+		plans: map[string]string{},
+	}
+	b.server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+	return b
+}
+
+func (b *fakeConformanceBroker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if b.username != "" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != b.username || pass != b.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"description":"unauthorized"}`))
+			return
+		}
+	}
+
+	// /v2/service_instances/{instance}[/service_bindings/{binding}]
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/service_instances/"), "/")
+	instanceID := path[0]
+	var body struct {
+		PlanID string `json:"plan_id"`
+	}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	operation := ""
+	switch {
+	case len(path) == 1 && r.Method == http.MethodPut:
+		operation = "provision"
+	case len(path) == 1 && r.Method == http.MethodPatch:
+		operation = "update"
+		if body.PlanID != "" && body.PlanID != b.plans[instanceID] {
+			operation = "planChange"
+		}
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		operation = "deprovision"
+	case len(path) == 3 && r.Method == http.MethodPut:
+		operation = "bind"
+	case len(path) == 3 && r.Method == http.MethodGet:
+		operation = "getBinding"
+	case len(path) == 3 && r.Method == http.MethodDelete:
+		operation = "unbind"
+	default:
+		b.t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if b.failures[operation] {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"description":"%v failed"}`, operation)
+		return
+	}
+
+	var response interface{} = map[string]interface{}{}
+	status := http.StatusOK
+	switch operation {
+	case "provision":
+		b.plans[instanceID] = body.PlanID
+		status = http.StatusCreated
+	case "planChange":
+		b.plans[instanceID] = body.PlanID
+	case "bind":
+		response = map[string]interface{}{"credentials": b.credentials}
+		status = http.StatusCreated
+	case "getBinding":
+		credentials := b.credentials
+		if b.fetchedCredentials != nil {
+			credentials = b.fetchedCredentials
+		}
+		response = map[string]interface{}{"credentials": credentials}
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (b *fakeConformanceBroker) client() osb.Client {
+	config := osb.DefaultClientConfiguration()
+	config.URL = b.server.URL
+	if b.username != "" {
+		config.AuthConfig = &osb.AuthConfig{
+			BasicAuthConfig: &osb.BasicAuthConfig{Username: b.username, Password: b.password},
+		}
+	}
+	client, err := osb.NewClient(config)
+	if err != nil {
+		b.t.Fatalf("unexpected error creating broker client: %v", err)
+	}
+	return client
+}
+
+func getTestConformanceBroker(url string) *v1beta1.ClusterServiceBroker {
+	return &v1beta1.ClusterServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: testConformanceBrokerName},
+		Spec: v1beta1.ClusterServiceBrokerSpec{
+			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{URL: url},
+		},
+		Status: v1beta1.ClusterServiceBrokerStatus{
+			CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+				Conditions: []v1beta1.ServiceBrokerCondition{
+					{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue},
+				},
+			},
+		},
+	}
+}
+
+func getTestConformanceClass() *v1beta1.ClusterServiceClass {
+	return &v1beta1.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: testConformanceClassID},
+		Spec: v1beta1.ClusterServiceClassSpec{
+			ClusterServiceBrokerName: testConformanceBrokerName,
+			CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+				ExternalName:       testConformanceClassID,
+				ExternalID:         testConformanceClassID,
+				Bindable:           true,
+				BindingRetrievable: true,
+				PlanUpdatable:      true,
+			},
+		},
+	}
+}
+
+func getTestConformancePlan(id string) *v1beta1.ClusterServicePlan {
+	return &v1beta1.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: id},
+		Spec: v1beta1.ClusterServicePlanSpec{
+			ClusterServiceBrokerName: testConformanceBrokerName,
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+				ExternalName: id,
+				ExternalID:   id,
+			},
+			ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: testConformanceClassID},
+		},
+	}
+}
+
+// newTestConformanceCheck creates a ConformanceCheck with fake clients that
+// hold the given objects. Reactors on the fake catalog client stand in for
+// the controller: they call the broker synchronously when instances and
+// bindings are created, updated and deleted, and record the result in their
+// status.
+func newTestConformanceCheck(b *fakeConformanceBroker, objects ...runtime.Object) *ConformanceCheck {
+	kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "broker-ns"},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	})
+	kubeClient.PrependReactor("create", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testConformanceNamespace}}, nil
+	})
+	kubeClient.PrependReactor("delete", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	// The reactors are handed copies of the objects of the actions, so they
+	// store the objects in the tracker themselves.
+	scheme := runtime.NewScheme()
+	servicecatalogfake.AddToScheme(scheme)
+	tracker := clientgotesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())
+	for _, obj := range objects {
+		if err := tracker.Add(obj); err != nil {
+			b.t.Fatalf("unexpected error adding %v: %v", obj, err)
+		}
+	}
+	catalogClient := &servicecatalogfake.Clientset{}
+	catalogClient.AddReactor("*", "*", clientgotesting.ObjectReaction(tracker))
+	instances := v1beta1.SchemeGroupVersion.WithResource("serviceinstances")
+	bindings := v1beta1.SchemeGroupVersion.WithResource("servicebindings")
+	brokerClient := b.client()
+
+	catalogClient.PrependReactor("create", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		instance := action.(clientgotesting.CreateAction).GetObject().(*v1beta1.ServiceInstance)
+		instance.Name = instance.GenerateName + "instance"
+		instance.Generation = 1
+		instance.Spec.ExternalID = instance.Name + "-id"
+		instance.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: instance.Spec.ClusterServiceClassName}
+		instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: instance.Spec.ClusterServicePlanName}
+		_, err := brokerClient.ProvisionInstance(&osb.ProvisionRequest{
+			InstanceID:        instance.Spec.ExternalID,
+			ServiceID:         instance.Spec.ClusterServiceClassName,
+			PlanID:            instance.Spec.ClusterServicePlanName,
+			OrganizationGUID:  instance.Namespace,
+			SpaceGUID:         instance.Namespace,
+			AcceptsIncomplete: true,
+		})
+		setTestInstanceStatus(instance, err)
+		return true, instance, tracker.Create(instances, instance, instance.Namespace)
+	})
+
+	catalogClient.PrependReactor("update", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		instance := action.(clientgotesting.UpdateAction).GetObject().(*v1beta1.ServiceInstance)
+		instance.Generation++
+		planID := instance.Spec.ClusterServicePlanName
+		instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: planID}
+		_, err := brokerClient.UpdateInstance(&osb.UpdateInstanceRequest{
+			InstanceID:        instance.Spec.ExternalID,
+			ServiceID:         instance.Spec.ClusterServiceClassName,
+			PlanID:            &planID,
+			AcceptsIncomplete: true,
+		})
+		setTestInstanceStatus(instance, err)
+		return true, instance, tracker.Update(instances, instance, instance.Namespace)
+	})
+
+	catalogClient.PrependReactor("delete", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		deleteAction := action.(clientgotesting.DeleteAction)
+		obj, err := tracker.Get(instances, deleteAction.GetNamespace(), deleteAction.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		instance := obj.(*v1beta1.ServiceInstance)
+		_, err = brokerClient.DeprovisionInstance(&osb.DeprovisionRequest{
+			InstanceID:        instance.Spec.ExternalID,
+			ServiceID:         instance.Spec.ClusterServiceClassName,
+			PlanID:            instance.Spec.ClusterServicePlanName,
+			AcceptsIncomplete: true,
+		})
+		if err == nil {
+			return true, nil, tracker.Delete(instances, instance.Namespace, instance.Name)
+		}
+		setTestInstanceStatus(instance, err)
+		return true, nil, tracker.Update(instances, instance, instance.Namespace)
+	})
+
+	catalogClient.PrependReactor("create", "servicebindings", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		binding := action.(clientgotesting.CreateAction).GetObject().(*v1beta1.ServiceBinding)
+		binding.Name = binding.GenerateName + "binding"
+		binding.Generation = 1
+		binding.Spec.ExternalID = binding.Name + "-id"
+		binding.Spec.SecretName = binding.Name
+		response, err := brokerClient.Bind(&osb.BindRequest{
+			InstanceID: binding.Spec.ServiceInstanceRef.Name + "-id",
+			BindingID:  binding.Spec.ExternalID,
+			ServiceID:  testConformanceClassID,
+			PlanID:     testConformancePlanID,
+		})
+		if err == nil {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: binding.Spec.SecretName, Namespace: binding.Namespace},
+				Data:       map[string][]byte{},
+			}
+			for key, value := range response.Credentials {
+				if s, ok := value.(string); ok {
+					secret.Data[key] = []byte(s)
+				} else {
+					secret.Data[key], _ = json.Marshal(value)
+				}
+			}
+			_, err = kubeClient.CoreV1().Secrets(binding.Namespace).Create(secret)
+		}
+		setTestBindingStatus(binding, err)
+		return true, binding, tracker.Create(bindings, binding, binding.Namespace)
+	})
+
+	catalogClient.PrependReactor("delete", "servicebindings", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		deleteAction := action.(clientgotesting.DeleteAction)
+		obj, err := tracker.Get(bindings, deleteAction.GetNamespace(), deleteAction.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		binding := obj.(*v1beta1.ServiceBinding)
+		_, err = brokerClient.Unbind(&osb.UnbindRequest{
+			InstanceID: binding.Spec.ServiceInstanceRef.Name + "-id",
+			BindingID:  binding.Spec.ExternalID,
+			ServiceID:  testConformanceClassID,
+			PlanID:     testConformancePlanID,
+		})
+		if err == nil {
+			kubeClient.CoreV1().Secrets(binding.Namespace).Delete(binding.Spec.SecretName, nil)
+			return true, nil, tracker.Delete(bindings, binding.Namespace, binding.Name)
+		}
+		setTestBindingStatus(binding, err)
+		return true, nil, tracker.Update(bindings, binding, binding.Namespace)
+	})
+
+	return &ConformanceCheck{
+		kubeClientSet:           kubeClient,
+		serviceCatalogClientSet: catalogClient,
+		brokerName:              testConformanceBrokerName,
+		timeout:                 100 * time.Millisecond,
+	}
+}
+
+func setTestInstanceStatus(instance *v1beta1.ServiceInstance, err error) {
+	instance.Status.ObservedGeneration = instance.Generation
+	if err != nil {
+		instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse, Message: err.Error()},
+			{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue, Message: err.Error()},
+		}
+		return
+	}
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+		{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+	}
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalID: instance.Spec.ClusterServicePlanName,
+	}
+}
+
+func setTestBindingStatus(binding *v1beta1.ServiceBinding, err error) {
+	binding.Status.ReconciledGeneration = binding.Generation
+	if err != nil {
+		binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+			{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Message: err.Error()},
+			{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue, Message: err.Error()},
+		}
+		return
+	}
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+		{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+	}
+}
+
+// reportStatuses returns the statuses of the tests of the report against the
+// given plan, by test name.
+func reportStatuses(report *Report, plan string) map[string]TestStatus {
+	statuses := map[string]TestStatus{}
+	for _, test := range report.Tests {
+		if test.Plan == plan {
+			statuses[test.Name] = test.Status
+		}
+	}
+	return statuses
+}
+
+func allConformanceTests(status TestStatus) map[string]TestStatus {
+	return map[string]TestStatus{
+		"schemas":     status,
+		"provision":   status,
+		"bind":        status,
+		"getBinding":  status,
+		"unbind":      status,
+		"update":      status,
+		"planChange":  status,
+		"deprovision": status,
+	}
+}
+
+func assertReportStatuses(t *testing.T, report *Report, plan string, expected map[string]TestStatus) {
+	actual := reportStatuses(report, plan)
+	for name, e := range expected {
+		if a := actual[name]; e != a {
+			t.Errorf("expected %v to be %v, got %v", name, e, a)
+		}
+	}
+	if e, a := len(expected), len(actual); e != a {
+		t.Errorf("expected %d tests for plan %v, got %d: %v", e, plan, a, actual)
+	}
+	if t.Failed() {
+		for _, test := range report.Tests {
+			t.Logf("%v/%v %v: %v: %v", test.Class, test.Plan, test.Name, test.Status, test.Message)
+		}
+	}
+}
+
+func TestConformanceCheckRun(t *testing.T) {
+	b := newFakeConformanceBroker(t)
+	defer b.server.Close()
+	c := newTestConformanceCheck(b,
+		getTestConformanceBroker(b.server.URL),
+		getTestConformanceClass(),
+		getTestConformancePlan(testConformancePlanID),
+		getTestConformancePlan(testConformanceOtherID),
+	)
+
+	report := c.Run()
+
+	if e, a := 0, report.Failures(); e != a {
+		t.Errorf("expected %d failures, got %d", e, a)
+	}
+	assertReportStatuses(t, report, "", map[string]TestStatus{"catalog": TestPassed})
+	assertReportStatuses(t, report, testConformancePlanID, allConformanceTests(TestPassed))
+	assertReportStatuses(t, report, testConformanceOtherID, allConformanceTests(TestPassed))
+}
+
+func TestConformanceCheckRunBrokerError(t *testing.T) {
+	b := newFakeConformanceBroker(t)
+	defer b.server.Close()
+	c := newTestConformanceCheck(b, getTestConformanceBroker(b.server.URL), getTestConformanceClass())
+	c.serviceCatalogClientSet.(*servicecatalogfake.Clientset).PrependReactor("get", "clusterservicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("broker unavailable")
+	})
+
+	report := c.Run()
+
+	assertReportStatuses(t, report, "", map[string]TestStatus{"catalog": TestFailed})
+}
+
+func TestConformanceCheckRunNoClasses(t *testing.T) {
+	b := newFakeConformanceBroker(t)
+	defer b.server.Close()
+	c := newTestConformanceCheck(b, getTestConformanceBroker(b.server.URL))
+
+	report := c.Run()
+
+	assertReportStatuses(t, report, "", map[string]TestStatus{"catalog": TestFailed})
+}
+
+// TestPlanCheck runs the tests against a single plan with a broker that
+// fails some operations, or a catalog that does not support them.
+func TestPlanCheck(t *testing.T) {
+	cases := []struct {
+		name string
+		// setup configures the broker and the class and plan under test
+		setup    func(*fakeConformanceBroker, *v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan)
+		expected map[string]TestStatus
+	}{
+		{
+			name:     "all pass",
+			setup:    func(*fakeConformanceBroker, *v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan) {},
+			expected: allConformanceTests(TestPassed),
+		},
+		{
+			name: "all pass with basic auth",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.username, b.password = "user", "pass"
+			},
+			expected: allConformanceTests(TestPassed),
+		},
+		{
+			name: "valid schemas",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				plan.Spec.ServiceInstanceCreateParameterSchema = &runtime.RawExtension{
+					Raw: []byte(`{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","properties":{"size":{"type":"integer"}}}`),
+				}
+				plan.Spec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{
+					Raw: []byte(`{"type":"object","properties":{"host":{"type":"string"},"port":{"type":"integer"}},"required":["host","port"]}`),
+				}
+			},
+			expected: allConformanceTests(TestPassed),
+		},
+		{
+			name: "invalid schema",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				plan.Spec.ServiceInstanceUpdateParameterSchema = &runtime.RawExtension{
+					Raw: []byte(`{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`),
+				}
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["schemas"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "provision fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["provision"] = true
+			},
+			expected: map[string]TestStatus{
+				"schemas":     TestPassed,
+				"provision":   TestFailed,
+				"bind":        TestSkipped,
+				"getBinding":  TestSkipped,
+				"unbind":      TestSkipped,
+				"update":      TestSkipped,
+				"planChange":  TestSkipped,
+				"deprovision": TestSkipped,
+			},
+		},
+		{
+			name: "provision requires parameters",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				plan.Spec.ServiceInstanceCreateParameterSchema = &runtime.RawExtension{
+					Raw: []byte(`{"type":"object","required":["size"]}`),
+				}
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestSkipped)
+				statuses["schemas"] = TestPassed
+				return statuses
+			}(),
+		},
+		{
+			name: "bind fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["bind"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["bind"] = TestFailed
+				statuses["getBinding"] = TestSkipped
+				statuses["unbind"] = TestSkipped
+				return statuses
+			}(),
+		},
+		{
+			name: "credentials do not match the response schema",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.credentials["port"] = "not a port"
+				plan.Spec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{
+					Raw: []byte(`{"type":"object","properties":{"port":{"type":"integer"}}}`),
+				}
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["bind"] = TestFailed
+				statuses["getBinding"] = TestSkipped
+				statuses["unbind"] = TestSkipped
+				return statuses
+			}(),
+		},
+		{
+			name: "plan not bindable",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				bindable := false
+				plan.Spec.Bindable = &bindable
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["bind"] = TestSkipped
+				statuses["getBinding"] = TestSkipped
+				statuses["unbind"] = TestSkipped
+				return statuses
+			}(),
+		},
+		{
+			name: "getBinding fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["getBinding"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["getBinding"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "fetched binding is missing a credential",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.fetchedCredentials = map[string]interface{}{"host": "db.example.com"}
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["getBinding"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "fetched binding has an extra credential",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.fetchedCredentials = map[string]interface{}{"host": "db.example.com", "port": 5432, "password": "secret"}
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["getBinding"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "class not bindings retrievable",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				class.Spec.BindingRetrievable = false
+				b.failures["getBinding"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["getBinding"] = TestSkipped
+				return statuses
+			}(),
+		},
+		{
+			name: "unbind fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["unbind"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["unbind"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "update fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["update"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["update"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "plan change fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["planChange"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["planChange"] = TestFailed
+				return statuses
+			}(),
+		},
+		{
+			name: "class not plan updatable",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				class.Spec.PlanUpdatable = false
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["update"] = TestSkipped
+				statuses["planChange"] = TestSkipped
+				return statuses
+			}(),
+		},
+		{
+			name: "deprovision fails",
+			setup: func(b *fakeConformanceBroker, class *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) {
+				b.failures["deprovision"] = true
+			},
+			expected: func() map[string]TestStatus {
+				statuses := allConformanceTests(TestPassed)
+				statuses["deprovision"] = TestFailed
+				return statuses
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newFakeConformanceBroker(t)
+			defer b.server.Close()
+			broker := getTestConformanceBroker(b.server.URL)
+			class := getTestConformanceClass()
+			plan := getTestConformancePlan(testConformancePlanID)
+			tc.setup(b, class, plan)
+			if b.username != "" {
+				broker.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
+					Basic: &v1beta1.ClusterBasicAuthConfig{
+						SecretRef: &v1beta1.ObjectReference{Namespace: "broker-ns", Name: "auth"},
+					},
+				}
+			}
+
+			c := newTestConformanceCheck(b, broker, class, plan)
+			c.report = NewReport(testConformanceBrokerName)
+			c.namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testConformanceNamespace}}
+			p := &planCheck{
+				ConformanceCheck: c,
+				broker:           broker,
+				class:            class,
+				plan:             plan,
+				otherPlan:        getTestConformancePlan(testConformanceOtherID),
+			}
+			p.run()
+
+			assertReportStatuses(t, c.report, testConformancePlanID, tc.expected)
+		})
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		valid  bool
+	}{
+		{"draft-04", `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object"}`, true},
+		{"draft-04 without fragment", `{"$schema":"http://json-schema.org/draft-04/schema","type":"object"}`, true},
+		{"no $schema or type", `{"properties":{}}`, true},
+		{"draft-07", `{"$schema":"http://json-schema.org/draft-07/schema#"}`, false},
+		{"not an object type", `{"type":"array"}`, false},
+		{"not a JSON object", `[]`, false},
+	}
+	for _, tc := range cases {
+		err := checkSchema([]byte(tc.schema))
+		if tc.valid && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%v: expected an error", tc.name)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	schema := []byte(`{"type":"object","properties":{"port":{"type":"integer"},"version":{"type":"string"},"tls":{"type":"boolean"}},"required":["port"]}`)
+	cases := []struct {
+		name  string
+		data  map[string][]byte
+		valid bool
+	}{
+		{
+			name:  "values decoded from JSON",
+			data:  map[string][]byte{"port": []byte("5432"), "tls": []byte("true")},
+			valid: true,
+		},
+		{
+			name:  "string property not decoded",
+			data:  map[string][]byte{"port": []byte("5432"), "version": []byte("10")},
+			valid: true,
+		},
+		{
+			name:  "wrong type",
+			data:  map[string][]byte{"port": []byte("postgres")},
+			valid: false,
+		},
+		{
+			name:  "missing required credential",
+			data:  map[string][]byte{"tls": []byte("false")},
+			valid: false,
+		},
+	}
+	for _, tc := range cases {
+		err := validateCredentials(schema, tc.data)
+		if tc.valid && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%v: expected an error", tc.name)
+		}
+	}
+}
@@ -104,15 +104,27 @@ type HealthCheck struct {
 // and catalog client sets.
 func NewHealthCheck(s *HealthCheckServer) (*HealthCheck, error) {
 	h := &HealthCheck{}
-	var kubeConfig *rest.Config
 
 	err := h.initBrokerAttributes(s)
 	if err != nil {
 		return nil, err
 	}
 
+	h.kubeClientSet, h.serviceCatalogClientSet, err = newClientSets(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// newClientSets creates the kube and catalog client sets, using the in
+// cluster config when running in a pod.
+func newClientSets(s *HealthCheckServer) (kubernetes.Interface, clientset.Interface, error) {
+	var kubeConfig *rest.Config
+
 	// If token exists assume we are running in a pod
-	_, err = os.Stat("/var/run/secrets/kubernetes.io/serviceaccount/token")
+	_, err := os.Stat("/var/run/secrets/kubernetes.io/serviceaccount/token")
 	if err == nil {
 		kubeConfig, err = rest.InClusterConfig()
 	} else {
@@ -120,22 +132,22 @@ func NewHealthCheck(s *HealthCheckServer) (*HealthCheck, error) {
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	h.kubeClientSet, err = kubernetes.NewForConfig(kubeConfig)
+	kubeClientSet, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		glog.Errorf("Error creating kubeClientSet: %v", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	h.serviceCatalogClientSet, err = clientset.NewForConfig(kubeConfig)
+	serviceCatalogClientSet, err := clientset.NewForConfig(kubeConfig)
 	if err != nil {
 		glog.Errorf("Error creating serviceCatalogClientSet: %v", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return h, nil
+	return kubeClientSet, serviceCatalogClientSet, nil
 }
 
 // RunHealthCheck runs an end to end verification against the "ups-broker".  It
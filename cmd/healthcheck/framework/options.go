@@ -36,19 +36,30 @@ type HealthCheckServer struct {
 	HealthCheckInterval  time.Duration
 	SecureServingOptions *genericoptions.SecureServingOptions
 	TestBrokerName       string
+
+	// ConformanceReport is the file the conformance command writes its
+	// report to, in the ConformanceReportFormat
+	ConformanceReport       string
+	ConformanceReportFormat string
+	// ConformanceTimeout is how long the conformance command waits for each
+	// operation on an instance or binding to complete
+	ConformanceTimeout time.Duration
 }
 
 const (
 	defaultHealthCheckInterval = 2 * time.Minute
 	defaultSecurePort          = 443
 	defaultCertDirectory       = "/var/run/service-catalog-healthcheck"
+	defaultConformanceTimeout  = 5 * time.Minute
 )
 
 // NewHealthCheckServer creates a new HealthCheckServer with a default config.
 func NewHealthCheckServer() *HealthCheckServer {
 	s := HealthCheckServer{
-		HealthCheckInterval:  defaultHealthCheckInterval,
-		SecureServingOptions: genericoptions.NewSecureServingOptions(),
+		HealthCheckInterval:     defaultHealthCheckInterval,
+		SecureServingOptions:    genericoptions.NewSecureServingOptions(),
+		ConformanceReportFormat: reportFormatJUnit,
+		ConformanceTimeout:      defaultConformanceTimeout,
 	}
 	s.SecureServingOptions.BindPort = defaultSecurePort
 	s.SecureServingOptions.ServerCert.CertDirectory = defaultCertDirectory
@@ -61,6 +72,9 @@ func (s *HealthCheckServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.KubeConfig, "kubernetes-config", os.Getenv(clientcmd.RecommendedConfigPathEnvVar), "Path to config containing embedded authinfo for kubernetes. Default value is from environment variable "+clientcmd.RecommendedConfigPathEnvVar)
 	fs.StringVar(&s.KubeContext, "kubernetes-context", "", "config context to use for kuberentes. If unset, will use value from 'current-context'")
 	fs.DurationVar(&s.HealthCheckInterval, "healthcheck-interval", s.HealthCheckInterval, "How frequently the end to end health check should be performed")
-	fs.StringVar(&s.TestBrokerName, "broker-name", "ups-broker", "Broker Name to test against - can only be ups-broker or osb-stub.  You must ensure the specified broker is deployed.")
+	fs.StringVar(&s.TestBrokerName, "broker-name", "ups-broker", "Broker Name to test against - can only be ups-broker or osb-stub, except for the conformance command, which tests any ClusterServiceBroker.  You must ensure the specified broker is deployed.")
+	fs.StringVar(&s.ConformanceReport, "conformance-report", "", "File to write the report of the conformance command to. If unset, the report is written to standard output")
+	fs.StringVar(&s.ConformanceReportFormat, "conformance-report-format", s.ConformanceReportFormat, "Format of the report of the conformance command - can be junit or json")
+	fs.DurationVar(&s.ConformanceTimeout, "conformance-timeout", s.ConformanceTimeout, "How long the conformance command waits for each operation on an instance or binding to complete")
 	s.SecureServingOptions.AddFlags(fs)
 }
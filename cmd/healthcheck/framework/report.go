@@ -0,0 +1,186 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	reportFormatJUnit = "junit"
+	reportFormatJSON  = "json"
+)
+
+// TestStatus is the outcome of a conformance test.
+type TestStatus string
+
+const (
+	// TestPassed means that the broker behaved as expected.
+	TestPassed TestStatus = "passed"
+	// TestFailed means that the broker did not behave as expected.
+	TestFailed TestStatus = "failed"
+	// TestSkipped means that the test does not apply to the plan according
+	// to the catalog of the broker, or that a test it depends on failed.
+	TestSkipped TestStatus = "skipped"
+)
+
+// Report is the report of a conformance run against a broker.
+type Report struct {
+	Broker    string       `json:"broker"`
+	StartTime time.Time    `json:"startTime"`
+	Tests     []TestResult `json:"tests"`
+}
+
+// TestResult is the result of a single conformance test against a plan of
+// the broker, or against the broker itself if Class is empty.
+type TestResult struct {
+	Class    string     `json:"class,omitempty"`
+	Plan     string     `json:"plan,omitempty"`
+	Name     string     `json:"name"`
+	Status   TestStatus `json:"status"`
+	Message  string     `json:"message,omitempty"`
+	Duration float64    `json:"durationSeconds"`
+}
+
+// NewReport creates a new empty Report for the given broker.
+func NewReport(broker string) *Report {
+	return &Report{
+		Broker:    broker,
+		StartTime: time.Now(),
+	}
+}
+
+// Add adds the result of a test that started at the given time to the
+// report.
+func (r *Report) Add(class, plan, name string, status TestStatus, message string, startTime time.Time) {
+	r.Tests = append(r.Tests, TestResult{
+		Class:    class,
+		Plan:     plan,
+		Name:     name,
+		Status:   status,
+		Message:  message,
+		Duration: time.Since(startTime).Seconds(),
+	})
+}
+
+// Failures returns the number of tests that failed.
+func (r *Report) Failures() int {
+	failures := 0
+	for _, t := range r.Tests {
+		if t.Status == TestFailed {
+			failures++
+		}
+	}
+	return failures
+}
+
+// Write writes the report to w in the given format, either junit or json.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case reportFormatJSON:
+		b, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	case reportFormatJUnit:
+		b, err := xml.MarshalIndent(r.junit(), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, b)
+		return err
+	default:
+		return fmt.Errorf("invalid report format %q. Valid formats are %s and %s", format, reportFormatJUnit, reportFormatJSON)
+	}
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// junit converts the report to a single JUnit test suite named after the
+// broker, with the class and plan of each test as its JUnit class name.
+func (r *Report) junit() *junitTestSuites {
+	suite := junitTestSuite{
+		Name:      r.Broker,
+		Tests:     len(r.Tests),
+		Timestamp: r.StartTime.UTC().Format("2006-01-02T15:04:05"),
+	}
+	var total float64
+	for _, t := range r.Tests {
+		className := r.Broker
+		if t.Class != "" {
+			className = className + "." + t.Class
+		}
+		if t.Plan != "" {
+			className = className + "." + t.Plan
+		}
+		testCase := junitTestCase{
+			Name:      t.Name,
+			ClassName: className,
+			Time:      formatSeconds(t.Duration),
+		}
+		switch t.Status {
+		case TestFailed:
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: t.Message}
+		case TestSkipped:
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: t.Message}
+		default:
+			testCase.SystemOut = t.Message
+		}
+		total += t.Duration
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Time = formatSeconds(total)
+	return &junitTestSuites{TestSuites: []junitTestSuite{suite}}
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
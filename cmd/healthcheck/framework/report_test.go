@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestReport() *Report {
+	r := NewReport("test-broker")
+	r.StartTime = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	r.Add("", "", "catalog", TestPassed, "the catalog has 1 classes and 2 plans", time.Now())
+	r.Add("db", "small", "provision", TestPassed, "provisioned asynchronously", time.Now())
+	r.Add("db", "small", "bind", TestFailed, "binding not ready", time.Now())
+	r.Add("db", "small", "getBinding", TestSkipped, "the binding was not created", time.Now())
+	return r
+}
+
+func TestReportFailures(t *testing.T) {
+	if e, a := 1, newTestReport().Failures(); e != a {
+		t.Fatalf("expected %d failures, got %d", e, a)
+	}
+	if e, a := 0, NewReport("test-broker").Failures(); e != a {
+		t.Fatalf("expected %d failures, got %d", e, a)
+	}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := newTestReport().Write(&b, reportFormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(b.Bytes(), &report); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if e, a := "test-broker", report.Broker; e != a {
+		t.Errorf("expected broker %q, got %q", e, a)
+	}
+	if e, a := 4, len(report.Tests); e != a {
+		t.Fatalf("expected %d tests, got %d", e, a)
+	}
+	if e, a := (TestResult{Class: "db", Plan: "small", Name: "bind", Status: TestFailed, Message: "binding not ready"}), report.Tests[2]; e.Name != a.Name || e.Status != a.Status || e.Message != a.Message || e.Class != a.Class || e.Plan != a.Plan {
+		t.Errorf("expected test %+v, got %+v", e, a)
+	}
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	if err := newTestReport().Write(&b, reportFormatJUnit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := b.String()
+	for _, expected := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuite name="test-broker" tests="4" failures="1" skipped="1"`,
+		`timestamp="2018-06-01T12:00:00"`,
+		`<testcase name="catalog" classname="test-broker"`,
+		`<testcase name="provision" classname="test-broker.db.small"`,
+		`<system-out>provisioned asynchronously</system-out>`,
+		`<failure message="binding not ready"></failure>`,
+		`<skipped message="the binding was not created"></skipped>`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected report to contain %q, got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestReportWriteInvalidFormat(t *testing.T) {
+	var b bytes.Buffer
+	if err := newTestReport().Write(&b, "yaml"); err == nil {
+		t.Fatal("expected an error for an invalid format")
+	}
+}
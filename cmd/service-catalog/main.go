@@ -34,6 +34,7 @@ func main() {
 
 	hk.AddServer(server.NewAPIServer())
 	hk.AddServer(server.NewControllerManager())
+	hk.AddServer(server.NewWebhook())
 
 	hk.RunToExit(os.Args)
 }
@@ -20,29 +20,35 @@ import (
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 // DeregisterCmd contains the info needed to delete a broker
 type DeregisterCmd struct {
+	*command.Namespaced
+	*command.Scoped
 	BrokerName string
-	Context    *command.Context
 }
 
 // NewDeregisterCmd builds a "svcat deregister" command
 func NewDeregisterCmd(cxt *command.Context) *cobra.Command {
 	deregisterCmd := &DeregisterCmd{
-		Context: cxt,
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
 	}
 	cmd := &cobra.Command{
 		Use:   "deregister NAME",
 		Short: "Deregisters an existing broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat deregister mysqlbroker
+		svcat deregister mysqlbroker --scope namespace --namespace dev
 		`),
 		PreRunE: command.PreRunE(deregisterCmd),
 		RunE:    command.RunE(deregisterCmd),
 	}
+	deregisterCmd.AddNamespaceFlags(cmd.Flags(), false)
+	deregisterCmd.AddScopedFlags(cmd.Flags(), false)
 	return cmd
 }
 
@@ -63,11 +69,15 @@ func (c *DeregisterCmd) Run() error {
 
 // Deregister calls out to the pkg lib to delete the broker and display the output
 func (c *DeregisterCmd) Deregister() error {
-	err := c.Context.App.Deregister(c.BrokerName)
+	scopeOpts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     c.Scope,
+	}
+	err := c.App.DeregisterScopedBroker(c.BrokerName, scopeOpts)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(c.Context.Output, "Successfully removed broker %q", c.BrokerName)
+	fmt.Fprintf(c.Output, "Successfully removed broker %q", c.BrokerName)
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/test"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -44,7 +45,6 @@ var _ = Describe("Deregister Command", func() {
 		It("errors if a broker name is not provided", func() {
 			cmd := DeregisterCmd{
 				BrokerName: "",
-				Context:    nil,
 			}
 			err := cmd.Validate([]string{})
 			Expect(err).To(HaveOccurred())
@@ -58,17 +58,22 @@ var _ = Describe("Deregister Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.DeregisterReturns(nil)
+			fakeSDK.DeregisterScopedBrokerReturns(nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := DeregisterCmd{
-				Context:    svcattest.NewContext(outputBuffer, fakeApp),
+				Namespaced: command.NewNamespaced(svcattest.NewContext(outputBuffer, fakeApp)),
+				Scoped:     command.NewScoped(),
 				BrokerName: brokerName,
 			}
+			cmd.Namespace = "foobar-namespace"
+			cmd.Scope = servicecatalog.NamespaceScope
 			err := cmd.Deregister()
 
 			Expect(err).NotTo(HaveOccurred())
-			returnedName := fakeSDK.DeregisterArgsForCall(0)
+			returnedName, returnedScopeOpts := fakeSDK.DeregisterScopedBrokerArgsForCall(0)
 			Expect(returnedName).To(Equal(brokerName))
+			Expect(returnedScopeOpts.Namespace).To(Equal("foobar-namespace"))
+			Expect(returnedScopeOpts.Scope).To(Equal(servicecatalog.NamespaceScope))
 
 			output := outputBuffer.String()
 			Expect(output).To(Equal("Successfully removed broker \"foobarbroker\""))
@@ -65,5 +65,6 @@ func (c *describeCmd) Describe() error {
 	}
 
 	output.WriteBrokerDetails(c.Output, broker)
+	output.WriteBrokerCatalogHistory(c.Output, broker)
 	return nil
 }
@@ -19,11 +19,13 @@ package broker
 import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	name         string
 	outputFormat string
 }
@@ -34,7 +36,10 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get brokers" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "brokers [NAME]",
 		Aliases: []string{"broker", "brk"},
@@ -42,11 +47,15 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat get brokers
   svcat get broker asb
+  svcat get brokers --scope namespace --namespace dev
+  svcat get brokers --scope all --all-namespaces
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
 	}
 	command.AddOutputFlags(cmd.Flags())
+	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	getCmd.AddScopedFlags(cmd.Flags(), true)
 	return cmd
 }
 
@@ -67,6 +76,20 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
+	if c.Scope != servicecatalog.ClusterScope {
+		opts := servicecatalog.ScopeOptions{
+			Namespace: c.Namespace,
+			Scope:     c.Scope,
+		}
+		brokers, err := c.App.RetrieveScopedBrokers(opts)
+		if err != nil {
+			return err
+		}
+
+		output.WriteScopedBrokerList(c.Output, c.outputFormat, brokers...)
+		return nil
+	}
+
 	brokers, err := c.App.RetrieveBrokers()
 	if err != nil {
 		return err
@@ -77,6 +100,20 @@ func (c *getCmd) getAll() error {
 }
 
 func (c *getCmd) get() error {
+	if c.Scope != servicecatalog.ClusterScope {
+		opts := servicecatalog.ScopeOptions{
+			Namespace: c.Namespace,
+			Scope:     c.Scope,
+		}
+		broker, err := c.App.RetrieveScopedBroker(c.name, opts)
+		if err != nil {
+			return err
+		}
+
+		output.WriteScopedBrokerList(c.Output, c.outputFormat, broker)
+		return nil
+	}
+
 	broker, err := c.App.RetrieveBroker(c.name)
 	if err != nil {
 		return err
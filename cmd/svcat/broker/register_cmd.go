@@ -18,29 +18,48 @@ package broker
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // RegisterCmd contains the information needed to register a broker
 type RegisterCmd struct {
-	BrokerName string
-	Context    *command.Context
-	URL        string
+	*command.Namespaced
+	*command.Scoped
+
+	BasicSecret       string
+	BearerSecret      string
+	BrokerName        string
+	CAFile            string
+	ClassRestrictions []string
+	PlanRestrictions  []string
+	RelistBehavior    string
+	RelistDuration    time.Duration
+	SkipTLS           bool
+	URL               string
 }
 
 // NewRegisterCmd builds a "svcat register" command
 func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	registerCmd := &RegisterCmd{
-		Context: cxt,
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
 	}
 	cmd := &cobra.Command{
 		Use:   "register NAME --url URL",
 		Short: "Registers a new broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat register mysqlbroker --url http://mysqlbroker.com
+		svcat register mysqlbroker --url http://mysqlbroker.com --scope namespace --namespace dev
+		svcat register mysqlbroker --url https://mysqlbroker.com --basic-secret mysqlbroker-auth --ca-file ./ca.pem
+		svcat register mysqlbroker --url http://mysqlbroker.com --relist-behavior Duration --relist-duration 30m
+		svcat register mysqlbroker --url http://mysqlbroker.com --class-restrictions "spec.externalName in (mysql)"
 		`),
 		PreRunE: command.PreRunE(registerCmd),
 		RunE:    command.RunE(registerCmd),
@@ -48,6 +67,24 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringVar(&registerCmd.URL, "url", "",
 		"The broker URL (Required)")
 	cmd.MarkFlagRequired("url")
+	cmd.Flags().StringVar(&registerCmd.BasicSecret, "basic-secret", "",
+		"A secret containing basic auth (username/password) information to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.BearerSecret, "bearer-secret", "",
+		"A secret containing a bearer token to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.CAFile, "ca-file", "",
+		"A file containing the CA certificate to connect to the broker")
+	cmd.Flags().StringSliceVar(&registerCmd.ClassRestrictions, "class-restrictions", []string{},
+		"A list of restrictions to apply to the classes allowed from the broker")
+	cmd.Flags().StringSliceVar(&registerCmd.PlanRestrictions, "plan-restrictions", []string{},
+		"A list of restrictions to apply to the plans allowed from the broker")
+	cmd.Flags().StringVar(&registerCmd.RelistBehavior, "relist-behavior", "",
+		"Behavior for relisting the broker's catalog. Valid options are Duration or Manual. If not present, the server default is used")
+	cmd.Flags().DurationVar(&registerCmd.RelistDuration, "relist-duration", 0,
+		"Interval to refetch broker catalog when relist-behavior is set to Duration, specified in human readable format: 30s, 1m, 1h")
+	cmd.Flags().BoolVar(&registerCmd.SkipTLS, "skip-tls", false,
+		"Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca-file instead")
+	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
+	registerCmd.AddScopedFlags(cmd.Flags(), false)
 	return cmd
 }
 
@@ -58,6 +95,25 @@ func (c *RegisterCmd) Validate(args []string) error {
 	}
 	c.BrokerName = args[0]
 
+	if c.BasicSecret != "" && c.BearerSecret != "" {
+		return fmt.Errorf("cannot use both basic auth and bearer auth")
+	}
+
+	if c.CAFile != "" && c.SkipTLS {
+		return fmt.Errorf("cannot use both --ca-file and --skip-tls")
+	}
+
+	switch v1beta1.ServiceBrokerRelistBehavior(c.RelistBehavior) {
+	case "", v1beta1.ServiceBrokerRelistBehaviorManual:
+		if c.RelistDuration != 0 {
+			return fmt.Errorf("--relist-duration can only be used with --relist-behavior Duration")
+		}
+	case v1beta1.ServiceBrokerRelistBehaviorDuration:
+	default:
+		return fmt.Errorf("invalid --relist-behavior %q, allowed values are %s and %s",
+			c.RelistBehavior, v1beta1.ServiceBrokerRelistBehaviorDuration, v1beta1.ServiceBrokerRelistBehaviorManual)
+	}
+
 	return nil
 }
 
@@ -68,11 +124,28 @@ func (c *RegisterCmd) Run() error {
 
 // Register calls out to the pkg lib to create the broker and displays the output
 func (c *RegisterCmd) Register() error {
-	broker, err := c.Context.App.Register(c.BrokerName, c.URL)
+	opts := &servicecatalog.RegisterOptions{
+		BasicSecret:       c.BasicSecret,
+		BearerSecret:      c.BearerSecret,
+		CAFile:            c.CAFile,
+		ClassRestrictions: c.ClassRestrictions,
+		PlanRestrictions:  c.PlanRestrictions,
+		RelistBehavior:    v1beta1.ServiceBrokerRelistBehavior(c.RelistBehavior),
+		SkipTLS:           c.SkipTLS,
+	}
+	if c.RelistDuration != 0 {
+		opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+	}
+	scopeOpts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     c.Scope,
+	}
+
+	broker, err := c.App.RegisterScopedBroker(c.BrokerName, c.URL, opts, scopeOpts)
 	if err != nil {
 		return err
 	}
 
-	output.WriteBrokerDetails(c.Context.Output, broker)
+	output.WriteBrokerDetails(c.Output, broker)
 	return nil
 }
@@ -18,12 +18,14 @@ package broker_test
 
 import (
 	"bytes"
+	"time"
 
 	. "github.com/kubernetes-incubator/service-catalog/cmd/svcat/broker"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/test"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -46,12 +48,50 @@ var _ = Describe("Register Command", func() {
 		It("errors if a broker name is not provided", func() {
 			cmd := RegisterCmd{
 				BrokerName: "",
-				Context:    nil,
 				URL:        "http://bananabroker.com",
 			}
 			err := cmd.Validate([]string{})
 			Expect(err).To(HaveOccurred())
 		})
+		It("errors if both basic and bearer secrets are provided", func() {
+			cmd := RegisterCmd{
+				BasicSecret:  "basic-secret",
+				BearerSecret: "bearer-secret",
+				URL:          "http://bananabroker.com",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use both basic auth and bearer auth"))
+		})
+		It("errors if both a CA file and skip-tls are provided", func() {
+			cmd := RegisterCmd{
+				CAFile:  "/tmp/ca.pem",
+				SkipTLS: true,
+				URL:     "http://bananabroker.com",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use both --ca-file and --skip-tls"))
+		})
+		It("errors if a relist duration is provided without the Duration relist behavior", func() {
+			cmd := RegisterCmd{
+				RelistBehavior: "Manual",
+				RelistDuration: 10 * time.Minute,
+				URL:            "http://bananabroker.com",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--relist-duration can only be used with --relist-behavior Duration"))
+		})
+		It("errors if the relist behavior is unknown", func() {
+			cmd := RegisterCmd{
+				RelistBehavior: "Sometimes",
+				URL:            "http://bananabroker.com",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-behavior"))
+		})
 	})
 	Describe("Register", func() {
 		It("Calls the pkg/svcat libs Register method with the passed in variables and prints output to the user", func() {
@@ -73,23 +113,79 @@ var _ = Describe("Register Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RegisterReturns(brokerToReturn, nil)
+			fakeSDK.RegisterScopedBrokerReturns(brokerToReturn, nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := RegisterCmd{
-				Context:    svcattest.NewContext(outputBuffer, fakeApp),
+				Namespaced: command.NewNamespaced(svcattest.NewContext(outputBuffer, fakeApp)),
+				Scoped:     command.NewScoped(),
 				BrokerName: brokerName,
 				URL:        brokerURL,
 			}
+			cmd.Scope = servicecatalog.ClusterScope
 			err := cmd.Register()
 
 			Expect(err).NotTo(HaveOccurred())
-			returnedName, returnedURL := fakeSDK.RegisterArgsForCall(0)
+			returnedName, returnedURL, returnedOpts, returnedScopeOpts := fakeSDK.RegisterScopedBrokerArgsForCall(0)
 			Expect(returnedName).To(Equal(brokerName))
 			Expect(returnedURL).To(Equal(brokerURL))
+			Expect(returnedOpts.RelistDuration).To(BeNil())
+			Expect(returnedScopeOpts.Scope).To(Equal(servicecatalog.ClusterScope))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Passes the namespace, auth, CA and relist options through to the pkg/svcat libs Register method", func() {
+			brokerName := "foobarbroker"
+			brokerURL := "http://foobar.com"
+			namespace := "foobar-namespace"
+
+			brokerToReturn := &v1beta1.ServiceBroker{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      brokerName,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
+						URL: brokerURL,
+					},
+				},
+			}
+
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterScopedBrokerReturns(brokerToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := RegisterCmd{
+				Namespaced:       command.NewNamespaced(svcattest.NewContext(outputBuffer, fakeApp)),
+				Scoped:           command.NewScoped(),
+				BearerSecret:     "foobar-token",
+				BrokerName:       brokerName,
+				CAFile:           "/tmp/ca.pem",
+				PlanRestrictions: []string{"spec.free==true"},
+				RelistBehavior:   "Duration",
+				RelistDuration:   10 * time.Minute,
+				URL:              brokerURL,
+			}
+			cmd.Namespace = namespace
+			cmd.Scope = servicecatalog.NamespaceScope
+			err := cmd.Register()
+
+			Expect(err).NotTo(HaveOccurred())
+			_, _, returnedOpts, returnedScopeOpts := fakeSDK.RegisterScopedBrokerArgsForCall(0)
+			Expect(returnedOpts.BearerSecret).To(Equal("foobar-token"))
+			Expect(returnedOpts.CAFile).To(Equal("/tmp/ca.pem"))
+			Expect(returnedOpts.PlanRestrictions).To(ConsistOf("spec.free==true"))
+			Expect(returnedOpts.RelistBehavior).To(Equal(v1beta1.ServiceBrokerRelistBehaviorDuration))
+			Expect(returnedOpts.RelistDuration.Duration).To(Equal(10 * time.Minute))
+			Expect(returnedScopeOpts.Namespace).To(Equal(namespace))
+			Expect(returnedScopeOpts.Scope).To(Equal(servicecatalog.NamespaceScope))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(brokerName))
+			Expect(output).To(ContainSubstring(namespace))
+		})
 	})
 })
@@ -20,24 +20,34 @@ import (
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type syncCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	name string
 }
 
 // NewSyncCmd builds a "svcat sync broker" command
 func NewSyncCmd(cxt *command.Context) *cobra.Command {
-	syncCmd := &syncCmd{Context: cxt}
+	syncCmd := &syncCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	rootCmd := &cobra.Command{
-		Use:     "broker NAME",
-		Short:   "Syncs service catalog for a service broker",
-		Example: command.NormalizeExamples(`svcat sync broker asb`),
+		Use:   "broker NAME",
+		Short: "Syncs service catalog for a service broker",
+		Example: command.NormalizeExamples(`
+		svcat sync broker asb
+		svcat sync broker asb --scope namespace --namespace dev
+		`),
 		PreRunE: command.PreRunE(syncCmd),
 		RunE:    command.RunE(syncCmd),
 	}
+	syncCmd.AddNamespaceFlags(rootCmd.Flags(), false)
+	syncCmd.AddScopedFlags(rootCmd.Flags(), false)
 	return rootCmd
 }
 
@@ -55,7 +65,11 @@ func (c *syncCmd) Run() error {
 
 func (c *syncCmd) sync() error {
 	const retries = 3
-	err := c.App.Sync(c.name, retries)
+	scopeOpts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     c.Scope,
+	}
+	err := c.App.SyncScopedBroker(c.name, scopeOpts, retries)
 	if err != nil {
 		return err
 	}
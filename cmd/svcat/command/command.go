@@ -47,6 +47,12 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 		if nsCmd, ok := cmd.(HasNamespaceFlags); ok {
 			nsCmd.ApplyNamespaceFlags(c.Flags())
 		}
+		if scopedCmd, ok := cmd.(HasScopedFlags); ok {
+			err := scopedCmd.ApplyScopedFlags(c.Flags())
+			if err != nil {
+				return err
+			}
+		}
 		if fmtCmd, ok := cmd.(FormattedCommand); ok {
 			fmtString, err := determineOutputFormat(c.Flags())
 			if err != nil {
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/pflag"
+)
+
+// HasScopedFlags represents a command that can be scoped to the cluster, a
+// namespace, or both.
+type HasScopedFlags interface {
+	// ApplyScopedFlags validates and persists the scope-related flags:
+	// * --scope
+	ApplyScopedFlags(flags *pflag.FlagSet) error
+}
+
+// Scoped adds support to a command for the --scope flag.
+type Scoped struct {
+	Scope    servicecatalog.Scope
+	allowAll bool
+}
+
+// NewScoped initializes a new scoped command.
+func NewScoped() *Scoped {
+	return &Scoped{}
+}
+
+// AddScopedFlags adds the scope-related flags:
+// * --scope
+func (c *Scoped) AddScopedFlags(flags *pflag.FlagSet, allowAll bool) {
+	c.allowAll = allowAll
+
+	usage := "The scope of the resource: cluster or namespace"
+	if allowAll {
+		usage = "Limit the results to a particular scope: cluster, namespace or all"
+	}
+	flags.String(
+		"scope",
+		string(servicecatalog.ClusterScope),
+		usage,
+	)
+}
+
+// ApplyScopedFlags validates and persists the scope-related flags:
+// * --scope
+func (c *Scoped) ApplyScopedFlags(flags *pflag.FlagSet) error {
+	scope, _ := flags.GetString("scope")
+
+	var err error
+	c.Scope, err = servicecatalog.ParseScope(scope)
+	if err != nil {
+		return err
+	}
+
+	if c.Scope == servicecatalog.AllScope && !c.allowAll {
+		return fmt.Errorf("invalid --scope %q, allowed values are: %s, %s", scope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	}
+	return nil
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-incubator/service-catalog/pkg/jsonschema"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type provisonCmd struct {
@@ -38,6 +40,7 @@ type provisonCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+	skipValidate bool
 }
 
 // NewProvisionCmd builds a "svcat provision" command
@@ -82,6 +85,8 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		"Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&provisionCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVar(&provisionCmd.skipValidate, "skip-validation", false,
+		"Do not validate the parameters against the parameter schema of the plan before provisioning")
 	provisionCmd.AddWaitFlags(cmd)
 
 	return cmd
@@ -124,6 +129,10 @@ func (c *provisonCmd) Run() error {
 }
 
 func (c *provisonCmd) Provision() error {
+	if err := c.validateParameters(); err != nil {
+		return err
+	}
+
 	instance, err := c.App.Provision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets)
 	if err != nil {
 		return err
@@ -145,3 +154,40 @@ func (c *provisonCmd) Provision() error {
 	output.WriteInstanceDetails(c.Output, instance)
 	return nil
 }
+
+// validateParameters checks the parameters against the create parameter
+// schema of the plan, so that mistakes are reported before the instance is
+// created. Parameters read from secrets are only known to the controller, so
+// the check is skipped when secrets are used, as it is when the schema is not
+// supported.
+func (c *provisonCmd) validateParameters() error {
+	if c.skipValidate || len(c.secrets) > 0 {
+		return nil
+	}
+	if len(c.rawParams) == 0 && c.jsonParams == "" {
+		return nil
+	}
+
+	plan, err := c.App.RetrievePlanByClassAndPlanNames(c.className, c.planName)
+	if err != nil {
+		return err
+	}
+	schema := plan.Spec.ServiceInstanceCreateParameterSchema
+	if schema == nil || len(schema.Raw) == 0 {
+		return nil
+	}
+
+	errs, err := jsonschema.Validate(schema.Raw, c.params, field.NewPath("parameters"))
+	if err != nil {
+		// The controller does not validate against unsupported schemas
+		// either, so the instance is provisioned without validation.
+		fmt.Fprintf(c.Output, "Not validating parameters against the unsupported schema of plan %s/%s: %s\n",
+			c.className, c.planName, err)
+		return nil
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid parameters for plan %s/%s (%s), use --skip-validation to provision anyway",
+			c.className, c.planName, errs.ToAggregate())
+	}
+	return nil
+}
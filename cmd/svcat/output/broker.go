@@ -19,23 +19,25 @@ package output
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 )
 
-func getBrokerStatusCondition(status v1beta1.ClusterServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
+func getBrokerStatusCondition(status v1beta1.CommonServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
 	if len(status.Conditions) > 0 {
 		return status.Conditions[len(status.Conditions)-1]
 	}
 	return v1beta1.ServiceBrokerCondition{}
 }
 
-func getBrokerStatusShort(status v1beta1.ClusterServiceBrokerStatus) string {
+func getBrokerStatusShort(status v1beta1.CommonServiceBrokerStatus) string {
 	lastCond := getBrokerStatusCondition(status)
 	return formatStatusShort(string(lastCond.Type), lastCond.Status, lastCond.Reason)
 }
 
-func getBrokerStatusFull(status v1beta1.ClusterServiceBrokerStatus) string {
+func getBrokerStatusFull(status v1beta1.CommonServiceBrokerStatus) string {
 	lastCond := getBrokerStatusCondition(status)
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
@@ -51,12 +53,44 @@ func writeBrokerListTable(w io.Writer, brokers []v1beta1.ClusterServiceBroker) {
 		t.Append([]string{
 			broker.Name,
 			broker.Spec.URL,
-			getBrokerStatusShort(broker.Status),
+			getBrokerStatusShort(broker.Status.CommonServiceBrokerStatus),
 		})
 	}
 	t.Render()
 }
 
+func writeScopedBrokerListTable(w io.Writer, brokers []svcatsdk.Broker) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Namespace",
+		"URL",
+		"Status",
+	})
+	for _, broker := range brokers {
+		t.Append([]string{
+			broker.GetName(),
+			broker.GetNamespace(),
+			broker.GetURL(),
+			getBrokerStatusShort(broker.GetStatus()),
+		})
+	}
+	t.Render()
+}
+
+// WriteScopedBrokerList prints a list of cluster and namespace scoped brokers
+// in the specified output format.
+func WriteScopedBrokerList(w io.Writer, outputFormat string, brokers ...svcatsdk.Broker) {
+	switch outputFormat {
+	case formatJSON:
+		writeJSON(w, brokers)
+	case formatYAML:
+		writeYAML(w, brokers, 0)
+	case formatTable:
+		writeScopedBrokerListTable(w, brokers)
+	}
+}
+
 // WriteBrokerList prints a list of brokers in the specified output format.
 func WriteBrokerList(w io.Writer, outputFormat string, brokers ...v1beta1.ClusterServiceBroker) {
 	l := v1beta1.ClusterServiceBrokerList{
@@ -90,20 +124,82 @@ func WriteParentBroker(w io.Writer, broker *v1beta1.ClusterServiceBroker) {
 	t := NewDetailsTable(w)
 	t.AppendBulk([][]string{
 		{"Name:", broker.Name},
-		{"Status:", getBrokerStatusShort(broker.Status)},
+		{"Status:", getBrokerStatusShort(broker.Status.CommonServiceBrokerStatus)},
 	})
 	t.Render()
 }
 
 // WriteBrokerDetails prints details for a single broker.
-func WriteBrokerDetails(w io.Writer, broker *v1beta1.ClusterServiceBroker) {
+func WriteBrokerDetails(w io.Writer, broker svcatsdk.Broker) {
 	t := NewDetailsTable(w)
 
+	t.Append([]string{"Name:", broker.GetName()})
+	if broker.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", broker.GetNamespace()})
+	}
 	t.AppendBulk([][]string{
-		{"Name:", broker.Name},
-		{"URL:", broker.Spec.URL},
-		{"Status:", getBrokerStatusFull(broker.Status)},
+		{"URL:", broker.GetURL()},
+		{"Status:", getBrokerStatusFull(broker.GetStatus())},
 	})
 
 	t.Render()
 }
+
+// WriteBrokerCatalogHistory prints the changes to the catalog of a broker,
+// most recent first.
+func WriteBrokerCatalogHistory(w io.Writer, broker svcatsdk.Broker) {
+	history := broker.GetStatus().CatalogHistory
+	if len(history) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nCatalog History:")
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Time",
+		"Change",
+		"Class",
+		"Plan",
+		"Fields",
+	})
+	for i := len(history) - 1; i >= 0; i-- {
+		change := history[i]
+		for _, class := range change.Classes {
+			t.Append([]string{
+				change.Time.UTC().String(),
+				string(class.Type),
+				class.ExternalName,
+				"",
+				strings.Join(class.Fields, ","),
+			})
+		}
+		if change.OmittedClasses > 0 {
+			t.Append([]string{
+				change.Time.UTC().String(),
+				"",
+				fmt.Sprintf("(%d more)", change.OmittedClasses),
+				"",
+				"",
+			})
+		}
+		for _, plan := range change.Plans {
+			t.Append([]string{
+				change.Time.UTC().String(),
+				string(plan.Type),
+				plan.ServiceClassExternalName,
+				plan.ExternalName,
+				strings.Join(plan.Fields, ","),
+			})
+		}
+		if change.OmittedPlans > 0 {
+			t.Append([]string{
+				change.Time.UTC().String(),
+				"",
+				"",
+				fmt.Sprintf("(%d more)", change.OmittedPlans),
+				"",
+			})
+		}
+	}
+	t.Render()
+}
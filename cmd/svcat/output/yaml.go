@@ -66,14 +66,19 @@ func writeParametersFrom(w io.Writer, parametersFrom []v1beta1.ParametersFromSou
 		return
 	}
 
-	headerPrinted := false
+	fmt.Fprintln(w, "\nParameters From:")
 	for _, p := range parametersFrom {
-		if p.SecretKeyRef != nil {
-			if !headerPrinted {
-				fmt.Fprintln(w, "\nParameters From:")
-				headerPrinted = true
-			}
+		switch {
+		case p.SecretKeyRef != nil:
 			fmt.Fprintf(w, "  Secret: %s.%s\n", p.SecretKeyRef.Name, p.SecretKeyRef.Key)
+		case p.ConfigMapKeyRef != nil:
+			fmt.Fprintf(w, "  ConfigMap: %s.%s\n", p.ConfigMapKeyRef.Name, p.ConfigMapKeyRef.Key)
+		case p.SecretRef != nil:
+			fmt.Fprintf(w, "  Secret: %s\n", p.SecretRef.Name)
+		case p.ConfigMapRef != nil:
+			fmt.Fprintf(w, "  ConfigMap: %s\n", p.ConfigMapRef.Name)
+		case p.ServiceBindingKeyRef != nil:
+			fmt.Fprintf(w, "  Binding: %s.%s\n", p.ServiceBindingKeyRef.Name, p.ServiceBindingKeyRef.Key)
 		}
 	}
 }
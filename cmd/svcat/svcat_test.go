@@ -99,6 +99,7 @@ func TestCommandOutput(t *testing.T) {
 		{name: "list all brokers", cmd: "get brokers", golden: "output/get-brokers.txt"},
 		{name: "list all brokers (json)", cmd: "get brokers -o json", golden: "output/get-brokers.json"},
 		{name: "list all brokers (yaml)", cmd: "get brokers -o yaml", golden: "output/get-brokers.yaml"},
+		{name: "list brokers in all scopes", cmd: "get brokers --scope all --all-namespaces", golden: "output/get-brokers-all-scopes.txt"},
 		{name: "get broker", cmd: "get broker ups-broker", golden: "output/get-broker.txt"},
 		{name: "get broker (json)", cmd: "get broker ups-broker -o json", golden: "output/get-broker.json"},
 		{name: "get broker (yaml)", cmd: "get broker ups-broker -o yaml", golden: "output/get-broker.yaml"},
@@ -151,6 +152,7 @@ func TestCommandOutput(t *testing.T) {
 		{name: "unbind instance and wait", cmd: "unbind ups-instance -n test-ns --wait", golden: "output/unbind-instance-and-wait.txt"},
 		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
 		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
+		{name: "provision instance with invalid parameters", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan premium -p testInstanceProprety=foo", golden: "output/provision-instance-invalid-parameters.txt", continueOnError: true},
 		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
 		{name: "deprovision instance and wait", cmd: "deprovision ups-instance -n test-ns --wait", golden: "output/deprovision-instance-and-wait.txt"},
 
@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// setWebhookCABundle sets the CA bundle of the webhooks of the named
+// MutatingWebhookConfiguration to the CA in caFile, which signed the
+// certificate of the webhook server. The Helm chart generates the
+// certificate once at install time, so the configuration it renders on
+// every upgrade leaves the CA bundle to the webhook.
+func setWebhookCABundle(client kubernetes.Interface, name, caFile string) error {
+	caBundle, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the CA of the webhook: %v", err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configurations := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+		configuration, err := configurations.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i := range configuration.Webhooks {
+			if !bytes.Equal(configuration.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				configuration.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		glog.Infof("Setting the CA bundle of MutatingWebhookConfiguration %q", name)
+		_, err = configurations.Update(configuration)
+		return err
+	})
+}
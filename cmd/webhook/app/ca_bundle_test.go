@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetWebhookCABundle(t *testing.T) {
+	caFile, err := ioutil.TempFile("", "ca.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(caFile.Name())
+	caBundle := []byte("new-ca")
+	if _, err := caFile.Write(caBundle); err != nil {
+		t.Fatal(err)
+	}
+	caFile.Close()
+
+	client := fake.NewSimpleClientset(&admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "podpresets"},
+		Webhooks: []admissionregistrationv1beta1.Webhook{
+			{Name: "podpresets.settings.servicecatalog.k8s.io"},
+			{
+				Name:         "other.settings.servicecatalog.k8s.io",
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{CABundle: []byte("old-ca")},
+			},
+		},
+	})
+
+	if err := setWebhookCABundle(client, "podpresets", caFile.Name()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	configuration, err := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Get("podpresets", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, webhook := range configuration.Webhooks {
+		if !bytes.Equal(webhook.ClientConfig.CABundle, caBundle) {
+			t.Errorf("%v: expected CA bundle %q, got %q", webhook.Name, caBundle, webhook.ClientConfig.CABundle)
+		}
+	}
+
+	// The configuration is not updated when its CA bundle is already set.
+	client.ClearActions()
+	if err := setWebhookCABundle(client, "podpresets", caFile.Name()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("unexpected action %v", action)
+		}
+	}
+
+	if err := setWebhookCABundle(client, "missing", caFile.Name()); err == nil {
+		t.Error("expected an error for a missing configuration")
+	}
+}
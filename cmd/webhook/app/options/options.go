@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the flags of the service catalog webhook server,
+// which serves the mutating admission webhook that applies PodPresets to
+// pods.
+package options
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+)
+
+const (
+	// Use the same SSL configuration as we use in Catalog API Server.
+	// Store generated SSL certificates in a place that won't collide with the
+	// k8s core API server.
+	certDirectory = "/var/run/kubernetes-service-catalog"
+)
+
+// WebhookServer is the main context object for the webhook server.
+type WebhookServer struct {
+	// K8sAPIServerURL is the URL for the k8s API server.
+	K8sAPIServerURL string
+	// K8sKubeconfigPath is the path to the kubeconfig file with
+	// authorization information for the k8s API server.
+	K8sKubeconfigPath string
+	// ServiceCatalogAPIServerURL is the URL for the service-catalog API
+	// server.
+	ServiceCatalogAPIServerURL string
+	// ServiceCatalogKubeconfigPath is the path to the kubeconfig file with
+	// authorization information for the service-catalog API server.
+	ServiceCatalogKubeconfigPath string
+	// ServiceCatalogInsecureSkipVerify controls whether the TLS certificate
+	// of the service-catalog API server is verified.
+	ServiceCatalogInsecureSkipVerify bool
+	// WebhookConfigurationName is the name of the
+	// MutatingWebhookConfiguration whose CA bundle is set to the CA in
+	// CAFile on startup. The CA bundle is left alone if it is empty.
+	WebhookConfigurationName string
+	// CAFile is the path to the CA that signed the serving certificate.
+	CAFile string
+	// ResyncInterval is the interval on which the PodPreset informer
+	// resyncs.
+	ResyncInterval time.Duration
+	// SecureServingOptions configures the HTTPS server for the webhook.
+	SecureServingOptions *genericoptions.SecureServingOptions
+}
+
+const (
+	defaultResyncInterval = 5 * time.Minute
+	defaultPort           = 8445
+)
+
+// NewWebhookServer creates a new WebhookServer with a default config.
+func NewWebhookServer() *WebhookServer {
+	s := WebhookServer{
+		ResyncInterval:       defaultResyncInterval,
+		SecureServingOptions: genericoptions.NewSecureServingOptions(),
+	}
+	// set defaults, these will be overriden by user specified flags
+	s.SecureServingOptions.BindPort = defaultPort
+	s.SecureServingOptions.ServerCert.CertDirectory = certDirectory
+	s.CAFile = filepath.Join(certDirectory, "ca.crt")
+	return &s
+}
+
+// AddFlags adds flags for a WebhookServer to the specified FlagSet.
+func (s *WebhookServer) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.K8sAPIServerURL, "k8s-api-server-url", "", "The URL for the k8s API server")
+	fs.StringVar(&s.K8sKubeconfigPath, "k8s-kubeconfig", "", "Path to k8s core kubeconfig")
+	fs.StringVar(&s.ServiceCatalogAPIServerURL, "service-catalog-api-server-url", "", "The URL for the service-catalog API server")
+	fs.StringVar(&s.ServiceCatalogKubeconfigPath, "service-catalog-kubeconfig", "", "Path to service-catalog kubeconfig")
+	fs.BoolVar(&s.ServiceCatalogInsecureSkipVerify, "service-catalog-insecure-skip-verify", s.ServiceCatalogInsecureSkipVerify, "Skip verification of the TLS certificate for the service-catalog API server")
+	fs.StringVar(&s.WebhookConfigurationName, "webhook-configuration-name", "", "The name of the MutatingWebhookConfiguration whose CA bundle is set to the CA in --ca-file on startup")
+	fs.StringVar(&s.CAFile, "ca-file", s.CAFile, "Path to the CA that signed the serving certificate")
+	fs.DurationVar(&s.ResyncInterval, "resync-interval", s.ResyncInterval, "The interval on which the webhook will resync its PodPreset informer")
+	s.SecureServingOptions.AddFlags(fs)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app implements a server that serves the service catalog mutating
+// admission webhooks.
+package app
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+
+	// The API groups for our API must be installed before we can use the
+	// client to work with them.  This needs to be done once per process; this
+	// is the point at which we handle this for the webhook process.  Please do
+	// not remove.
+	_ "github.com/kubernetes-incubator/service-catalog/pkg/api"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/webhook/app/options"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	servicecatalogclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	servicecataloginformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
+	"github.com/kubernetes-incubator/service-catalog/pkg/webhook/podpreset"
+)
+
+const webhookAgentName = "service-catalog-webhook"
+
+// PodPresetPath is the path on which the PodPreset webhook is served.
+const PodPresetPath = "/podpresets"
+
+// Run runs the service-catalog webhook server; should never exit.
+func Run(s *options.WebhookServer, stopCh <-chan struct{}) error {
+	glog.V(4).Info("Building k8s kubeconfig")
+	var err error
+	var k8sKubeconfig *rest.Config
+	if s.K8sAPIServerURL == "" && s.K8sKubeconfigPath == "" {
+		k8sKubeconfig, err = rest.InClusterConfig()
+	} else {
+		k8sKubeconfig, err = clientcmd.BuildConfigFromFlags(s.K8sAPIServerURL, s.K8sKubeconfigPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Kubernetes client config: %v", err)
+	}
+	k8sKubeClient, err := kubernetes.NewForConfig(rest.AddUserAgent(k8sKubeconfig, webhookAgentName))
+	if err != nil {
+		return fmt.Errorf("invalid Kubernetes API configuration: %v", err)
+	}
+
+	if s.WebhookConfigurationName != "" {
+		if err := setWebhookCABundle(k8sKubeClient, s.WebhookConfigurationName, s.CAFile); err != nil {
+			return fmt.Errorf("failed to set the CA bundle of the webhook configuration: %v", err)
+		}
+	}
+
+	glog.V(4).Infof("Building service-catalog kubeconfig for url: %v\n", s.ServiceCatalogAPIServerURL)
+	var serviceCatalogKubeconfig *rest.Config
+	if s.ServiceCatalogAPIServerURL == "" && s.ServiceCatalogKubeconfigPath == "" {
+		// explicitly fall back to InClusterConfig, assuming we're talking to an API server which does aggregation
+		glog.V(4).Infof("Using inClusterConfig to talk to service catalog API server -- make sure your API server is registered with the aggregator")
+		serviceCatalogKubeconfig, err = rest.InClusterConfig()
+	} else {
+		serviceCatalogKubeconfig, err = clientcmd.BuildConfigFromFlags(s.ServiceCatalogAPIServerURL, s.ServiceCatalogKubeconfigPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Service Catalog client configuration: %v", err)
+	}
+	serviceCatalogKubeconfig.Insecure = s.ServiceCatalogInsecureSkipVerify
+	serviceCatalogClient, err := servicecatalogclientset.NewForConfig(rest.AddUserAgent(serviceCatalogKubeconfig, webhookAgentName))
+	if err != nil {
+		return fmt.Errorf("invalid Service Catalog API configuration: %v", err)
+	}
+
+	// Initialize SSL/TLS configuration.  Ensures we have a certificate and key
+	// to use.  The API server only calls webhooks over HTTPS, with a
+	// certificate signed by the CA in the webhook configuration, which the
+	// Helm chart generates once at install time.
+	if err := s.SecureServingOptions.MaybeDefaultWithSelfSignedCerts("" /*AdvertiseAddress*/, nil /*alternateDNS*/, []net.IP{net.ParseIP("127.0.0.1")}); err != nil {
+		return fmt.Errorf("failed to establish SecureServingOptions %v", err)
+	}
+
+	// PodPreset conflicts are reported as events on the PodPresets
+	glog.V(4).Info("Creating event broadcaster")
+	eventsScheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(eventsScheme); err != nil {
+		return err
+	}
+	if err := settingsv1alpha1.AddToScheme(eventsScheme); err != nil {
+		return err
+	}
+	eventBroadcaster := record.NewBroadcaster()
+	loggingWatch := eventBroadcaster.StartLogging(glog.Infof)
+	defer loggingWatch.Stop()
+	recordingWatch := eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: k8sKubeClient.CoreV1().Events("")})
+	defer recordingWatch.Stop()
+	recorder := eventBroadcaster.NewRecorder(eventsScheme, corev1.EventSource{Component: webhookAgentName})
+
+	informerFactory := servicecataloginformers.NewSharedInformerFactory(serviceCatalogClient, s.ResyncInterval)
+	podPresetInformer := informerFactory.Settings().V1alpha1().PodPresets()
+	podPresetWebhook := podpreset.NewWebhook(podPresetInformer.Lister(), recorder)
+	informerFactory.Start(stopCh)
+
+	glog.V(4).Info("Waiting for the PodPreset informer to sync")
+	if !cache.WaitForCacheSync(stopCh, podPresetInformer.Informer().HasSynced) {
+		return fmt.Errorf("timed out waiting for the PodPreset informer to sync")
+	}
+
+	mux := http.NewServeMux()
+	healthz.InstallHandler(mux, healthz.PingHealthz)
+	mux.Handle(PodPresetPath, podPresetWebhook)
+
+	server := &http.Server{
+		Addr: net.JoinHostPort(s.SecureServingOptions.BindAddress.String(),
+			strconv.Itoa(int(s.SecureServingOptions.BindPort))),
+		Handler: mux,
+	}
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+
+	glog.Infof("Serving the PodPreset webhook on %s%s", server.Addr, PodPresetPath)
+	err = server.ListenAndServeTLS(s.SecureServingOptions.ServerCert.CertKey.CertFile,
+		s.SecureServingOptions.ServerCert.CertKey.KeyFile)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
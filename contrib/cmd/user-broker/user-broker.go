@@ -29,19 +29,40 @@ import (
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/server"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/user_provided/controller"
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/user_provided/storage"
 	"github.com/kubernetes-incubator/service-catalog/pkg"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	storageMemory = "memory"
+	storageFile   = "file"
+	storageSecret = "secret"
+
+	// secretPrefix is the prefix of the names of the Secrets that hold the
+	// instances with the secret storage.
+	secretPrefix = "user-broker-instance-"
 )
 
 var options struct {
-	Port    int
-	TLSCert string
-	TLSKey  string
+	Port             int
+	TLSCert          string
+	TLSKey           string
+	Storage          string
+	StorageFile      string
+	StorageNamespace string
+	Kubeconfig       string
 }
 
 func init() {
 	flag.IntVar(&options.Port, "port", 8005, "use '--port' option to specify the port for broker to listen on")
 	flag.StringVar(&options.TLSCert, "tlsCert", "", "base-64 encoded PEM block to use as the certificate for TLS. If '--tlsCert' is used, then '--tlsKey' must also be used. If '--tlsCert' is not used, then TLS will not be used.")
 	flag.StringVar(&options.TLSKey, "tlsKey", "", "base-64 encoded PEM block to use as the private key matching the TLS certificate. If '--tlsKey' is used, then '--tlsCert' must also be used")
+	flag.StringVar(&options.Storage, "storage", storageMemory, "where the broker keeps its instances, one of 'memory', 'file' or 'secret'. Instances kept in memory are lost when the broker restarts")
+	flag.StringVar(&options.StorageFile, "storageFile", "", "path of the file to keep the instances in with the 'file' storage")
+	flag.StringVar(&options.StorageNamespace, "storageNamespace", "default", "namespace of the Secrets to keep the instances in with the 'secret' storage")
+	flag.StringVar(&options.Kubeconfig, "kubeconfig", "", "path to the kubeconfig for the 'secret' storage. The in-cluster configuration is used if it is not set")
 	flag.Parse()
 }
 
@@ -70,10 +91,14 @@ func runWithContext(ctx context.Context) error {
 		return nil
 	}
 
+	s, err := createStorage()
+	if err != nil {
+		return err
+	}
+
 	addr := ":" + strconv.Itoa(options.Port)
-	ctrlr := controller.CreateController()
+	ctrlr := controller.CreateControllerWithStorage(s)
 
-	var err error
 	if options.TLSCert == "" && options.TLSKey == "" {
 		err = server.Run(ctx, addr, ctrlr)
 	} else {
@@ -82,6 +107,32 @@ func runWithContext(ctx context.Context) error {
 	return err
 }
 
+// createStorage creates the storage for the instances selected by the
+// '--storage' flag.
+func createStorage() (storage.Storage, error) {
+	switch options.Storage {
+	case storageMemory:
+		return storage.NewMemoryStorage(), nil
+	case storageFile:
+		if options.StorageFile == "" {
+			return nil, fmt.Errorf("--storageFile is required with the %q storage", storageFile)
+		}
+		return storage.NewFileStorage(options.StorageFile)
+	case storageSecret:
+		config, err := clientcmd.BuildConfigFromFlags("", options.Kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Kubernetes client configuration: %v", err)
+		}
+		client, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client: %v", err)
+		}
+		return storage.NewSecretStorage(client, options.StorageNamespace, secretPrefix), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q, must be one of %q, %q or %q", options.Storage, storageMemory, storageFile, storageSecret)
+	}
+}
+
 // cancelOnInterrupt calls f when os.Interrupt or SIGTERM is received.
 // It ignores subsequent interrupts on purpose - program should exit correctly after the first signal.
 func cancelOnInterrupt(ctx context.Context, f context.CancelFunc) {
@@ -21,14 +21,22 @@ import (
 )
 
 // Controller defines the APIs that all controllers are expected to support. Implementations
-// should be concurrency-safe
+// should be concurrency-safe. Errors created with the functions in errors.go are
+// returned to the platform with their HTTP status code, all other errors as bad requests.
+// Implementations must not start asynchronous operations for requests whose
+// AcceptsIncomplete is false, and return an error created with
+// NewAsyncRequiredError instead.
 type Controller interface {
 	Catalog() (*brokerapi.Catalog, error)
 
 	GetServiceInstanceLastOperation(instanceID, serviceID, planID, operation string) (*brokerapi.LastOperationResponse, error)
 	CreateServiceInstance(instanceID string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error)
-	RemoveServiceInstance(instanceID, serviceID, planID string, acceptsIncomplete bool) (*brokerapi.DeleteServiceInstanceResponse, error)
+	UpdateServiceInstance(instanceID string, req *brokerapi.UpdateServiceInstanceRequest) (*brokerapi.UpdateServiceInstanceResponse, error)
+	GetServiceInstance(instanceID string) (*brokerapi.GetServiceInstanceResponse, error)
+	RemoveServiceInstance(instanceID string, req *brokerapi.DeleteServiceInstanceRequest) (*brokerapi.DeleteServiceInstanceResponse, error)
 
+	GetServiceBindingLastOperation(instanceID, bindingID, serviceID, planID, operation string) (*brokerapi.LastOperationResponse, error)
 	Bind(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error)
-	UnBind(instanceID, bindingID, serviceID, planID string) error
+	GetServiceBinding(instanceID, bindingID string) (*brokerapi.GetServiceBindingResponse, error)
+	UnBind(instanceID, bindingID string, req *brokerapi.UnbindRequest) error
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
+)
+
+// Error is an error that a Controller returns for the server to respond with
+// the given HTTP status code, and an OSB error response with the given error
+// code and description.
+type Error struct {
+	StatusCode  int
+	ErrorCode   string
+	Description string
+}
+
+func (e *Error) Error() string {
+	if e.ErrorCode != "" {
+		return e.ErrorCode + ": " + e.Description
+	}
+	return e.Description
+}
+
+// NewBadRequestError returns an error for a malformed or invalid request.
+func NewBadRequestError(description string) error {
+	return &Error{StatusCode: http.StatusBadRequest, Description: description}
+}
+
+// NewNotFoundError returns an error for fetching an instance or binding that
+// does not exist.
+func NewNotFoundError(description string) error {
+	return &Error{StatusCode: http.StatusNotFound, Description: description}
+}
+
+// NewConflictError returns an error for creating an instance or binding that
+// already exists with different attributes.
+func NewConflictError(description string) error {
+	return &Error{StatusCode: http.StatusConflict, Description: description}
+}
+
+// NewGoneError returns an error for deleting an instance or binding that does
+// not exist, or for polling an operation that deleted it.
+func NewGoneError(description string) error {
+	return &Error{StatusCode: http.StatusGone, Description: description}
+}
+
+// NewAsyncRequiredError returns an error for a request that can only be
+// fulfilled asynchronously, when the platform does not accept incomplete
+// operations.
+func NewAsyncRequiredError(description string) error {
+	return &Error{StatusCode: http.StatusUnprocessableEntity, ErrorCode: brokerapi.ErrorAsyncRequired, Description: description}
+}
+
+// NewConcurrencyError returns an error for a request on an instance or binding
+// that has another operation in progress.
+func NewConcurrencyError(description string) error {
+	return &Error{StatusCode: http.StatusUnprocessableEntity, ErrorCode: brokerapi.ErrorConcurrencyError, Description: description}
+}
+
+// NewRequiresAppError returns an error for a bind request without the app
+// GUID that the binding requires.
+func NewRequiresAppError(description string) error {
+	return &Error{StatusCode: http.StatusUnprocessableEntity, ErrorCode: brokerapi.ErrorRequiresApp, Description: description}
+}
@@ -20,18 +20,32 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/controller"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi/openservicebroker/constants"
 	"github.com/kubernetes-incubator/service-catalog/pkg/util"
 
 	"github.com/gorilla/mux"
 )
 
+const (
+	// minAPIVersionMinor is the oldest minor version of the 2.x OSB API that
+	// the server accepts requests for.
+	minAPIVersionMinor = 11
+	// fetchAPIVersionMinor is the minor version of the OSB API that added
+	// fetching instances and bindings, and asynchronous bindings.
+	fetchAPIVersionMinor = 14
+)
+
 type server struct {
 	controller controller.Controller
 }
@@ -48,11 +62,15 @@ func createHandler(c controller.Controller) http.Handler {
 	router.HandleFunc("/v2/catalog", s.catalog).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}/last_operation", s.getServiceInstanceLastOperation).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}", s.createServiceInstance).Methods("PUT")
+	router.HandleFunc("/v2/service_instances/{instance_id}", s.updateServiceInstance).Methods("PATCH")
+	router.HandleFunc("/v2/service_instances/{instance_id}", s.getServiceInstance).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}", s.removeServiceInstance).Methods("DELETE")
+	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}/last_operation", s.getServiceBindingLastOperation).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", s.bind).Methods("PUT")
+	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", s.getServiceBinding).Methods("GET")
 	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", s.unBind).Methods("DELETE")
 
-	return router
+	return checkAPIVersion(router)
 }
 
 // Run creates the HTTP handler based on an implementation of a
@@ -113,7 +131,7 @@ func (s *server) catalog(w http.ResponseWriter, r *http.Request) {
 	if result, err := s.controller.Catalog(); err == nil {
 		util.WriteResponse(w, http.StatusOK, result)
 	} else {
-		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		writeError(w, err)
 	}
 }
 
@@ -128,7 +146,7 @@ func (s *server) getServiceInstanceLastOperation(w http.ResponseWriter, r *http.
 	if result, err := s.controller.GetServiceInstanceLastOperation(instanceID, serviceID, planID, operation); err == nil {
 		util.WriteResponse(w, http.StatusOK, result)
 	} else {
-		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		writeError(w, err)
 	}
 }
 
@@ -142,6 +160,13 @@ func (s *server) createServiceInstance(w http.ResponseWriter, r *http.Request) {
 		util.WriteErrorResponse(w, http.StatusBadRequest, err)
 		return
 	}
+	identity, err := getOriginatingIdentity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.OriginatingIdentity = identity
+	req.AcceptsIncomplete = req.AcceptsIncomplete || acceptsIncomplete(r)
 
 	// TODO: Check if parameters are required, if not, this thing below is ok to leave in,
 	// if they are ,they should be checked. Because if no parameters are passed in, this will
@@ -150,25 +175,116 @@ func (s *server) createServiceInstance(w http.ResponseWriter, r *http.Request) {
 		req.Parameters = make(map[string]interface{})
 	}
 
-	if result, err := s.controller.CreateServiceInstance(id, &req); err == nil {
+	result, err := s.controller.CreateServiceInstance(id, &req)
+	switch {
+	case err != nil:
+		writeError(w, err)
+	case result.Async && !req.AcceptsIncomplete:
+		writeUnexpectedAsyncError(w)
+	case result.Async:
+		util.WriteResponse(w, http.StatusAccepted, result)
+	case result.Exists:
+		util.WriteResponse(w, http.StatusOK, result)
+	default:
 		util.WriteResponse(w, http.StatusCreated, result)
-	} else {
+	}
+}
+
+func (s *server) updateServiceInstance(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["instance_id"]
+	glog.Infof("UpdateServiceInstance %s...\n", id)
+
+	var req brokerapi.UpdateServiceInstanceRequest
+	if err := util.BodyToObject(r, &req); err != nil {
+		glog.Errorf("error unmarshalling: %v", err)
 		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	identity, err := getOriginatingIdentity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.OriginatingIdentity = identity
+	req.AcceptsIncomplete = req.AcceptsIncomplete || acceptsIncomplete(r)
+
+	result, err := s.controller.UpdateServiceInstance(id, &req)
+	switch {
+	case err != nil:
+		writeError(w, err)
+	case result.Async && !req.AcceptsIncomplete:
+		writeUnexpectedAsyncError(w)
+	case result.Async:
+		util.WriteResponse(w, http.StatusAccepted, result)
+	default:
+		util.WriteResponse(w, http.StatusOK, result)
+	}
+}
+
+func (s *server) getServiceInstance(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["instance_id"]
+	glog.Infof("GetServiceInstance %s...\n", id)
+
+	if err := requireAPIVersion(r, fetchAPIVersionMinor); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if result, err := s.controller.GetServiceInstance(id); err == nil {
+		util.WriteResponse(w, http.StatusOK, result)
+	} else {
+		writeError(w, err)
 	}
 }
 
 func (s *server) removeServiceInstance(w http.ResponseWriter, r *http.Request) {
 	instanceID := mux.Vars(r)["instance_id"]
 	q := r.URL.Query()
+	req := brokerapi.DeleteServiceInstanceRequest{
+		ServiceID:         q.Get("service_id"),
+		PlanID:            q.Get("plan_id"),
+		AcceptsIncomplete: acceptsIncomplete(r),
+	}
+	glog.Infof("RemoveServiceInstance %s...\n", instanceID)
+
+	identity, err := getOriginatingIdentity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.OriginatingIdentity = identity
+
+	result, err := s.controller.RemoveServiceInstance(instanceID, &req)
+	switch {
+	case err != nil:
+		writeError(w, err)
+	case result.Async && !req.AcceptsIncomplete:
+		writeUnexpectedAsyncError(w)
+	case result.Async:
+		util.WriteResponse(w, http.StatusAccepted, result)
+	default:
+		util.WriteResponse(w, http.StatusOK, result)
+	}
+}
+
+func (s *server) getServiceBindingLastOperation(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+	bindingID := mux.Vars(r)["binding_id"]
+	q := r.URL.Query()
 	serviceID := q.Get("service_id")
 	planID := q.Get("plan_id")
-	acceptsIncomplete := q.Get("accepts_incomplete") == "true"
-	glog.Infof("RemoveServiceInstance %s...\n", instanceID)
+	operation := q.Get("operation")
+	glog.Infof("GetServiceBindingLastOperation binding_id=%s, instance_id=%s\n", bindingID, instanceID)
+
+	if err := requireAPIVersion(r, fetchAPIVersionMinor); err != nil {
+		writeError(w, err)
+		return
+	}
 
-	if result, err := s.controller.RemoveServiceInstance(instanceID, serviceID, planID, acceptsIncomplete); err == nil {
+	if result, err := s.controller.GetServiceBindingLastOperation(instanceID, bindingID, serviceID, planID, operation); err == nil {
 		util.WriteResponse(w, http.StatusOK, result)
 	} else {
-		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		writeError(w, err)
 	}
 }
 
@@ -185,6 +301,13 @@ func (s *server) bind(w http.ResponseWriter, r *http.Request) {
 		util.WriteErrorResponse(w, http.StatusBadRequest, err)
 		return
 	}
+	identity, err := getOriginatingIdentity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.OriginatingIdentity = identity
+	req.AcceptsIncomplete = req.AcceptsIncomplete || acceptsIncomplete(r)
 
 	// TODO: Check if parameters are required, if not, this thing below is ok to leave in,
 	// if they are ,they should be checked. Because if no parameters are passed in, this will
@@ -196,10 +319,40 @@ func (s *server) bind(w http.ResponseWriter, r *http.Request) {
 	// Pass in the instanceId to the template.
 	req.Parameters["instanceId"] = instanceID
 
-	if result, err := s.controller.Bind(instanceID, bindingID, &req); err == nil {
+	// Asynchronous bindings can only be polled from API version 2.14 on.
+	if requireAPIVersion(r, fetchAPIVersionMinor) != nil {
+		req.AcceptsIncomplete = false
+	}
+
+	result, err := s.controller.Bind(instanceID, bindingID, &req)
+	switch {
+	case err != nil:
+		writeError(w, err)
+	case result.Async && !req.AcceptsIncomplete:
+		writeUnexpectedAsyncError(w)
+	case result.Async:
+		util.WriteResponse(w, http.StatusAccepted, result)
+	case result.Exists:
+		util.WriteResponse(w, http.StatusOK, result)
+	default:
+		util.WriteResponse(w, http.StatusCreated, result)
+	}
+}
+
+func (s *server) getServiceBinding(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+	bindingID := mux.Vars(r)["binding_id"]
+	glog.Infof("GetServiceBinding binding_id=%s, instance_id=%s\n", bindingID, instanceID)
+
+	if err := requireAPIVersion(r, fetchAPIVersionMinor); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if result, err := s.controller.GetServiceBinding(instanceID, bindingID); err == nil {
 		util.WriteResponse(w, http.StatusOK, result)
 	} else {
-		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		writeError(w, err)
 	}
 }
 
@@ -207,15 +360,110 @@ func (s *server) unBind(w http.ResponseWriter, r *http.Request) {
 	instanceID := mux.Vars(r)["instance_id"]
 	bindingID := mux.Vars(r)["binding_id"]
 	q := r.URL.Query()
-	serviceID := q.Get("service_id")
-	planID := q.Get("plan_id")
+	req := brokerapi.UnbindRequest{
+		ServiceID: q.Get("service_id"),
+		PlanID:    q.Get("plan_id"),
+	}
 	glog.Infof("UnBind: Service instance guid: %s:%s", bindingID, instanceID)
 
-	if err := s.controller.UnBind(instanceID, bindingID, serviceID, planID); err == nil {
-		w.WriteHeader(http.StatusOK)
+	identity, err := getOriginatingIdentity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.OriginatingIdentity = identity
+
+	if err := s.controller.UnBind(instanceID, bindingID, &req); err == nil {
 		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, "{}") //id)
 	} else {
-		util.WriteErrorResponse(w, http.StatusBadRequest, err)
+		writeError(w, err)
+	}
+}
+
+// checkAPIVersion rejects the requests for OSB API versions that the server
+// does not support with a 412 Precondition Failed.
+func checkAPIVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := requireAPIVersion(r, minAPIVersionMinor); err != nil {
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireAPIVersion returns an error if the OSB API version of the request is
+// not 2.x with at least the given minor version.
+func requireAPIVersion(r *http.Request, minMinor int) error {
+	version := r.Header.Get(constants.APIVersionHeader)
+	if version == "" {
+		return &controller.Error{
+			StatusCode:  http.StatusPreconditionFailed,
+			Description: fmt.Sprintf("The %s header is required.", constants.APIVersionHeader),
+		}
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) == 2 && parts[0] == "2" {
+		if minor, err := strconv.Atoi(parts[1]); err == nil && minor >= minMinor {
+			return nil
+		}
+	}
+	return &controller.Error{
+		StatusCode:  http.StatusPreconditionFailed,
+		Description: fmt.Sprintf("The request requires API version 2.%d or later, got %q.", minMinor, version),
+	}
+}
+
+// getOriginatingIdentity parses the originating identity header of the
+// request, which is made of the platform and the base64 encoded JSON object
+// identifying the user, separated by a space. It returns nil if the request
+// has no originating identity.
+func getOriginatingIdentity(r *http.Request) (*brokerapi.OriginatingIdentity, error) {
+	header := r.Header.Get(constants.OriginatingIdentityHeader)
+	if header == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, controller.NewBadRequestError(fmt.Sprintf("The %s header must be a platform and a value separated by a space.", constants.OriginatingIdentityHeader))
+	}
+	value, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil || !json.Valid(value) {
+		return nil, controller.NewBadRequestError(fmt.Sprintf("The value of the %s header must be a base64 encoded JSON object.", constants.OriginatingIdentityHeader))
+	}
+	return &brokerapi.OriginatingIdentity{
+		Platform: parts[0],
+		Value:    string(value),
+	}, nil
+}
+
+// acceptsIncomplete returns whether the platform accepts asynchronous
+// operations for the request.
+func acceptsIncomplete(r *http.Request) bool {
+	return r.URL.Query().Get("accepts_incomplete") == "true"
+}
+
+// writeUnexpectedAsyncError writes the response for an asynchronous result
+// of a request that does not accept incomplete results. Controllers refuse
+// such requests with controller.NewAsyncRequiredError instead of starting
+// the operation, so this is a bug of the controller.
+func writeUnexpectedAsyncError(w http.ResponseWriter) {
+	glog.Error("The controller started an asynchronous operation for a request that does not accept incomplete results")
+	util.WriteErrorResponse(w, http.StatusInternalServerError, errors.New("the operation was started asynchronously, although the request does not accept incomplete results"))
+}
+
+// writeError writes the error response for an error returned by a controller.
+// Errors of type controller.Error are returned with their status code as OSB
+// error responses, all other errors as bad requests.
+func writeError(w http.ResponseWriter, err error) {
+	if e, ok := err.(*controller.Error); ok {
+		util.WriteResponse(w, e.StatusCode, &brokerapi.ErrorResponse{
+			Error:       e.ErrorCode,
+			Description: e.Description,
+		})
+		return
 	}
+	util.WriteErrorResponse(w, http.StatusBadRequest, err)
 }
@@ -17,14 +17,20 @@ limitations under the License.
 package server
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/controller"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi/openservicebroker/constants"
 )
 
 //
@@ -44,7 +50,7 @@ func TestCatalogReturnsHTTPErrorOnError(t *testing.T) {
 	})
 
 	rr := httptest.NewRecorder()
-	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/v2/catalog", nil))
+	handler.ServeHTTP(rr, newRequest("GET", "/v2/catalog", nil))
 
 	if rr.Code != http.StatusBadRequest {
 		t.Errorf("Expected HTTP status http.StatusBadRequest (%d), got %d", http.StatusBadRequest, rr.Code)
@@ -72,7 +78,7 @@ func TestCatalogReturnsCompliantJSON(t *testing.T) {
 		}})
 
 	rr := httptest.NewRecorder()
-	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/v2/catalog", nil))
+	handler.ServeHTTP(rr, newRequest("GET", "/v2/catalog", nil))
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("Expected HTTP status http.StatusOK (%d), got %d", http.StatusOK, rr.Code)
@@ -116,14 +122,351 @@ func readJSON(rr *httptest.ResponseRecorder) (map[string]interface{}, error) {
 	return result, err
 }
 
+// newRequest returns a request for the latest supported OSB API version.
+func newRequest(method, target string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(method, target, body)
+	r.Header.Set(constants.APIVersionHeader, "2.14")
+	return r
+}
+
+// Requests without a supported API version are rejected.
+func TestAPIVersionNegotiation(t *testing.T) {
+	handler := createHandler(&Controller{
+		t: t,
+		catalog: func() (*brokerapi.Catalog, error) {
+			return &brokerapi.Catalog{}, nil
+		},
+		getServiceInstance: func(id string) (*brokerapi.GetServiceInstanceResponse, error) {
+			return &brokerapi.GetServiceInstanceResponse{}, nil
+		},
+	})
+
+	cases := []struct {
+		name         string
+		path         string
+		version      string
+		expectedCode int
+	}{
+		{name: "no version", path: "/v2/catalog", expectedCode: http.StatusPreconditionFailed},
+		{name: "invalid version", path: "/v2/catalog", version: "latest", expectedCode: http.StatusPreconditionFailed},
+		{name: "unsupported major version", path: "/v2/catalog", version: "3.0", expectedCode: http.StatusPreconditionFailed},
+		{name: "unsupported minor version", path: "/v2/catalog", version: "2.10", expectedCode: http.StatusPreconditionFailed},
+		{name: "oldest supported version", path: "/v2/catalog", version: "2.11", expectedCode: http.StatusOK},
+		{name: "newer minor version", path: "/v2/catalog", version: "2.15", expectedCode: http.StatusOK},
+		{name: "fetch instance before 2.14", path: "/v2/service_instances/i", version: "2.13", expectedCode: http.StatusPreconditionFailed},
+		{name: "fetch instance", path: "/v2/service_instances/i", version: "2.14", expectedCode: http.StatusOK},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest("GET", tc.path, nil)
+		if tc.version != "" {
+			r.Header.Set(constants.APIVersionHeader, tc.version)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, r)
+		if rr.Code != tc.expectedCode {
+			t.Errorf("%v: expected HTTP status %d, got %d", tc.name, tc.expectedCode, rr.Code)
+		}
+	}
+}
+
+// The originating identity header is parsed and passed to the controller.
+func TestOriginatingIdentity(t *testing.T) {
+	var identity *brokerapi.OriginatingIdentity
+	handler := createHandler(&Controller{
+		t: t,
+		createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+			identity = req.OriginatingIdentity
+			return &brokerapi.CreateServiceInstanceResponse{}, nil
+		},
+	})
+
+	cases := []struct {
+		name             string
+		header           string
+		expectedCode     int
+		expectedIdentity *brokerapi.OriginatingIdentity
+	}{
+		{name: "no identity", expectedCode: http.StatusCreated},
+		{
+			name:             "identity",
+			header:           "kubernetes " + base64.StdEncoding.EncodeToString([]byte(`{"username":"alice"}`)),
+			expectedCode:     http.StatusCreated,
+			expectedIdentity: &brokerapi.OriginatingIdentity{Platform: "kubernetes", Value: `{"username":"alice"}`},
+		},
+		{name: "no value", header: "kubernetes", expectedCode: http.StatusBadRequest},
+		{name: "value not base64", header: "kubernetes {}", expectedCode: http.StatusBadRequest},
+		{name: "value not JSON", header: "kubernetes " + base64.StdEncoding.EncodeToString([]byte("alice")), expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		identity = nil
+		r := newRequest("PUT", "/v2/service_instances/i", strings.NewReader(`{"service_id":"s","plan_id":"p"}`))
+		if tc.header != "" {
+			r.Header.Set(constants.OriginatingIdentityHeader, tc.header)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, r)
+		if rr.Code != tc.expectedCode {
+			t.Errorf("%v: expected HTTP status %d, got %d", tc.name, tc.expectedCode, rr.Code)
+		}
+		if !reflect.DeepEqual(tc.expectedIdentity, identity) {
+			t.Errorf("%v: expected originating identity %+v, got %+v", tc.name, tc.expectedIdentity, identity)
+		}
+	}
+}
+
+// Responses and controller errors are mapped to the HTTP status codes of the
+// OSB API.
+func TestStatusCodes(t *testing.T) {
+	cases := []struct {
+		name         string
+		controller   *Controller
+		method       string
+		path         string
+		body         string
+		apiVersion   string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "provision",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				return &brokerapi.CreateServiceInstanceResponse{}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "provision existing instance",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				return &brokerapi.CreateServiceInstanceResponse{Exists: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "provision asynchronously",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				return &brokerapi.CreateServiceInstanceResponse{Async: true, Operation: "op"}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i?accepts_incomplete=true",
+			expectedCode: http.StatusAccepted,
+			expectedBody: `{"operation":"op"}`,
+		},
+		{
+			name: "provision asynchronously without accepts_incomplete",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				if !req.AcceptsIncomplete {
+					return nil, controller.NewAsyncRequiredError("async required")
+				}
+				return &brokerapi.CreateServiceInstanceResponse{Async: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"AsyncRequired","description":"async required"}`,
+		},
+		{
+			name: "provision asynchronously ignoring accepts_incomplete",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				return &brokerapi.CreateServiceInstanceResponse{Async: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "provision conflict",
+			controller: &Controller{createServiceInstance: func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error) {
+				return nil, controller.NewConflictError("conflict")
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"description":"conflict"}`,
+		},
+		{
+			name: "update",
+			controller: &Controller{updateServiceInstance: func(id string, req *brokerapi.UpdateServiceInstanceRequest) (*brokerapi.UpdateServiceInstanceResponse, error) {
+				if req.PlanID != "p" {
+					return nil, fmt.Errorf("unexpected plan %q", req.PlanID)
+				}
+				return &brokerapi.UpdateServiceInstanceResponse{}, nil
+			}},
+			method:       "PATCH",
+			path:         "/v2/service_instances/i",
+			body:         `{"service_id":"s","plan_id":"p"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "update concurrently",
+			controller: &Controller{updateServiceInstance: func(id string, req *brokerapi.UpdateServiceInstanceRequest) (*brokerapi.UpdateServiceInstanceResponse, error) {
+				return nil, controller.NewConcurrencyError("in progress")
+			}},
+			method:       "PATCH",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"ConcurrencyError","description":"in progress"}`,
+		},
+		{
+			name: "fetch instance",
+			controller: &Controller{getServiceInstance: func(id string) (*brokerapi.GetServiceInstanceResponse, error) {
+				return &brokerapi.GetServiceInstanceResponse{ServiceID: "s", PlanID: "p"}, nil
+			}},
+			method:       "GET",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"service_id":"s","plan_id":"p"}`,
+		},
+		{
+			name: "fetch missing instance",
+			controller: &Controller{getServiceInstance: func(id string) (*brokerapi.GetServiceInstanceResponse, error) {
+				return nil, controller.NewNotFoundError("not found")
+			}},
+			method:       "GET",
+			path:         "/v2/service_instances/i",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "deprovision missing instance",
+			controller: &Controller{removeServiceInstance: func(id string) (*brokerapi.DeleteServiceInstanceResponse, error) {
+				return nil, controller.NewGoneError("gone")
+			}},
+			method:       "DELETE",
+			path:         "/v2/service_instances/i?service_id=s&plan_id=p",
+			expectedCode: http.StatusGone,
+		},
+		{
+			name: "bind",
+			controller: &Controller{bind: func(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
+				return &brokerapi.CreateServiceBindingResponse{Credentials: brokerapi.Credential{"user": "u"}}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i/service_bindings/b",
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"credentials":{"user":"u"}}`,
+		},
+		{
+			name: "bind existing binding",
+			controller: &Controller{bind: func(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
+				return &brokerapi.CreateServiceBindingResponse{Exists: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i/service_bindings/b",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bind asynchronously",
+			controller: &Controller{bind: func(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
+				return &brokerapi.CreateServiceBindingResponse{Async: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i/service_bindings/b?accepts_incomplete=true",
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "bind asynchronously before API version 2.14",
+			controller: &Controller{bind: func(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
+				if !req.AcceptsIncomplete {
+					return nil, controller.NewAsyncRequiredError("async required")
+				}
+				return &brokerapi.CreateServiceBindingResponse{Async: true}, nil
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i/service_bindings/b?accepts_incomplete=true",
+			apiVersion:   "2.13",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"AsyncRequired","description":"async required"}`,
+		},
+		{
+			name: "bind requires app",
+			controller: &Controller{bind: func(instanceID, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
+				return nil, controller.NewRequiresAppError("app required")
+			}},
+			method:       "PUT",
+			path:         "/v2/service_instances/i/service_bindings/b",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"RequiresApp","description":"app required"}`,
+		},
+		{
+			name: "fetch binding",
+			controller: &Controller{getServiceBinding: func(instanceID, bindingID string) (*brokerapi.GetServiceBindingResponse, error) {
+				return &brokerapi.GetServiceBindingResponse{Credentials: brokerapi.Credential{"user": "u"}}, nil
+			}},
+			method:       "GET",
+			path:         "/v2/service_instances/i/service_bindings/b",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"credentials":{"user":"u"}}`,
+		},
+		{
+			name: "poll binding",
+			controller: &Controller{getServiceBindingLastOperation: func(instanceID, bindingID string) (*brokerapi.LastOperationResponse, error) {
+				return &brokerapi.LastOperationResponse{State: brokerapi.StateSucceeded}, nil
+			}},
+			method:       "GET",
+			path:         "/v2/service_instances/i/service_bindings/b/last_operation",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"state":"succeeded"}`,
+		},
+		{
+			name: "unbind",
+			controller: &Controller{unBind: func(instanceID, bindingID string) error {
+				return nil
+			}},
+			method:       "DELETE",
+			path:         "/v2/service_instances/i/service_bindings/b?service_id=s&plan_id=p",
+			expectedCode: http.StatusOK,
+			expectedBody: `{}`,
+		},
+		{
+			name: "unbind missing binding",
+			controller: &Controller{unBind: func(instanceID, bindingID string) error {
+				return controller.NewGoneError("gone")
+			}},
+			method:       "DELETE",
+			path:         "/v2/service_instances/i/service_bindings/b?service_id=s&plan_id=p",
+			expectedCode: http.StatusGone,
+		},
+	}
+
+	for _, tc := range cases {
+		tc.controller.t = t
+		handler := createHandler(tc.controller)
+
+		body := tc.body
+		if body == "" && tc.method != "GET" && tc.method != "DELETE" {
+			body = `{}`
+		}
+		r := newRequest(tc.method, tc.path, strings.NewReader(body))
+		if tc.apiVersion != "" {
+			r.Header.Set(constants.APIVersionHeader, tc.apiVersion)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, r)
+
+		if rr.Code != tc.expectedCode {
+			t.Errorf("%v: expected HTTP status %d, got %d: %s", tc.name, tc.expectedCode, rr.Code, rr.Body.String())
+		}
+		if tc.expectedBody != "" && rr.Body.String() != tc.expectedBody {
+			t.Errorf("%v: expected body %s, got %s", tc.name, tc.expectedBody, rr.Body.String())
+		}
+	}
+}
+
 type Controller struct {
 	t *testing.T
 
 	catalog                         func() (*brokerapi.Catalog, error)
 	getServiceInstanceLastOperation func(id string) (*brokerapi.LastOperationResponse, error)
 	createServiceInstance           func(id string, req *brokerapi.CreateServiceInstanceRequest) (*brokerapi.CreateServiceInstanceResponse, error)
+	updateServiceInstance           func(id string, req *brokerapi.UpdateServiceInstanceRequest) (*brokerapi.UpdateServiceInstanceResponse, error)
+	getServiceInstance              func(id string) (*brokerapi.GetServiceInstanceResponse, error)
 	removeServiceInstance           func(id string) (*brokerapi.DeleteServiceInstanceResponse, error)
+	getServiceBindingLastOperation  func(instanceID string, bindingID string) (*brokerapi.LastOperationResponse, error)
 	bind                            func(instanceID string, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error)
+	getServiceBinding               func(instanceID string, bindingID string) (*brokerapi.GetServiceBindingResponse, error)
 	unBind                          func(instanceID string, bindingID string) error
 }
 
@@ -151,7 +494,23 @@ func (controller *Controller) CreateServiceInstance(id string, req *brokerapi.Cr
 	return controller.createServiceInstance(id, req)
 }
 
-func (controller *Controller) RemoveServiceInstance(instanceID, serviceID, planID string, acceptsIncomplete bool) (*brokerapi.DeleteServiceInstanceResponse, error) {
+func (controller *Controller) UpdateServiceInstance(id string, req *brokerapi.UpdateServiceInstanceRequest) (*brokerapi.UpdateServiceInstanceResponse, error) {
+	if controller.updateServiceInstance == nil {
+		controller.t.Error("Test failed to provide 'updateServiceInstance' handler")
+	}
+
+	return controller.updateServiceInstance(id, req)
+}
+
+func (controller *Controller) GetServiceInstance(id string) (*brokerapi.GetServiceInstanceResponse, error) {
+	if controller.getServiceInstance == nil {
+		controller.t.Error("Test failed to provide 'getServiceInstance' handler")
+	}
+
+	return controller.getServiceInstance(id)
+}
+
+func (controller *Controller) RemoveServiceInstance(instanceID string, req *brokerapi.DeleteServiceInstanceRequest) (*brokerapi.DeleteServiceInstanceResponse, error) {
 	if controller.removeServiceInstance == nil {
 		controller.t.Error("Test failed to provide 'removeServiceInstance' handler")
 	}
@@ -159,6 +518,14 @@ func (controller *Controller) RemoveServiceInstance(instanceID, serviceID, planI
 	return controller.removeServiceInstance(instanceID)
 }
 
+func (controller *Controller) GetServiceBindingLastOperation(instanceID, bindingID, serviceID, planID, operation string) (*brokerapi.LastOperationResponse, error) {
+	if controller.getServiceBindingLastOperation == nil {
+		controller.t.Error("Test failed to provide 'getServiceBindingLastOperation' handler")
+	}
+
+	return controller.getServiceBindingLastOperation(instanceID, bindingID)
+}
+
 func (controller *Controller) Bind(instanceID string, bindingID string, req *brokerapi.BindingRequest) (*brokerapi.CreateServiceBindingResponse, error) {
 	if controller.bind == nil {
 		controller.t.Error("Test failed to provide 'bind' handler")
@@ -167,7 +534,15 @@ func (controller *Controller) Bind(instanceID string, bindingID string, req *bro
 	return controller.bind(instanceID, bindingID, req)
 }
 
-func (controller *Controller) UnBind(instanceID, bindingID, serviceID, planID string) error {
+func (controller *Controller) GetServiceBinding(instanceID, bindingID string) (*brokerapi.GetServiceBindingResponse, error) {
+	if controller.getServiceBinding == nil {
+		controller.t.Error("Test failed to provide 'getServiceBinding' handler")
+	}
+
+	return controller.getServiceBinding(instanceID, bindingID)
+}
+
+func (controller *Controller) UnBind(instanceID, bindingID string, req *brokerapi.UnbindRequest) error {
 	if controller.unBind == nil {
 		controller.t.Error("Test failed to provide 'unBind' handler")
 	}
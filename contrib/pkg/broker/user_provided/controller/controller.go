@@ -20,10 +20,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/controller"
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/user_provided/storage"
 	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
 )
 
@@ -35,21 +37,23 @@ func (e errNoSuchInstance) Error() string {
 	return fmt.Sprintf("no such instance with ID %s", e.instanceID)
 }
 
-type userProvidedServiceInstance struct {
-	Name       string
-	Credential *brokerapi.Credential
-}
-
 type userProvidedController struct {
-	rwMutex     sync.RWMutex
-	instanceMap map[string]*userProvidedServiceInstance
+	// rwMutex serializes the operations that read and then write instances.
+	rwMutex sync.RWMutex
+	storage storage.Storage
 }
 
-// CreateController creates an instance of a User Provided service broker controller.
+// CreateController creates an instance of a User Provided service broker controller
+// that keeps its instances in memory.
 func CreateController() controller.Controller {
-	var instanceMap = make(map[string]*userProvidedServiceInstance)
+	return CreateControllerWithStorage(storage.NewMemoryStorage())
+}
+
+// CreateControllerWithStorage creates an instance of a User Provided service broker
+// controller that keeps its instances in the given storage.
+func CreateControllerWithStorage(s storage.Storage) controller.Controller {
 	return &userProvidedController{
-		instanceMap: instanceMap,
+		storage: s,
 	}
 }
 
@@ -73,8 +77,10 @@ func (c *userProvidedController) Catalog() (*brokerapi.Catalog, error) {
 					Free:        false,
 				},
 				},
-				Bindable:       true,
-				PlanUpdateable: true,
+				Bindable:             true,
+				PlanUpdateable:       true,
+				InstancesRetrievable: true,
+				BindingsRetrievable:  true,
 			},
 			{
 				Name:        "user-provided-service-single-plan",
@@ -88,8 +94,10 @@ func (c *userProvidedController) Catalog() (*brokerapi.Catalog, error) {
 						Free:        true,
 					},
 				},
-				Bindable:       true,
-				PlanUpdateable: true,
+				Bindable:             true,
+				PlanUpdateable:       true,
+				InstancesRetrievable: true,
+				BindingsRetrievable:  true,
 			},
 			{
 				Name:        "user-provided-service-with-schemas",
@@ -178,8 +186,10 @@ func (c *userProvidedController) Catalog() (*brokerapi.Catalog, error) {
 						},
 					},
 				},
-				Bindable:       true,
-				PlanUpdateable: true,
+				Bindable:             true,
+				PlanUpdateable:       true,
+				InstancesRetrievable: true,
+				BindingsRetrievable:  true,
 			},
 		},
 	}, nil
@@ -190,38 +200,120 @@ func (c *userProvidedController) CreateServiceInstance(
 	req *brokerapi.CreateServiceInstanceRequest,
 ) (*brokerapi.CreateServiceInstanceResponse, error) {
 	glog.Info("CreateServiceInstance()")
-	credString, ok := req.Parameters["credentials"]
 	c.rwMutex.Lock()
 	defer c.rwMutex.Unlock()
-	if ok {
-		jsonCred, err := json.Marshal(credString)
-		if err != nil {
-			glog.Errorf("Failed to marshal credentials: %v", err)
-			return nil, err
-		}
-		var cred brokerapi.Credential
-		err = json.Unmarshal(jsonCred, &cred)
-		if err != nil {
-			glog.Errorf("Failed to unmarshal credentials: %v", err)
-			return nil, err
+	instance, err := c.storage.GetInstance(id)
+	if err != nil {
+		return nil, err
+	}
+	if instance != nil {
+		if instance.ServiceID != req.ServiceID || instance.PlanID != req.PlanID || !reflect.DeepEqual(instance.Parameters, req.Parameters) {
+			return nil, controller.NewConflictError(fmt.Sprintf("instance with ID %s already exists with different attributes", id))
 		}
+		return &brokerapi.CreateServiceInstanceResponse{Exists: true}, nil
+	}
 
-		c.instanceMap[id] = &userProvidedServiceInstance{
-			Name:       id,
-			Credential: &cred,
+	cred, err := credentialsFromParameters(req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	instance = &storage.Instance{
+		ID:         id,
+		ServiceID:  req.ServiceID,
+		PlanID:     req.PlanID,
+		Parameters: req.Parameters,
+		Credential: cred,
+	}
+	if err := c.storage.PutInstance(instance); err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Created User Provided Service Instance:\n%v\n", instance.ID)
+	return &brokerapi.CreateServiceInstanceResponse{}, nil
+}
+
+// credentialsFromParameters returns the credentials given in the parameters of
+// an instance, or sample credentials if there are none.
+func credentialsFromParameters(parameters map[string]interface{}) (brokerapi.Credential, error) {
+	credString, ok := parameters["credentials"]
+	if !ok {
+		return brokerapi.Credential{
+			"special-key-1": "special-value-1",
+			"special-key-2": "special-value-2",
+		}, nil
+	}
+
+	jsonCred, err := json.Marshal(credString)
+	if err != nil {
+		glog.Errorf("Failed to marshal credentials: %v", err)
+		return nil, err
+	}
+	var cred brokerapi.Credential
+	err = json.Unmarshal(jsonCred, &cred)
+	if err != nil {
+		glog.Errorf("Failed to unmarshal credentials: %v", err)
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (c *userProvidedController) UpdateServiceInstance(
+	id string,
+	req *brokerapi.UpdateServiceInstanceRequest,
+) (*brokerapi.UpdateServiceInstanceResponse, error) {
+	glog.Info("UpdateServiceInstance()")
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	instance, err := c.storage.GetInstance(id)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, errNoSuchInstance{instanceID: id}
+	}
+
+	if req.PlanID != "" {
+		instance.PlanID = req.PlanID
+	}
+	if len(req.Parameters) > 0 {
+		if _, ok := req.Parameters["credentials"]; ok {
+			cred, err := credentialsFromParameters(req.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			instance.Credential = cred
 		}
-	} else {
-		c.instanceMap[id] = &userProvidedServiceInstance{
-			Name: id,
-			Credential: &brokerapi.Credential{
-				"special-key-1": "special-value-1",
-				"special-key-2": "special-value-2",
-			},
+		if instance.Parameters == nil {
+			instance.Parameters = make(map[string]interface{})
+		}
+		for k, v := range req.Parameters {
+			instance.Parameters[k] = v
 		}
 	}
+	if err := c.storage.PutInstance(instance); err != nil {
+		return nil, err
+	}
 
-	glog.Infof("Created User Provided Service Instance:\n%v\n", c.instanceMap[id])
-	return &brokerapi.CreateServiceInstanceResponse{}, nil
+	glog.Infof("Updated User Provided Service Instance:\n%v\n", instance.ID)
+	return &brokerapi.UpdateServiceInstanceResponse{}, nil
+}
+
+func (c *userProvidedController) GetServiceInstance(id string) (*brokerapi.GetServiceInstanceResponse, error) {
+	glog.Info("GetServiceInstance()")
+	c.rwMutex.RLock()
+	defer c.rwMutex.RUnlock()
+	instance, err := c.storage.GetInstance(id)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, controller.NewNotFoundError(errNoSuchInstance{instanceID: id}.Error())
+	}
+	return &brokerapi.GetServiceInstanceResponse{
+		ServiceID:  instance.ServiceID,
+		PlanID:     instance.PlanID,
+		Parameters: instance.Parameters,
+	}, nil
 }
 
 func (c *userProvidedController) GetServiceInstanceLastOperation(
@@ -235,41 +327,103 @@ func (c *userProvidedController) GetServiceInstanceLastOperation(
 }
 
 func (c *userProvidedController) RemoveServiceInstance(
-	instanceID,
-	serviceID,
-	planID string,
-	acceptsIncomplete bool,
+	instanceID string,
+	req *brokerapi.DeleteServiceInstanceRequest,
 ) (*brokerapi.DeleteServiceInstanceResponse, error) {
 	glog.Info("RemoveServiceInstance()")
 	c.rwMutex.Lock()
 	defer c.rwMutex.Unlock()
-	_, ok := c.instanceMap[instanceID]
-	if ok {
-		delete(c.instanceMap, instanceID)
-		return &brokerapi.DeleteServiceInstanceResponse{}, nil
+	instance, err := c.storage.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, controller.NewGoneError(errNoSuchInstance{instanceID: instanceID}.Error())
+	}
+	if err := c.storage.DeleteInstance(instanceID); err != nil {
+		return nil, err
 	}
-
 	return &brokerapi.DeleteServiceInstanceResponse{}, nil
 }
 
+func (c *userProvidedController) GetServiceBindingLastOperation(
+	instanceID,
+	bindingID,
+	serviceID,
+	planID,
+	operation string,
+) (*brokerapi.LastOperationResponse, error) {
+	glog.Info("GetServiceBindingLastOperation()")
+	return nil, errors.New("Unimplemented")
+}
+
 func (c *userProvidedController) Bind(
 	instanceID,
 	bindingID string,
 	req *brokerapi.BindingRequest,
 ) (*brokerapi.CreateServiceBindingResponse, error) {
 	glog.Info("Bind()")
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	instance, err := c.storage.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, errNoSuchInstance{instanceID: instanceID}
+	}
+	if parameters, ok := instance.Bindings[bindingID]; ok {
+		if !reflect.DeepEqual(parameters, req.Parameters) {
+			return nil, controller.NewConflictError(fmt.Sprintf("binding with ID %s already exists with different parameters", bindingID))
+		}
+		return &brokerapi.CreateServiceBindingResponse{Credentials: instance.Credential, Exists: true}, nil
+	}
+
+	if instance.Bindings == nil {
+		instance.Bindings = make(map[string]map[string]interface{})
+	}
+	instance.Bindings[bindingID] = req.Parameters
+	if err := c.storage.PutInstance(instance); err != nil {
+		return nil, err
+	}
+	return &brokerapi.CreateServiceBindingResponse{Credentials: instance.Credential}, nil
+}
+
+func (c *userProvidedController) GetServiceBinding(instanceID, bindingID string) (*brokerapi.GetServiceBindingResponse, error) {
+	glog.Info("GetServiceBinding()")
 	c.rwMutex.RLock()
 	defer c.rwMutex.RUnlock()
-	instance, ok := c.instanceMap[instanceID]
+	instance, err := c.storage.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, controller.NewNotFoundError(errNoSuchInstance{instanceID: instanceID}.Error())
+	}
+	parameters, ok := instance.Bindings[bindingID]
 	if !ok {
-		return nil, errNoSuchInstance{instanceID: instanceID}
+		return nil, controller.NewNotFoundError(fmt.Sprintf("no such binding with ID %s", bindingID))
 	}
-	cred := instance.Credential
-	return &brokerapi.CreateServiceBindingResponse{Credentials: *cred}, nil
+	return &brokerapi.GetServiceBindingResponse{
+		Credentials: instance.Credential,
+		Parameters:  parameters,
+	}, nil
 }
 
-func (c *userProvidedController) UnBind(instanceID, bindingID, serviceID, planID string) error {
+func (c *userProvidedController) UnBind(instanceID, bindingID string, req *brokerapi.UnbindRequest) error {
 	glog.Info("UnBind()")
-	// Since we don't persist the binding, there's nothing to do here.
-	return nil
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	instance, err := c.storage.GetInstance(instanceID)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return controller.NewGoneError(errNoSuchInstance{instanceID: instanceID}.Error())
+	}
+	if _, ok := instance.Bindings[bindingID]; !ok {
+		return controller.NewGoneError(fmt.Sprintf("no such binding with ID %s", bindingID))
+	}
+	delete(instance.Bindings, bindingID)
+	return c.storage.PutInstance(instance)
 }
@@ -17,8 +17,12 @@ limitations under the License.
 package controller
 
 import (
-	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/controller"
+	"net/http"
+	"reflect"
 	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/broker/controller"
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
 )
 
 // Make sure that userProvidedController implements Controller interface
@@ -26,3 +30,72 @@ var _ controller.Controller = &userProvidedController{}
 
 func TestController(t *testing.T) {
 }
+
+func TestInstanceAndBindingLifecycle(t *testing.T) {
+	c := CreateController()
+	createReq := &brokerapi.CreateServiceInstanceRequest{
+		ServiceID:  "4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468",
+		PlanID:     "86064792-7ea2-467b-af93-ac9694d96d52",
+		Parameters: map[string]interface{}{"credentials": map[string]interface{}{"user": "u"}},
+	}
+
+	if resp, err := c.CreateServiceInstance("i", createReq); err != nil || resp.Exists {
+		t.Fatalf("unexpected result of provisioning: %+v, %v", resp, err)
+	}
+	if resp, err := c.CreateServiceInstance("i", createReq); err != nil || !resp.Exists {
+		t.Fatalf("expected identical instance to exist, got %+v, %v", resp, err)
+	}
+	conflictReq := *createReq
+	conflictReq.PlanID = "cc0d7529-18e8-416d-8946-6f7456acd589"
+	_, err := c.CreateServiceInstance("i", &conflictReq)
+	expectStatusCode(t, err, http.StatusConflict)
+
+	if _, err := c.UpdateServiceInstance("i", &brokerapi.UpdateServiceInstanceRequest{PlanID: conflictReq.PlanID}); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+	instance, err := c.GetServiceInstance("i")
+	if err != nil {
+		t.Fatalf("unexpected error fetching instance: %v", err)
+	}
+	if e, a := conflictReq.PlanID, instance.PlanID; e != a {
+		t.Errorf("expected plan %q, got %q", e, a)
+	}
+
+	bindReq := &brokerapi.BindingRequest{Parameters: map[string]interface{}{"instanceId": "i"}}
+	if resp, err := c.Bind("i", "b", bindReq); err != nil || resp.Exists {
+		t.Fatalf("unexpected result of binding: %+v, %v", resp, err)
+	}
+	binding, err := c.GetServiceBinding("i", "b")
+	if err != nil {
+		t.Fatalf("unexpected error fetching binding: %v", err)
+	}
+	if e, a := (brokerapi.Credential{"user": "u"}), binding.Credentials; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected credentials %v, got %v", e, a)
+	}
+
+	if err := c.UnBind("i", "b", &brokerapi.UnbindRequest{}); err != nil {
+		t.Fatalf("unexpected error unbinding: %v", err)
+	}
+	expectStatusCode(t, c.UnBind("i", "b", &brokerapi.UnbindRequest{}), http.StatusGone)
+	_, err = c.GetServiceBinding("i", "b")
+	expectStatusCode(t, err, http.StatusNotFound)
+
+	if _, err := c.RemoveServiceInstance("i", &brokerapi.DeleteServiceInstanceRequest{}); err != nil {
+		t.Fatalf("unexpected error deprovisioning: %v", err)
+	}
+	_, err = c.RemoveServiceInstance("i", &brokerapi.DeleteServiceInstanceRequest{})
+	expectStatusCode(t, err, http.StatusGone)
+	_, err = c.GetServiceInstance("i")
+	expectStatusCode(t, err, http.StatusNotFound)
+}
+
+func expectStatusCode(t *testing.T, err error, code int) {
+	e, ok := err.(*controller.Error)
+	if !ok {
+		t.Errorf("expected an error with status code %d, got %v", code, err)
+		return
+	}
+	if e.StatusCode != code {
+		t.Errorf("expected status code %d, got %d", code, e.StatusCode)
+	}
+}
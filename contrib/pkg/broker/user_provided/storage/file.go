@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type fileStorage struct {
+	mutex     sync.RWMutex
+	path      string
+	instances map[string]*Instance
+}
+
+// NewFileStorage returns a Storage that keeps the instances in a JSON file at
+// the given path, which is created if it does not exist. The instances are
+// read from the file once, so it must not be shared between brokers.
+func NewFileStorage(path string) (Storage, error) {
+	s := &fileStorage{
+		path:      path,
+		instances: make(map[string]*Instance),
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instances from %s: %v", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.instances); err != nil {
+			return nil, fmt.Errorf("failed to decode instances from %s: %v", path, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *fileStorage) GetInstance(id string) (*Instance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, nil
+	}
+	return instance.DeepCopy(), nil
+}
+
+func (s *fileStorage) PutInstance(instance *Instance) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	previous, existed := s.instances[instance.ID]
+	s.instances[instance.ID] = instance.DeepCopy()
+	if err := s.save(); err != nil {
+		if existed {
+			s.instances[instance.ID] = previous
+		} else {
+			delete(s.instances, instance.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *fileStorage) DeleteInstance(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	previous, existed := s.instances[id]
+	if !existed {
+		return nil
+	}
+	delete(s.instances, id)
+	if err := s.save(); err != nil {
+		s.instances[id] = previous
+		return err
+	}
+	return nil
+}
+
+// save writes all instances to the file. The file is replaced by renaming a
+// temporary file, so that it is never left partially written.
+func (s *fileStorage) save() error {
+	data, err := json.Marshal(s.instances)
+	if err != nil {
+		return fmt.Errorf("failed to encode instances: %v", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path))
+	if err != nil {
+		return fmt.Errorf("failed to write instances to %s: %v", s.path, err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write instances to %s: %v", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// secretInstanceKey is the key of the JSON encoded instance in the data
+	// of its Secret.
+	secretInstanceKey = "instance.json"
+	// secretLabel marks the Secrets that hold instances.
+	secretLabel = "user-broker.servicecatalog.k8s.io/instance"
+)
+
+type secretStorage struct {
+	client    kubernetes.Interface
+	namespace string
+	prefix    string
+}
+
+// NewSecretStorage returns a Storage that keeps each instance in a Secret in
+// the given namespace. The names of the Secrets start with the given prefix,
+// followed by a hash of the instance ID, since instance IDs are not
+// necessarily valid object names.
+func NewSecretStorage(client kubernetes.Interface, namespace, prefix string) Storage {
+	return &secretStorage{
+		client:    client,
+		namespace: namespace,
+		prefix:    prefix,
+	}
+}
+
+func (s *secretStorage) secretName(id string) string {
+	return fmt.Sprintf("%s%x", s.prefix, sha256.Sum256([]byte(id)))[:len(s.prefix)+32]
+}
+
+func (s *secretStorage) GetInstance(id string) (*Instance, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(s.secretName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance %s: %v", id, err)
+	}
+	instance := &Instance{}
+	if err := json.Unmarshal(secret.Data[secretInstanceKey], instance); err != nil {
+		return nil, fmt.Errorf("failed to decode instance %s from Secret %s/%s: %v", id, s.namespace, secret.Name, err)
+	}
+	return instance, nil
+}
+
+func (s *secretStorage) PutInstance(instance *Instance) error {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return fmt.Errorf("failed to encode instance %s: %v", instance.ID, err)
+	}
+
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	name := s.secretName(instance.ID)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.namespace,
+				Labels:    map[string]string{secretLabel: "true"},
+			},
+			Data: map[string][]byte{secretInstanceKey: data},
+		}
+		if _, err := secrets.Create(secret); err != nil {
+			return fmt.Errorf("failed to create Secret %s/%s for instance %s: %v", s.namespace, name, instance.ID, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Secret %s/%s for instance %s: %v", s.namespace, name, instance.ID, err)
+	}
+
+	secret.Data = map[string][]byte{secretInstanceKey: data}
+	if _, err := secrets.Update(secret); err != nil {
+		return fmt.Errorf("failed to update Secret %s/%s for instance %s: %v", s.namespace, name, instance.ID, err)
+	}
+	return nil
+}
+
+func (s *secretStorage) DeleteInstance(id string) error {
+	name := s.secretName(id)
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Secret %s/%s for instance %s: %v", s.namespace, name, id, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage persists the service instances of the user-provided broker,
+// so that their credentials survive restarts of the broker.
+package storage
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
+)
+
+// Instance is a service instance of the user-provided broker, with its
+// credentials and bindings.
+type Instance struct {
+	ID         string                 `json:"id"`
+	ServiceID  string                 `json:"serviceID,omitempty"`
+	PlanID     string                 `json:"planID,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Credential brokerapi.Credential   `json:"credential,omitempty"`
+	// Bindings holds the parameters of the bindings to the instance by
+	// binding ID.
+	Bindings map[string]map[string]interface{} `json:"bindings,omitempty"`
+}
+
+// Storage stores instances by ID. Implementations must be safe for
+// concurrent use.
+type Storage interface {
+	// GetInstance returns the instance with the given ID, or nil if there is
+	// no such instance.
+	GetInstance(id string) (*Instance, error)
+	// PutInstance creates or replaces an instance.
+	PutInstance(instance *Instance) error
+	// DeleteInstance deletes the instance with the given ID, if it exists.
+	DeleteInstance(id string) error
+}
+
+type memoryStorage struct {
+	mutex     sync.RWMutex
+	instances map[string]*Instance
+}
+
+// NewMemoryStorage returns a Storage that keeps the instances in memory, and
+// loses them when the broker restarts.
+func NewMemoryStorage() Storage {
+	return &memoryStorage{instances: make(map[string]*Instance)}
+}
+
+func (s *memoryStorage) GetInstance(id string) (*Instance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, nil
+	}
+	return instance.DeepCopy(), nil
+}
+
+func (s *memoryStorage) PutInstance(instance *Instance) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.instances[instance.ID] = instance.DeepCopy()
+	return nil
+}
+
+func (s *memoryStorage) DeleteInstance(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.instances, id)
+	return nil
+}
+
+// DeepCopy returns a deep copy of the instance, so that the instances that a
+// Storage returns can be modified without changing the stored ones.
+func (in *Instance) DeepCopy() *Instance {
+	if in == nil {
+		return nil
+	}
+	// The parameters and credentials are arbitrary JSON, so copying them
+	// through JSON is the simplest way to copy them deeply.
+	data, err := json.Marshal(in)
+	if err != nil {
+		panic(err)
+	}
+	out := &Instance{}
+	if err := json.Unmarshal(data, out); err != nil {
+		panic(err)
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/contrib/pkg/brokerapi"
+)
+
+func testInstance() *Instance {
+	return &Instance{
+		ID:         "b6b0e2a4-5b38-4e7d-8f4e-1c0f4b4f3a6e",
+		ServiceID:  "service",
+		PlanID:     "plan",
+		Parameters: map[string]interface{}{"size": "large"},
+		Credential: brokerapi.Credential{"user": "u", "password": "p"},
+		Bindings: map[string]map[string]interface{}{
+			"binding": {"instanceId": "b6b0e2a4-5b38-4e7d-8f4e-1c0f4b4f3a6e"},
+		},
+	}
+}
+
+// testStorage tests the operations of a Storage. reopen returns a new Storage
+// for the same underlying data.
+func testStorage(t *testing.T, s Storage, reopen func() Storage) {
+	instance := testInstance()
+
+	if got, err := s.GetInstance(instance.ID); err != nil || got != nil {
+		t.Fatalf("expected no instance before it was put, got %+v, %v", got, err)
+	}
+
+	if err := s.PutInstance(instance); err != nil {
+		t.Fatalf("unexpected error putting instance: %v", err)
+	}
+	got, err := s.GetInstance(instance.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting instance: %v", err)
+	}
+	if !reflect.DeepEqual(instance, got) {
+		t.Fatalf("unexpected instance:\nexpected %+v\ngot      %+v", instance, got)
+	}
+
+	// Modifying a returned instance does not change the stored one.
+	got.Credential["user"] = "modified"
+	if got, _ := s.GetInstance(instance.ID); got.Credential["user"] != "u" {
+		t.Errorf("stored instance was modified through a returned instance")
+	}
+
+	instance.Credential = brokerapi.Credential{"user": "updated"}
+	if err := s.PutInstance(instance); err != nil {
+		t.Fatalf("unexpected error updating instance: %v", err)
+	}
+
+	if reopen != nil {
+		s = reopen()
+	}
+	got, err = s.GetInstance(instance.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting instance: %v", err)
+	}
+	if !reflect.DeepEqual(instance, got) {
+		t.Fatalf("unexpected updated instance:\nexpected %+v\ngot      %+v", instance, got)
+	}
+
+	if err := s.DeleteInstance(instance.ID); err != nil {
+		t.Fatalf("unexpected error deleting instance: %v", err)
+	}
+	if got, err := s.GetInstance(instance.ID); err != nil || got != nil {
+		t.Fatalf("expected no instance after it was deleted, got %+v, %v", got, err)
+	}
+	if err := s.DeleteInstance(instance.ID); err != nil {
+		t.Fatalf("unexpected error deleting missing instance: %v", err)
+	}
+}
+
+func TestMemoryStorage(t *testing.T) {
+	testStorage(t, NewMemoryStorage(), nil)
+}
+
+func TestFileStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "user-broker-storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "instances.json")
+
+	open := func() Storage {
+		s, err := NewFileStorage(path)
+		if err != nil {
+			t.Fatalf("unexpected error opening file storage: %v", err)
+		}
+		return s
+	}
+	testStorage(t, open(), open)
+}
+
+func TestFileStorageInvalidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "user-broker-storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "instances.json")
+	if err := ioutil.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStorage(path); err == nil {
+		t.Fatal("expected an error opening a file storage with an invalid file")
+	}
+}
+
+func TestSecretStorage(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	open := func() Storage {
+		return NewSecretStorage(client, "brokers", "ups-instance-")
+	}
+	s := open()
+
+	if err := s.PutInstance(testInstance()); err != nil {
+		t.Fatalf("unexpected error putting instance: %v", err)
+	}
+	secrets, err := client.CoreV1().Secrets("brokers").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets.Items) != 1 {
+		t.Fatalf("expected 1 Secret, got %d", len(secrets.Items))
+	}
+	if name := secrets.Items[0].Name; len(name) != len("ups-instance-")+32 {
+		t.Errorf("unexpected Secret name %q", name)
+	}
+	if err := s.DeleteInstance(testInstance().ID); err != nil {
+		t.Fatal(err)
+	}
+
+	testStorage(t, s, open)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerapi
+
+// ErrorResponse is the body of the error responses of a broker
+//
+// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/spec.md#service-broker-errors
+type ErrorResponse struct {
+	Error       string `json:"error,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// The error codes that a broker returns in an ErrorResponse for the failures
+// that the platform is expected to handle.
+const (
+	// ErrorAsyncRequired means that the request can only be fulfilled
+	// asynchronously, but the platform did not accept incomplete operations.
+	ErrorAsyncRequired = "AsyncRequired"
+	// ErrorConcurrencyError means that another operation on the same instance
+	// or binding is in progress.
+	ErrorConcurrencyError = "ConcurrencyError"
+	// ErrorRequiresApp means that the binding requires an app_guid.
+	ErrorRequiresApp = "RequiresApp"
+)
@@ -21,4 +21,7 @@ const (
 	APIVersionHeader = "X-Broker-Api-Version"
 	// APIVersion is the supported OSB-API version
 	APIVersion = "2.11"
+	// OriginatingIdentityHeader is the optional header for the identity of
+	// the platform user that made an OSB-API request
+	OriginatingIdentityHeader = "X-Broker-API-Originating-Identity"
 )
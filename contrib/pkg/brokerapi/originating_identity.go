@@ -0,0 +1,29 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerapi
+
+// OriginatingIdentity is the identity of the platform user on whose behalf
+// the platform makes a request to a broker, as sent in the
+// X-Broker-API-Originating-Identity header.
+//
+// https://github.com/openservicebrokerapi/servicebroker/blob/v2.14/profile.md#originating-identity-header
+type OriginatingIdentity struct {
+	// Platform is the platform the user is on, for example kubernetes.
+	Platform string
+	// Value is the JSON object that identifies the user on the platform.
+	Value string
+}
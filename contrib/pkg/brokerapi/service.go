@@ -31,4 +31,9 @@ type Service struct {
 	DashboardClient interface{}   `json:"dashboard_client"`
 	PlanUpdateable  bool          `json:"plan_updateable,omitempty"`
 	Plans           []ServicePlan `json:"plans"`
+
+	// InstancesRetrievable and BindingsRetrievable are whether the instances
+	// and bindings of the service can be fetched, as of OSB API 2.14.
+	InstancesRetrievable bool `json:"instances_retrievable,omitempty"`
+	BindingsRetrievable  bool `json:"bindings_retrievable,omitempty"`
 }
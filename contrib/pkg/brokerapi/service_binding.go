@@ -35,12 +35,42 @@ type BindingRequest struct {
 	ServiceID    string                 `json:"service_id,omitempty"`
 	BindResource map[string]interface{} `json:"bind_resource,omitempty"`
 	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+
+	AcceptsIncomplete bool `json:"accepts_incomplete,omitempty"`
+
+	// OriginatingIdentity is the identity of the platform user that made the
+	// request, if the platform sent one.
+	OriginatingIdentity *OriginatingIdentity `json:"-"`
 }
 
 // CreateServiceBindingResponse represents a response to a service binding
 // request
 type CreateServiceBindingResponse struct {
-	Credentials Credential `json:"credentials"`
+	Credentials Credential `json:"credentials,omitempty"`
+	Operation   string     `json:"operation,omitempty"`
+
+	// Exists is whether an identical binding already existed, and Async is
+	// whether the binding is being created asynchronously. They select the
+	// HTTP status code of the response.
+	Exists bool `json:"-"`
+	Async  bool `json:"-"`
+}
+
+// GetServiceBindingResponse represents the response from a broker after a
+// request to fetch a service binding
+type GetServiceBindingResponse struct {
+	Credentials Credential             `json:"credentials,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// UnbindRequest represents a request to unbind from a service instance
+type UnbindRequest struct {
+	ServiceID string `json:"service_id"`
+	PlanID    string `json:"plan_id"`
+
+	// OriginatingIdentity is the identity of the platform user that made the
+	// request, if the platform sent one.
+	OriginatingIdentity *OriginatingIdentity `json:"-"`
 }
 
 // Credential represents connection details, username, and password that are
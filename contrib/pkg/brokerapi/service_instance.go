@@ -41,6 +41,10 @@ type CreateServiceInstanceRequest struct {
 	Parameters        map[string]interface{} `json:"parameters,omitempty"`
 	AcceptsIncomplete bool                   `json:"accepts_incomplete,omitempty"`
 	ContextProfile    ContextProfile         `json:"context,omitempty"`
+
+	// OriginatingIdentity is the identity of the platform user that made the
+	// request, if the platform sent one.
+	OriginatingIdentity *OriginatingIdentity `json:"-"`
 }
 
 // ContextProfilePlatformKubernetes is a constant to send when the
@@ -61,6 +65,54 @@ type ContextProfile struct {
 type CreateServiceInstanceResponse struct {
 	DashboardURL string `json:"dashboard_url,omitempty"`
 	Operation    string `json:"operation,omitempty"`
+
+	// Exists is whether an identical instance already existed, and Async is
+	// whether the instance is being provisioned asynchronously. They select
+	// the HTTP status code of the response.
+	Exists bool `json:"-"`
+	Async  bool `json:"-"`
+}
+
+// UpdateServiceInstanceRequest represents a request to a broker to update an
+// instance of a service
+type UpdateServiceInstanceRequest struct {
+	ServiceID         string                 `json:"service_id"`
+	PlanID            string                 `json:"plan_id,omitempty"`
+	Parameters        map[string]interface{} `json:"parameters,omitempty"`
+	PreviousValues    *PreviousValues        `json:"previous_values,omitempty"`
+	AcceptsIncomplete bool                   `json:"accepts_incomplete,omitempty"`
+	ContextProfile    ContextProfile         `json:"context,omitempty"`
+
+	// OriginatingIdentity is the identity of the platform user that made the
+	// request, if the platform sent one.
+	OriginatingIdentity *OriginatingIdentity `json:"-"`
+}
+
+// PreviousValues holds the values of an instance before an update
+type PreviousValues struct {
+	ServiceID string `json:"service_id,omitempty"`
+	PlanID    string `json:"plan_id,omitempty"`
+	OrgID     string `json:"organization_id,omitempty"`
+	SpaceID   string `json:"space_id,omitempty"`
+}
+
+// UpdateServiceInstanceResponse represents the response from a broker after a
+// request to update an instance of a service
+type UpdateServiceInstanceResponse struct {
+	DashboardURL string `json:"dashboard_url,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+
+	// Async is whether the instance is being updated asynchronously.
+	Async bool `json:"-"`
+}
+
+// GetServiceInstanceResponse represents the response from a broker after a
+// request to fetch an instance of a service
+type GetServiceInstanceResponse struct {
+	ServiceID    string                 `json:"service_id,omitempty"`
+	PlanID       string                 `json:"plan_id,omitempty"`
+	DashboardURL string                 `json:"dashboard_url,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
 }
 
 // DeleteServiceInstanceRequest represents a request to a broker to deprovision an
@@ -69,12 +121,19 @@ type DeleteServiceInstanceRequest struct {
 	ServiceID         string `json:"service_id"`
 	PlanID            string `json:"plan_id"`
 	AcceptsIncomplete bool   `json:"accepts_incomplete,omitempty"`
+
+	// OriginatingIdentity is the identity of the platform user that made the
+	// request, if the platform sent one.
+	OriginatingIdentity *OriginatingIdentity `json:"-"`
 }
 
 // DeleteServiceInstanceResponse represents the response from a broker after a request
 // to deprovision an instance of a service
 type DeleteServiceInstanceResponse struct {
 	Operation string `json:"operation,omitempty"`
+
+	// Async is whether the instance is being deprovisioned asynchronously.
+	Async bool `json:"-"`
 }
 
 // LastOperationRequest represents a request to a broker to give the state of the action
@@ -103,6 +103,28 @@ type ControllerManagerConfiguration struct {
 	// backoff for polling OSB API operations will use.
 	OperationPollingMaximumBackoffDuration time.Duration
 
+	// BindingRotationGracePeriod is the amount of time that retired binding
+	// credentials remain valid after a credential rotation before they are
+	// unbound at the broker.
+	BindingRotationGracePeriod time.Duration
+
+	// BrokerRequestQPS, BrokerRequestBurst and BrokerMaxConcurrentRequests
+	// are the default limits on the requests to each broker, for brokers
+	// that set no limits of their own. Zero means no limit.
+	BrokerRequestQPS            float32
+	BrokerRequestBurst          int
+	BrokerMaxConcurrentRequests int
+
+	// RequestContextInstance, RequestContextNamespaceLabels,
+	// RequestContextNamespaceAnnotations and RequestContextStaticFields
+	// select the extra fields of the context of the requests sent to
+	// brokers that select none of their own. The static fields are given
+	// as key=value pairs.
+	RequestContextInstance             bool
+	RequestContextNamespaceLabels      []string
+	RequestContextNamespaceAnnotations []string
+	RequestContextStaticFields         []string
+
 	SecureServingOptions *genericoptions.SecureServingOptions
 
 	// ClusterIDConfigMapName is the k8s name that the clusterid configmap will have
@@ -0,0 +1,68 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import "strings"
+
+// The properties that catalog restrictions can select service classes and
+// plans by. They are the Filter properties of the versioned API, which
+// classes and plans are converted into when the controller filters them.
+const (
+	filterName                        = "name"
+	filterSpecExternalName            = "spec.externalName"
+	filterSpecExternalID              = "spec.externalID"
+	filterSpecClusterServiceClassName = "spec.clusterServiceClass.name"
+	filterSpecServiceClassName        = "spec.serviceClass.name"
+	filterSpecFree                    = "spec.free"
+	filterSpecBindable                = "spec.bindable"
+	filterSpecTagPrefix               = "spec.tags."
+	filterSpecExternalMetadataPrefix  = "spec.externalMetadata."
+)
+
+// IsServiceClassProperty returns whether the given key names a property that
+// catalog restrictions can select service classes by.
+func IsServiceClassProperty(key string) bool {
+	switch key {
+	case filterName, filterSpecExternalName, filterSpecExternalID, filterSpecBindable:
+		return true
+	}
+	return hasPropertyPrefix(key, filterSpecTagPrefix) || hasPropertyPrefix(key, filterSpecExternalMetadataPrefix)
+}
+
+// IsClusterServicePlanProperty returns whether the given key names a property
+// that catalog restrictions can select ClusterServicePlans by.
+func IsClusterServicePlanProperty(key string) bool {
+	return key == filterSpecClusterServiceClassName || isCommonServicePlanProperty(key)
+}
+
+// IsServicePlanProperty returns whether the given key names a property that
+// catalog restrictions can select ServicePlans by.
+func IsServicePlanProperty(key string) bool {
+	return key == filterSpecServiceClassName || isCommonServicePlanProperty(key)
+}
+
+func isCommonServicePlanProperty(key string) bool {
+	switch key {
+	case filterName, filterSpecExternalName, filterSpecExternalID, filterSpecFree, filterSpecBindable:
+		return true
+	}
+	return hasPropertyPrefix(key, filterSpecExternalMetadataPrefix)
+}
+
+func hasPropertyPrefix(key, prefix string) bool {
+	return strings.HasPrefix(key, prefix) && len(key) > len(prefix)
+}
@@ -64,6 +64,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceInstanceList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
+		&ClusterServicePlanPolicy{},
+		&ClusterServicePlanPolicyList{},
+		&ServiceInstanceGrant{},
+		&ServiceInstanceGrantList{},
 	)
 	return nil
 }
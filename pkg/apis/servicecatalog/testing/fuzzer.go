@@ -122,6 +122,16 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			for bs.SecretName == "" {
 				bs.SecretName = c.RandString()
 			}
+			// Likewise for the name of the ConfigMap.
+			if bs.ConfigMap != nil {
+				for bs.ConfigMap.Name == "" {
+					bs.ConfigMap.Name = c.RandString()
+				}
+			}
+			// And the injection mode of workloads.
+			if bs.WorkloadInjection != nil && bs.WorkloadInjection.Mode == "" {
+				bs.WorkloadInjection.Mode = servicecatalog.ServiceBindingWorkloadInjectionModeEnvFrom
+			}
 			parameters, err := createParameter(c)
 			if err != nil {
 				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
@@ -184,6 +194,23 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			sp.Spec.ServiceInstanceCreateParameterSchema = metadata
 			sp.Spec.ServiceInstanceUpdateParameterSchema = metadata
 		},
+		func(ps *servicecatalog.ClusterServicePlanPolicySpec, c fuzz.Continue) {
+			c.FuzzNoCustom(ps)
+			ps.NamespaceSelector = nil
+			if c.RandBool() {
+				ps.NamespaceSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"environment": c.RandString()},
+				}
+			}
+		},
+		func(pp *servicecatalog.ParametersPolicy, c fuzz.Continue) {
+			c.FuzzNoCustom(pp)
+			defaults, err := createParameter(c)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
+			}
+			pp.Defaults = defaults
+		},
 	}
 }
 
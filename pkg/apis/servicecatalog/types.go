@@ -105,6 +105,78 @@ type CommonServiceBrokerSpec struct {
 	// CatalogRestrictions is a set of restrictions on which of a broker's services
 	// and plans have resources created for them.
 	CatalogRestrictions *CatalogRestrictions
+
+	// PollingPolicy controls how often the last operations of the
+	// asynchronous requests to the broker are polled. When unset, the
+	// defaults of the controller are used.
+	PollingPolicy *ServiceBrokerPollingPolicy
+
+	// RequestLimits limits the rate and the concurrency of the requests
+	// that the controller sends to the broker. Limits that are unset or zero
+	// fall back to the defaults of the controller.
+	RequestLimits *ServiceBrokerRequestLimits
+
+	// RequestContext selects the extra fields that the controller adds to
+	// the context of the requests it sends to the broker. When unset, the
+	// fields selected by the controller are added.
+	RequestContext *ServiceBrokerRequestContext
+}
+
+// ServiceBrokerPollingPolicy controls how often the last operations of a
+// broker are polled. The interval between polls starts at InitialInterval and
+// doubles after each poll up to MaxInterval. A Retry-After header in the
+// response of the broker overrides the interval until the next poll.
+type ServiceBrokerPollingPolicy struct {
+	// InitialInterval is the interval before the first poll of an
+	// operation. Defaults to one second.
+	InitialInterval *metav1.Duration
+
+	// MaxInterval is the maximum interval between polls. Defaults to the
+	// --operation-polling-maximum-backoff-duration of the controller.
+	MaxInterval *metav1.Duration
+
+	// JitterPercent randomly lengthens each interval by up to the given
+	// percentage of it, so that operations started together are not all
+	// polled at the same time.
+	JitterPercent int32
+}
+
+// ServiceBrokerRequestLimits limits the requests that the controller sends
+// to a broker. Requests over a limit are not sent; the resource that needed
+// them is requeued and retried once the broker has room for them.
+type ServiceBrokerRequestLimits struct {
+	// QPS is the maximum average number of requests per second sent to the
+	// broker.
+	QPS int32
+
+	// Burst is the maximum number of requests sent to the broker at once
+	// above QPS. Defaults to QPS.
+	Burst int32
+
+	// MaxConcurrentRequests is the maximum number of requests to the broker
+	// that may be in flight at the same time.
+	MaxConcurrentRequests int32
+}
+
+// ServiceBrokerRequestContext selects the extra fields that the controller
+// adds to the OSB context of the provision, update and bind requests it
+// sends to a broker, next to platform, namespace and clusterid.
+type ServiceBrokerRequestContext struct {
+	// Instance adds the name and the UID of the ServiceInstance as
+	// instance_name and instance_uid.
+	Instance bool
+
+	// NamespaceLabels lists the keys of the labels of the namespace of the
+	// ServiceInstance added in namespace_labels.
+	NamespaceLabels []string
+
+	// NamespaceAnnotations lists the keys of the annotations of the
+	// namespace of the ServiceInstance added in namespace_annotations.
+	NamespaceAnnotations []string
+
+	// StaticFields are added to the context as they are. They cannot
+	// replace the fields set by the controller.
+	StaticFields map[string]string
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -167,6 +239,43 @@ type ClusterServiceBrokerSpec struct {
 	// AuthInfo contains the data that the service catalog should use to authenticate
 	// with the Service Broker.
 	AuthInfo *ClusterServiceBrokerAuthInfo
+
+	// PlanMigrationPolicy moves the ServiceInstances of plans of the
+	// ClusterServiceBroker to replacement plans, typically because the
+	// plans are deprecated or were removed from the catalog of the broker.
+	// It is honored only when the ServicePlanMigration feature is enabled.
+	PlanMigrationPolicy *PlanMigrationPolicy
+}
+
+// PlanMigrationPolicy describes how the ServiceInstances of plans of a
+// ClusterServiceBroker are moved to other plans of the same class.
+type PlanMigrationPolicy struct {
+	// Migrations map the plans to migrate off to their replacement plans.
+	Migrations []PlanMigration
+
+	// BatchSize is the maximum number of instances of a migration whose
+	// plan change is in progress, or has failed, at any time. Defaults
+	// to 10.
+	BatchSize int32
+
+	// DryRun, when true, only reports the instances that would be migrated
+	// in the status of the broker without changing them.
+	DryRun bool
+}
+
+// PlanMigration maps a plan to the plan its instances are moved to.
+type PlanMigration struct {
+	// ServiceClassExternalName is the external name of the class of the
+	// plans. When empty, the migration applies to every class of the
+	// broker that has both plans.
+	ServiceClassExternalName string
+
+	// FromPlanExternalName is the external name of the plan to migrate
+	// instances off.
+	FromPlanExternalName string
+
+	// ToPlanExternalName is the external name of the replacement plan.
+	ToPlanExternalName string
 }
 
 // ServiceBrokerSpec represents a description of a Broker.
@@ -288,12 +397,115 @@ type CommonServiceBrokerStatus struct {
 	// LastCatalogRetrievalTime is the time the Catalog was last fetched from
 	// the Service Broker
 	LastCatalogRetrievalTime *metav1.Time
+
+	// CatalogHistory records how the catalog of the broker changed each
+	// time it was fetched, oldest first. Only the most recent fetches that
+	// changed the catalog are kept, and the first fetch, which adds the
+	// whole catalog, is not recorded.
+	CatalogHistory []ServiceBrokerCatalogChange
+}
+
+// ServiceBrokerCatalogChange records the classes and plans that were added,
+// removed or changed when the catalog of a broker was fetched.
+type ServiceBrokerCatalogChange struct {
+	// Time is the time the catalog was fetched.
+	Time metav1.Time
+
+	// Classes are the changes to the service classes of the broker.
+	Classes []ServiceBrokerCatalogEntryChange
+
+	// Plans are the changes to the service plans of the broker.
+	Plans []ServiceBrokerCatalogEntryChange
+
+	// OmittedClasses is the number of changes to service classes that are
+	// not listed in Classes, as only a limited number is recorded.
+	OmittedClasses int32
+
+	// OmittedPlans is the number of changes to service plans that are not
+	// listed in Plans, as only a limited number is recorded.
+	OmittedPlans int32
 }
 
+// ServiceBrokerCatalogEntryChange records how a service class or service
+// plan changed in the catalog of a broker.
+type ServiceBrokerCatalogEntryChange struct {
+	// Type is whether the class or plan was added, removed or changed.
+	Type ServiceBrokerCatalogChangeType
+
+	// Name is the k8s name of the class or plan.
+	Name string
+
+	// ExternalName is the name of the class or plan in the catalog.
+	ExternalName string
+
+	// ServiceClassExternalName is the name of the class of a plan in the
+	// catalog. It is empty for classes.
+	ServiceClassExternalName string
+
+	// Fields are the fields of the spec of a changed class or plan that
+	// changed, like "free" or "instanceCreateParameterSchema".
+	Fields []string
+}
+
+// ServiceBrokerCatalogChangeType is the type of a change to a class or plan
+// in the catalog of a broker.
+type ServiceBrokerCatalogChangeType string
+
+const (
+	// ServiceBrokerCatalogChangeAdded means that the class or plan was added
+	// to the catalog, or added back after having been removed.
+	ServiceBrokerCatalogChangeAdded ServiceBrokerCatalogChangeType = "Added"
+
+	// ServiceBrokerCatalogChangeRemoved means that the class or plan was
+	// removed from the catalog.
+	ServiceBrokerCatalogChangeRemoved ServiceBrokerCatalogChangeType = "Removed"
+
+	// ServiceBrokerCatalogChangeChanged means that fields of the class or
+	// plan changed in the catalog.
+	ServiceBrokerCatalogChangeChanged ServiceBrokerCatalogChangeType = "Changed"
+)
+
 // ClusterServiceBrokerStatus represents the current status of a
 // ClusterServiceBroker.
 type ClusterServiceBrokerStatus struct {
 	CommonServiceBrokerStatus
+
+	// PlanMigrations reports the progress of the migrations of the
+	// PlanMigrationPolicy of the broker, one entry per migration and class.
+	PlanMigrations []PlanMigrationStatus
+}
+
+// PlanMigrationStatus reports the progress of a PlanMigration for a class.
+type PlanMigrationStatus struct {
+	// ServiceClassExternalName is the external name of the class.
+	ServiceClassExternalName string
+
+	// FromPlanExternalName is the external name of the plan to migrate
+	// instances off.
+	FromPlanExternalName string
+
+	// ToPlanExternalName is the external name of the replacement plan.
+	ToPlanExternalName string
+
+	// RemainingInstances is the number of instances that are still
+	// provisioned on the old plan, including those being migrated.
+	RemainingInstances int32
+
+	// MigratingInstances are the instances, as namespace/name, whose plan
+	// change is in progress.
+	MigratingInstances []string
+
+	// FailedInstances are the instances, as namespace/name, whose plan
+	// change failed. They hold their place in the batch until they are
+	// moved to a plan or the failure is resolved.
+	FailedInstances []string
+
+	// PendingInstances are the instances, as namespace/name, that would be
+	// migrated next in dry run mode.
+	PendingInstances []string
+
+	// Message explains why the migration cannot proceed, if it cannot.
+	Message string
 }
 
 // ServiceBrokerStatus represents the current status of a ServiceBroker.
@@ -354,6 +566,22 @@ const (
 	ConditionUnknown ConditionStatus = "Unknown"
 )
 
+// ConditionFieldError describes a field of a request made for a resource that
+// the condition reports as invalid, such as a parameter that does not match
+// the parameter schema of the plan.
+type ConditionFieldError struct {
+	// Type is the machine readable type of the error, such as
+	// 'FieldValueRequired' or 'FieldValueInvalid'.
+	Type string
+
+	// Field is the path of the invalid field, such as 'parameters.size'.
+	Field string
+
+	// Detail is a human readable description of the error. The value of
+	// the field is never included, as it may come from a secret.
+	Detail string
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ClusterServiceClassList is a list of ClusterServiceClasses.
@@ -823,6 +1051,30 @@ type ServiceInstanceSpec struct {
 	// allows for parameters to be updated with any out-of-band changes that have
 	// been made to the secrets from which the parameters are sourced.
 	UpdateRequests int64
+
+	// UpdateOnParametersFromChange makes the controller update the instance
+	// at the broker whenever the parameters read from the sources of
+	// ParametersFrom change, as if UpdateRequests had been incremented. It is
+	// honored only when the WatchParametersFrom feature is enabled.
+	UpdateOnParametersFromChange bool
+
+	// MaintenanceWindow restricts when updates of the instance are sent to
+	// the broker. Updates requested outside of the window are held back
+	// until the window opens. It overrides the maintenance window of the
+	// namespace of the instance, and is honored only when the
+	// MaintenanceWindows feature is enabled.
+	MaintenanceWindow *MaintenanceWindow
+}
+
+// MaintenanceWindow is a recurring period of time.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with five fields (minute, hour, day of
+	// month, month and day of week) for the start times of the window, in
+	// UTC.
+	Schedule string
+
+	// Duration is how long the window stays open after each start.
+	Duration metav1.Duration
 }
 
 // ServiceInstanceStatus represents the current status of an Instance.
@@ -902,6 +1154,10 @@ type ServiceInstanceCondition struct {
 	// Message is a human readable description of the details of the last
 	// transition, complementing reason.
 	Message string
+
+	// FieldErrors are the invalid fields of the request that caused the
+	// last transition, if any.
+	FieldErrors []ConditionFieldError
 }
 
 // ServiceInstanceConditionType represents a instance condition value.
@@ -919,6 +1175,11 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionUpdatePending represents information about an
+	// update that is held back until the maintenance window of the instance
+	// opens.
+	ServiceInstanceConditionUpdatePending ServiceInstanceConditionType = "UpdatePending"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -1040,6 +1301,18 @@ type ServiceBindingSpec struct {
 	// Immutable.
 	ServiceInstanceRef LocalObjectReference
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// ServiceInstanceNamespace is the namespace of the Instance this
+	// ServiceBinding is to, if it is not in the namespace of the
+	// ServiceBinding. A ServiceInstanceGrant in that namespace must allow the
+	// namespace of the ServiceBinding to bind to the Instance.
+	//
+	// Immutable.
+	// +optional
+	ServiceInstanceNamespace string
+
 	// Parameters is a set of the parameters to be passed to the underlying
 	// broker. The inline YAML/JSON payload to be translated into equivalent
 	// JSON object. If a top-level parameter name exists in multiples sources
@@ -1069,6 +1342,23 @@ type ServiceBindingSpec struct {
 	// by the broker before they are inserted into the Secret
 	SecretTransforms []SecretTransform
 
+	// SecretFormat controls how the credentials associated with the
+	// ServiceBinding are laid out in the Secret. If not specified, each
+	// credential is stored under its own key.
+	SecretFormat ServiceBindingSecretFormat
+
+	// ConfigMap, if specified, moves the given non-sensitive credentials out
+	// of the Secret and into a ConfigMap in the ServiceBinding's namespace.
+	ConfigMap *ServiceBindingConfigMap
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// WorkloadInjection, if specified, makes the controller inject the Secret
+	// of this ServiceBinding into the pod templates of the selected
+	// Deployments and StatefulSets in the ServiceBinding's namespace.
+	WorkloadInjection *ServiceBindingWorkloadInjection
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1082,6 +1372,16 @@ type ServiceBindingSpec struct {
 	// settable by the end-user. User-provided values for this field are not saved.
 	// +optional
 	UserInfo *UserInfo
+
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to request that the
+	// credentials of this ServiceBinding be rotated. Each increment causes a
+	// new binding to be created at the broker with a new ExternalID, the
+	// contents of the Secret to be replaced with the new credentials, and the
+	// previous binding to be unbound once the rotation grace period has
+	// elapsed.
+	// +optional
+	RotationRequests int64
 }
 
 // ServiceBindingStatus represents the current status of a ServiceBinding.
@@ -1131,6 +1431,35 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind a ServiceBinding
 	UnbindStatus ServiceBindingUnbindStatus
+
+	// RetiredCredentials is the list of credentials that have been replaced
+	// by a credential rotation and that have not been unbound at the broker
+	// yet.
+	RetiredCredentials []ServiceBindingRetiredCredentials
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InjectedWorkloads is the list of workloads that the Secret of this
+	// ServiceBinding has been injected into.
+	InjectedWorkloads []ServiceBindingWorkload
+
+	// SyslogDrainURL is the URL the broker asked the logs of the applications
+	// using the binding to be streamed to. It is specific to Cloud Foundry
+	// and only recorded.
+	SyslogDrainURL *string
+
+	// RouteServiceURL is the URL the broker asked the requests to the
+	// applications using the binding to be proxied through. It is specific to
+	// Cloud Foundry and only recorded.
+	RouteServiceURL *string
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// VolumeMounts is the list of volumes the broker returned for the
+	// ServiceBinding.
+	VolumeMounts []ServiceBindingVolumeMount
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1152,6 +1481,63 @@ type ServiceBindingCondition struct {
 	// Message is a human readable description of the details of the last
 	// transition, complementing reason.
 	Message string
+
+	// FieldErrors are the invalid fields of the request that caused the
+	// last transition, if any.
+	FieldErrors []ConditionFieldError
+}
+
+// ServiceBindingRetiredCredentials describes credentials of a ServiceBinding
+// that have been replaced by a credential rotation and are waiting to be
+// unbound at the broker.
+type ServiceBindingRetiredCredentials struct {
+	// ExternalID is the identity of the retired binding for use with the OSB
+	// API.
+	ExternalID string
+
+	// RetiredTime is the time at which the replacement credentials were
+	// injected into the ServiceBinding's Secret. The retired binding is
+	// unbound once the rotation grace period has elapsed after this time.
+	// It is not set while the replacement credentials are being bound.
+	// +optional
+	RetiredTime *metav1.Time
+}
+
+// ServiceBindingWorkload identifies a workload that the Secret of a
+// ServiceBinding has been injected into.
+type ServiceBindingWorkload struct {
+	// Kind is the kind of the workload, either Deployment or StatefulSet.
+	Kind string
+
+	// Name is the name of the workload in the ServiceBinding's namespace.
+	Name string
+}
+
+// ServiceBindingVolumeMount describes a volume a broker returned for a
+// ServiceBinding. The mount configuration of the device is not recorded, as
+// it may hold credentials.
+type ServiceBindingVolumeMount struct {
+	// Driver is the name of the volume driver plugin that manages the device.
+	Driver string
+
+	// ContainerDir is the directory the broker asked the volume to be mounted
+	// at in the containers of the applications.
+	ContainerDir string
+
+	// Mode is the access mode of the volume, either "r" or "rw".
+	Mode string
+
+	// DeviceType is the type of the device, currently only "shared".
+	DeviceType string
+
+	// VolumeID is the identity of the device in the volume driver.
+	VolumeID string
+
+	// PersistentVolumeClaimName is the name of the PersistentVolumeClaim in
+	// the ServiceBinding's namespace that is bound to the volume. It is only
+	// set if the driver is one the controller translates into a
+	// PersistentVolume.
+	PersistentVolumeClaimName string
 }
 
 // ServiceBindingConditionType represents a ServiceBindingCondition value.
@@ -1164,6 +1550,18 @@ const (
 	// ServiceBindingConditionFailed represents a ServiceBindingCondition that has failed
 	// completely and should not be retried.
 	ServiceBindingConditionFailed ServiceBindingConditionType = "Failed"
+
+	// ServiceBindingConditionParametersDrifted represents a binding whose
+	// parameters, as read from the sources of its ParametersFrom, differ from
+	// the parameters it was bound with. Bindings cannot be updated, so the
+	// binding has to be recreated to pick up the new parameters.
+	ServiceBindingConditionParametersDrifted ServiceBindingConditionType = "ParametersDrifted"
+
+	// ServiceBindingConditionRetiredCredentialsUnrevoked represents a binding
+	// whose retired credentials the broker refused to unbind. The message of
+	// the condition names the external ID of the credentials, which remain
+	// valid until they are revoked at the broker by other means.
+	ServiceBindingConditionRetiredCredentialsUnrevoked ServiceBindingConditionType = "RetiredCredentialsUnrevoked"
 )
 
 // ServiceBindingOperation represents a type of operation
@@ -1199,6 +1597,69 @@ type ServiceBindingPropertiesState struct {
 	UserInfo *UserInfo
 }
 
+// ServiceBindingSecretFormat is the layout of the credentials in the Secret
+// associated with a ServiceBinding.
+type ServiceBindingSecretFormat string
+
+const (
+	// ServiceBindingSecretFormatKeys stores each credential under its own key
+	// in the Secret. This is the default.
+	ServiceBindingSecretFormatKeys ServiceBindingSecretFormat = "Keys"
+	// ServiceBindingSecretFormatJSON stores all the credentials as a single
+	// JSON object under the "credentials.json" key in the Secret.
+	ServiceBindingSecretFormatJSON ServiceBindingSecretFormat = "JSON"
+	// ServiceBindingSecretFormatEnv stores all the credentials as KEY=value
+	// lines under the "credentials.env" key in the Secret.
+	ServiceBindingSecretFormatEnv ServiceBindingSecretFormat = "Env"
+	// ServiceBindingSecretFormatProperties stores all the credentials as a
+	// Java properties file under the "credentials.properties" key in the
+	// Secret.
+	ServiceBindingSecretFormatProperties ServiceBindingSecretFormat = "Properties"
+)
+
+// ServiceBindingConfigMap describes a ConfigMap that non-sensitive
+// credentials associated with a ServiceBinding are stored in instead of the
+// Secret.
+type ServiceBindingConfigMap struct {
+	// Name is the name of the ConfigMap to create in the ServiceBinding's
+	// namespace.
+	Name string
+
+	// Keys is the list of credentials keys that are stored in the ConfigMap
+	// instead of the Secret.
+	Keys []string
+}
+
+// ServiceBindingWorkloadInjection selects the workloads that the Secret of a
+// ServiceBinding is injected into, and how it is injected.
+type ServiceBindingWorkloadInjection struct {
+	// Selector is a label query over the Deployments and StatefulSets in the
+	// ServiceBinding's namespace.
+	Selector metav1.LabelSelector
+
+	// Mode is how the Secret is injected into the containers of the pod
+	// templates of the workloads.
+	Mode ServiceBindingWorkloadInjectionMode
+
+	// MountPath is the path in the containers that the Secret is mounted at
+	// in the Volume mode.
+	MountPath string
+}
+
+// ServiceBindingWorkloadInjectionMode is how the Secret of a ServiceBinding
+// is injected into workloads.
+type ServiceBindingWorkloadInjectionMode string
+
+const (
+	// ServiceBindingWorkloadInjectionModeEnvFrom exposes the credentials as
+	// environment variables, with an envFrom source for the Secret.
+	ServiceBindingWorkloadInjectionModeEnvFrom ServiceBindingWorkloadInjectionMode = "EnvFrom"
+
+	// ServiceBindingWorkloadInjectionModeVolume exposes the credentials as
+	// files, with a volume for the Secret that is mounted at the MountPath.
+	ServiceBindingWorkloadInjectionModeVolume ServiceBindingWorkloadInjectionMode = "Volume"
+)
+
 // ServiceBindingUnbindStatus is the status of unbinding a Binding
 type ServiceBindingUnbindStatus string
 
@@ -1220,12 +1681,34 @@ const (
 	ServiceBindingUnbindStatusFailed ServiceBindingUnbindStatus = "Failed"
 )
 
-// ParametersFromSource represents the source of a set of Parameters
+// ParametersFromSource represents the source of a set of Parameters. Exactly
+// one of its fields must be set.
 type ParametersFromSource struct {
 	// The Secret key to select from.
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference
+
+	// The ConfigMap key to select from.
+	// The value must be a JSON object.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference
+
+	// The Secret to select all keys from. Every key of the Secret is a
+	// parameter whose value is the value of the key, as a string.
+	// +optional
+	SecretRef *LocalObjectReference
+
+	// The ConfigMap to select all keys from. Every key of the ConfigMap is a
+	// parameter whose value is the value of the key, as a string.
+	// +optional
+	ConfigMapRef *LocalObjectReference
+
+	// The key of the Secret of a ServiceBinding to select from. The value
+	// is set as a single parameter, as a string. This passes the outputs of
+	// one ServiceInstance, such as the endpoint of a database, to another.
+	// +optional
+	ServiceBindingKeyRef *ServiceBindingKeyReference
 }
 
 // SecretKeyReference references a key of a Secret.
@@ -1236,6 +1719,27 @@ type SecretKeyReference struct {
 	Key string
 }
 
+// ConfigMapKeyReference references a key of a ConfigMap.
+type ConfigMapKeyReference struct {
+	// The name of the ConfigMap in the pod's namespace to select from.
+	Name string
+	// The key of the ConfigMap to select from.
+	Key string
+}
+
+// ServiceBindingKeyReference references a key of the Secret of a
+// ServiceBinding.
+type ServiceBindingKeyReference struct {
+	// The name of the ServiceBinding in the pod's namespace to select from.
+	Name string
+	// The key of the Secret of the ServiceBinding to select from.
+	Key string
+	// The name of the parameter to set to the value of the key. Defaults to
+	// the key.
+	// +optional
+	Parameter string
+}
+
 // ObjectReference contains enough information to let you locate the
 // referenced object.
 type ObjectReference struct {
@@ -1262,10 +1766,11 @@ type ClusterObjectReference struct {
 // SecretTransform is a single transformation of the credentials returned
 // from the broker
 type SecretTransform struct {
-	RenameKey   *RenameKeyTransform
-	AddKey      *AddKeyTransform
-	AddKeysFrom *AddKeysFromTransform
-	RemoveKey   *RemoveKeyTransform
+	RenameKey      *RenameKeyTransform
+	AddKey         *AddKeyTransform
+	AddKeysFrom    *AddKeysFromTransform
+	RemoveKey      *RemoveKeyTransform
+	RenderTemplate *RenderTemplateTransform
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1295,3 +1800,124 @@ type AddKeysFromTransform struct {
 type RemoveKeyTransform struct {
 	Key string
 }
+
+// RenderTemplateTransform specifies that Service Catalog should add an
+// entry to the credentials Secret whose value is rendered from a Go template
+// evaluated against the credentials returned from the broker.
+type RenderTemplateTransform struct {
+	Key      string
+	Template string
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServicePlanPolicy sets default parameters for, and restricts the
+// parameters of, the ServiceInstances and ServiceBindings of the service
+// plans it selects.
+type ClusterServicePlanPolicy struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec ClusterServicePlanPolicySpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServicePlanPolicyList is a list of ClusterServicePlanPolicies.
+type ClusterServicePlanPolicyList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ClusterServicePlanPolicy
+}
+
+// ClusterServicePlanPolicySpec represents the plans a
+// ClusterServicePlanPolicy applies to and its rules.
+type ClusterServicePlanPolicySpec struct {
+	// ServiceClassExternalName selects the plans of the service class with
+	// this external name. The plans of all classes are selected if it is
+	// empty.
+	ServiceClassExternalName string
+
+	// ServicePlanExternalName selects the plans with this external name.
+	// All plans are selected if it is empty.
+	ServicePlanExternalName string
+
+	// NamespaceSelector selects the namespaces the policy applies to. The
+	// policy applies to all namespaces if it is not set.
+	NamespaceSelector *metav1.LabelSelector
+
+	// InstanceParameters is the policy for the parameters of the
+	// ServiceInstances of the selected plans.
+	InstanceParameters *ParametersPolicy
+
+	// BindingParameters is the policy for the parameters of the
+	// ServiceBindings to instances of the selected plans.
+	BindingParameters *ParametersPolicy
+}
+
+// ParametersPolicy sets default parameters and restricts the parameters
+// that may be set on a resource.
+type ParametersPolicy struct {
+	// Defaults are top-level parameters that are added to the parameters of
+	// a resource that does not set them.
+	Defaults *runtime.RawExtension
+
+	// LockedKeys are top-level parameters that may not be set to a value
+	// other than their default. Locked parameters without a default may not
+	// be set at all.
+	LockedKeys []string
+
+	// AllowedValues restricts the values of top-level parameters.
+	AllowedValues []AllowedParameterValues
+}
+
+// AllowedParameterValues restricts the values of a top-level parameter.
+// String values are compared as is, other values are compared with their
+// JSON encoding.
+type AllowedParameterValues struct {
+	// Key is the name of the top-level parameter.
+	Key string
+
+	// Values are the values the parameter may be set to.
+	Values []string
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceGrant allows the ServiceBindings of other namespaces to bind
+// to a ServiceInstance in the namespace of the grant.
+type ServiceInstanceGrant struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec ServiceInstanceGrantSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceGrantList is a list of ServiceInstanceGrants.
+type ServiceInstanceGrantList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ServiceInstanceGrant
+}
+
+// ServiceInstanceGrantSpec represents the ServiceInstance a
+// ServiceInstanceGrant shares and the namespaces it is shared with.
+type ServiceInstanceGrantSpec struct {
+	// ServiceInstanceRef is the reference to the ServiceInstance in the
+	// namespace of the grant that is shared.
+	//
+	// Immutable.
+	ServiceInstanceRef LocalObjectReference
+
+	// Namespaces are the namespaces whose ServiceBindings may bind to the
+	// ServiceInstance. Removing a namespace does not remove the
+	// ServiceBindings that already exist.
+	Namespaces []string
+}
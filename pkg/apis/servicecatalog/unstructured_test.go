@@ -85,6 +85,10 @@ func doUnstructuredRoundTrip(t *testing.T, group testapi.TestGroup, kind string)
 			c.FuzzNoCustom(ps)
 			ps.Parameters = nil
 		},
+		func(pp *servicecatalog.ParametersPolicy, c fuzz.Continue) {
+			c.FuzzNoCustom(pp)
+			pp.Defaults = nil
+		},
 	).Fuzz(internalObj)
 
 	item, err := api.Scheme.New(group.GroupVersion().WithKind(kind))
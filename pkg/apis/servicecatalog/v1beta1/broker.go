@@ -0,0 +1,47 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// GetURL returns the broker's URL.
+func (b *ClusterServiceBroker) GetURL() string {
+	return b.Spec.URL
+}
+
+// GetURL returns the broker's URL.
+func (b *ServiceBroker) GetURL() string {
+	return b.Spec.URL
+}
+
+// GetSpec returns the spec fields common to both broker scopes.
+func (b *ClusterServiceBroker) GetSpec() CommonServiceBrokerSpec {
+	return b.Spec.CommonServiceBrokerSpec
+}
+
+// GetSpec returns the spec fields common to both broker scopes.
+func (b *ServiceBroker) GetSpec() CommonServiceBrokerSpec {
+	return b.Spec.CommonServiceBrokerSpec
+}
+
+// GetStatus returns the status fields common to both broker scopes.
+func (b *ClusterServiceBroker) GetStatus() CommonServiceBrokerStatus {
+	return b.Status.CommonServiceBrokerStatus
+}
+
+// GetStatus returns the status fields common to both broker scopes.
+func (b *ServiceBroker) GetStatus() CommonServiceBrokerStatus {
+	return b.Status.CommonServiceBrokerStatus
+}
@@ -26,6 +26,9 @@ func addDefaultingFuncs(scheme *runtime.Scheme) error {
 
 func SetDefaults_ClusterServiceBrokerSpec(spec *ClusterServiceBrokerSpec) {
 	setCommonServiceBrokerDefaults(&spec.CommonServiceBrokerSpec)
+	if spec.PlanMigrationPolicy != nil && spec.PlanMigrationPolicy.BatchSize == 0 {
+		spec.PlanMigrationPolicy.BatchSize = 10
+	}
 }
 
 func SetDefaults_ServiceBrokerSpec(spec *ServiceBrokerSpec) {
@@ -43,4 +46,12 @@ func SetDefaults_ServiceBinding(binding *ServiceBinding) {
 	if binding.Spec.SecretName == "" {
 		binding.Spec.SecretName = binding.Name
 	}
+	// If not specified, make the ConfigMap name default to the Secret name
+	if binding.Spec.ConfigMap != nil && binding.Spec.ConfigMap.Name == "" {
+		binding.Spec.ConfigMap.Name = binding.Spec.SecretName
+	}
+	// If not specified, inject the Secret into workloads with envFrom
+	if binding.Spec.WorkloadInjection != nil && binding.Spec.WorkloadInjection.Mode == "" {
+		binding.Spec.WorkloadInjection.Mode = ServiceBindingWorkloadInjectionModeEnvFrom
+	}
 }
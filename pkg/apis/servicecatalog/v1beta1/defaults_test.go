@@ -117,3 +117,88 @@ func TestSetDefaultClusterServiceBroker(t *testing.T) {
 		}
 	}
 }
+
+func TestSetDefaultPlanMigrationPolicy(t *testing.T) {
+	cases := []struct {
+		name      string
+		batchSize int32
+		expected  int32
+	}{
+		{
+			name:     "batch size not set",
+			expected: 10,
+		},
+		{
+			name:      "batch size set",
+			batchSize: 3,
+			expected:  3,
+		},
+	}
+
+	for _, tc := range cases {
+		b := &versioned.ClusterServiceBroker{}
+		b.Spec.PlanMigrationPolicy = &versioned.PlanMigrationPolicy{BatchSize: tc.batchSize}
+		o := roundTrip(t, runtime.Object(b))
+		actual := o.(*versioned.ClusterServiceBroker).Spec.PlanMigrationPolicy.BatchSize
+
+		if tc.expected != actual {
+			t.Errorf("%v: unexpected default BatchSize: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestSetDefaultServiceBinding(t *testing.T) {
+	cases := []struct {
+		name          string
+		binding       *versioned.ServiceBinding
+		secretName    string
+		configMapName string
+	}{
+		{
+			name: "neither secret nor config map name set",
+			binding: &versioned.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+				Spec: versioned.ServiceBindingSpec{
+					ConfigMap: &versioned.ServiceBindingConfigMap{Keys: []string{"host"}},
+				},
+			},
+			secretName:    "binding",
+			configMapName: "binding",
+		},
+		{
+			name: "secret name set",
+			binding: &versioned.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+				Spec: versioned.ServiceBindingSpec{
+					SecretName: "secret",
+					ConfigMap:  &versioned.ServiceBindingConfigMap{Keys: []string{"host"}},
+				},
+			},
+			secretName:    "secret",
+			configMapName: "secret",
+		},
+		{
+			name: "config map name set",
+			binding: &versioned.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+				Spec: versioned.ServiceBindingSpec{
+					ConfigMap: &versioned.ServiceBindingConfigMap{Name: "config", Keys: []string{"host"}},
+				},
+			},
+			secretName:    "binding",
+			configMapName: "config",
+		},
+	}
+
+	for _, tc := range cases {
+		o := roundTrip(t, runtime.Object(tc.binding))
+		actualSpec := o.(*versioned.ServiceBinding).Spec
+
+		if tc.secretName != actualSpec.SecretName {
+			t.Errorf("%v: unexpected default SecretName: expected %v, got %v", tc.name, tc.secretName, actualSpec.SecretName)
+		}
+		if tc.configMapName != actualSpec.ConfigMap.Name {
+			t.Errorf("%v: unexpected default ConfigMap name: expected %v, got %v", tc.name, tc.configMapName, actualSpec.ConfigMap.Name)
+		}
+	}
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/filter"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // These are functions to support filtering. This is where we can add more fields
@@ -33,11 +36,13 @@ func ConvertServiceClassToProperties(serviceClass *ServiceClass) filter.Properti
 	if serviceClass == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
+	properties := labels.Set{
 		FilterName:             serviceClass.Name,
 		FilterSpecExternalName: serviceClass.Spec.ExternalName,
 		FilterSpecExternalID:   serviceClass.Spec.ExternalID,
 	}
+	addCommonServiceClassProperties(properties, &serviceClass.Spec.CommonServiceClassSpec)
+	return properties
 }
 
 // ConvertServicePlanToProperties takes a Service Plan and pulls out the
@@ -47,13 +52,15 @@ func ConvertServicePlanToProperties(servicePlan *ServicePlan) filter.Properties
 	if servicePlan == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
+	properties := labels.Set{
 		FilterName:                 servicePlan.Name,
 		FilterSpecExternalName:     servicePlan.Spec.ExternalName,
 		FilterSpecExternalID:       servicePlan.Spec.ExternalID,
 		FilterSpecServiceClassName: servicePlan.Spec.ServiceClassRef.Name,
 		FilterSpecFree:             strconv.FormatBool(servicePlan.Spec.Free),
 	}
+	addCommonServicePlanProperties(properties, &servicePlan.Spec.CommonServicePlanSpec)
+	return properties
 }
 
 // ConvertClusterServiceClassToProperties takes a Service Class and pulls out the
@@ -63,11 +70,13 @@ func ConvertClusterServiceClassToProperties(serviceClass *ClusterServiceClass) f
 	if serviceClass == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
+	properties := labels.Set{
 		FilterName:             serviceClass.Name,
 		FilterSpecExternalName: serviceClass.Spec.ExternalName,
 		FilterSpecExternalID:   serviceClass.Spec.ExternalID,
 	}
+	addCommonServiceClassProperties(properties, &serviceClass.Spec.CommonServiceClassSpec)
+	return properties
 }
 
 // ConvertClusterServicePlanToProperties takes a Service Plan and pulls out the
@@ -77,11 +86,62 @@ func ConvertClusterServicePlanToProperties(servicePlan *ClusterServicePlan) filt
 	if servicePlan == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
+	properties := labels.Set{
 		FilterName:                        servicePlan.Name,
 		FilterSpecExternalName:            servicePlan.Spec.ExternalName,
 		FilterSpecExternalID:              servicePlan.Spec.ExternalID,
 		FilterSpecClusterServiceClassName: servicePlan.Spec.ClusterServiceClassRef.Name,
 		FilterSpecFree:                    strconv.FormatBool(servicePlan.Spec.Free),
 	}
+	addCommonServicePlanProperties(properties, &servicePlan.Spec.CommonServicePlanSpec)
+	return properties
+}
+
+// addCommonServiceClassProperties adds the properties shared by
+// ClusterServiceClasses and ServiceClasses to the given set.
+func addCommonServiceClassProperties(properties labels.Set, spec *CommonServiceClassSpec) {
+	properties[FilterSpecBindable] = strconv.FormatBool(spec.Bindable)
+	for _, tag := range spec.Tags {
+		properties[FilterSpecTagPrefix+tag] = "true"
+	}
+	addExternalMetadataProperties(properties, spec.ExternalMetadata)
+}
+
+// addCommonServicePlanProperties adds the properties shared by
+// ClusterServicePlans and ServicePlans to the given set.
+func addCommonServicePlanProperties(properties labels.Set, spec *CommonServicePlanSpec) {
+	if spec.Bindable != nil {
+		properties[FilterSpecBindable] = strconv.FormatBool(*spec.Bindable)
+	}
+	addExternalMetadataProperties(properties, spec.ExternalMetadata)
+}
+
+// addExternalMetadataProperties flattens the strings, numbers and booleans of
+// the given external metadata into the given set. Metadata that is not a JSON
+// object is ignored, as there are no keys to select it by.
+func addExternalMetadataProperties(properties labels.Set, metadata *runtime.RawExtension) {
+	if metadata == nil || len(metadata.Raw) == 0 {
+		return
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(metadata.Raw, &object); err != nil {
+		return
+	}
+	flattenExternalMetadata(properties, strings.TrimSuffix(FilterSpecExternalMetadataPrefix, "."), object)
+}
+
+func flattenExternalMetadata(properties labels.Set, path string, object map[string]interface{}) {
+	for k, v := range object {
+		key := path + "." + k
+		switch value := v.(type) {
+		case map[string]interface{}:
+			flattenExternalMetadata(properties, key, value)
+		case string:
+			properties[key] = value
+		case bool:
+			properties[key] = strconv.FormatBool(value)
+		case float64:
+			properties[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
 }
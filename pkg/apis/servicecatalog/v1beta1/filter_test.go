@@ -21,6 +21,10 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 )
 
 func TestConvertServiceClassToProperties(t *testing.T) {
@@ -44,7 +48,25 @@ func TestConvertServiceClassToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-class","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+			json: `{"name":"service-class","spec.bindable":"false","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+		},
+		{
+			name: "object with tags and external metadata",
+			sc: &ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-class"},
+				Spec: ServiceClassSpec{
+					CommonServiceClassSpec: CommonServiceClassSpec{
+						ExternalName: "external-class-name",
+						ExternalID:   "external-id",
+						Bindable:     true,
+						Tags:         []string{"database", "mysql"},
+						ExternalMetadata: &runtime.RawExtension{
+							Raw: []byte(`{"costCenter":"x","tier":2,"compliance":{"approved":true},"regions":["eu"]}`),
+						},
+					},
+				},
+			},
+			json: `{"name":"service-class","spec.bindable":"true","spec.externalID":"external-id","spec.externalMetadata.compliance.approved":"true","spec.externalMetadata.costCenter":"x","spec.externalMetadata.tier":"2","spec.externalName":"external-class-name","spec.tags.database":"true","spec.tags.mysql":"true"}`,
 		},
 	}
 	for _, tc := range cases {
@@ -92,6 +114,26 @@ func TestConvertServicePlanToProperties(t *testing.T) {
 			},
 			json: `{"name":"service-plan","spec.externalID":"external-id","spec.externalName":"external-plan-name","spec.free":"true","spec.serviceClass.name":"service-class-name"}`,
 		},
+		{
+			name: "object with bindable and external metadata",
+			sp: &ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-plan"},
+				Spec: ServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{
+						ExternalName: "external-plan-name",
+						ExternalID:   "external-id",
+						Bindable:     boolPtr(false),
+						ExternalMetadata: &runtime.RawExtension{
+							Raw: []byte(`{"costCenter":"y"}`),
+						},
+					},
+					ServiceClassRef: LocalObjectReference{
+						Name: "service-class-name",
+					},
+				},
+			},
+			json: `{"name":"service-plan","spec.bindable":"false","spec.externalID":"external-id","spec.externalMetadata.costCenter":"y","spec.externalName":"external-plan-name","spec.free":"false","spec.serviceClass.name":"service-class-name"}`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -132,7 +174,25 @@ func TestConvertClusterServiceClassToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-class","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+			json: `{"name":"service-class","spec.bindable":"false","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+		},
+		{
+			name: "object with tags and external metadata",
+			sc: &ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-class"},
+				Spec: ClusterServiceClassSpec{
+					CommonServiceClassSpec: CommonServiceClassSpec{
+						ExternalName: "external-class-name",
+						ExternalID:   "external-id",
+						Bindable:     true,
+						Tags:         []string{"database", "mysql"},
+						ExternalMetadata: &runtime.RawExtension{
+							Raw: []byte(`{"costCenter":"x","tier":2,"compliance":{"approved":true},"regions":["eu"]}`),
+						},
+					},
+				},
+			},
+			json: `{"name":"service-class","spec.bindable":"true","spec.externalID":"external-id","spec.externalMetadata.compliance.approved":"true","spec.externalMetadata.costCenter":"x","spec.externalMetadata.tier":"2","spec.externalName":"external-class-name","spec.tags.database":"true","spec.tags.mysql":"true"}`,
 		},
 	}
 	for _, tc := range cases {
@@ -180,6 +240,26 @@ func TestConvertClusterServicePlanToProperties(t *testing.T) {
 			},
 			json: `{"name":"service-plan","spec.clusterServiceClass.name":"cluster-service-class-name","spec.externalID":"external-id","spec.externalName":"external-plan-name","spec.free":"true"}`,
 		},
+		{
+			name: "object with bindable and external metadata",
+			sp: &ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-plan"},
+				Spec: ClusterServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{
+						ExternalName: "external-plan-name",
+						ExternalID:   "external-id",
+						Bindable:     boolPtr(true),
+						ExternalMetadata: &runtime.RawExtension{
+							Raw: []byte(`{"costCenter":"y"}`),
+						},
+					},
+					ClusterServiceClassRef: ClusterObjectReference{
+						Name: "cluster-service-class-name",
+					},
+				},
+			},
+			json: `{"name":"service-plan","spec.bindable":"true","spec.clusterServiceClass.name":"cluster-service-class-name","spec.externalID":"external-id","spec.externalMetadata.costCenter":"y","spec.externalName":"external-plan-name","spec.free":"false"}`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -198,3 +278,60 @@ func TestConvertClusterServicePlanToProperties(t *testing.T) {
 		})
 	}
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// TestCatalogRestrictionProperties checks that catalog restrictions may
+// select on every property that classes and plans are converted into.
+func TestCatalogRestrictionProperties(t *testing.T) {
+	bindable := true
+	metadata := &runtime.RawExtension{Raw: []byte(`{"costCenter":"a","tier":{"level":1}}`)}
+	commonClassSpec := CommonServiceClassSpec{
+		ExternalName:     "class",
+		ExternalID:       "class-id",
+		Tags:             []string{"database"},
+		ExternalMetadata: metadata,
+	}
+	commonPlanSpec := CommonServicePlanSpec{
+		ExternalName:     "plan",
+		ExternalID:       "plan-id",
+		Bindable:         &bindable,
+		ExternalMetadata: metadata,
+	}
+
+	cases := []struct {
+		name       string
+		properties labels.Set
+		isProperty func(string) bool
+	}{
+		{
+			name:       "ClusterServiceClass",
+			properties: ConvertClusterServiceClassToProperties(&ClusterServiceClass{Spec: ClusterServiceClassSpec{CommonServiceClassSpec: commonClassSpec}}).(labels.Set),
+			isProperty: servicecatalog.IsServiceClassProperty,
+		},
+		{
+			name:       "ServiceClass",
+			properties: ConvertServiceClassToProperties(&ServiceClass{Spec: ServiceClassSpec{CommonServiceClassSpec: commonClassSpec}}).(labels.Set),
+			isProperty: servicecatalog.IsServiceClassProperty,
+		},
+		{
+			name:       "ClusterServicePlan",
+			properties: ConvertClusterServicePlanToProperties(&ClusterServicePlan{Spec: ClusterServicePlanSpec{CommonServicePlanSpec: commonPlanSpec}}).(labels.Set),
+			isProperty: servicecatalog.IsClusterServicePlanProperty,
+		},
+		{
+			name:       "ServicePlan",
+			properties: ConvertServicePlanToProperties(&ServicePlan{Spec: ServicePlanSpec{CommonServicePlanSpec: commonPlanSpec}}).(labels.Set),
+			isProperty: servicecatalog.IsServicePlanProperty,
+		},
+	}
+	for _, tc := range cases {
+		for key := range tc.properties {
+			if !tc.isProperty(key) {
+				t.Errorf("%v: catalog restrictions may not select on property %q", tc.name, key)
+			}
+		}
+	}
+}
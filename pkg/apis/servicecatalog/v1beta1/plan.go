@@ -45,3 +45,33 @@ func (p *ClusterServicePlan) GetDescription() string {
 func (p *ServicePlan) GetDescription() string {
 	return p.Spec.Description
 }
+
+// GetServiceBrokerName returns the name of the service broker for the plan.
+func (p *ClusterServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ClusterServiceBrokerName
+}
+
+// GetServiceBrokerName returns the name of the service broker for the plan.
+func (p *ServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ServiceBrokerName
+}
+
+// GetClassID returns the name of the class that the plan belongs to.
+func (p *ClusterServicePlan) GetClassID() string {
+	return p.Spec.ClusterServiceClassRef.Name
+}
+
+// GetClassID returns the name of the class that the plan belongs to.
+func (p *ServicePlan) GetClassID() string {
+	return p.Spec.ServiceClassRef.Name
+}
+
+// GetSpec returns the spec fields common to both plan scopes.
+func (p *ClusterServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
+
+// GetSpec returns the spec fields common to both plan scopes.
+func (p *ServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
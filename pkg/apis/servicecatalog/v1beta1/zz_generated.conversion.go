@@ -41,6 +41,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_AddKeyTransform_To_v1beta1_AddKeyTransform,
 		Convert_v1beta1_AddKeysFromTransform_To_servicecatalog_AddKeysFromTransform,
 		Convert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform,
+		Convert_v1beta1_AllowedParameterValues_To_servicecatalog_AllowedParameterValues,
+		Convert_servicecatalog_AllowedParameterValues_To_v1beta1_AllowedParameterValues,
 		Convert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig,
 		Convert_servicecatalog_BasicAuthConfig_To_v1beta1_BasicAuthConfig,
 		Convert_v1beta1_BearerTokenAuthConfig_To_servicecatalog_BearerTokenAuthConfig,
@@ -75,6 +77,12 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ClusterServicePlan_To_v1beta1_ClusterServicePlan,
 		Convert_v1beta1_ClusterServicePlanList_To_servicecatalog_ClusterServicePlanList,
 		Convert_servicecatalog_ClusterServicePlanList_To_v1beta1_ClusterServicePlanList,
+		Convert_v1beta1_ClusterServicePlanPolicy_To_servicecatalog_ClusterServicePlanPolicy,
+		Convert_servicecatalog_ClusterServicePlanPolicy_To_v1beta1_ClusterServicePlanPolicy,
+		Convert_v1beta1_ClusterServicePlanPolicyList_To_servicecatalog_ClusterServicePlanPolicyList,
+		Convert_servicecatalog_ClusterServicePlanPolicyList_To_v1beta1_ClusterServicePlanPolicyList,
+		Convert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec,
+		Convert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec,
 		Convert_v1beta1_ClusterServicePlanSpec_To_servicecatalog_ClusterServicePlanSpec,
 		Convert_servicecatalog_ClusterServicePlanSpec_To_v1beta1_ClusterServicePlanSpec,
 		Convert_v1beta1_ClusterServicePlanStatus_To_servicecatalog_ClusterServicePlanStatus,
@@ -91,18 +99,34 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePlanSpec,
 		Convert_v1beta1_CommonServicePlanStatus_To_servicecatalog_CommonServicePlanStatus,
 		Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus,
+		Convert_v1beta1_ConditionFieldError_To_servicecatalog_ConditionFieldError,
+		Convert_servicecatalog_ConditionFieldError_To_v1beta1_ConditionFieldError,
+		Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference,
+		Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference,
 		Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference,
 		Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference,
+		Convert_v1beta1_MaintenanceWindow_To_servicecatalog_MaintenanceWindow,
+		Convert_servicecatalog_MaintenanceWindow_To_v1beta1_MaintenanceWindow,
 		Convert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference,
 		Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference,
 		Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource,
 		Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource,
+		Convert_v1beta1_ParametersPolicy_To_servicecatalog_ParametersPolicy,
+		Convert_servicecatalog_ParametersPolicy_To_v1beta1_ParametersPolicy,
+		Convert_v1beta1_PlanMigration_To_servicecatalog_PlanMigration,
+		Convert_servicecatalog_PlanMigration_To_v1beta1_PlanMigration,
+		Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy,
+		Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy,
+		Convert_v1beta1_PlanMigrationStatus_To_servicecatalog_PlanMigrationStatus,
+		Convert_servicecatalog_PlanMigrationStatus_To_v1beta1_PlanMigrationStatus,
 		Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference,
 		Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference,
 		Convert_v1beta1_RemoveKeyTransform_To_servicecatalog_RemoveKeyTransform,
 		Convert_servicecatalog_RemoveKeyTransform_To_v1beta1_RemoveKeyTransform,
 		Convert_v1beta1_RenameKeyTransform_To_servicecatalog_RenameKeyTransform,
 		Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform,
+		Convert_v1beta1_RenderTemplateTransform_To_servicecatalog_RenderTemplateTransform,
+		Convert_servicecatalog_RenderTemplateTransform_To_v1beta1_RenderTemplateTransform,
 		Convert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference,
 		Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference,
 		Convert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform,
@@ -111,22 +135,44 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceBinding_To_v1beta1_ServiceBinding,
 		Convert_v1beta1_ServiceBindingCondition_To_servicecatalog_ServiceBindingCondition,
 		Convert_servicecatalog_ServiceBindingCondition_To_v1beta1_ServiceBindingCondition,
+		Convert_v1beta1_ServiceBindingConfigMap_To_servicecatalog_ServiceBindingConfigMap,
+		Convert_servicecatalog_ServiceBindingConfigMap_To_v1beta1_ServiceBindingConfigMap,
+		Convert_v1beta1_ServiceBindingKeyReference_To_servicecatalog_ServiceBindingKeyReference,
+		Convert_servicecatalog_ServiceBindingKeyReference_To_v1beta1_ServiceBindingKeyReference,
 		Convert_v1beta1_ServiceBindingList_To_servicecatalog_ServiceBindingList,
 		Convert_servicecatalog_ServiceBindingList_To_v1beta1_ServiceBindingList,
 		Convert_v1beta1_ServiceBindingPropertiesState_To_servicecatalog_ServiceBindingPropertiesState,
 		Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState,
+		Convert_v1beta1_ServiceBindingRetiredCredentials_To_servicecatalog_ServiceBindingRetiredCredentials,
+		Convert_servicecatalog_ServiceBindingRetiredCredentials_To_v1beta1_ServiceBindingRetiredCredentials,
 		Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec,
 		Convert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec,
 		Convert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingStatus,
 		Convert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingStatus,
+		Convert_v1beta1_ServiceBindingVolumeMount_To_servicecatalog_ServiceBindingVolumeMount,
+		Convert_servicecatalog_ServiceBindingVolumeMount_To_v1beta1_ServiceBindingVolumeMount,
+		Convert_v1beta1_ServiceBindingWorkload_To_servicecatalog_ServiceBindingWorkload,
+		Convert_servicecatalog_ServiceBindingWorkload_To_v1beta1_ServiceBindingWorkload,
+		Convert_v1beta1_ServiceBindingWorkloadInjection_To_servicecatalog_ServiceBindingWorkloadInjection,
+		Convert_servicecatalog_ServiceBindingWorkloadInjection_To_v1beta1_ServiceBindingWorkloadInjection,
 		Convert_v1beta1_ServiceBroker_To_servicecatalog_ServiceBroker,
 		Convert_servicecatalog_ServiceBroker_To_v1beta1_ServiceBroker,
 		Convert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthInfo,
 		Convert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthInfo,
+		Convert_v1beta1_ServiceBrokerCatalogChange_To_servicecatalog_ServiceBrokerCatalogChange,
+		Convert_servicecatalog_ServiceBrokerCatalogChange_To_v1beta1_ServiceBrokerCatalogChange,
+		Convert_v1beta1_ServiceBrokerCatalogEntryChange_To_servicecatalog_ServiceBrokerCatalogEntryChange,
+		Convert_servicecatalog_ServiceBrokerCatalogEntryChange_To_v1beta1_ServiceBrokerCatalogEntryChange,
 		Convert_v1beta1_ServiceBrokerCondition_To_servicecatalog_ServiceBrokerCondition,
 		Convert_servicecatalog_ServiceBrokerCondition_To_v1beta1_ServiceBrokerCondition,
 		Convert_v1beta1_ServiceBrokerList_To_servicecatalog_ServiceBrokerList,
 		Convert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList,
+		Convert_v1beta1_ServiceBrokerPollingPolicy_To_servicecatalog_ServiceBrokerPollingPolicy,
+		Convert_servicecatalog_ServiceBrokerPollingPolicy_To_v1beta1_ServiceBrokerPollingPolicy,
+		Convert_v1beta1_ServiceBrokerRequestContext_To_servicecatalog_ServiceBrokerRequestContext,
+		Convert_servicecatalog_ServiceBrokerRequestContext_To_v1beta1_ServiceBrokerRequestContext,
+		Convert_v1beta1_ServiceBrokerRequestLimits_To_servicecatalog_ServiceBrokerRequestLimits,
+		Convert_servicecatalog_ServiceBrokerRequestLimits_To_v1beta1_ServiceBrokerRequestLimits,
 		Convert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec,
 		Convert_servicecatalog_ServiceBrokerSpec_To_v1beta1_ServiceBrokerSpec,
 		Convert_v1beta1_ServiceBrokerStatus_To_servicecatalog_ServiceBrokerStatus,
@@ -143,6 +189,12 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceInstance_To_v1beta1_ServiceInstance,
 		Convert_v1beta1_ServiceInstanceCondition_To_servicecatalog_ServiceInstanceCondition,
 		Convert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceCondition,
+		Convert_v1beta1_ServiceInstanceGrant_To_servicecatalog_ServiceInstanceGrant,
+		Convert_servicecatalog_ServiceInstanceGrant_To_v1beta1_ServiceInstanceGrant,
+		Convert_v1beta1_ServiceInstanceGrantList_To_servicecatalog_ServiceInstanceGrantList,
+		Convert_servicecatalog_ServiceInstanceGrantList_To_v1beta1_ServiceInstanceGrantList,
+		Convert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec,
+		Convert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec,
 		Convert_v1beta1_ServiceInstanceList_To_servicecatalog_ServiceInstanceList,
 		Convert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList,
 		Convert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceInstancePropertiesState,
@@ -210,6 +262,28 @@ func Convert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform
 	return autoConvert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_AllowedParameterValues_To_servicecatalog_AllowedParameterValues(in *AllowedParameterValues, out *servicecatalog.AllowedParameterValues, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1beta1_AllowedParameterValues_To_servicecatalog_AllowedParameterValues is an autogenerated conversion function.
+func Convert_v1beta1_AllowedParameterValues_To_servicecatalog_AllowedParameterValues(in *AllowedParameterValues, out *servicecatalog.AllowedParameterValues, s conversion.Scope) error {
+	return autoConvert_v1beta1_AllowedParameterValues_To_servicecatalog_AllowedParameterValues(in, out, s)
+}
+
+func autoConvert_servicecatalog_AllowedParameterValues_To_v1beta1_AllowedParameterValues(in *servicecatalog.AllowedParameterValues, out *AllowedParameterValues, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_servicecatalog_AllowedParameterValues_To_v1beta1_AllowedParameterValues is an autogenerated conversion function.
+func Convert_servicecatalog_AllowedParameterValues_To_v1beta1_AllowedParameterValues(in *servicecatalog.AllowedParameterValues, out *AllowedParameterValues, s conversion.Scope) error {
+	return autoConvert_servicecatalog_AllowedParameterValues_To_v1beta1_AllowedParameterValues(in, out, s)
+}
+
 func autoConvert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig(in *BasicAuthConfig, out *servicecatalog.BasicAuthConfig, s conversion.Scope) error {
 	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
@@ -413,6 +487,7 @@ func autoConvert_v1beta1_ClusterServiceBrokerSpec_To_servicecatalog_ClusterServi
 		return err
 	}
 	out.AuthInfo = (*servicecatalog.ClusterServiceBrokerAuthInfo)(unsafe.Pointer(in.AuthInfo))
+	out.PlanMigrationPolicy = (*servicecatalog.PlanMigrationPolicy)(unsafe.Pointer(in.PlanMigrationPolicy))
 	return nil
 }
 
@@ -426,6 +501,7 @@ func autoConvert_servicecatalog_ClusterServiceBrokerSpec_To_v1beta1_ClusterServi
 		return err
 	}
 	out.AuthInfo = (*ClusterServiceBrokerAuthInfo)(unsafe.Pointer(in.AuthInfo))
+	out.PlanMigrationPolicy = (*PlanMigrationPolicy)(unsafe.Pointer(in.PlanMigrationPolicy))
 	return nil
 }
 
@@ -438,6 +514,7 @@ func autoConvert_v1beta1_ClusterServiceBrokerStatus_To_servicecatalog_ClusterSer
 	if err := Convert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServiceBrokerStatus(&in.CommonServiceBrokerStatus, &out.CommonServiceBrokerStatus, s); err != nil {
 		return err
 	}
+	out.PlanMigrations = *(*[]servicecatalog.PlanMigrationStatus)(unsafe.Pointer(&in.PlanMigrations))
 	return nil
 }
 
@@ -450,6 +527,7 @@ func autoConvert_servicecatalog_ClusterServiceBrokerStatus_To_v1beta1_ClusterSer
 	if err := Convert_servicecatalog_CommonServiceBrokerStatus_To_v1beta1_CommonServiceBrokerStatus(&in.CommonServiceBrokerStatus, &out.CommonServiceBrokerStatus, s); err != nil {
 		return err
 	}
+	out.PlanMigrations = *(*[]PlanMigrationStatus)(unsafe.Pointer(&in.PlanMigrations))
 	return nil
 }
 
@@ -616,6 +694,82 @@ func Convert_servicecatalog_ClusterServicePlanList_To_v1beta1_ClusterServicePlan
 	return autoConvert_servicecatalog_ClusterServicePlanList_To_v1beta1_ClusterServicePlanList(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterServicePlanPolicy_To_servicecatalog_ClusterServicePlanPolicy(in *ClusterServicePlanPolicy, out *servicecatalog.ClusterServicePlanPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterServicePlanPolicy_To_servicecatalog_ClusterServicePlanPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServicePlanPolicy_To_servicecatalog_ClusterServicePlanPolicy(in *ClusterServicePlanPolicy, out *servicecatalog.ClusterServicePlanPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServicePlanPolicy_To_servicecatalog_ClusterServicePlanPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServicePlanPolicy_To_v1beta1_ClusterServicePlanPolicy(in *servicecatalog.ClusterServicePlanPolicy, out *ClusterServicePlanPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServicePlanPolicy_To_v1beta1_ClusterServicePlanPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServicePlanPolicy_To_v1beta1_ClusterServicePlanPolicy(in *servicecatalog.ClusterServicePlanPolicy, out *ClusterServicePlanPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServicePlanPolicy_To_v1beta1_ClusterServicePlanPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterServicePlanPolicyList_To_servicecatalog_ClusterServicePlanPolicyList(in *ClusterServicePlanPolicyList, out *servicecatalog.ClusterServicePlanPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ClusterServicePlanPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ClusterServicePlanPolicyList_To_servicecatalog_ClusterServicePlanPolicyList is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServicePlanPolicyList_To_servicecatalog_ClusterServicePlanPolicyList(in *ClusterServicePlanPolicyList, out *servicecatalog.ClusterServicePlanPolicyList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServicePlanPolicyList_To_servicecatalog_ClusterServicePlanPolicyList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServicePlanPolicyList_To_v1beta1_ClusterServicePlanPolicyList(in *servicecatalog.ClusterServicePlanPolicyList, out *ClusterServicePlanPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterServicePlanPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServicePlanPolicyList_To_v1beta1_ClusterServicePlanPolicyList is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServicePlanPolicyList_To_v1beta1_ClusterServicePlanPolicyList(in *servicecatalog.ClusterServicePlanPolicyList, out *ClusterServicePlanPolicyList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServicePlanPolicyList_To_v1beta1_ClusterServicePlanPolicyList(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec(in *ClusterServicePlanPolicySpec, out *servicecatalog.ClusterServicePlanPolicySpec, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.InstanceParameters = (*servicecatalog.ParametersPolicy)(unsafe.Pointer(in.InstanceParameters))
+	out.BindingParameters = (*servicecatalog.ParametersPolicy)(unsafe.Pointer(in.BindingParameters))
+	return nil
+}
+
+// Convert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec(in *ClusterServicePlanPolicySpec, out *servicecatalog.ClusterServicePlanPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServicePlanPolicySpec_To_servicecatalog_ClusterServicePlanPolicySpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec(in *servicecatalog.ClusterServicePlanPolicySpec, out *ClusterServicePlanPolicySpec, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.InstanceParameters = (*ParametersPolicy)(unsafe.Pointer(in.InstanceParameters))
+	out.BindingParameters = (*ParametersPolicy)(unsafe.Pointer(in.BindingParameters))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec(in *servicecatalog.ClusterServicePlanPolicySpec, out *ClusterServicePlanPolicySpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServicePlanPolicySpec_To_v1beta1_ClusterServicePlanPolicySpec(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterServicePlanSpec_To_servicecatalog_ClusterServicePlanSpec(in *ClusterServicePlanSpec, out *servicecatalog.ClusterServicePlanSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_CommonServicePlanSpec_To_servicecatalog_CommonServicePlanSpec(&in.CommonServicePlanSpec, &out.CommonServicePlanSpec, s); err != nil {
 		return err
@@ -680,6 +834,9 @@ func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.PollingPolicy = (*servicecatalog.ServiceBrokerPollingPolicy)(unsafe.Pointer(in.PollingPolicy))
+	out.RequestLimits = (*servicecatalog.ServiceBrokerRequestLimits)(unsafe.Pointer(in.RequestLimits))
+	out.RequestContext = (*servicecatalog.ServiceBrokerRequestContext)(unsafe.Pointer(in.RequestContext))
 	return nil
 }
 
@@ -696,6 +853,9 @@ func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.PollingPolicy = (*ServiceBrokerPollingPolicy)(unsafe.Pointer(in.PollingPolicy))
+	out.RequestLimits = (*ServiceBrokerRequestLimits)(unsafe.Pointer(in.RequestLimits))
+	out.RequestContext = (*ServiceBrokerRequestContext)(unsafe.Pointer(in.RequestContext))
 	return nil
 }
 
@@ -709,6 +869,7 @@ func autoConvert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServi
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.CatalogHistory = *(*[]servicecatalog.ServiceBrokerCatalogChange)(unsafe.Pointer(&in.CatalogHistory))
 	return nil
 }
 
@@ -722,6 +883,7 @@ func autoConvert_servicecatalog_CommonServiceBrokerStatus_To_v1beta1_CommonServi
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.CatalogHistory = *(*[]ServiceBrokerCatalogChange)(unsafe.Pointer(&in.CatalogHistory))
 	return nil
 }
 
@@ -844,6 +1006,52 @@ func Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlan
 	return autoConvert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ConditionFieldError_To_servicecatalog_ConditionFieldError(in *ConditionFieldError, out *servicecatalog.ConditionFieldError, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Field = in.Field
+	out.Detail = in.Detail
+	return nil
+}
+
+// Convert_v1beta1_ConditionFieldError_To_servicecatalog_ConditionFieldError is an autogenerated conversion function.
+func Convert_v1beta1_ConditionFieldError_To_servicecatalog_ConditionFieldError(in *ConditionFieldError, out *servicecatalog.ConditionFieldError, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConditionFieldError_To_servicecatalog_ConditionFieldError(in, out, s)
+}
+
+func autoConvert_servicecatalog_ConditionFieldError_To_v1beta1_ConditionFieldError(in *servicecatalog.ConditionFieldError, out *ConditionFieldError, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Field = in.Field
+	out.Detail = in.Detail
+	return nil
+}
+
+// Convert_servicecatalog_ConditionFieldError_To_v1beta1_ConditionFieldError is an autogenerated conversion function.
+func Convert_servicecatalog_ConditionFieldError_To_v1beta1_ConditionFieldError(in *servicecatalog.ConditionFieldError, out *ConditionFieldError, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ConditionFieldError_To_v1beta1_ConditionFieldError(in, out, s)
+}
+
+func autoConvert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in *ConfigMapKeyReference, out *servicecatalog.ConfigMapKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference is an autogenerated conversion function.
+func Convert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in *ConfigMapKeyReference, out *servicecatalog.ConfigMapKeyReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConfigMapKeyReference_To_servicecatalog_ConfigMapKeyReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in *servicecatalog.ConfigMapKeyReference, out *ConfigMapKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference is an autogenerated conversion function.
+func Convert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in *servicecatalog.ConfigMapKeyReference, out *ConfigMapKeyReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ConfigMapKeyReference_To_v1beta1_ConfigMapKeyReference(in, out, s)
+}
+
 func autoConvert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(in *LocalObjectReference, out *servicecatalog.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -864,6 +1072,28 @@ func Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference
 	return autoConvert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_MaintenanceWindow_To_servicecatalog_MaintenanceWindow(in *MaintenanceWindow, out *servicecatalog.MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1beta1_MaintenanceWindow_To_servicecatalog_MaintenanceWindow is an autogenerated conversion function.
+func Convert_v1beta1_MaintenanceWindow_To_servicecatalog_MaintenanceWindow(in *MaintenanceWindow, out *servicecatalog.MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_v1beta1_MaintenanceWindow_To_servicecatalog_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_servicecatalog_MaintenanceWindow_To_v1beta1_MaintenanceWindow(in *servicecatalog.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_servicecatalog_MaintenanceWindow_To_v1beta1_MaintenanceWindow is an autogenerated conversion function.
+func Convert_servicecatalog_MaintenanceWindow_To_v1beta1_MaintenanceWindow(in *servicecatalog.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_servicecatalog_MaintenanceWindow_To_v1beta1_MaintenanceWindow(in, out, s)
+}
+
 func autoConvert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference(in *ObjectReference, out *servicecatalog.ObjectReference, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.Name = in.Name
@@ -888,6 +1118,10 @@ func Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in *servi
 
 func autoConvert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource(in *ParametersFromSource, out *servicecatalog.ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*servicecatalog.SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ConfigMapKeyRef = (*servicecatalog.ConfigMapKeyReference)(unsafe.Pointer(in.ConfigMapKeyRef))
+	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.ConfigMapRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ConfigMapRef))
+	out.ServiceBindingKeyRef = (*servicecatalog.ServiceBindingKeyReference)(unsafe.Pointer(in.ServiceBindingKeyRef))
 	return nil
 }
 
@@ -898,6 +1132,10 @@ func Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource
 
 func autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in *servicecatalog.ParametersFromSource, out *ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ConfigMapKeyRef = (*ConfigMapKeyReference)(unsafe.Pointer(in.ConfigMapKeyRef))
+	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.ConfigMapRef = (*LocalObjectReference)(unsafe.Pointer(in.ConfigMapRef))
+	out.ServiceBindingKeyRef = (*ServiceBindingKeyReference)(unsafe.Pointer(in.ServiceBindingKeyRef))
 	return nil
 }
 
@@ -906,6 +1144,112 @@ func Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource
 	return autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in, out, s)
 }
 
+func autoConvert_v1beta1_ParametersPolicy_To_servicecatalog_ParametersPolicy(in *ParametersPolicy, out *servicecatalog.ParametersPolicy, s conversion.Scope) error {
+	out.Defaults = (*runtime.RawExtension)(unsafe.Pointer(in.Defaults))
+	out.LockedKeys = *(*[]string)(unsafe.Pointer(&in.LockedKeys))
+	out.AllowedValues = *(*[]servicecatalog.AllowedParameterValues)(unsafe.Pointer(&in.AllowedValues))
+	return nil
+}
+
+// Convert_v1beta1_ParametersPolicy_To_servicecatalog_ParametersPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ParametersPolicy_To_servicecatalog_ParametersPolicy(in *ParametersPolicy, out *servicecatalog.ParametersPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ParametersPolicy_To_servicecatalog_ParametersPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_ParametersPolicy_To_v1beta1_ParametersPolicy(in *servicecatalog.ParametersPolicy, out *ParametersPolicy, s conversion.Scope) error {
+	out.Defaults = (*runtime.RawExtension)(unsafe.Pointer(in.Defaults))
+	out.LockedKeys = *(*[]string)(unsafe.Pointer(&in.LockedKeys))
+	out.AllowedValues = *(*[]AllowedParameterValues)(unsafe.Pointer(&in.AllowedValues))
+	return nil
+}
+
+// Convert_servicecatalog_ParametersPolicy_To_v1beta1_ParametersPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_ParametersPolicy_To_v1beta1_ParametersPolicy(in *servicecatalog.ParametersPolicy, out *ParametersPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ParametersPolicy_To_v1beta1_ParametersPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PlanMigration_To_servicecatalog_PlanMigration(in *PlanMigration, out *servicecatalog.PlanMigration, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.FromPlanExternalName = in.FromPlanExternalName
+	out.ToPlanExternalName = in.ToPlanExternalName
+	return nil
+}
+
+// Convert_v1beta1_PlanMigration_To_servicecatalog_PlanMigration is an autogenerated conversion function.
+func Convert_v1beta1_PlanMigration_To_servicecatalog_PlanMigration(in *PlanMigration, out *servicecatalog.PlanMigration, s conversion.Scope) error {
+	return autoConvert_v1beta1_PlanMigration_To_servicecatalog_PlanMigration(in, out, s)
+}
+
+func autoConvert_servicecatalog_PlanMigration_To_v1beta1_PlanMigration(in *servicecatalog.PlanMigration, out *PlanMigration, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.FromPlanExternalName = in.FromPlanExternalName
+	out.ToPlanExternalName = in.ToPlanExternalName
+	return nil
+}
+
+// Convert_servicecatalog_PlanMigration_To_v1beta1_PlanMigration is an autogenerated conversion function.
+func Convert_servicecatalog_PlanMigration_To_v1beta1_PlanMigration(in *servicecatalog.PlanMigration, out *PlanMigration, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PlanMigration_To_v1beta1_PlanMigration(in, out, s)
+}
+
+func autoConvert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in *PlanMigrationPolicy, out *servicecatalog.PlanMigrationPolicy, s conversion.Scope) error {
+	out.Migrations = *(*[]servicecatalog.PlanMigration)(unsafe.Pointer(&in.Migrations))
+	out.BatchSize = in.BatchSize
+	out.DryRun = in.DryRun
+	return nil
+}
+
+// Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy is an autogenerated conversion function.
+func Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in *PlanMigrationPolicy, out *servicecatalog.PlanMigrationPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in *servicecatalog.PlanMigrationPolicy, out *PlanMigrationPolicy, s conversion.Scope) error {
+	out.Migrations = *(*[]PlanMigration)(unsafe.Pointer(&in.Migrations))
+	out.BatchSize = in.BatchSize
+	out.DryRun = in.DryRun
+	return nil
+}
+
+// Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in *servicecatalog.PlanMigrationPolicy, out *PlanMigrationPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PlanMigrationStatus_To_servicecatalog_PlanMigrationStatus(in *PlanMigrationStatus, out *servicecatalog.PlanMigrationStatus, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.FromPlanExternalName = in.FromPlanExternalName
+	out.ToPlanExternalName = in.ToPlanExternalName
+	out.RemainingInstances = in.RemainingInstances
+	out.MigratingInstances = *(*[]string)(unsafe.Pointer(&in.MigratingInstances))
+	out.FailedInstances = *(*[]string)(unsafe.Pointer(&in.FailedInstances))
+	out.PendingInstances = *(*[]string)(unsafe.Pointer(&in.PendingInstances))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_PlanMigrationStatus_To_servicecatalog_PlanMigrationStatus is an autogenerated conversion function.
+func Convert_v1beta1_PlanMigrationStatus_To_servicecatalog_PlanMigrationStatus(in *PlanMigrationStatus, out *servicecatalog.PlanMigrationStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_PlanMigrationStatus_To_servicecatalog_PlanMigrationStatus(in, out, s)
+}
+
+func autoConvert_servicecatalog_PlanMigrationStatus_To_v1beta1_PlanMigrationStatus(in *servicecatalog.PlanMigrationStatus, out *PlanMigrationStatus, s conversion.Scope) error {
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.FromPlanExternalName = in.FromPlanExternalName
+	out.ToPlanExternalName = in.ToPlanExternalName
+	out.RemainingInstances = in.RemainingInstances
+	out.MigratingInstances = *(*[]string)(unsafe.Pointer(&in.MigratingInstances))
+	out.FailedInstances = *(*[]string)(unsafe.Pointer(&in.FailedInstances))
+	out.PendingInstances = *(*[]string)(unsafe.Pointer(&in.PendingInstances))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_servicecatalog_PlanMigrationStatus_To_v1beta1_PlanMigrationStatus is an autogenerated conversion function.
+func Convert_servicecatalog_PlanMigrationStatus_To_v1beta1_PlanMigrationStatus(in *servicecatalog.PlanMigrationStatus, out *PlanMigrationStatus, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PlanMigrationStatus_To_v1beta1_PlanMigrationStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_PlanReference_To_servicecatalog_PlanReference(in *PlanReference, out *servicecatalog.PlanReference, s conversion.Scope) error {
 	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
 	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
@@ -990,6 +1334,28 @@ func Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in
 	return autoConvert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_RenderTemplateTransform_To_servicecatalog_RenderTemplateTransform(in *RenderTemplateTransform, out *servicecatalog.RenderTemplateTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1beta1_RenderTemplateTransform_To_servicecatalog_RenderTemplateTransform is an autogenerated conversion function.
+func Convert_v1beta1_RenderTemplateTransform_To_servicecatalog_RenderTemplateTransform(in *RenderTemplateTransform, out *servicecatalog.RenderTemplateTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_RenderTemplateTransform_To_servicecatalog_RenderTemplateTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_RenderTemplateTransform_To_v1beta1_RenderTemplateTransform(in *servicecatalog.RenderTemplateTransform, out *RenderTemplateTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_servicecatalog_RenderTemplateTransform_To_v1beta1_RenderTemplateTransform is an autogenerated conversion function.
+func Convert_servicecatalog_RenderTemplateTransform_To_v1beta1_RenderTemplateTransform(in *servicecatalog.RenderTemplateTransform, out *RenderTemplateTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_RenderTemplateTransform_To_v1beta1_RenderTemplateTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference(in *SecretKeyReference, out *servicecatalog.SecretKeyReference, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Key = in.Key
@@ -1017,6 +1383,7 @@ func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *S
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*servicecatalog.AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*servicecatalog.RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.RenderTemplate = (*servicecatalog.RenderTemplateTransform)(unsafe.Pointer(in.RenderTemplate))
 	return nil
 }
 
@@ -1030,6 +1397,7 @@ func autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *s
 	out.AddKey = (*AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.RenderTemplate = (*RenderTemplateTransform)(unsafe.Pointer(in.RenderTemplate))
 	return nil
 }
 
@@ -1076,6 +1444,7 @@ func autoConvert_v1beta1_ServiceBindingCondition_To_servicecatalog_ServiceBindin
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.FieldErrors = *(*[]servicecatalog.ConditionFieldError)(unsafe.Pointer(&in.FieldErrors))
 	return nil
 }
 
@@ -1090,6 +1459,7 @@ func autoConvert_servicecatalog_ServiceBindingCondition_To_v1beta1_ServiceBindin
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.FieldErrors = *(*[]ConditionFieldError)(unsafe.Pointer(&in.FieldErrors))
 	return nil
 }
 
@@ -1098,6 +1468,52 @@ func Convert_servicecatalog_ServiceBindingCondition_To_v1beta1_ServiceBindingCon
 	return autoConvert_servicecatalog_ServiceBindingCondition_To_v1beta1_ServiceBindingCondition(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingConfigMap_To_servicecatalog_ServiceBindingConfigMap(in *ServiceBindingConfigMap, out *servicecatalog.ServiceBindingConfigMap, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingConfigMap_To_servicecatalog_ServiceBindingConfigMap is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingConfigMap_To_servicecatalog_ServiceBindingConfigMap(in *ServiceBindingConfigMap, out *servicecatalog.ServiceBindingConfigMap, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingConfigMap_To_servicecatalog_ServiceBindingConfigMap(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingConfigMap_To_v1beta1_ServiceBindingConfigMap(in *servicecatalog.ServiceBindingConfigMap, out *ServiceBindingConfigMap, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingConfigMap_To_v1beta1_ServiceBindingConfigMap is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingConfigMap_To_v1beta1_ServiceBindingConfigMap(in *servicecatalog.ServiceBindingConfigMap, out *ServiceBindingConfigMap, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingConfigMap_To_v1beta1_ServiceBindingConfigMap(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBindingKeyReference_To_servicecatalog_ServiceBindingKeyReference(in *ServiceBindingKeyReference, out *servicecatalog.ServiceBindingKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingKeyReference_To_servicecatalog_ServiceBindingKeyReference is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingKeyReference_To_servicecatalog_ServiceBindingKeyReference(in *ServiceBindingKeyReference, out *servicecatalog.ServiceBindingKeyReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingKeyReference_To_servicecatalog_ServiceBindingKeyReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingKeyReference_To_v1beta1_ServiceBindingKeyReference(in *servicecatalog.ServiceBindingKeyReference, out *ServiceBindingKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingKeyReference_To_v1beta1_ServiceBindingKeyReference is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingKeyReference_To_v1beta1_ServiceBindingKeyReference(in *servicecatalog.ServiceBindingKeyReference, out *ServiceBindingKeyReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingKeyReference_To_v1beta1_ServiceBindingKeyReference(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBindingList_To_servicecatalog_ServiceBindingList(in *ServiceBindingList, out *servicecatalog.ServiceBindingList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]servicecatalog.ServiceBinding)(unsafe.Pointer(&in.Items))
@@ -1144,16 +1560,43 @@ func Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBind
 	return autoConvert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingRetiredCredentials_To_servicecatalog_ServiceBindingRetiredCredentials(in *ServiceBindingRetiredCredentials, out *servicecatalog.ServiceBindingRetiredCredentials, s conversion.Scope) error {
+	out.ExternalID = in.ExternalID
+	out.RetiredTime = (*v1.Time)(unsafe.Pointer(in.RetiredTime))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingRetiredCredentials_To_servicecatalog_ServiceBindingRetiredCredentials is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingRetiredCredentials_To_servicecatalog_ServiceBindingRetiredCredentials(in *ServiceBindingRetiredCredentials, out *servicecatalog.ServiceBindingRetiredCredentials, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingRetiredCredentials_To_servicecatalog_ServiceBindingRetiredCredentials(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingRetiredCredentials_To_v1beta1_ServiceBindingRetiredCredentials(in *servicecatalog.ServiceBindingRetiredCredentials, out *ServiceBindingRetiredCredentials, s conversion.Scope) error {
+	out.ExternalID = in.ExternalID
+	out.RetiredTime = (*v1.Time)(unsafe.Pointer(in.RetiredTime))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingRetiredCredentials_To_v1beta1_ServiceBindingRetiredCredentials is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingRetiredCredentials_To_v1beta1_ServiceBindingRetiredCredentials(in *servicecatalog.ServiceBindingRetiredCredentials, out *ServiceBindingRetiredCredentials, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingRetiredCredentials_To_v1beta1_ServiceBindingRetiredCredentials(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(in *ServiceBindingSpec, out *servicecatalog.ServiceBindingSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(&in.ServiceInstanceRef, &out.ServiceInstanceRef, s); err != nil {
 		return err
 	}
+	out.ServiceInstanceNamespace = in.ServiceInstanceNamespace
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.SecretFormat = servicecatalog.ServiceBindingSecretFormat(in.SecretFormat)
+	out.ConfigMap = (*servicecatalog.ServiceBindingConfigMap)(unsafe.Pointer(in.ConfigMap))
+	out.WorkloadInjection = (*servicecatalog.ServiceBindingWorkloadInjection)(unsafe.Pointer(in.WorkloadInjection))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1166,12 +1609,17 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	if err := Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(&in.ServiceInstanceRef, &out.ServiceInstanceRef, s); err != nil {
 		return err
 	}
+	out.ServiceInstanceNamespace = in.ServiceInstanceNamespace
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.SecretFormat = ServiceBindingSecretFormat(in.SecretFormat)
+	out.ConfigMap = (*ServiceBindingConfigMap)(unsafe.Pointer(in.ConfigMap))
+	out.WorkloadInjection = (*ServiceBindingWorkloadInjection)(unsafe.Pointer(in.WorkloadInjection))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1191,6 +1639,11 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RetiredCredentials = *(*[]servicecatalog.ServiceBindingRetiredCredentials)(unsafe.Pointer(&in.RetiredCredentials))
+	out.InjectedWorkloads = *(*[]servicecatalog.ServiceBindingWorkload)(unsafe.Pointer(&in.InjectedWorkloads))
+	out.SyslogDrainURL = (*string)(unsafe.Pointer(in.SyslogDrainURL))
+	out.RouteServiceURL = (*string)(unsafe.Pointer(in.RouteServiceURL))
+	out.VolumeMounts = *(*[]servicecatalog.ServiceBindingVolumeMount)(unsafe.Pointer(&in.VolumeMounts))
 	return nil
 }
 
@@ -1210,6 +1663,11 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.RetiredCredentials = *(*[]ServiceBindingRetiredCredentials)(unsafe.Pointer(&in.RetiredCredentials))
+	out.InjectedWorkloads = *(*[]ServiceBindingWorkload)(unsafe.Pointer(&in.InjectedWorkloads))
+	out.SyslogDrainURL = (*string)(unsafe.Pointer(in.SyslogDrainURL))
+	out.RouteServiceURL = (*string)(unsafe.Pointer(in.RouteServiceURL))
+	out.VolumeMounts = *(*[]ServiceBindingVolumeMount)(unsafe.Pointer(&in.VolumeMounts))
 	return nil
 }
 
@@ -1218,6 +1676,82 @@ func Convert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingStatus
 	return autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingVolumeMount_To_servicecatalog_ServiceBindingVolumeMount(in *ServiceBindingVolumeMount, out *servicecatalog.ServiceBindingVolumeMount, s conversion.Scope) error {
+	out.Driver = in.Driver
+	out.ContainerDir = in.ContainerDir
+	out.Mode = in.Mode
+	out.DeviceType = in.DeviceType
+	out.VolumeID = in.VolumeID
+	out.PersistentVolumeClaimName = in.PersistentVolumeClaimName
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingVolumeMount_To_servicecatalog_ServiceBindingVolumeMount is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingVolumeMount_To_servicecatalog_ServiceBindingVolumeMount(in *ServiceBindingVolumeMount, out *servicecatalog.ServiceBindingVolumeMount, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingVolumeMount_To_servicecatalog_ServiceBindingVolumeMount(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingVolumeMount_To_v1beta1_ServiceBindingVolumeMount(in *servicecatalog.ServiceBindingVolumeMount, out *ServiceBindingVolumeMount, s conversion.Scope) error {
+	out.Driver = in.Driver
+	out.ContainerDir = in.ContainerDir
+	out.Mode = in.Mode
+	out.DeviceType = in.DeviceType
+	out.VolumeID = in.VolumeID
+	out.PersistentVolumeClaimName = in.PersistentVolumeClaimName
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingVolumeMount_To_v1beta1_ServiceBindingVolumeMount is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingVolumeMount_To_v1beta1_ServiceBindingVolumeMount(in *servicecatalog.ServiceBindingVolumeMount, out *ServiceBindingVolumeMount, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingVolumeMount_To_v1beta1_ServiceBindingVolumeMount(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBindingWorkload_To_servicecatalog_ServiceBindingWorkload(in *ServiceBindingWorkload, out *servicecatalog.ServiceBindingWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingWorkload_To_servicecatalog_ServiceBindingWorkload is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingWorkload_To_servicecatalog_ServiceBindingWorkload(in *ServiceBindingWorkload, out *servicecatalog.ServiceBindingWorkload, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingWorkload_To_servicecatalog_ServiceBindingWorkload(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingWorkload_To_v1beta1_ServiceBindingWorkload(in *servicecatalog.ServiceBindingWorkload, out *ServiceBindingWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingWorkload_To_v1beta1_ServiceBindingWorkload is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingWorkload_To_v1beta1_ServiceBindingWorkload(in *servicecatalog.ServiceBindingWorkload, out *ServiceBindingWorkload, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingWorkload_To_v1beta1_ServiceBindingWorkload(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBindingWorkloadInjection_To_servicecatalog_ServiceBindingWorkloadInjection(in *ServiceBindingWorkloadInjection, out *servicecatalog.ServiceBindingWorkloadInjection, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.Mode = servicecatalog.ServiceBindingWorkloadInjectionMode(in.Mode)
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingWorkloadInjection_To_servicecatalog_ServiceBindingWorkloadInjection is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingWorkloadInjection_To_servicecatalog_ServiceBindingWorkloadInjection(in *ServiceBindingWorkloadInjection, out *servicecatalog.ServiceBindingWorkloadInjection, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingWorkloadInjection_To_servicecatalog_ServiceBindingWorkloadInjection(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingWorkloadInjection_To_v1beta1_ServiceBindingWorkloadInjection(in *servicecatalog.ServiceBindingWorkloadInjection, out *ServiceBindingWorkloadInjection, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.Mode = ServiceBindingWorkloadInjectionMode(in.Mode)
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingWorkloadInjection_To_v1beta1_ServiceBindingWorkloadInjection is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingWorkloadInjection_To_v1beta1_ServiceBindingWorkloadInjection(in *servicecatalog.ServiceBindingWorkloadInjection, out *ServiceBindingWorkloadInjection, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingWorkloadInjection_To_v1beta1_ServiceBindingWorkloadInjection(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBroker_To_servicecatalog_ServiceBroker(in *ServiceBroker, out *servicecatalog.ServiceBroker, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1272,6 +1806,62 @@ func Convert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthIn
 	return autoConvert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthInfo(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBrokerCatalogChange_To_servicecatalog_ServiceBrokerCatalogChange(in *ServiceBrokerCatalogChange, out *servicecatalog.ServiceBrokerCatalogChange, s conversion.Scope) error {
+	out.Time = in.Time
+	out.Classes = *(*[]servicecatalog.ServiceBrokerCatalogEntryChange)(unsafe.Pointer(&in.Classes))
+	out.Plans = *(*[]servicecatalog.ServiceBrokerCatalogEntryChange)(unsafe.Pointer(&in.Plans))
+	out.OmittedClasses = in.OmittedClasses
+	out.OmittedPlans = in.OmittedPlans
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerCatalogChange_To_servicecatalog_ServiceBrokerCatalogChange is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerCatalogChange_To_servicecatalog_ServiceBrokerCatalogChange(in *ServiceBrokerCatalogChange, out *servicecatalog.ServiceBrokerCatalogChange, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerCatalogChange_To_servicecatalog_ServiceBrokerCatalogChange(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerCatalogChange_To_v1beta1_ServiceBrokerCatalogChange(in *servicecatalog.ServiceBrokerCatalogChange, out *ServiceBrokerCatalogChange, s conversion.Scope) error {
+	out.Time = in.Time
+	out.Classes = *(*[]ServiceBrokerCatalogEntryChange)(unsafe.Pointer(&in.Classes))
+	out.Plans = *(*[]ServiceBrokerCatalogEntryChange)(unsafe.Pointer(&in.Plans))
+	out.OmittedClasses = in.OmittedClasses
+	out.OmittedPlans = in.OmittedPlans
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerCatalogChange_To_v1beta1_ServiceBrokerCatalogChange is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerCatalogChange_To_v1beta1_ServiceBrokerCatalogChange(in *servicecatalog.ServiceBrokerCatalogChange, out *ServiceBrokerCatalogChange, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerCatalogChange_To_v1beta1_ServiceBrokerCatalogChange(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBrokerCatalogEntryChange_To_servicecatalog_ServiceBrokerCatalogEntryChange(in *ServiceBrokerCatalogEntryChange, out *servicecatalog.ServiceBrokerCatalogEntryChange, s conversion.Scope) error {
+	out.Type = servicecatalog.ServiceBrokerCatalogChangeType(in.Type)
+	out.Name = in.Name
+	out.ExternalName = in.ExternalName
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerCatalogEntryChange_To_servicecatalog_ServiceBrokerCatalogEntryChange is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerCatalogEntryChange_To_servicecatalog_ServiceBrokerCatalogEntryChange(in *ServiceBrokerCatalogEntryChange, out *servicecatalog.ServiceBrokerCatalogEntryChange, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerCatalogEntryChange_To_servicecatalog_ServiceBrokerCatalogEntryChange(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerCatalogEntryChange_To_v1beta1_ServiceBrokerCatalogEntryChange(in *servicecatalog.ServiceBrokerCatalogEntryChange, out *ServiceBrokerCatalogEntryChange, s conversion.Scope) error {
+	out.Type = ServiceBrokerCatalogChangeType(in.Type)
+	out.Name = in.Name
+	out.ExternalName = in.ExternalName
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerCatalogEntryChange_To_v1beta1_ServiceBrokerCatalogEntryChange is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerCatalogEntryChange_To_v1beta1_ServiceBrokerCatalogEntryChange(in *servicecatalog.ServiceBrokerCatalogEntryChange, out *ServiceBrokerCatalogEntryChange, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerCatalogEntryChange_To_v1beta1_ServiceBrokerCatalogEntryChange(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBrokerCondition_To_servicecatalog_ServiceBrokerCondition(in *ServiceBrokerCondition, out *servicecatalog.ServiceBrokerCondition, s conversion.Scope) error {
 	out.Type = servicecatalog.ServiceBrokerConditionType(in.Type)
 	out.Status = servicecatalog.ConditionStatus(in.Status)
@@ -1322,6 +1912,80 @@ func Convert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in *s
 	return autoConvert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBrokerPollingPolicy_To_servicecatalog_ServiceBrokerPollingPolicy(in *ServiceBrokerPollingPolicy, out *servicecatalog.ServiceBrokerPollingPolicy, s conversion.Scope) error {
+	out.InitialInterval = (*v1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*v1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.JitterPercent = in.JitterPercent
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerPollingPolicy_To_servicecatalog_ServiceBrokerPollingPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerPollingPolicy_To_servicecatalog_ServiceBrokerPollingPolicy(in *ServiceBrokerPollingPolicy, out *servicecatalog.ServiceBrokerPollingPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerPollingPolicy_To_servicecatalog_ServiceBrokerPollingPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerPollingPolicy_To_v1beta1_ServiceBrokerPollingPolicy(in *servicecatalog.ServiceBrokerPollingPolicy, out *ServiceBrokerPollingPolicy, s conversion.Scope) error {
+	out.InitialInterval = (*v1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*v1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.JitterPercent = in.JitterPercent
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerPollingPolicy_To_v1beta1_ServiceBrokerPollingPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerPollingPolicy_To_v1beta1_ServiceBrokerPollingPolicy(in *servicecatalog.ServiceBrokerPollingPolicy, out *ServiceBrokerPollingPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerPollingPolicy_To_v1beta1_ServiceBrokerPollingPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBrokerRequestContext_To_servicecatalog_ServiceBrokerRequestContext(in *ServiceBrokerRequestContext, out *servicecatalog.ServiceBrokerRequestContext, s conversion.Scope) error {
+	out.Instance = in.Instance
+	out.NamespaceLabels = *(*[]string)(unsafe.Pointer(&in.NamespaceLabels))
+	out.NamespaceAnnotations = *(*[]string)(unsafe.Pointer(&in.NamespaceAnnotations))
+	out.StaticFields = *(*map[string]string)(unsafe.Pointer(&in.StaticFields))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerRequestContext_To_servicecatalog_ServiceBrokerRequestContext is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerRequestContext_To_servicecatalog_ServiceBrokerRequestContext(in *ServiceBrokerRequestContext, out *servicecatalog.ServiceBrokerRequestContext, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerRequestContext_To_servicecatalog_ServiceBrokerRequestContext(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerRequestContext_To_v1beta1_ServiceBrokerRequestContext(in *servicecatalog.ServiceBrokerRequestContext, out *ServiceBrokerRequestContext, s conversion.Scope) error {
+	out.Instance = in.Instance
+	out.NamespaceLabels = *(*[]string)(unsafe.Pointer(&in.NamespaceLabels))
+	out.NamespaceAnnotations = *(*[]string)(unsafe.Pointer(&in.NamespaceAnnotations))
+	out.StaticFields = *(*map[string]string)(unsafe.Pointer(&in.StaticFields))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerRequestContext_To_v1beta1_ServiceBrokerRequestContext is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerRequestContext_To_v1beta1_ServiceBrokerRequestContext(in *servicecatalog.ServiceBrokerRequestContext, out *ServiceBrokerRequestContext, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerRequestContext_To_v1beta1_ServiceBrokerRequestContext(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceBrokerRequestLimits_To_servicecatalog_ServiceBrokerRequestLimits(in *ServiceBrokerRequestLimits, out *servicecatalog.ServiceBrokerRequestLimits, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxConcurrentRequests = in.MaxConcurrentRequests
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerRequestLimits_To_servicecatalog_ServiceBrokerRequestLimits is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerRequestLimits_To_servicecatalog_ServiceBrokerRequestLimits(in *ServiceBrokerRequestLimits, out *servicecatalog.ServiceBrokerRequestLimits, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerRequestLimits_To_servicecatalog_ServiceBrokerRequestLimits(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerRequestLimits_To_v1beta1_ServiceBrokerRequestLimits(in *servicecatalog.ServiceBrokerRequestLimits, out *ServiceBrokerRequestLimits, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxConcurrentRequests = in.MaxConcurrentRequests
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerRequestLimits_To_v1beta1_ServiceBrokerRequestLimits is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerRequestLimits_To_v1beta1_ServiceBrokerRequestLimits(in *servicecatalog.ServiceBrokerRequestLimits, out *ServiceBrokerRequestLimits, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerRequestLimits_To_v1beta1_ServiceBrokerRequestLimits(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec(in *ServiceBrokerSpec, out *servicecatalog.ServiceBrokerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(&in.CommonServiceBrokerSpec, &out.CommonServiceBrokerSpec, s); err != nil {
 		return err
@@ -1514,6 +2178,7 @@ func autoConvert_v1beta1_ServiceInstanceCondition_To_servicecatalog_ServiceInsta
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.FieldErrors = *(*[]servicecatalog.ConditionFieldError)(unsafe.Pointer(&in.FieldErrors))
 	return nil
 }
 
@@ -1528,6 +2193,7 @@ func autoConvert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInsta
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.FieldErrors = *(*[]ConditionFieldError)(unsafe.Pointer(&in.FieldErrors))
 	return nil
 }
 
@@ -1536,6 +2202,80 @@ func Convert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceC
 	return autoConvert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceCondition(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceGrant_To_servicecatalog_ServiceInstanceGrant(in *ServiceInstanceGrant, out *servicecatalog.ServiceInstanceGrant, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceGrant_To_servicecatalog_ServiceInstanceGrant is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceGrant_To_servicecatalog_ServiceInstanceGrant(in *ServiceInstanceGrant, out *servicecatalog.ServiceInstanceGrant, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceGrant_To_servicecatalog_ServiceInstanceGrant(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceGrant_To_v1beta1_ServiceInstanceGrant(in *servicecatalog.ServiceInstanceGrant, out *ServiceInstanceGrant, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceGrant_To_v1beta1_ServiceInstanceGrant is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceGrant_To_v1beta1_ServiceInstanceGrant(in *servicecatalog.ServiceInstanceGrant, out *ServiceInstanceGrant, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceGrant_To_v1beta1_ServiceInstanceGrant(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceGrantList_To_servicecatalog_ServiceInstanceGrantList(in *ServiceInstanceGrantList, out *servicecatalog.ServiceInstanceGrantList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ServiceInstanceGrant)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceGrantList_To_servicecatalog_ServiceInstanceGrantList is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceGrantList_To_servicecatalog_ServiceInstanceGrantList(in *ServiceInstanceGrantList, out *servicecatalog.ServiceInstanceGrantList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceGrantList_To_servicecatalog_ServiceInstanceGrantList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceGrantList_To_v1beta1_ServiceInstanceGrantList(in *servicecatalog.ServiceInstanceGrantList, out *ServiceInstanceGrantList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ServiceInstanceGrant)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceGrantList_To_v1beta1_ServiceInstanceGrantList is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceGrantList_To_v1beta1_ServiceInstanceGrantList(in *servicecatalog.ServiceInstanceGrantList, out *ServiceInstanceGrantList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceGrantList_To_v1beta1_ServiceInstanceGrantList(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec(in *ServiceInstanceGrantSpec, out *servicecatalog.ServiceInstanceGrantSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(&in.ServiceInstanceRef, &out.ServiceInstanceRef, s); err != nil {
+		return err
+	}
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec(in *ServiceInstanceGrantSpec, out *servicecatalog.ServiceInstanceGrantSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceGrantSpec_To_servicecatalog_ServiceInstanceGrantSpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec(in *servicecatalog.ServiceInstanceGrantSpec, out *ServiceInstanceGrantSpec, s conversion.Scope) error {
+	if err := Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(&in.ServiceInstanceRef, &out.ServiceInstanceRef, s); err != nil {
+		return err
+	}
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec(in *servicecatalog.ServiceInstanceGrantSpec, out *ServiceInstanceGrantSpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceGrantSpec_To_v1beta1_ServiceInstanceGrantSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstanceList_To_servicecatalog_ServiceInstanceList(in *ServiceInstanceList, out *servicecatalog.ServiceInstanceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]servicecatalog.ServiceInstance)(unsafe.Pointer(&in.Items))
@@ -1603,6 +2343,8 @@ func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.UpdateOnParametersFromChange = in.UpdateOnParametersFromChange
+	out.MaintenanceWindow = (*servicecatalog.MaintenanceWindow)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
@@ -1624,6 +2366,8 @@ func autoConvert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.UpdateOnParametersFromChange = in.UpdateOnParametersFromChange
+	out.MaintenanceWindow = (*MaintenanceWindow)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
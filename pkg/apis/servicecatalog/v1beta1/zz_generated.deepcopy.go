@@ -89,6 +89,27 @@ func (in *AddKeysFromTransform) DeepCopy() *AddKeysFromTransform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedParameterValues) DeepCopyInto(out *AllowedParameterValues) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedParameterValues.
+func (in *AllowedParameterValues) DeepCopy() *AllowedParameterValues {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedParameterValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuthConfig) DeepCopyInto(out *BasicAuthConfig) {
 	*out = *in
@@ -339,6 +360,15 @@ func (in *ClusterServiceBrokerSpec) DeepCopyInto(out *ClusterServiceBrokerSpec)
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PlanMigrationPolicy != nil {
+		in, out := &in.PlanMigrationPolicy, &out.PlanMigrationPolicy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PlanMigrationPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -356,6 +386,13 @@ func (in *ClusterServiceBrokerSpec) DeepCopy() *ClusterServiceBrokerSpec {
 func (in *ClusterServiceBrokerStatus) DeepCopyInto(out *ClusterServiceBrokerStatus) {
 	*out = *in
 	in.CommonServiceBrokerStatus.DeepCopyInto(&out.CommonServiceBrokerStatus)
+	if in.PlanMigrations != nil {
+		in, out := &in.PlanMigrations, &out.PlanMigrations
+		*out = make([]PlanMigrationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -525,6 +562,109 @@ func (in *ClusterServicePlanList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServicePlanPolicy) DeepCopyInto(out *ClusterServicePlanPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServicePlanPolicy.
+func (in *ClusterServicePlanPolicy) DeepCopy() *ClusterServicePlanPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServicePlanPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServicePlanPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServicePlanPolicyList) DeepCopyInto(out *ClusterServicePlanPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterServicePlanPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServicePlanPolicyList.
+func (in *ClusterServicePlanPolicyList) DeepCopy() *ClusterServicePlanPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServicePlanPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServicePlanPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServicePlanPolicySpec) DeepCopyInto(out *ClusterServicePlanPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.InstanceParameters != nil {
+		in, out := &in.InstanceParameters, &out.InstanceParameters
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ParametersPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.BindingParameters != nil {
+		in, out := &in.BindingParameters, &out.BindingParameters
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ParametersPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServicePlanPolicySpec.
+func (in *ClusterServicePlanPolicySpec) DeepCopy() *ClusterServicePlanPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServicePlanPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterServicePlanSpec) DeepCopyInto(out *ClusterServicePlanSpec) {
 	*out = *in
@@ -586,6 +726,33 @@ func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PollingPolicy != nil {
+		in, out := &in.PollingPolicy, &out.PollingPolicy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBrokerPollingPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RequestLimits != nil {
+		in, out := &in.RequestLimits, &out.RequestLimits
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBrokerRequestLimits)
+			**out = **in
+		}
+	}
+	if in.RequestContext != nil {
+		in, out := &in.RequestContext, &out.RequestContext
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBrokerRequestContext)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -625,6 +792,13 @@ func (in *CommonServiceBrokerStatus) DeepCopyInto(out *CommonServiceBrokerStatus
 			*out = (*in).DeepCopy()
 		}
 	}
+	if in.CatalogHistory != nil {
+		in, out := &in.CatalogHistory, &out.CatalogHistory
+		*out = make([]ServiceBrokerCatalogChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -775,6 +949,38 @@ func (in *CommonServicePlanStatus) DeepCopy() *CommonServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionFieldError) DeepCopyInto(out *ConditionFieldError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionFieldError.
+func (in *ConditionFieldError) DeepCopy() *ConditionFieldError {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionFieldError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ExtraValue) DeepCopyInto(out *ExtraValue) {
 	{
@@ -811,6 +1017,23 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -839,6 +1062,42 @@ func (in *ParametersFromSource) DeepCopyInto(out *ParametersFromSource) {
 			**out = **in
 		}
 	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConfigMapKeyReference)
+			**out = **in
+		}
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalObjectReference)
+			**out = **in
+		}
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalObjectReference)
+			**out = **in
+		}
+	}
+	if in.ServiceBindingKeyRef != nil {
+		in, out := &in.ServiceBindingKeyRef, &out.ServiceBindingKeyRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBindingKeyReference)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -853,89 +1112,210 @@ func (in *ParametersFromSource) DeepCopy() *ParametersFromSource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanReference) DeepCopyInto(out *PlanReference) {
+func (in *ParametersPolicy) DeepCopyInto(out *ParametersPolicy) {
 	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(runtime.RawExtension)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.LockedKeys != nil {
+		in, out := &in.LockedKeys, &out.LockedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]AllowedParameterValues, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanReference.
-func (in *PlanReference) DeepCopy() *PlanReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParametersPolicy.
+func (in *ParametersPolicy) DeepCopy() *ParametersPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanReference)
+	out := new(ParametersPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RemoveKeyTransform) DeepCopyInto(out *RemoveKeyTransform) {
+func (in *PlanMigration) DeepCopyInto(out *PlanMigration) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveKeyTransform.
-func (in *RemoveKeyTransform) DeepCopy() *RemoveKeyTransform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanMigration.
+func (in *PlanMigration) DeepCopy() *PlanMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(RemoveKeyTransform)
+	out := new(PlanMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RenameKeyTransform) DeepCopyInto(out *RenameKeyTransform) {
+func (in *PlanMigrationPolicy) DeepCopyInto(out *PlanMigrationPolicy) {
 	*out = *in
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = make([]PlanMigration, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenameKeyTransform.
-func (in *RenameKeyTransform) DeepCopy() *RenameKeyTransform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanMigrationPolicy.
+func (in *PlanMigrationPolicy) DeepCopy() *PlanMigrationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RenameKeyTransform)
+	out := new(PlanMigrationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+func (in *PlanMigrationStatus) DeepCopyInto(out *PlanMigrationStatus) {
 	*out = *in
+	if in.MigratingInstances != nil {
+		in, out := &in.MigratingInstances, &out.MigratingInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedInstances != nil {
+		in, out := &in.FailedInstances, &out.FailedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingInstances != nil {
+		in, out := &in.PendingInstances, &out.PendingInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
-func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanMigrationStatus.
+func (in *PlanMigrationStatus) DeepCopy() *PlanMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyReference)
+	out := new(PlanMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
+func (in *PlanReference) DeepCopyInto(out *PlanReference) {
 	*out = *in
-	if in.RenameKey != nil {
-		in, out := &in.RenameKey, &out.RenameKey
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(RenameKeyTransform)
-			**out = **in
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanReference.
+func (in *PlanReference) DeepCopy() *PlanReference {
+	if in == nil {
+		return nil
 	}
-	if in.AddKey != nil {
-		in, out := &in.AddKey, &out.AddKey
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(AddKeyTransform)
-			(*in).DeepCopyInto(*out)
-		}
+	out := new(PlanReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveKeyTransform) DeepCopyInto(out *RemoveKeyTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveKeyTransform.
+func (in *RemoveKeyTransform) DeepCopy() *RemoveKeyTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveKeyTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenameKeyTransform) DeepCopyInto(out *RenameKeyTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenameKeyTransform.
+func (in *RenameKeyTransform) DeepCopy() *RenameKeyTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(RenameKeyTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderTemplateTransform) DeepCopyInto(out *RenderTemplateTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderTemplateTransform.
+func (in *RenderTemplateTransform) DeepCopy() *RenderTemplateTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderTemplateTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
+	*out = *in
+	if in.RenameKey != nil {
+		in, out := &in.RenameKey, &out.RenameKey
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RenameKeyTransform)
+			**out = **in
+		}
+	}
+	if in.AddKey != nil {
+		in, out := &in.AddKey, &out.AddKey
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(AddKeyTransform)
+			(*in).DeepCopyInto(*out)
+		}
 	}
 	if in.AddKeysFrom != nil {
 		in, out := &in.AddKeysFrom, &out.AddKeysFrom
@@ -955,6 +1335,15 @@ func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
 			**out = **in
 		}
 	}
+	if in.RenderTemplate != nil {
+		in, out := &in.RenderTemplate, &out.RenderTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RenderTemplateTransform)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -1000,6 +1389,11 @@ func (in *ServiceBinding) DeepCopyObject() runtime.Object {
 func (in *ServiceBindingCondition) DeepCopyInto(out *ServiceBindingCondition) {
 	*out = *in
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.FieldErrors != nil {
+		in, out := &in.FieldErrors, &out.FieldErrors
+		*out = make([]ConditionFieldError, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1013,6 +1407,43 @@ func (in *ServiceBindingCondition) DeepCopy() *ServiceBindingCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingConfigMap) DeepCopyInto(out *ServiceBindingConfigMap) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingConfigMap.
+func (in *ServiceBindingConfigMap) DeepCopy() *ServiceBindingConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingKeyReference) DeepCopyInto(out *ServiceBindingKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingKeyReference.
+func (in *ServiceBindingKeyReference) DeepCopy() *ServiceBindingKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingList) DeepCopyInto(out *ServiceBindingList) {
 	*out = *in
@@ -1080,6 +1511,30 @@ func (in *ServiceBindingPropertiesState) DeepCopy() *ServiceBindingPropertiesSta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingRetiredCredentials) DeepCopyInto(out *ServiceBindingRetiredCredentials) {
+	*out = *in
+	if in.RetiredTime != nil {
+		in, out := &in.RetiredTime, &out.RetiredTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingRetiredCredentials.
+func (in *ServiceBindingRetiredCredentials) DeepCopy() *ServiceBindingRetiredCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingRetiredCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 	*out = *in
@@ -1107,6 +1562,24 @@ func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBindingConfigMap)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.WorkloadInjection != nil {
+		in, out := &in.WorkloadInjection, &out.WorkloadInjection
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBindingWorkloadInjection)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.UserInfo != nil {
 		in, out := &in.UserInfo, &out.UserInfo
 		if *in == nil {
@@ -1174,6 +1647,41 @@ func (in *ServiceBindingStatus) DeepCopyInto(out *ServiceBindingStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.RetiredCredentials != nil {
+		in, out := &in.RetiredCredentials, &out.RetiredCredentials
+		*out = make([]ServiceBindingRetiredCredentials, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InjectedWorkloads != nil {
+		in, out := &in.InjectedWorkloads, &out.InjectedWorkloads
+		*out = make([]ServiceBindingWorkload, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyslogDrainURL != nil {
+		in, out := &in.SyslogDrainURL, &out.SyslogDrainURL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.RouteServiceURL != nil {
+		in, out := &in.RouteServiceURL, &out.RouteServiceURL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceBindingVolumeMount, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1187,6 +1695,55 @@ func (in *ServiceBindingStatus) DeepCopy() *ServiceBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingVolumeMount) DeepCopyInto(out *ServiceBindingVolumeMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingVolumeMount.
+func (in *ServiceBindingVolumeMount) DeepCopy() *ServiceBindingVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingWorkload) DeepCopyInto(out *ServiceBindingWorkload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingWorkload.
+func (in *ServiceBindingWorkload) DeepCopy() *ServiceBindingWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingWorkloadInjection) DeepCopyInto(out *ServiceBindingWorkloadInjection) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingWorkloadInjection.
+func (in *ServiceBindingWorkloadInjection) DeepCopy() *ServiceBindingWorkloadInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingWorkloadInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBroker) DeepCopyInto(out *ServiceBroker) {
 	*out = *in
@@ -1249,6 +1806,58 @@ func (in *ServiceBrokerAuthInfo) DeepCopy() *ServiceBrokerAuthInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerCatalogChange) DeepCopyInto(out *ServiceBrokerCatalogChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]ServiceBrokerCatalogEntryChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plans != nil {
+		in, out := &in.Plans, &out.Plans
+		*out = make([]ServiceBrokerCatalogEntryChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerCatalogChange.
+func (in *ServiceBrokerCatalogChange) DeepCopy() *ServiceBrokerCatalogChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerCatalogChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerCatalogEntryChange) DeepCopyInto(out *ServiceBrokerCatalogEntryChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerCatalogEntryChange.
+func (in *ServiceBrokerCatalogEntryChange) DeepCopy() *ServiceBrokerCatalogEntryChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerCatalogEntryChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerCondition) DeepCopyInto(out *ServiceBrokerCondition) {
 	*out = *in
@@ -1299,6 +1908,89 @@ func (in *ServiceBrokerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerPollingPolicy) DeepCopyInto(out *ServiceBrokerPollingPolicy) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerPollingPolicy.
+func (in *ServiceBrokerPollingPolicy) DeepCopy() *ServiceBrokerPollingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerPollingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerRequestContext) DeepCopyInto(out *ServiceBrokerRequestContext) {
+	*out = *in
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceAnnotations != nil {
+		in, out := &in.NamespaceAnnotations, &out.NamespaceAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticFields != nil {
+		in, out := &in.StaticFields, &out.StaticFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerRequestContext.
+func (in *ServiceBrokerRequestContext) DeepCopy() *ServiceBrokerRequestContext {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerRequestContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerRequestLimits) DeepCopyInto(out *ServiceBrokerRequestLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerRequestLimits.
+func (in *ServiceBrokerRequestLimits) DeepCopy() *ServiceBrokerRequestLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerRequestLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerSpec) DeepCopyInto(out *ServiceBrokerSpec) {
 	*out = *in
@@ -1469,6 +2161,11 @@ func (in *ServiceInstance) DeepCopyObject() runtime.Object {
 func (in *ServiceInstanceCondition) DeepCopyInto(out *ServiceInstanceCondition) {
 	*out = *in
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.FieldErrors != nil {
+		in, out := &in.FieldErrors, &out.FieldErrors
+		*out = make([]ConditionFieldError, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1482,6 +2179,88 @@ func (in *ServiceInstanceCondition) DeepCopy() *ServiceInstanceCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceGrant) DeepCopyInto(out *ServiceInstanceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceGrant.
+func (in *ServiceInstanceGrant) DeepCopy() *ServiceInstanceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceGrantList) DeepCopyInto(out *ServiceInstanceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceInstanceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceGrantList.
+func (in *ServiceInstanceGrantList) DeepCopy() *ServiceInstanceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceGrantSpec) DeepCopyInto(out *ServiceInstanceGrantSpec) {
+	*out = *in
+	out.ServiceInstanceRef = in.ServiceInstanceRef
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceGrantSpec.
+func (in *ServiceInstanceGrantSpec) DeepCopy() *ServiceInstanceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstanceList) DeepCopyInto(out *ServiceInstanceList) {
 	*out = *in
@@ -1614,6 +2393,15 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceWindow)
+			**out = **in
+		}
+	}
 	return
 }
 
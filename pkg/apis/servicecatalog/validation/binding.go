@@ -17,10 +17,15 @@ limitations under the License.
 package validation
 
 import (
+	"path"
+	"text/template"
+
 	"github.com/ghodss/yaml"
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 )
@@ -61,6 +66,29 @@ var validServiceBindingUnbindStatusValues = func() []string {
 	return validValues
 }()
 
+var validServiceBindingSecretFormats = map[sc.ServiceBindingSecretFormat]bool{
+	sc.ServiceBindingSecretFormat(""):       true,
+	sc.ServiceBindingSecretFormatKeys:       true,
+	sc.ServiceBindingSecretFormatJSON:       true,
+	sc.ServiceBindingSecretFormatEnv:        true,
+	sc.ServiceBindingSecretFormatProperties: true,
+}
+
+var validServiceBindingSecretFormatValues = func() []string {
+	validValues := make([]string, len(validServiceBindingSecretFormats))
+	i := 0
+	for format := range validServiceBindingSecretFormats {
+		validValues[i] = string(format)
+		i++
+	}
+	return validValues
+}()
+
+var validServiceBindingWorkloadInjectionModeValues = []string{
+	string(sc.ServiceBindingWorkloadInjectionModeEnvFrom),
+	string(sc.ServiceBindingWorkloadInjectionModeVolume),
+}
+
 // ValidateServiceBinding validates a ServiceBinding and returns a list of errors.
 func ValidateServiceBinding(binding *sc.ServiceBinding) field.ErrorList {
 	return internalValidateServiceBinding(binding, true)
@@ -87,6 +115,12 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceRef", "name"), spec.ServiceInstanceRef.Name, msg))
 	}
 
+	if spec.ServiceInstanceNamespace != "" {
+		for _, msg := range apivalidation.ValidateNamespaceName(spec.ServiceInstanceNamespace, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceNamespace"), spec.ServiceInstanceNamespace, msg))
+		}
+	}
+
 	for _, msg := range apivalidation.NameIsDNSSubdomain(spec.SecretName, false /* prefix */) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("secretName"), spec.SecretName, msg))
 	}
@@ -95,6 +129,129 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
 	}
 
+	for i, transform := range spec.SecretTransforms {
+		allErrs = append(allErrs, validateSecretTransform(&transform, fldPath.Child("secretTransforms").Index(i))...)
+	}
+
+	if !validServiceBindingSecretFormats[spec.SecretFormat] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("secretFormat"), spec.SecretFormat, validServiceBindingSecretFormatValues))
+	}
+
+	if spec.ConfigMap != nil {
+		allErrs = append(allErrs, validateServiceBindingConfigMap(spec.ConfigMap, fldPath.Child("configMap"))...)
+	}
+
+	if spec.WorkloadInjection != nil {
+		allErrs = append(allErrs, validateServiceBindingWorkloadInjection(spec.WorkloadInjection, fldPath.Child("workloadInjection"))...)
+	}
+
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RotationRequests, fldPath.Child("rotationRequests"))...)
+
+	return allErrs
+}
+
+func validateServiceBindingConfigMap(configMap *sc.ServiceBindingConfigMap, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range apivalidation.NameIsDNSSubdomain(configMap.Name, false /* prefix */) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), configMap.Name, msg))
+	}
+
+	if len(configMap.Keys) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keys"), "at least one key is required"))
+	}
+	for i, key := range configMap.Keys {
+		for _, msg := range utilvalidation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keys").Index(i), key, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateServiceBindingWorkloadInjection(injection *sc.ServiceBindingWorkloadInjection, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&injection.Selector, fldPath.Child("selector"))...)
+
+	switch injection.Mode {
+	case sc.ServiceBindingWorkloadInjectionModeEnvFrom:
+		if injection.MountPath != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mountPath"), "mountPath may only be set in the Volume mode"))
+		}
+	case sc.ServiceBindingWorkloadInjectionModeVolume:
+		if injection.MountPath == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("mountPath"), "mountPath is required in the Volume mode"))
+		} else if !path.IsAbs(injection.MountPath) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), injection.MountPath, "must be an absolute path"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), injection.Mode, validServiceBindingWorkloadInjectionModeValues))
+	}
+
+	return allErrs
+}
+
+func validateSecretTransform(transform *sc.SecretTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if transform.RenderTemplate != nil {
+		allErrs = append(allErrs, validateRenderTemplateTransform(transform.RenderTemplate, fldPath.Child("renderTemplate"))...)
+	}
+
+	return allErrs
+}
+
+// validateSecretTransformsHaveOneTransform checks that each secret transform
+// specifies exactly one transform, as the controller only applies the first
+// one. It is only checked on create, as the spec of a binding cannot change
+// afterwards, so that bindings stored before the check was added can still
+// be updated.
+func validateSecretTransformsHaveOneTransform(transforms []sc.SecretTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, transform := range transforms {
+		numTransforms := 0
+		if transform.RenameKey != nil {
+			numTransforms++
+		}
+		if transform.AddKey != nil {
+			numTransforms++
+		}
+		if transform.AddKeysFrom != nil {
+			numTransforms++
+		}
+		if transform.RemoveKey != nil {
+			numTransforms++
+		}
+		if transform.RenderTemplate != nil {
+			numTransforms++
+		}
+		if numTransforms != 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), transform, "exactly one transform must be specified"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRenderTemplateTransform(transform *sc.RenderTemplateTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if transform.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key is required"))
+	} else {
+		for _, msg := range utilvalidation.IsConfigMapKey(transform.Key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), transform.Key, msg))
+		}
+	}
+
+	if transform.Template == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("template"), "template is required"))
+	} else if _, err := template.New(transform.Key).Parse(transform.Template); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), transform.Template, err.Error()))
+	}
+
 	return allErrs
 }
 
@@ -148,6 +305,12 @@ func validateServiceBindingStatus(status *sc.ServiceBindingStatus, fldPath *fiel
 		allErrs = append(allErrs, validateServiceBindingPropertiesState(status.ExternalProperties, fldPath.Child("externalProperties"), create)...)
 	}
 
+	for i, retired := range status.RetiredCredentials {
+		if retired.ExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("retiredCredentials").Index(i).Child("externalID"), "externalID is required"))
+		}
+	}
+
 	if create {
 		if status.UnbindStatus != sc.ServiceBindingUnbindStatusNotRequired {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("unbindStatus"), status.UnbindStatus, `unbindStatus must be "NotRequired" on create`))
@@ -199,6 +362,11 @@ func validateServiceBindingCreate(binding *sc.ServiceBinding) field.ErrorList {
 	if binding.Status.ReconciledGeneration >= binding.Generation {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("status").Child("reconciledGeneration"), binding.Status.ReconciledGeneration, "reconciledGeneration must be less than generation on create"))
 	}
+	if binding.Spec.ServiceInstanceNamespace != "" && binding.Spec.ServiceInstanceNamespace != binding.Namespace &&
+		!utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("instanceNamespace"), "binding to a ServiceInstance in another namespace requires the ServiceInstanceSharing feature"))
+	}
+	allErrs = append(allErrs, validateSecretTransformsHaveOneTransform(binding.Spec.SecretTransforms, field.NewPath("spec").Child("secretTransforms"))...)
 	return allErrs
 }
 
@@ -236,6 +404,14 @@ func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
+
+	specFieldPath := field.NewPath("spec")
+	if new.Spec.RotationRequests < old.Spec.RotationRequests {
+		allErrs = append(allErrs, field.Invalid(specFieldPath.Child("rotationRequests"), new.Spec.RotationRequests, "new rotationRequests value must not be less than the old one"))
+	} else if new.Spec.RotationRequests > old.Spec.RotationRequests && old.Status.CurrentOperation != "" {
+		allErrs = append(allErrs, field.Forbidden(specFieldPath.Child("rotationRequests"), "credentials cannot be rotated while an operation is in progress"))
+	}
+
 	return allErrs
 }
 
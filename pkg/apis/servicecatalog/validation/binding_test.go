@@ -17,13 +17,16 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 func validServiceBinding() *servicecatalog.ServiceBinding {
@@ -564,6 +567,292 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "negative rotationRequests",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = -1
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid retired credentials",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RetiredCredentials = []servicecatalog.ServiceBindingRetiredCredentials{
+					{ExternalID: "retired-id"},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "retired credentials missing externalID",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RetiredCredentials = []servicecatalog.ServiceBindingRetiredCredentials{
+					{},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid renderTemplate transform",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Key:      "DATABASE_URL",
+							Template: "postgres://{{.username}}:{{.password}}@{{.host}}:{{.port}}/",
+						},
+					},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "renderTemplate transform missing key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Template: "{{.host}}",
+						},
+					},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "renderTemplate transform with invalid key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Key:      "DATABASE URL",
+							Template: "{{.host}}",
+						},
+					},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "renderTemplate transform missing template",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Key: "DATABASE_URL",
+						},
+					},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "renderTemplate transform with unparseable template",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Key:      "DATABASE_URL",
+							Template: "{{.host",
+						},
+					},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "secret transform with multiple members",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{
+						RemoveKey: &servicecatalog.RemoveKeyTransform{
+							Key: "host",
+						},
+						RenderTemplate: &servicecatalog.RenderTemplateTransform{
+							Key:      "DATABASE_URL",
+							Template: "{{.host}}",
+						},
+					},
+				}
+				return b
+			}(),
+			create: true,
+			valid:  false,
+		},
+		{
+			name: "valid secretFormat",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretFormat = servicecatalog.ServiceBindingSecretFormatJSON
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid secretFormat",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretFormat = "XML"
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid configMap",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ConfigMap = &servicecatalog.ServiceBindingConfigMap{
+					Name: "test-config-map",
+					Keys: []string{"host", "port"},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "configMap with invalid name",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ConfigMap = &servicecatalog.ServiceBindingConfigMap{
+					Name: "Test_Config_Map",
+					Keys: []string{"host"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "configMap without keys",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ConfigMap = &servicecatalog.ServiceBindingConfigMap{
+					Name: "test-config-map",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "configMap with invalid key",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ConfigMap = &servicecatalog.ServiceBindingConfigMap{
+					Name: "test-config-map",
+					Keys: []string{"host name"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid workload injection with envFrom",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					Mode:     servicecatalog.ServiceBindingWorkloadInjectionModeEnvFrom,
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid workload injection with volume",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Mode:      servicecatalog.ServiceBindingWorkloadInjectionModeVolume,
+					MountPath: "/etc/credentials",
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "workload injection with invalid mode",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Mode: "Inline",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "workload injection with invalid selector",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web ui"}},
+					Mode:     servicecatalog.ServiceBindingWorkloadInjectionModeEnvFrom,
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "workload injection with volume without mount path",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Mode: servicecatalog.ServiceBindingWorkloadInjectionModeVolume,
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "workload injection with volume and relative mount path",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Mode:      servicecatalog.ServiceBindingWorkloadInjectionModeVolume,
+					MountPath: "credentials",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "workload injection with envFrom and mount path",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadInjection = &servicecatalog.ServiceBindingWorkloadInjection{
+					Mode:      servicecatalog.ServiceBindingWorkloadInjectionModeEnvFrom,
+					MountPath: "/etc/credentials",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty secret transform",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTransforms = []servicecatalog.SecretTransform{{}}
+				return b
+			}(),
+			create: true,
+			valid:  false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -637,3 +926,109 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateServiceBindingUpdateRotationRequests(t *testing.T) {
+	cases := []struct {
+		name                string
+		oldRotationRequests int64
+		newRotationRequests int64
+		operationInProgress bool
+		valid               bool
+	}{
+		{
+			name:                "rotation requested",
+			oldRotationRequests: 1,
+			newRotationRequests: 2,
+			valid:               true,
+		},
+		{
+			name:                "rotation requests unchanged",
+			oldRotationRequests: 1,
+			newRotationRequests: 1,
+			operationInProgress: true,
+			valid:               true,
+		},
+		{
+			name:                "rotation requests decreased",
+			oldRotationRequests: 2,
+			newRotationRequests: 1,
+			valid:               false,
+		},
+		{
+			name:                "rotation requested while operation in progress",
+			oldRotationRequests: 1,
+			newRotationRequests: 2,
+			operationInProgress: true,
+			valid:               false,
+		},
+	}
+
+	for _, tc := range cases {
+		oldBinding := validServiceBinding()
+		if tc.operationInProgress {
+			oldBinding = validServiceBindingWithInProgressBind()
+		}
+		oldBinding.Spec.RotationRequests = tc.oldRotationRequests
+
+		newBinding := validServiceBinding()
+		newBinding.Generation = oldBinding.Generation
+		newBinding.Status.ReconciledGeneration = oldBinding.Generation
+		newBinding.Spec.RotationRequests = tc.newRotationRequests
+
+		errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+			continue
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+}
+
+func TestValidateServiceBindingInstanceNamespace(t *testing.T) {
+	cases := []struct {
+		name              string
+		instanceNamespace string
+		sharingEnabled    bool
+		valid             bool
+	}{
+		{
+			name:              "same namespace",
+			instanceNamespace: "test-ns",
+			valid:             true,
+		},
+		{
+			name:              "other namespace",
+			instanceNamespace: "shared-ns",
+			sharingEnabled:    true,
+			valid:             true,
+		},
+		{
+			name:              "other namespace without sharing",
+			instanceNamespace: "shared-ns",
+			valid:             false,
+		},
+		{
+			name:              "invalid namespace",
+			instanceNamespace: "Shared_NS",
+			sharingEnabled:    true,
+			valid:             false,
+		},
+	}
+
+	for _, tc := range cases {
+		if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.ServiceInstanceSharing, tc.sharingEnabled)); err != nil {
+			t.Fatalf("Failed to set ServiceInstanceSharing feature: %v", err)
+		}
+		binding := validServiceBinding()
+		binding.Generation = 1
+		binding.Spec.ServiceInstanceNamespace = tc.instanceNamespace
+		errs := internalValidateServiceBinding(binding, true)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceSharing))
+}
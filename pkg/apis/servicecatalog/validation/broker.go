@@ -17,8 +17,13 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"reflect"
+
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
@@ -29,9 +34,27 @@ import (
 // broker names.
 var validateCommonServiceBrokerName = apivalidation.NameIsDNSSubdomain
 
+// reservedRequestContextFields are the fields of the context of the requests
+// to brokers that are set by the controller.
+var reservedRequestContextFields = sets.NewString(
+	"platform",
+	"namespace",
+	"clusterid",
+	"instance_name",
+	"instance_uid",
+	"namespace_labels",
+	"namespace_annotations",
+)
+
 // ValidateClusterServiceBroker implements the validation rules for a
 // ClusterServiceBroker.
 func ValidateClusterServiceBroker(broker *sc.ClusterServiceBroker) field.ErrorList {
+	allErrs := validateClusterServiceBroker(broker)
+	allErrs = append(allErrs, validateClusterServiceBrokerCatalogRestrictionProperties(broker)...)
+	return allErrs
+}
+
+func validateClusterServiceBroker(broker *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs,
@@ -94,12 +117,22 @@ func validateClusterServiceBrokerSpec(spec *sc.ClusterServiceBrokerSpec, fldPath
 		allErrs = append(allErrs, commonErrs...)
 	}
 
+	if spec.PlanMigrationPolicy != nil {
+		allErrs = append(allErrs, validatePlanMigrationPolicy(spec.PlanMigrationPolicy, fldPath.Child("planMigrationPolicy"))...)
+	}
+
 	return allErrs
 }
 
 // ValidateServiceBroker implements the validation rules for a
 // ServiceBroker.
 func ValidateServiceBroker(broker *sc.ServiceBroker) field.ErrorList {
+	allErrs := validateServiceBroker(broker)
+	allErrs = append(allErrs, validateServiceBrokerCatalogRestrictionProperties(broker)...)
+	return allErrs
+}
+
+func validateServiceBroker(broker *sc.ServiceBroker) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs,
@@ -205,8 +238,16 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
-	// TODO: could validate if the fields being selected are on the approve list, but this will require breaking
-	// apart the label selector.
+	if spec.PollingPolicy != nil {
+		commonErrs = append(commonErrs, validateServiceBrokerPollingPolicy(spec.PollingPolicy, fldPath.Child("pollingPolicy"))...)
+	}
+	if spec.RequestLimits != nil {
+		commonErrs = append(commonErrs, validateServiceBrokerRequestLimits(spec.RequestLimits, fldPath.Child("requestLimits"))...)
+	}
+	if spec.RequestContext != nil {
+		commonErrs = append(commonErrs, validateServiceBrokerRequestContext(spec.RequestContext, fldPath.Child("requestContext"))...)
+	}
+
 	if spec.CatalogRestrictions != nil && len(spec.CatalogRestrictions.ServiceClass) > 0 {
 		// confirm that the restrictions can turn into a predicate.
 		_, err := filter.CreatePredicate(spec.CatalogRestrictions.ServiceClass)
@@ -222,24 +263,174 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		if err != nil {
 			commonErrs = append(commonErrs,
 				field.Invalid(fldPath.Child("catalogRestrictions", "servicePlan"),
-					spec.CatalogRestrictions.ServiceClass, err.Error()))
+					spec.CatalogRestrictions.ServicePlan, err.Error()))
 		}
 	}
 
 	return commonErrs
 }
 
+func validateServiceBrokerPollingPolicy(policy *sc.ServiceBrokerPollingPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.InitialInterval != nil && policy.InitialInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialInterval"), policy.InitialInterval.Duration.String(), "initialInterval must be greater than zero"))
+	}
+	if policy.MaxInterval != nil && policy.MaxInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInterval"), policy.MaxInterval.Duration.String(), "maxInterval must be greater than zero"))
+	}
+	if policy.InitialInterval != nil && policy.MaxInterval != nil && policy.InitialInterval.Duration > policy.MaxInterval.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInterval"), policy.MaxInterval.Duration.String(), "maxInterval must not be less than initialInterval"))
+	}
+	if policy.JitterPercent < 0 || policy.JitterPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jitterPercent"), policy.JitterPercent, "jitterPercent must be between 0 and 100"))
+	}
+
+	return allErrs
+}
+
+func validateServiceBrokerRequestLimits(limits *sc.ServiceBrokerRequestLimits, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if limits.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), limits.QPS, "qps must not be negative"))
+	}
+	if limits.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), limits.Burst, "burst must not be negative"))
+	}
+	if limits.MaxConcurrentRequests < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentRequests"), limits.MaxConcurrentRequests, "maxConcurrentRequests must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateServiceBrokerRequestContext checks that the selected keys of the
+// labels and annotations are valid keys, and that the static fields do not
+// replace the fields set by the controller.
+func validateServiceBrokerRequestContext(requestContext *sc.ServiceBrokerRequestContext, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, key := range requestContext.NamespaceLabels {
+		for _, msg := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceLabels").Index(i), key, msg))
+		}
+	}
+	for i, key := range requestContext.NamespaceAnnotations {
+		for _, msg := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceAnnotations").Index(i), key, msg))
+		}
+	}
+	for key := range requestContext.StaticFields {
+		if key == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("staticFields"), key, "the keys of staticFields must not be empty"))
+		} else if reservedRequestContextFields.Has(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("staticFields").Key(key), key, "the field is set by the controller"))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePlanMigrationPolicy checks that every migration names both plans,
+// that no migration maps a plan to itself and that no plan is migrated to
+// more than one plan.
+func validatePlanMigrationPolicy(policy *sc.PlanMigrationPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.BatchSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("batchSize"), policy.BatchSize, "batchSize must not be negative"))
+	}
+
+	seen := map[sc.PlanMigration]bool{}
+	for i, m := range policy.Migrations {
+		migrationPath := fldPath.Child("migrations").Index(i)
+		if m.FromPlanExternalName == "" {
+			allErrs = append(allErrs, field.Required(migrationPath.Child("fromPlanExternalName"), "the plan to migrate off is required"))
+		}
+		if m.ToPlanExternalName == "" {
+			allErrs = append(allErrs, field.Required(migrationPath.Child("toPlanExternalName"), "the replacement plan is required"))
+		}
+		if m.FromPlanExternalName != "" && m.FromPlanExternalName == m.ToPlanExternalName {
+			allErrs = append(allErrs, field.Invalid(migrationPath.Child("toPlanExternalName"), m.ToPlanExternalName, "the replacement plan must differ from the plan to migrate off"))
+		}
+		key := sc.PlanMigration{ServiceClassExternalName: m.ServiceClassExternalName, FromPlanExternalName: m.FromPlanExternalName}
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(migrationPath.Child("fromPlanExternalName"), m.FromPlanExternalName))
+		}
+		seen[key] = true
+	}
+
+	return allErrs
+}
+
+// validateClusterServiceBrokerCatalogRestrictionProperties checks the
+// properties that the catalog restrictions of a ClusterServiceBroker select
+// on.
+func validateClusterServiceBrokerCatalogRestrictionProperties(broker *sc.ClusterServiceBroker) field.ErrorList {
+	return validateCatalogRestrictionProperties(broker.Spec.CatalogRestrictions, sc.IsServiceClassProperty, sc.IsClusterServicePlanProperty, field.NewPath("spec", "catalogRestrictions"))
+}
+
+// validateServiceBrokerCatalogRestrictionProperties checks the properties
+// that the catalog restrictions of a ServiceBroker select on.
+func validateServiceBrokerCatalogRestrictionProperties(broker *sc.ServiceBroker) field.ErrorList {
+	return validateCatalogRestrictionProperties(broker.Spec.CatalogRestrictions, sc.IsServiceClassProperty, sc.IsServicePlanProperty, field.NewPath("spec", "catalogRestrictions"))
+}
+
+// validateCatalogRestrictionProperties checks that the catalog restrictions
+// only select on the properties that classes and plans can be filtered by.
+// Restrictions that cannot be parsed are reported by
+// validateCommonServiceBrokerSpec. It is only checked when the restrictions
+// are set or changed, so that brokers whose restrictions were accepted before
+// the check existed can still be updated.
+func validateCatalogRestrictionProperties(restrictions *sc.CatalogRestrictions, isServiceClassProperty, isServicePlanProperty func(string) bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if restrictions == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateRestrictionProperties(restrictions.ServiceClass, isServiceClassProperty, fldPath.Child("serviceClass"))...)
+	allErrs = append(allErrs, validateRestrictionProperties(restrictions.ServicePlan, isServicePlanProperty, fldPath.Child("servicePlan"))...)
+	return allErrs
+}
+
+func validateRestrictionProperties(restrictions []string, isValidProperty func(string) bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(restrictions) == 0 {
+		return allErrs
+	}
+	predicate, err := filter.CreatePredicate(restrictions)
+	if err != nil {
+		return allErrs
+	}
+	keys, err := filter.Keys(predicate)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, restrictions, err.Error()))
+	}
+	for _, key := range keys {
+		if !isValidProperty(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath, restrictions, fmt.Sprintf("unsupported property %q", key)))
+		}
+	}
+	return allErrs
+}
+
 // ValidateClusterServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateClusterServiceBrokerUpdate(new *sc.ClusterServiceBroker, old *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
-	allErrs = append(allErrs, ValidateClusterServiceBroker(new)...)
+	allErrs = append(allErrs, validateClusterServiceBroker(new)...)
+	if !reflect.DeepEqual(new.Spec.CatalogRestrictions, old.Spec.CatalogRestrictions) {
+		allErrs = append(allErrs, validateClusterServiceBrokerCatalogRestrictionProperties(new)...)
+	}
 	return allErrs
 }
 
 // ValidateServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateServiceBrokerUpdate(new *sc.ServiceBroker, old *sc.ServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
-	allErrs = append(allErrs, ValidateServiceBroker(new)...)
+	allErrs = append(allErrs, validateServiceBroker(new)...)
+	if !reflect.DeepEqual(new.Spec.CatalogRestrictions, old.Spec.CatalogRestrictions) {
+		allErrs = append(allErrs, validateServiceBrokerCatalogRestrictionProperties(new)...)
+	}
 	return allErrs
 }
 
@@ -402,16 +402,400 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
 							ServiceClass: []string{
 								"name==foobar",
+								"spec.externalName in (foobar, bazboof, wizzbang)",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"invalid restriction",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements.servicePlan",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"name==foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - complex catalogRequirements.servicePlan",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"name==foobar",
+								"spec.externalName in (foobar, bazboof, wizzbang)",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"invalid restriction",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements with serviceClass and servicePlan",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"name==barfoobar",
+								"spec.externalName in (barfoobar, batbazboof, batwizzbang)",
+							},
+							ServicePlan: []string{
+								"name==foobar",
+								"spec.externalName in (foobar, bazboof, wizzbang)",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements.serviceClass on tags, bindable and metadata",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.tags.database",
+								"!spec.tags.deprecated",
+								"spec.bindable=true",
+								"spec.externalMetadata.costCenter in (x, y)",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements.servicePlan on free, bindable and metadata",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"spec.free=true",
+								"spec.bindable!=false",
+								"spec.externalMetadata.compliance.approved=true",
+								"spec.clusterServiceClass.name=foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass on unsupported property",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
 								"externalName in (foobar, bazboof, wizzbang)",
 							},
 						},
 					},
 				},
 			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass on plan property",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.free=true",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan on namespaced class name",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"spec.serviceClass.name=foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass on empty tag",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.tags.",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - polling policy",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						PollingPolicy: &servicecatalog.ServiceBrokerPollingPolicy{
+							InitialInterval: &metav1.Duration{Duration: 10 * time.Second},
+							MaxInterval:     &metav1.Duration{Duration: 5 * time.Minute},
+							JitterPercent:   20,
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - polling max interval less than initial interval",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						PollingPolicy: &servicecatalog.ServiceBrokerPollingPolicy{
+							InitialInterval: &metav1.Duration{Duration: 10 * time.Second},
+							MaxInterval:     &metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - zero polling initial interval",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						PollingPolicy: &servicecatalog.ServiceBrokerPollingPolicy{
+							InitialInterval: &metav1.Duration{},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - polling jitter above 100 percent",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						PollingPolicy: &servicecatalog.ServiceBrokerPollingPolicy{
+							JitterPercent: 101,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - request limits",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RequestLimits: &servicecatalog.ServiceBrokerRequestLimits{
+							QPS:                   5,
+							Burst:                 10,
+							MaxConcurrentRequests: 4,
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - negative request qps",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RequestLimits: &servicecatalog.ServiceBrokerRequestLimits{
+							QPS: -1,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - negative max concurrent requests",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RequestLimits: &servicecatalog.ServiceBrokerRequestLimits{
+							MaxConcurrentRequests: -1,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - request context",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RequestContext: &servicecatalog.ServiceBrokerRequestContext{
+							Instance:             true,
+							NamespaceLabels:      []string{"cost-center", "example.com/environment"},
+							NamespaceAnnotations: []string{"example.com/owner"},
+							StaticFields:         map[string]string{"region": "eu-west-1"},
+						},
+					},
+				},
+			},
 			valid: true,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.serviceClass",
+			name: "invalid clusterservicebroker - request context label key",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -420,10 +804,8 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
-						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
-								"invalid restriction",
-							},
+						RequestContext: &servicecatalog.ServiceBrokerRequestContext{
+							NamespaceLabels: []string{"cost center"},
 						},
 					},
 				},
@@ -431,7 +813,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "valid clusterservicebroker - catalogRequirements.servicePlan",
+			name: "invalid clusterservicebroker - request context annotation key",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -440,18 +822,16 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
-						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServicePlan: []string{
-								"name==foobar",
-							},
+						RequestContext: &servicecatalog.ServiceBrokerRequestContext{
+							NamespaceAnnotations: []string{"/owner"},
 						},
 					},
 				},
 			},
-			valid: true,
+			valid: false,
 		},
 		{
-			name: "valid clusterservicebroker - complex catalogRequirements.servicePlan",
+			name: "invalid clusterservicebroker - request context static field set by the controller",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -460,11 +840,30 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
-						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServicePlan: []string{
-								"name==foobar",
-								"externalName in (foobar, bazboof, wizzbang)",
-							},
+						RequestContext: &servicecatalog.ServiceBrokerRequestContext{
+							StaticFields: map[string]string{"clusterid": "other"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - plan migration policy",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+					},
+					PlanMigrationPolicy: &servicecatalog.PlanMigrationPolicy{
+						BatchSize: 5,
+						Migrations: []servicecatalog.PlanMigration{
+							{FromPlanExternalName: "old", ToPlanExternalName: "new"},
+							{ServiceClassExternalName: "class", FromPlanExternalName: "old", ToPlanExternalName: "other"},
 						},
 					},
 				},
@@ -472,7 +871,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: true,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.servicePlan",
+			name: "invalid clusterservicebroker - plan migration without replacement plan",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -481,10 +880,10 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
-						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServicePlan: []string{
-								"invalid restriction",
-							},
+					},
+					PlanMigrationPolicy: &servicecatalog.PlanMigrationPolicy{
+						Migrations: []servicecatalog.PlanMigration{
+							{FromPlanExternalName: "old"},
 						},
 					},
 				},
@@ -492,7 +891,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "valid clusterservicebroker - catalogRequirements with serviceClass and servicePlan",
+			name: "invalid clusterservicebroker - plan migration to the same plan",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -501,20 +900,54 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
-						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
-								"name==barfoobar",
-								"externalName in (barfoobar, batbazboof, batwizzbang)",
-							},
-							ServicePlan: []string{
-								"name==foobar",
-								"externalName in (foobar, bazboof, wizzbang)",
-							},
+					},
+					PlanMigrationPolicy: &servicecatalog.PlanMigrationPolicy{
+						Migrations: []servicecatalog.PlanMigration{
+							{FromPlanExternalName: "old", ToPlanExternalName: "old"},
 						},
 					},
 				},
 			},
-			valid: true,
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - plan migrated twice",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+					},
+					PlanMigrationPolicy: &servicecatalog.PlanMigrationPolicy{
+						Migrations: []servicecatalog.PlanMigration{
+							{FromPlanExternalName: "old", ToPlanExternalName: "new"},
+							{FromPlanExternalName: "old", ToPlanExternalName: "other"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - negative plan migration batch size",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+					},
+					PlanMigrationPolicy: &servicecatalog.PlanMigrationPolicy{
+						BatchSize: -1,
+					},
+				},
+			},
+			valid: false,
 		},
 	}
 
@@ -624,6 +1057,74 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid clusterservicebroker update - unchanged catalogRestrictions on unsupported property",
+			newBroker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 2,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			oldBroker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker update - catalogRestrictions changed to unsupported property",
+			newBroker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			oldBroker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"spec.externalName=foo"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range updateCases {
 		errs := ValidateClusterServiceBrokerUpdate(tc.newBroker, tc.oldBroker)
@@ -942,6 +1443,48 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid servicebroker - catalogRequirements.servicePlan on class name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-servicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"spec.serviceClass.name=foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - catalogRequirements.servicePlan on cluster class name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-servicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServicePlan: []string{
+								"spec.clusterServiceClass.name=foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1056,6 +1599,78 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid servicebroker update - unchanged catalogRestrictions on unsupported property",
+			newBroker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-broker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 2,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			oldBroker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-broker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker update - catalogRestrictions changed to unsupported property",
+			newBroker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-broker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"externalName=foo"},
+						},
+					},
+				},
+			},
+			oldBroker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-broker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RelistRequests: 1,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{"spec.externalName=foo"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range updateCases {
 		errs := ValidateServiceBrokerUpdate(tc.newBroker, tc.oldBroker)
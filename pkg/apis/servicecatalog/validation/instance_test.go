@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -209,6 +210,42 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "valid maintenance window",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.MaintenanceWindow = &servicecatalog.MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid maintenance window schedule",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.MaintenanceWindow = &servicecatalog.MaintenanceWindow{
+					Schedule: "0 2 * *",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid maintenance window duration",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.MaintenanceWindow = &servicecatalog.MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+					Duration: metav1.Duration{Duration: 0},
+				}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing namespace",
 			instance: func() *servicecatalog.ServiceInstance {
@@ -297,6 +334,65 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid configMapKeyRef in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ConfigMapKeyRef: &servicecatalog.ConfigMapKeyReference{Name: "test-configmap", Key: "test-key"}}}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid secretRef and configMapRef in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{SecretRef: &servicecatalog.LocalObjectReference{Name: "test-secret"}},
+						{ConfigMapRef: &servicecatalog.LocalObjectReference{Name: "test-configmap"}}}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid serviceBindingKeyRef in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ServiceBindingKeyRef: &servicecatalog.ServiceBindingKeyReference{Name: "test-binding", Key: "uri", Parameter: "databaseURI"}}}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "serviceBindingKeyRef key is missing in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{ServiceBindingKeyRef: &servicecatalog.ServiceBindingKeyReference{Name: "test-binding"}}}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "more than one source in a parametersFrom entry",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{
+							SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"},
+							SecretRef:    &servicecatalog.LocalObjectReference{Name: "test-secret"},
+						}}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid with in-progress provision",
 			instance: validServiceInstanceWithInProgressProvision(),
@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+// ValidateServiceInstanceGrant validates a ServiceInstanceGrant and returns
+// a list of errors.
+func ValidateServiceInstanceGrant(grant *sc.ServiceInstanceGrant) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs,
+		apivalidation.ValidateObjectMeta(
+			&grant.ObjectMeta,
+			true, /* namespace required */
+			apivalidation.NameIsDNSSubdomain,
+			field.NewPath("metadata"))...)
+
+	allErrs = append(allErrs, validateServiceInstanceGrantSpec(&grant.Spec, grant.Namespace, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateServiceInstanceGrantUpdate checks that an update to a
+// ServiceInstanceGrant is valid.
+func ValidateServiceInstanceGrantUpdate(new *sc.ServiceInstanceGrant, old *sc.ServiceInstanceGrant) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&new.ObjectMeta, &old.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateServiceInstanceGrant(new)...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.ServiceInstanceRef, old.Spec.ServiceInstanceRef, field.NewPath("spec").Child("instanceRef"))...)
+	return allErrs
+}
+
+func validateServiceInstanceGrantSpec(spec *sc.ServiceInstanceGrantSpec, namespace string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validateServiceInstanceName(spec.ServiceInstanceRef.Name, false /* prefix */) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceRef", "name"), spec.ServiceInstanceRef.Name, msg))
+	}
+
+	if len(spec.Namespaces) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespaces"), "at least one namespace is required"))
+	}
+	namespaces := sets.NewString()
+	for i, ns := range spec.Namespaces {
+		for _, msg := range apivalidation.ValidateNamespaceName(ns, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), ns, msg))
+		}
+		if ns == namespace {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), ns, "the namespace of the grant can always bind to its instances"))
+		}
+		if namespaces.Has(ns) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("namespaces").Index(i), ns))
+		}
+		namespaces.Insert(ns)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+func validServiceInstanceGrant() *servicecatalog.ServiceInstanceGrant {
+	return &servicecatalog.ServiceInstanceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-grant",
+			Namespace: "test-ns",
+		},
+		Spec: servicecatalog.ServiceInstanceGrantSpec{
+			ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: "test-instance"},
+			Namespaces:         []string{"team-a", "team-b"},
+		},
+	}
+}
+
+func TestValidateServiceInstanceGrant(t *testing.T) {
+	testCases := []struct {
+		name  string
+		grant *servicecatalog.ServiceInstanceGrant
+		valid bool
+	}{
+		{
+			name:  "valid ServiceInstanceGrant",
+			grant: validServiceInstanceGrant(),
+			valid: true,
+		},
+		{
+			name: "missing namespace",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Namespace = ""
+				return g
+			}(),
+			valid: false,
+		},
+		{
+			name: "missing instance",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Spec.ServiceInstanceRef.Name = ""
+				return g
+			}(),
+			valid: false,
+		},
+		{
+			name: "no namespaces",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Spec.Namespaces = nil
+				return g
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid namespace",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Spec.Namespaces = []string{"Team_A"}
+				return g
+			}(),
+			valid: false,
+		},
+		{
+			name: "own namespace",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Spec.Namespaces = []string{"test-ns"}
+				return g
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate namespace",
+			grant: func() *servicecatalog.ServiceInstanceGrant {
+				g := validServiceInstanceGrant()
+				g.Spec.Namespaces = []string{"team-a", "team-a"}
+				return g
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		errs := ValidateServiceInstanceGrant(tc.grant)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+}
+
+func TestValidateServiceInstanceGrantUpdate(t *testing.T) {
+	old := validServiceInstanceGrant()
+	old.ResourceVersion = "1"
+
+	sharedWithMore := validServiceInstanceGrant()
+	sharedWithMore.ResourceVersion = "1"
+	sharedWithMore.Spec.Namespaces = append(sharedWithMore.Spec.Namespaces, "team-c")
+	if errs := ValidateServiceInstanceGrantUpdate(sharedWithMore, old); len(errs) != 0 {
+		t.Errorf("unexpected error changing the namespaces: %v", errs)
+	}
+
+	otherInstance := validServiceInstanceGrant()
+	otherInstance.ResourceVersion = "1"
+	otherInstance.Spec.ServiceInstanceRef.Name = "other-instance"
+	if errs := ValidateServiceInstanceGrantUpdate(otherInstance, old); len(errs) == 0 {
+		t.Error("unexpected success changing the instance")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
+)
+
+// ValidateClusterServicePlanPolicy validates a ClusterServicePlanPolicy and
+// returns a list of errors.
+func ValidateClusterServicePlanPolicy(policy *sc.ClusterServicePlanPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs,
+		apivalidation.ValidateObjectMeta(
+			&policy.ObjectMeta,
+			false, /* namespace required */
+			apivalidation.NameIsDNSSubdomain,
+			field.NewPath("metadata"))...)
+
+	allErrs = append(allErrs, validateClusterServicePlanPolicySpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateClusterServicePlanPolicyUpdate checks that an update to a
+// ClusterServicePlanPolicy is valid.
+func ValidateClusterServicePlanPolicyUpdate(new *sc.ClusterServicePlanPolicy, old *sc.ClusterServicePlanPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&new.ObjectMeta, &old.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateClusterServicePlanPolicy(new)...)
+	return allErrs
+}
+
+func validateClusterServicePlanPolicySpec(spec *sc.ClusterServicePlanPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ServiceClassExternalName != "" {
+		for _, msg := range validateCommonServiceClassName(spec.ServiceClassExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassExternalName"), spec.ServiceClassExternalName, msg))
+		}
+	}
+	if spec.ServicePlanExternalName != "" {
+		for _, msg := range validateCommonServicePlanName(spec.ServicePlanExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanExternalName"), spec.ServicePlanExternalName, msg))
+		}
+	}
+	if spec.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+
+	if spec.InstanceParameters == nil && spec.BindingParameters == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of instanceParameters or bindingParameters is required"))
+	}
+	if spec.InstanceParameters != nil {
+		allErrs = append(allErrs, validateParametersPolicy(spec.InstanceParameters, fldPath.Child("instanceParameters"))...)
+	}
+	if spec.BindingParameters != nil {
+		allErrs = append(allErrs, validateParametersPolicy(spec.BindingParameters, fldPath.Child("bindingParameters"))...)
+	}
+
+	return allErrs
+}
+
+func validateParametersPolicy(policy *sc.ParametersPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.Defaults != nil {
+		if len(policy.Defaults.Raw) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("defaults"), "default parameters must not be empty if present"))
+		} else if _, err := controller.UnmarshalRawParameters(policy.Defaults.Raw); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaults"), string(policy.Defaults.Raw), "default parameters must be a JSON object"))
+		}
+	}
+
+	lockedKeys := sets.NewString()
+	for i, key := range policy.LockedKeys {
+		if key == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("lockedKeys").Index(i), "key must not be empty"))
+		} else if lockedKeys.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("lockedKeys").Index(i), key))
+		}
+		lockedKeys.Insert(key)
+	}
+
+	allowedKeys := sets.NewString()
+	for i, allowed := range policy.AllowedValues {
+		allowedPath := fldPath.Child("allowedValues").Index(i)
+		if allowed.Key == "" {
+			allErrs = append(allErrs, field.Required(allowedPath.Child("key"), "key must not be empty"))
+		} else if allowedKeys.Has(allowed.Key) {
+			allErrs = append(allErrs, field.Duplicate(allowedPath.Child("key"), allowed.Key))
+		}
+		allowedKeys.Insert(allowed.Key)
+		if len(allowed.Values) == 0 {
+			allErrs = append(allErrs, field.Required(allowedPath.Child("values"), "at least one value is required"))
+		}
+	}
+
+	return allErrs
+}
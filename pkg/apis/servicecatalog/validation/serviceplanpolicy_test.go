@@ -0,0 +1,205 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+func validClusterServicePlanPolicy() *servicecatalog.ClusterServicePlanPolicy {
+	return &servicecatalog.ClusterServicePlanPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-clusterserviceplanpolicy",
+		},
+		Spec: servicecatalog.ClusterServicePlanPolicySpec{
+			ServiceClassExternalName: "test-serviceclass",
+			ServicePlanExternalName:  "test-serviceplan",
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "production"},
+			},
+			InstanceParameters: &servicecatalog.ParametersPolicy{
+				Defaults:   &runtime.RawExtension{Raw: []byte(`{"backupEnabled":true}`)},
+				LockedKeys: []string{"region"},
+				AllowedValues: []servicecatalog.AllowedParameterValues{
+					{Key: "backupEnabled", Values: []string{"true"}},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateClusterServicePlanPolicy(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy *servicecatalog.ClusterServicePlanPolicy
+		valid  bool
+	}{
+		{
+			name:   "valid ClusterServicePlanPolicy",
+			policy: validClusterServicePlanPolicy(),
+			valid:  true,
+		},
+		{
+			name: "valid ClusterServicePlanPolicy - all plans",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.ServiceClassExternalName = ""
+				p.Spec.ServicePlanExternalName = ""
+				p.Spec.NamespaceSelector = nil
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid ClusterServicePlanPolicy - binding parameters only",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.BindingParameters = p.Spec.InstanceParameters
+				p.Spec.InstanceParameters = nil
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "missing name",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Name = ""
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad serviceClassExternalName",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.ServiceClassExternalName = "#"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad servicePlanExternalName",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.ServicePlanExternalName = "#"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad namespaceSelector",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.NamespaceSelector.MatchLabels = map[string]string{"env": "#"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "no parameters policy",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters = nil
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty defaults",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.Defaults = &runtime.RawExtension{}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "defaults not an object",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.Defaults = &runtime.RawExtension{Raw: []byte(`[true]`)}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "empty locked key",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.LockedKeys = []string{""}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate locked key",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.LockedKeys = []string{"region", "region"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "allowed values without key",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.AllowedValues[0].Key = ""
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "allowed values without values",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.AllowedValues[0].Values = nil
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate allowed values key",
+			policy: func() *servicecatalog.ClusterServicePlanPolicy {
+				p := validClusterServicePlanPolicy()
+				p.Spec.InstanceParameters.AllowedValues = append(p.Spec.InstanceParameters.AllowedValues, p.Spec.InstanceParameters.AllowedValues[0])
+				return p
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateClusterServicePlanPolicy(tc.policy)
+			t.Log(errs)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("%v: unexpected error: %v", tc.name, errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Errorf("%v: unexpected success", tc.name)
+			}
+		})
+	}
+}
@@ -32,15 +32,52 @@ func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldP
 	allErrs := field.ErrorList{}
 
 	for _, paramsFrom := range parametersFrom {
+		sources := 0
 		if paramsFrom.SecretKeyRef != nil {
+			sources++
 			if paramsFrom.SecretKeyRef.Name == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.name"), "name is required"))
 			}
 			if paramsFrom.SecretKeyRef.Key == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.key"), "key is required"))
 			}
-		} else {
+		}
+		if paramsFrom.ConfigMapKeyRef != nil {
+			sources++
+			if paramsFrom.ConfigMapKeyRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.configMapKeyRef.name"), "name is required"))
+			}
+			if paramsFrom.ConfigMapKeyRef.Key == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.configMapKeyRef.key"), "key is required"))
+			}
+		}
+		if paramsFrom.SecretRef != nil {
+			sources++
+			if paramsFrom.SecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretRef.name"), "name is required"))
+			}
+		}
+		if paramsFrom.ConfigMapRef != nil {
+			sources++
+			if paramsFrom.ConfigMapRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.configMapRef.name"), "name is required"))
+			}
+		}
+		if paramsFrom.ServiceBindingKeyRef != nil {
+			sources++
+			if paramsFrom.ServiceBindingKeyRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceBindingKeyRef.name"), "name is required"))
+			}
+			if paramsFrom.ServiceBindingKeyRef.Key == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceBindingKeyRef.key"), "key is required"))
+			}
+		}
+
+		switch {
+		case sources == 0:
 			allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom"), "source must not be empty if present"))
+		case sources > 1:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parametersFrom"), paramsFrom, "only one source may be set"))
 		}
 	}
 
@@ -40,6 +40,10 @@ func (c *FakeServicecatalogV1beta1) ClusterServicePlans() v1beta1.ClusterService
 	return &FakeClusterServicePlans{c}
 }
 
+func (c *FakeServicecatalogV1beta1) ClusterServicePlanPolicies() v1beta1.ClusterServicePlanPolicyInterface {
+	return &FakeClusterServicePlanPolicies{c}
+}
+
 func (c *FakeServicecatalogV1beta1) ServiceBindings(namespace string) v1beta1.ServiceBindingInterface {
 	return &FakeServiceBindings{c, namespace}
 }
@@ -56,6 +60,10 @@ func (c *FakeServicecatalogV1beta1) ServiceInstances(namespace string) v1beta1.S
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalogV1beta1) ServiceInstanceGrants(namespace string) v1beta1.ServiceInstanceGrantInterface {
+	return &FakeServiceInstanceGrants{c, namespace}
+}
+
 func (c *FakeServicecatalogV1beta1) ServicePlans(namespace string) v1beta1.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
@@ -24,10 +24,14 @@ type ClusterServiceClassExpansion interface{}
 
 type ClusterServicePlanExpansion interface{}
 
+type ClusterServicePlanPolicyExpansion interface{}
+
 type ServiceBindingExpansion interface{}
 
 type ServiceBrokerExpansion interface{}
 
 type ServiceClassExpansion interface{}
 
+type ServiceInstanceGrantExpansion interface{}
+
 type ServicePlanExpansion interface{}
@@ -30,10 +30,12 @@ type ServicecatalogV1beta1Interface interface {
 	ClusterServiceBrokersGetter
 	ClusterServiceClassesGetter
 	ClusterServicePlansGetter
+	ClusterServicePlanPoliciesGetter
 	ServiceBindingsGetter
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceGrantsGetter
 	ServicePlansGetter
 }
 
@@ -54,6 +56,10 @@ func (c *ServicecatalogV1beta1Client) ClusterServicePlans() ClusterServicePlanIn
 	return newClusterServicePlans(c)
 }
 
+func (c *ServicecatalogV1beta1Client) ClusterServicePlanPolicies() ClusterServicePlanPolicyInterface {
+	return newClusterServicePlanPolicies(c)
+}
+
 func (c *ServicecatalogV1beta1Client) ServiceBindings(namespace string) ServiceBindingInterface {
 	return newServiceBindings(c, namespace)
 }
@@ -70,6 +76,10 @@ func (c *ServicecatalogV1beta1Client) ServiceInstances(namespace string) Service
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogV1beta1Client) ServiceInstanceGrants(namespace string) ServiceInstanceGrantInterface {
+	return newServiceInstanceGrants(c, namespace)
+}
+
 func (c *ServicecatalogV1beta1Client) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
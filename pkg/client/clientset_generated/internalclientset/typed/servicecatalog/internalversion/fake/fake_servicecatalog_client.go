@@ -40,6 +40,10 @@ func (c *FakeServicecatalog) ClusterServicePlans() internalversion.ClusterServic
 	return &FakeClusterServicePlans{c}
 }
 
+func (c *FakeServicecatalog) ClusterServicePlanPolicies() internalversion.ClusterServicePlanPolicyInterface {
+	return &FakeClusterServicePlanPolicies{c}
+}
+
 func (c *FakeServicecatalog) ServiceBindings(namespace string) internalversion.ServiceBindingInterface {
 	return &FakeServiceBindings{c, namespace}
 }
@@ -56,6 +60,10 @@ func (c *FakeServicecatalog) ServiceInstances(namespace string) internalversion.
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalog) ServiceInstanceGrants(namespace string) internalversion.ServiceInstanceGrantInterface {
+	return &FakeServiceInstanceGrants{c, namespace}
+}
+
 func (c *FakeServicecatalog) ServicePlans(namespace string) internalversion.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
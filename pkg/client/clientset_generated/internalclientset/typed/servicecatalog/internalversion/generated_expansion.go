@@ -24,6 +24,8 @@ type ClusterServiceClassExpansion interface{}
 
 type ClusterServicePlanExpansion interface{}
 
+type ClusterServicePlanPolicyExpansion interface{}
+
 type ServiceBindingExpansion interface{}
 
 type ServiceBrokerExpansion interface{}
@@ -32,4 +34,6 @@ type ServiceClassExpansion interface{}
 
 type ServiceInstanceExpansion interface{}
 
+type ServiceInstanceGrantExpansion interface{}
+
 type ServicePlanExpansion interface{}
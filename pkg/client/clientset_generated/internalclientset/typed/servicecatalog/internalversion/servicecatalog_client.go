@@ -28,10 +28,12 @@ type ServicecatalogInterface interface {
 	ClusterServiceBrokersGetter
 	ClusterServiceClassesGetter
 	ClusterServicePlansGetter
+	ClusterServicePlanPoliciesGetter
 	ServiceBindingsGetter
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceGrantsGetter
 	ServicePlansGetter
 }
 
@@ -52,6 +54,10 @@ func (c *ServicecatalogClient) ClusterServicePlans() ClusterServicePlanInterface
 	return newClusterServicePlans(c)
 }
 
+func (c *ServicecatalogClient) ClusterServicePlanPolicies() ClusterServicePlanPolicyInterface {
+	return newClusterServicePlanPolicies(c)
+}
+
 func (c *ServicecatalogClient) ServiceBindings(namespace string) ServiceBindingInterface {
 	return newServiceBindings(c, namespace)
 }
@@ -68,6 +74,10 @@ func (c *ServicecatalogClient) ServiceInstances(namespace string) ServiceInstanc
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogClient) ServiceInstanceGrants(namespace string) ServiceInstanceGrantInterface {
+	return newServiceInstanceGrants(c, namespace)
+}
+
 func (c *ServicecatalogClient) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
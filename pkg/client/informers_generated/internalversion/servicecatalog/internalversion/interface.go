@@ -30,6 +30,8 @@ type Interface interface {
 	ClusterServiceClasses() ClusterServiceClassInformer
 	// ClusterServicePlans returns a ClusterServicePlanInformer.
 	ClusterServicePlans() ClusterServicePlanInformer
+	// ClusterServicePlanPolicies returns a ClusterServicePlanPolicyInformer.
+	ClusterServicePlanPolicies() ClusterServicePlanPolicyInformer
 	// ServiceBindings returns a ServiceBindingInformer.
 	ServiceBindings() ServiceBindingInformer
 	// ServiceBrokers returns a ServiceBrokerInformer.
@@ -38,6 +40,8 @@ type Interface interface {
 	ServiceClasses() ServiceClassInformer
 	// ServiceInstances returns a ServiceInstanceInformer.
 	ServiceInstances() ServiceInstanceInformer
+	// ServiceInstanceGrants returns a ServiceInstanceGrantInformer.
+	ServiceInstanceGrants() ServiceInstanceGrantInformer
 	// ServicePlans returns a ServicePlanInformer.
 	ServicePlans() ServicePlanInformer
 }
@@ -68,6 +72,11 @@ func (v *version) ClusterServicePlans() ClusterServicePlanInformer {
 	return &clusterServicePlanInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterServicePlanPolicies returns a ClusterServicePlanPolicyInformer.
+func (v *version) ClusterServicePlanPolicies() ClusterServicePlanPolicyInformer {
+	return &clusterServicePlanPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceBindings returns a ServiceBindingInformer.
 func (v *version) ServiceBindings() ServiceBindingInformer {
 	return &serviceBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -88,6 +97,11 @@ func (v *version) ServiceInstances() ServiceInstanceInformer {
 	return &serviceInstanceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ServiceInstanceGrants returns a ServiceInstanceGrantInformer.
+func (v *version) ServiceInstanceGrants() ServiceInstanceGrantInformer {
+	return &serviceInstanceGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServicePlans returns a ServicePlanInformer.
 func (v *version) ServicePlans() ServicePlanInformer {
 	return &servicePlanInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
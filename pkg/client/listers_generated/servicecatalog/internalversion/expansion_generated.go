@@ -30,6 +30,10 @@ type ClusterServiceClassListerExpansion interface{}
 // ClusterServicePlanLister.
 type ClusterServicePlanListerExpansion interface{}
 
+// ClusterServicePlanPolicyListerExpansion allows custom methods to be added to
+// ClusterServicePlanPolicyLister.
+type ClusterServicePlanPolicyListerExpansion interface{}
+
 // ServiceBindingListerExpansion allows custom methods to be added to
 // ServiceBindingLister.
 type ServiceBindingListerExpansion interface{}
@@ -62,6 +66,14 @@ type ServiceInstanceListerExpansion interface{}
 // ServiceInstanceNamespaceLister.
 type ServiceInstanceNamespaceListerExpansion interface{}
 
+// ServiceInstanceGrantListerExpansion allows custom methods to be added to
+// ServiceInstanceGrantLister.
+type ServiceInstanceGrantListerExpansion interface{}
+
+// ServiceInstanceGrantNamespaceListerExpansion allows custom methods to be added to
+// ServiceInstanceGrantNamespaceLister.
+type ServiceInstanceGrantNamespaceListerExpansion interface{}
+
 // ServicePlanListerExpansion allows custom methods to be added to
 // ServicePlanLister.
 type ServicePlanListerExpansion interface{}
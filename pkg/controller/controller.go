@@ -35,7 +35,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -46,6 +50,7 @@ import (
 	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/filter"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -80,15 +85,24 @@ func NewController(
 	bindingInformer informers.ServiceBindingInformer,
 	clusterServicePlanInformer informers.ClusterServicePlanInformer,
 	servicePlanInformer informers.ServicePlanInformer,
+	serviceInstanceGrantInformer informers.ServiceInstanceGrantInformer,
+	secretInformer coreinformers.SecretInformer,
+	configMapInformer coreinformers.ConfigMapInformer,
+	deploymentInformer appsinformers.DeploymentInformer,
+	statefulSetInformer appsinformers.StatefulSetInformer,
 	brokerClientCreateFunc osb.CreateFunc,
 	brokerRelistInterval time.Duration,
 	osbAPIPreferredVersion string,
 	recorder record.EventRecorder,
 	reconciliationRetryDuration time.Duration,
 	operationPollingMaximumBackoffDuration time.Duration,
+	bindingRotationGracePeriod time.Duration,
+	defaultRequestContext *v1beta1.ServiceBrokerRequestContext,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
 ) (Controller, error) {
+	instancePollingRateLimiter := newPollingRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration)
+	bindingPollingRateLimiter := newPollingRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration)
 	controller := &controller{
 		kubeClient:                  kubeClient,
 		serviceCatalogClient:        serviceCatalogClient,
@@ -97,6 +111,8 @@ func NewController(
 		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
 		recorder:                    recorder,
 		reconciliationRetryDuration: reconciliationRetryDuration,
+		bindingRotationGracePeriod:  bindingRotationGracePeriod,
+		defaultRequestContext:       defaultRequestContext,
 		clusterServiceBrokerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-broker"),
 		serviceBrokerQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-broker"),
 		clusterServiceClassQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
@@ -105,8 +121,10 @@ func NewController(
 		servicePlanQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-plan"),
 		instanceQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance"),
 		bindingQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-binding"),
-		instancePollingQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "instance-poller"),
-		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
+		instancePollingQueue:        workqueue.NewNamedRateLimitingQueue(instancePollingRateLimiter, "instance-poller"),
+		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(bindingPollingRateLimiter, "binding-poller"),
+		instancePollingRateLimiter:  instancePollingRateLimiter,
+		bindingPollingRateLimiter:   bindingPollingRateLimiter,
 		clusterIDConfigMapName:      clusterIDConfigMapName,
 		clusterIDConfigMapNamespace: clusterIDConfigMapNamespace,
 	}
@@ -167,6 +185,44 @@ func NewController(
 		})
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) {
+		controller.serviceInstanceGrantLister = serviceInstanceGrantInformer.Lister()
+		serviceInstanceGrantInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.serviceInstanceGrantAdd,
+			UpdateFunc: controller.serviceInstanceGrantUpdate,
+		})
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.WatchParametersFrom) {
+		controller.secretLister = secretInformer.Lister()
+		secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.secretAdd,
+			UpdateFunc: controller.secretUpdate,
+			DeleteFunc: controller.secretDelete,
+		})
+		controller.configMapLister = configMapInformer.Lister()
+		configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.configMapAdd,
+			UpdateFunc: controller.configMapUpdate,
+			DeleteFunc: controller.configMapDelete,
+		})
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingWorkloadInjection) {
+		controller.deploymentLister = deploymentInformer.Lister()
+		deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.workloadAdd,
+			UpdateFunc: controller.workloadUpdate,
+			DeleteFunc: controller.workloadDelete,
+		})
+		controller.statefulSetLister = statefulSetInformer.Lister()
+		statefulSetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.workloadAdd,
+			UpdateFunc: controller.workloadUpdate,
+			DeleteFunc: controller.workloadDelete,
+		})
+	}
+
 	return controller, nil
 }
 
@@ -192,10 +248,16 @@ type controller struct {
 	bindingLister               listers.ServiceBindingLister
 	clusterServicePlanLister    listers.ClusterServicePlanLister
 	servicePlanLister           listers.ServicePlanLister
+	serviceInstanceGrantLister  listers.ServiceInstanceGrantLister
+	secretLister                corelisters.SecretLister
+	configMapLister             corelisters.ConfigMapLister
+	deploymentLister            appslisters.DeploymentLister
+	statefulSetLister           appslisters.StatefulSetLister
 	brokerRelistInterval        time.Duration
 	OSBAPIPreferredVersion      string
 	recorder                    record.EventRecorder
 	reconciliationRetryDuration time.Duration
+	bindingRotationGracePeriod  time.Duration
 	clusterServiceBrokerQueue   workqueue.RateLimitingInterface
 	serviceBrokerQueue          workqueue.RateLimitingInterface
 	clusterServiceClassQueue    workqueue.RateLimitingInterface
@@ -206,6 +268,11 @@ type controller struct {
 	bindingQueue                workqueue.RateLimitingInterface
 	instancePollingQueue        workqueue.RateLimitingInterface
 	bindingPollingQueue         workqueue.RateLimitingInterface
+	instancePollingRateLimiter  *pollingRateLimiter
+	bindingPollingRateLimiter   *pollingRateLimiter
+	// defaultRequestContext selects the extra fields of the context of the
+	// requests to brokers that select none of their own.
+	defaultRequestContext *v1beta1.ServiceBrokerRequestContext
 	// clusterIDConfigMapName is the k8s name that the clusterid
 	// configmap will have.
 	clusterIDConfigMapName string
@@ -385,6 +452,9 @@ func worker(queue workqueue.RateLimitingInterface, resourceType string, maxRetri
 type operationError struct {
 	reason  string
 	message string
+	// fieldErrors are the invalid fields of the request, if the error is
+	// caused by them.
+	fieldErrors []v1beta1.ConditionFieldError
 }
 
 func (e *operationError) Error() string { return e.message }
@@ -482,7 +552,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 	glog.V(4).Info(pcb.Messagef("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL))
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+	brokerClient, err := c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -531,7 +601,7 @@ func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceIn
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 	glog.V(4).Info(pcb.Messagef("Creating client for ServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL))
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+	brokerClient, err := c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -674,7 +744,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
-		brokerClient, err = c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err = c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 		if err != nil {
 			return nil, err
 		}
@@ -710,7 +780,7 @@ func (c *controller) getBrokerClientForServiceBinding(instance *v1beta1.ServiceI
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
-		brokerClient, err = c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err = c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 		if err != nil {
 			return nil, err
 		}
@@ -1238,7 +1308,7 @@ func isServiceInstanceOrphanMitigation(instance *v1beta1.ServiceInstance) bool {
 // to the specified Broker
 func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, authConfig *osb.AuthConfig) *osb.ClientConfiguration {
 	clientConfig := osb.DefaultClientConfiguration()
-	clientConfig.Name = meta.Name
+	clientConfig.Name = osbclientproxy.BrokerName(meta.Namespace, meta.Name)
 	clientConfig.URL = commonSpec.URL
 	clientConfig.AuthConfig = authConfig
 	clientConfig.EnableAlphaFeatures = true
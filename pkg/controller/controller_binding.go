@@ -20,6 +20,9 @@ import (
 	"bytes"
 	"fmt"
 	"net"
+	"net/http"
+	"text/template"
+	"time"
 
 	"github.com/golang/glog"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
@@ -27,11 +30,13 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
@@ -49,10 +54,14 @@ const (
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	errorUnbindRetiredCredentialsReason       string = "UnbindRetiredCredentialsFailed"
+	errorRetiredCredentialsUnrevokedReason    string = "RetiredCredentialsUnrevoked"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
 	successUnboundReason             string = "UnboundSuccessfully"
+	successUnboundRetiredReason      string = "UnboundRetiredCredentials"
+	successUnboundRetiredMessage     string = "The retired credentials were unbound successfully"
 	asyncBindingReason               string = "Binding"
 	asyncBindingMessage              string = "The binding is being created asynchronously"
 	asyncUnbindingReason             string = "Unbinding"
@@ -139,6 +148,17 @@ func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	return false
 }
 
+// isServiceBindingReady returns whether the given binding has a ready
+// condition with status true.
+func isServiceBindingReady(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady && condition.Status == v1beta1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 // getReconciliationActionForServiceBinding gets the action the reconciler
 // should be taking on the given binding.
 func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) ReconciliationAction {
@@ -183,6 +203,20 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	if binding.Status.ReconciledGeneration == binding.Generation {
+		if shouldInjectServiceBindingWorkloads(binding) && isServiceBindingReady(binding) {
+			updated, err := c.reconcileServiceBindingWorkloads(binding)
+			if err != nil || updated {
+				// If the Status has been updated, the retired credentials
+				// are handled in the next iteration.
+				return err
+			}
+		}
+		if updated, err := c.reconcileServiceBindingParametersDrift(binding); err != nil || updated {
+			return err
+		}
+		if len(binding.Status.RetiredCredentials) > 0 {
+			return c.reconcileServiceBindingRetiredCredentials(binding)
+		}
 		glog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
 		return nil
 	}
@@ -191,13 +225,17 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 
 	binding = binding.DeepCopy()
 
-	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
 	if err != nil {
-		msg := fmt.Sprintf(`References a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.Namespace, binding.Spec.ServiceInstanceRef.Name)
+		msg := fmt.Sprintf(`References a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonexistentServiceInstanceReason, msg)
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
+	if err := c.checkServiceInstanceSharedWithServiceBinding(binding); err != nil {
+		return err
+	}
+
 	var prettyName string
 	var brokerClient osb.Client
 	var request *osb.BindRequest
@@ -290,6 +328,9 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	response, err := brokerClient.Bind(request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		return c.requeueServiceBindingForRequestLimit(binding, limitedErr)
+	}
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ServiceBroker returned failure; bind operation will not be retried: %v", err.Error())
@@ -325,8 +366,10 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	// request, so this is what the Broker knows about the state of the
 	// binding.
 	binding.Status.ExternalProperties = binding.Status.InProgressProperties
+	binding.Status.SyslogDrainURL = response.SyslogDrainURL
+	binding.Status.RouteServiceURL = response.RouteServiceURL
 
-	err = c.injectServiceBinding(binding, response.Credentials)
+	err = c.injectServiceBinding(binding, response.Credentials, response.VolumeMounts)
 	if err != nil {
 		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
@@ -399,11 +442,11 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		}
 	}
 
-	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
 	if err != nil {
 		msg := fmt.Sprintf(
 			`References a non-existent %s "%s/%s"`,
-			pretty.ServiceInstance, binding.Namespace, binding.Spec.ServiceInstanceRef.Name,
+			pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name,
 		)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonexistentServiceInstanceReason, msg)
 		return c.processServiceBindingOperationError(binding, readyCond)
@@ -412,7 +455,7 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	if instance.Status.AsyncOpInProgress {
 		msg := fmt.Sprintf(
 			`trying to unbind to %s "%s/%s" that has ongoing asynchronous operation`,
-			pretty.ServiceInstance, binding.Namespace, binding.Spec.ServiceInstanceRef.Name,
+			pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name,
 		)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorWithOngoingAsyncOperation, msg)
 		return c.processServiceBindingOperationError(binding, readyCond)
@@ -456,12 +499,24 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		prettyBrokerName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	// Credentials retired by a rotation are unbound along with the binding,
+	// regardless of whether their grace period has elapsed.
+	if binding.DeletionTimestamp != nil && len(binding.Status.RetiredCredentials) > 0 {
+		if _, err := c.unbindRetiredServiceBindingCredentials(binding, instance, brokerClient, true); err != nil {
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindRetiredCredentialsReason, err.Error())
+			return c.processServiceBindingOperationError(binding, readyCond)
+		}
+	}
+
 	request, err := c.prepareUnbindRequest(binding, instance)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
 	response, err := brokerClient.Unbind(request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		return c.requeueServiceBindingForRequestLimit(binding, limitedErr)
+	}
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error unbinding from %s: %s`, prettyBrokerName, err,
@@ -484,6 +539,151 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	return c.processUnbindSuccess(binding)
 }
 
+// reconcileServiceBindingRetiredCredentials unbinds the credentials that
+// have been retired by a rotation of the given binding once their grace
+// period has elapsed.
+func (c *controller) reconcileServiceBindingRetiredCredentials(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	due, wait := c.retiredServiceBindingCredentialsDue(binding)
+	if !due {
+		if wait > 0 {
+			glog.V(4).Info(pcb.Messagef("Waiting %v before unbinding retired credentials", wait))
+			return c.requeueServiceBindingAfter(binding, wait)
+		}
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message("Unbinding retired credentials"))
+
+	binding = binding.DeepCopy()
+
+	instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return fmt.Errorf(`Unable to unbind retired credentials: references a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name)
+	}
+
+	brokerClient, err := c.getBrokerClientForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+
+	unbound, unbindErr := c.unbindRetiredServiceBindingCredentials(binding, instance, brokerClient, false)
+	if unbindErr != nil {
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindRetiredCredentialsReason, unbindErr.Error())
+	}
+
+	binding, err = c.updateServiceBindingStatus(binding)
+	if err != nil {
+		return err
+	}
+	if unbindErr != nil {
+		return unbindErr
+	}
+
+	if unbound > 0 {
+		c.recorder.Event(binding, corev1.EventTypeNormal, successUnboundRetiredReason, successUnboundRetiredMessage)
+	}
+
+	if _, wait := c.retiredServiceBindingCredentialsDue(binding); wait > 0 {
+		return c.requeueServiceBindingAfter(binding, wait)
+	}
+	return nil
+}
+
+// retiredServiceBindingCredentialsDue returns whether any of the retired
+// credentials of the given binding are due to be unbound and, if not, how
+// long it will be until the next ones are.
+func (c *controller) retiredServiceBindingCredentialsDue(binding *v1beta1.ServiceBinding) (bool, time.Duration) {
+	var wait time.Duration
+	now := time.Now()
+	for _, retired := range binding.Status.RetiredCredentials {
+		if retired.RetiredTime == nil {
+			continue
+		}
+		remaining := retired.RetiredTime.Add(c.bindingRotationGracePeriod).Sub(now)
+		if remaining <= 0 {
+			return true, 0
+		}
+		if wait == 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+	return false, wait
+}
+
+// unbindRetiredServiceBindingCredentials sends an unbind request to the broker
+// for each of the retired credentials of the given binding whose grace period
+// has elapsed, or for all of them if ignoreGracePeriod is true. Credentials
+// that are unbound successfully, or that the broker refuses to unbind, are
+// removed from the binding's status. It returns the number of credentials
+// that were unbound. The Status is *not* recorded in the registry.
+func (c *controller) unbindRetiredServiceBindingCredentials(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osb.Client, ignoreGracePeriod bool) (int, error) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	request, err := c.prepareUnbindRequest(binding, instance)
+	if err != nil {
+		return 0, err
+	}
+	// Retired credentials are always unbound synchronously, as there is
+	// nowhere to track an asynchronous unbind operation for them.
+	request.AcceptsIncomplete = false
+
+	now := time.Now()
+	var remaining []v1beta1.ServiceBindingRetiredCredentials
+	var unbound int
+	var unbindErr error
+	for _, retired := range binding.Status.RetiredCredentials {
+		due := retired.RetiredTime != nil && !now.Before(retired.RetiredTime.Add(c.bindingRotationGracePeriod))
+		if unbindErr != nil || !(due || ignoreGracePeriod) {
+			remaining = append(remaining, retired)
+			continue
+		}
+
+		retiredRequest := *request
+		retiredRequest.BindingID = retired.ExternalID
+		if _, err := brokerClient.Unbind(&retiredRequest); err != nil {
+			// A broker that refuses the request, for example because it
+			// requires an asynchronous unbind, would refuse it again on
+			// every retry, so the credentials are given up on instead.
+			if httpErr, ok := osb.IsHTTPError(err); ok && isTerminalRetiredUnbindStatus(httpErr.StatusCode) {
+				c.recordServiceBindingCredentialsUnrevoked(binding, retired.ExternalID, err)
+				continue
+			}
+			unbindErr = fmt.Errorf(`Error unbinding retired credentials %q: %s`, retired.ExternalID, err)
+			remaining = append(remaining, retired)
+			continue
+		}
+		glog.V(4).Info(pcb.Messagef("Unbound retired credentials %q", retired.ExternalID))
+		unbound++
+	}
+	binding.Status.RetiredCredentials = remaining
+
+	return unbound, unbindErr
+}
+
+// isTerminalRetiredUnbindStatus returns whether an unbind request for retired
+// credentials that failed with the given HTTP status code should not be
+// retried. Client errors are terminal, except for those that indicate the
+// request may succeed later.
+func isTerminalRetiredUnbindStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+	return statusCode >= 400 && statusCode < 500
+}
+
+// recordServiceBindingCredentialsUnrevoked records on the given binding that
+// the broker refused to unbind the retired credentials with the given
+// external ID, which therefore remain valid until they are revoked at the
+// broker by other means. The Status is *not* recorded in the registry.
+func (c *controller) recordServiceBindingCredentialsUnrevoked(binding *v1beta1.ServiceBinding, externalID string, err error) {
+	msg := fmt.Sprintf(`The broker refused to unbind the retired credentials %q, which have to be revoked at the broker: %s`, externalID, err)
+	c.recorder.Event(binding, corev1.EventTypeWarning, errorRetiredCredentialsUnrevokedReason, msg)
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionRetiredCredentialsUnrevoked, v1beta1.ConditionTrue, errorRetiredCredentialsUnrevokedReason, msg)
+}
+
 // isClusterServicePlanBindable returns whether the given ClusterServiceClass and ClusterServicePlan
 // combination is bindable.  Plans may override the service-level bindable
 // attribute, so if the plan provides a value, return that value.  Otherwise,
@@ -514,7 +714,7 @@ func isServicePlanBindable(serviceClass *v1beta1.ServiceClass, plan *v1beta1.Ser
 	return serviceClass.Spec.Bindable
 }
 
-func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
+func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}, volumeMounts []interface{}) error {
 	pcb := pretty.NewBindingContextBuilder(binding)
 	glog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
 		binding.Namespace, binding.Spec.SecretName, len(credentials),
@@ -525,15 +725,30 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
 	}
 
-	secretData := make(map[string][]byte)
-	for k, v := range credentials {
-		var err error
-		secretData[k], err = serialize(v)
-		if err != nil {
-			return fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+	// Non-sensitive credentials requested to be in a ConfigMap are moved out
+	// of the credentials before they are laid out in the Secret.
+	var configMapData map[string]string
+	if binding.Spec.ConfigMap != nil {
+		configMapData = make(map[string]string)
+		for _, k := range binding.Spec.ConfigMap.Keys {
+			v, ok := credentials[k]
+			if !ok {
+				continue
+			}
+			value, err := serialize(v)
+			if err != nil {
+				return fmt.Errorf("Unable to serialize value for credential key %q: %s", k, err)
+			}
+			configMapData[k] = string(value)
+			delete(credentials, k)
 		}
 	}
 
+	secretData, err := serializeCredentials(binding.Spec.SecretFormat, credentials)
+	if err != nil {
+		return err
+	}
+
 	// Creating/updating the Secret
 	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
 	existingSecret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
@@ -582,9 +797,77 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		}
 	}
 
+	if binding.Spec.ConfigMap != nil {
+		if err := c.injectServiceBindingConfigMap(binding, configMapData); err != nil {
+			return err
+		}
+	}
+
+	if err := c.injectServiceBindingVolumeMounts(binding, volumeMounts); err != nil {
+		return err
+	}
+
+	if shouldInjectServiceBindingWorkloads(binding) {
+		return c.injectServiceBindingWorkloads(binding)
+	}
+
 	return err
 }
 
+func (c *controller) injectServiceBindingConfigMap(binding *v1beta1.ServiceBinding, data map[string]string) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	glog.V(5).Info(pcb.Messagef(`Creating/updating ConfigMap "%s/%s" with %d keys`,
+		binding.Namespace, binding.Spec.ConfigMap.Name, len(data),
+	))
+
+	configMapClient := c.kubeClient.CoreV1().ConfigMaps(binding.Namespace)
+	existingConfigMap, err := configMapClient.Get(binding.Spec.ConfigMap.Name, metav1.GetOptions{})
+	if err == nil {
+		// Update existing config map
+		if !metav1.IsControlledBy(existingConfigMap, binding) {
+			controllerRef := metav1.GetControllerOf(existingConfigMap)
+			return fmt.Errorf(`ConfigMap "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingConfigMap.Name, controllerRef)
+		}
+		existingConfigMap.Data = data
+		_, err = configMapClient.Update(existingConfigMap)
+		if err != nil {
+			if apierrors.IsConflict(err) {
+				// Conflicting update detected, try again later
+				return fmt.Errorf(`Conflicting ConfigMap "%s/%s" update detected`, binding.Namespace, existingConfigMap.Name)
+			}
+			return fmt.Errorf(`Unexpected error updating ConfigMap "%s/%s": %v`, binding.Namespace, existingConfigMap.Name, err)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		// Terminal error
+		return fmt.Errorf(`Unexpected error getting ConfigMap "%s/%s": %v`, binding.Namespace, binding.Spec.ConfigMap.Name, err)
+	}
+
+	// Create new config map
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      binding.Spec.ConfigMap.Name,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Data: data,
+	}
+	if _, err := configMapClient.Create(configMap); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Concurrent controller has created config map under the same name,
+			// Update the config map at the next retry iteration
+			return fmt.Errorf(`Conflicting ConfigMap "%s/%s" creation detected`, binding.Namespace, configMap.Name)
+		}
+		// Terminal error
+		return fmt.Errorf(`Unexpected error creating ConfigMap "%s/%s": %v`, binding.Namespace, configMap.Name, err)
+	}
+
+	return nil
+}
+
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
 	for _, t := range transforms {
 		switch {
@@ -620,11 +903,76 @@ func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform,
 			}
 		case t.RemoveKey != nil:
 			delete(credentials, t.RemoveKey.Key)
+		case t.RenderTemplate != nil:
+			value, err := evaluateTemplate(t.RenderTemplate.Template, credentials)
+			if err != nil {
+				return err
+			}
+			credentials[t.RenderTemplate.Key] = value
 		}
 	}
 	return nil
 }
 
+// maxTemplateOutputSize limits the output of a template of a
+// RenderTemplateTransform to the maximum size of a Secret.
+const maxTemplateOutputSize = 1024 * 1024
+
+// templateExecutionTimeout limits how long a template of a
+// RenderTemplateTransform may run.
+var templateExecutionTimeout = time.Second
+
+// templateWriter collects the output of a template, failing the execution
+// of the template once the output exceeds maxTemplateOutputSize or the
+// deadline has passed.
+type templateWriter struct {
+	buf      bytes.Buffer
+	deadline time.Time
+}
+
+func (w *templateWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > maxTemplateOutputSize {
+		return 0, fmt.Errorf("template output exceeds %d bytes", maxTemplateOutputSize)
+	}
+	if time.Now().After(w.deadline) {
+		return 0, fmt.Errorf("template execution exceeded %v", templateExecutionTimeout)
+	}
+	return w.buf.Write(p)
+}
+
+func evaluateTemplate(text string, credentials map[string]interface{}) (string, error) {
+	tmpl, err := template.New("template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	// Values merged from other Secrets are raw bytes; render them as the
+	// strings they will appear as in the credentials Secret.
+	data := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		data[k] = v
+	}
+
+	// Templates cannot be interrupted, so the template runs in its own
+	// goroutine, which stops at its next write once the deadline has passed.
+	w := &templateWriter{deadline: time.Now().Add(templateExecutionTimeout)}
+	done := make(chan error, 1)
+	go func() {
+		done <- tmpl.Execute(w, data)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return w.buf.String(), nil
+	case <-time.After(templateExecutionTimeout):
+		return "", fmt.Errorf("template execution exceeded %v", templateExecutionTimeout)
+	}
+}
+
 func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (string, error) {
 	j := jsonpath.New("expression")
 	buf := new(bytes.Buffer)
@@ -640,6 +988,15 @@ func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (stri
 func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error {
 	var err error
 	pcb := pretty.NewBindingContextBuilder(binding)
+
+	// The workloads stop referencing the Secret before it is deleted.
+	if shouldInjectServiceBindingWorkloads(binding) {
+		glog.V(5).Info(pcb.Message("Removing Secret from injected workloads"))
+		if err = c.ejectServiceBindingWorkloads(binding); err != nil {
+			return err
+		}
+	}
+
 	glog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`,
 		binding.Namespace, binding.Spec.SecretName,
 	))
@@ -648,7 +1005,17 @@ func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error
 		return err
 	}
 
-	return nil
+	if binding.Spec.ConfigMap != nil {
+		glog.V(5).Info(pcb.Messagef(`Deleting ConfigMap "%s/%s"`,
+			binding.Namespace, binding.Spec.ConfigMap.Name,
+		))
+		err = c.kubeClient.CoreV1().ConfigMaps(binding.Namespace).Delete(binding.Spec.ConfigMap.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return c.ejectServiceBindingVolumeMounts(binding)
 }
 
 // setServiceBindingCondition sets a single condition on a ServiceBinding's
@@ -668,6 +1035,20 @@ func setServiceBindingCondition(toUpdate *v1beta1.ServiceBinding,
 	setServiceBindingConditionInternal(toUpdate, conditionType, status, reason, message, metav1.Now())
 }
 
+// setServiceBindingConditionFieldErrors sets the field errors of the
+// condition of the given type, which setServiceBindingCondition clears
+// whenever it sets the condition.
+func setServiceBindingConditionFieldErrors(toUpdate *v1beta1.ServiceBinding,
+	conditionType v1beta1.ServiceBindingConditionType,
+	fieldErrors []v1beta1.ConditionFieldError) {
+	for i := range toUpdate.Status.Conditions {
+		if toUpdate.Status.Conditions[i].Type == conditionType {
+			toUpdate.Status.Conditions[i].FieldErrors = fieldErrors
+			return
+		}
+	}
+}
+
 // setServiceBindingConditionInternal is
 // setServiceBindingCondition but allows the time to be parameterized
 // for testing.
@@ -840,6 +1221,20 @@ func (c *controller) requeueServiceBindingForPoll(key string) error {
 	return nil
 }
 
+// requeueServiceBindingAfter adds the key for the given binding to the
+// controller's binding queue once the given duration has passed.
+func (c *controller) requeueServiceBindingAfter(binding *v1beta1.ServiceBinding, duration time.Duration) error {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(binding)
+	if err != nil {
+		glog.Errorf("Couldn't create a key for object %+v: %v", binding, err)
+		return fmt.Errorf("Couldn't create a key for object %+v: %v", binding, err)
+	}
+
+	c.bindingQueue.AddAfter(key, duration)
+
+	return nil
+}
+
 // beginPollingServiceBinding does a rate-limited add of the key for the given
 // binding to the controller's binding polling queue.
 func (c *controller) beginPollingServiceBinding(binding *v1beta1.ServiceBinding) error {
@@ -849,6 +1244,7 @@ func (c *controller) beginPollingServiceBinding(binding *v1beta1.ServiceBinding)
 		return fmt.Errorf("Couldn't create a key for object %+v: %v", binding, err)
 	}
 
+	c.bindingPollingRateLimiter.setPollingPolicy(key, c.getServiceBindingBrokerPollingPolicy(binding))
 	c.bindingPollingQueue.AddRateLimited(key)
 
 	return nil
@@ -860,6 +1256,39 @@ func (c *controller) continuePollingServiceBinding(binding *v1beta1.ServiceBindi
 	return c.beginPollingServiceBinding(binding)
 }
 
+// setServiceBindingPollDelay makes the next poll of the binding wait for the
+// delay the broker asked for, if it asked for one.
+func (c *controller) setServiceBindingPollDelay(binding *v1beta1.ServiceBinding, delay *time.Duration) {
+	if delay == nil {
+		return
+	}
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(binding); err == nil {
+		c.bindingPollingRateLimiter.setPollDelay(key, *delay)
+	}
+}
+
+// pollBindingLastOperation polls the last operation of a binding and returns
+// the delay the broker asked for before the next poll, if the client reports
+// it.
+func pollBindingLastOperation(brokerClient osb.Client, request *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error) {
+	if client, ok := brokerClient.(osbclientproxy.PollDelayClient); ok {
+		return client.PollBindingLastOperationWithDelay(request)
+	}
+	response, err := brokerClient.PollBindingLastOperation(request)
+	return response, nil, err
+}
+
+// getServiceBindingBrokerPollingPolicy returns the polling policy of the
+// broker of the instance of the binding, or nil if the broker has none or
+// cannot be found.
+func (c *controller) getServiceBindingBrokerPollingPolicy(binding *v1beta1.ServiceBinding) *v1beta1.ServiceBrokerPollingPolicy {
+	instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return nil
+	}
+	return c.getServiceInstanceBrokerPollingPolicy(instance)
+}
+
 // finishPollingServiceBinding removes the binding's key from the controller's
 // binding polling queue.
 func (c *controller) finishPollingServiceBinding(binding *v1beta1.ServiceBinding) error {
@@ -880,9 +1309,9 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 	binding = binding.DeepCopy()
 
-	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
 	if err != nil {
-		msg := fmt.Sprintf(`References a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.Namespace, binding.Spec.ServiceInstanceRef.Name)
+		msg := fmt.Sprintf(`References a non-existent %s "%s/%s"`, pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonexistentServiceInstanceReason, msg)
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
@@ -905,7 +1334,11 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 	glog.V(5).Info(pcb.Message("Polling last operation"))
 
-	response, err := brokerClient.PollBindingLastOperation(request)
+	response, pollDelay, err := pollBindingLastOperation(brokerClient, request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		c.setServiceBindingPollDelay(binding, &limitedErr.RetryAfter)
+		return c.continuePollingServiceBinding(binding)
+	}
 	if err != nil {
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec.
@@ -963,6 +1396,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		}
 
 		glog.V(4).Info(pcb.Message("Last operation not completed (still in progress)"))
+		c.setServiceBindingPollDelay(binding, pollDelay)
 		return c.continuePollingServiceBinding(binding)
 	case osb.StateSucceeded:
 		if deleting {
@@ -983,6 +1417,10 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 		// TODO(mkibbe): Break this logic out so that GET and inject are retried separately on error
 		getBindingResponse, err := brokerClient.GetBinding(getBindingRequest)
+		if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+			c.setServiceBindingPollDelay(binding, &limitedErr.RetryAfter)
+			return c.continuePollingServiceBinding(binding)
+		}
 		if err != nil {
 			reason := errorFetchingBindingFailedReason
 			msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
@@ -996,7 +1434,10 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 			return c.finishPollingServiceBinding(binding)
 		}
 
-		if err := c.injectServiceBinding(binding, getBindingResponse.Credentials); err != nil {
+		binding.Status.SyslogDrainURL = getBindingResponse.SyslogDrainURL
+		binding.Status.RouteServiceURL = getBindingResponse.RouteServiceURL
+
+		if err := c.injectServiceBinding(binding, getBindingResponse.Credentials, getBindingResponse.VolumeMounts); err != nil {
 			reason := errorInjectingBindResultReason
 			msg := fmt.Sprintf("Error injecting bind results: %v", err)
 
@@ -1143,6 +1584,7 @@ func (c *controller) prepareBindRequest(
 	var scExternalID string
 	var spExternalID string
 	var scBindingRetrievable bool
+	var parameterSchema *runtime.RawExtension
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -1165,6 +1607,7 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		parameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
 
 	} else if instance.Spec.ServiceClassSpecified() {
 
@@ -1187,18 +1630,32 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		parameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
 	}
 
-	ns, err := c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
+	// The app GUID identifies the namespace of the binding, which is not
+	// the namespace of the instance when the instance is shared.
+	ns, err := c.kubeClient.CoreV1().Namespaces().Get(binding.Namespace, metav1.GetOptions{})
 	if err != nil {
 		return nil, nil, &operationError{
 			reason:  errorFindingNamespaceServiceInstanceReason,
-			message: fmt.Sprintf(`Failed to get namespace %q during binding: %s`, instance.Namespace, err),
+			message: fmt.Sprintf(`Failed to get namespace %q during binding: %s`, binding.Namespace, err),
+		}
+	}
+	instanceNamespace := ns
+	if instance.Namespace != binding.Namespace {
+		instanceNamespace, err = c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, &operationError{
+				reason:  errorFindingNamespaceServiceInstanceReason,
+				message: fmt.Sprintf(`Failed to get namespace %q during binding: %s`, instance.Namespace, err),
+			}
 		}
 	}
 
 	parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
-		c.kubeClient,
+		clientParametersSources{c.kubeClient},
+		c.bindingLister,
 		binding.Namespace,
 		binding.Spec.Parameters,
 		binding.Spec.ParametersFrom,
@@ -1209,6 +1666,9 @@ func (c *controller) prepareBindRequest(
 			message: err.Error(),
 		}
 	}
+	if err := validateParameters(parameters, parameterSchema); err != nil {
+		return nil, nil, err
+	}
 
 	inProgressProperties := &v1beta1.ServiceBindingPropertiesState{
 		Parameters:         rawParametersWithRedaction,
@@ -1225,6 +1685,7 @@ func (c *controller) prepareBindRequest(
 		AppGUID:      &appGUID,
 		Parameters:   parameters,
 		BindResource: &osb.BindResource{AppGUID: &appGUID},
+		Context:      c.buildRequestContext(instance, instanceNamespace),
 	}
 
 	// Asynchronous binding operations are currently ALPHA and not
@@ -1386,6 +1847,7 @@ func (c *controller) prepareServiceBindingLastOperationRequest(
 func (c *controller) processServiceBindingOperationError(binding *v1beta1.ServiceBinding, readyCond *v1beta1.ServiceBindingCondition) error {
 	c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
 	setServiceBindingCondition(binding, readyCond.Type, readyCond.Status, readyCond.Reason, readyCond.Message)
+	setServiceBindingConditionFieldErrors(binding, readyCond.Type, readyCond.FieldErrors)
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
 		return err
 	}
@@ -1401,15 +1863,35 @@ func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
 	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
+	rotated := startServiceBindingRetiredCredentialsGracePeriod(binding)
 
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
 		return err
 	}
 
 	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	if rotated {
+		return c.requeueServiceBindingAfter(binding, c.bindingRotationGracePeriod)
+	}
 	return nil
 }
 
+// startServiceBindingRetiredCredentialsGracePeriod marks the retired
+// credentials of the given binding that were waiting for their replacement to
+// be injected as retired as of now, and returns whether there were any. The
+// Status is *not* recorded in the registry.
+func startServiceBindingRetiredCredentialsGracePeriod(binding *v1beta1.ServiceBinding) bool {
+	started := false
+	now := metav1.Now()
+	for i := range binding.Status.RetiredCredentials {
+		if binding.Status.RetiredCredentials[i].RetiredTime == nil {
+			binding.Status.RetiredCredentials[i].RetiredTime = &now
+			started = true
+		}
+	}
+	return started
+}
+
 // processBindFailure handles the logging and updating of a ServiceBinding that
 // hit a terminal failure during bind reconciliation.
 func (c *controller) processBindFailure(binding *v1beta1.ServiceBinding, readyCond, failedCond *v1beta1.ServiceBindingCondition, shouldMitigateOrphan bool) error {
@@ -1465,6 +1947,7 @@ func (c *controller) processBindAsyncResponse(binding *v1beta1.ServiceBinding, r
 func (c *controller) handleServiceBindingReconciliationError(binding *v1beta1.ServiceBinding, err error) error {
 	if resourceErr, ok := err.(*operationError); ok {
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, resourceErr.reason, resourceErr.message)
+		readyCond.FieldErrors = resourceErr.fieldErrors
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 	return err
@@ -134,6 +134,7 @@ func TestReconcileServiceBindingWithParametersNamespacedRefs(t *testing.T) {
 		BindResource: &osb.BindResource{
 			AppGUID: strPtr(testNamespaceGUID),
 		},
+		Context: testContext,
 	})
 
 	actions := fakeCatalogClient.Actions()
@@ -0,0 +1,346 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	volumeMountModeReadOnly = "r"
+
+	volumeMountNotTranslatedReason string = "VolumeMountNotTranslated"
+
+	volumeMountFromServiceBrokerMessage string = "Volume mounts of namespaced ServiceBrokers are not translated into PersistentVolumes"
+)
+
+// volumeMountCapacity is the capacity of the PersistentVolumes and
+// PersistentVolumeClaims of volume mounts. Brokers do not report the size of
+// shared devices, and shared file systems do not enforce it, so it is only
+// there because the API requires one.
+var volumeMountCapacity = resource.MustParse("1Gi")
+
+// brokerVolumeMount is a volume mount as returned by a broker in the
+// volume_mounts of a bind response.
+type brokerVolumeMount struct {
+	Driver       string             `json:"driver"`
+	ContainerDir string             `json:"container_dir"`
+	Mode         string             `json:"mode"`
+	DeviceType   string             `json:"device_type"`
+	Device       brokerVolumeDevice `json:"device"`
+}
+
+// brokerVolumeDevice is the device of a brokerVolumeMount.
+type brokerVolumeDevice struct {
+	VolumeID    string                 `json:"volume_id"`
+	MountConfig map[string]interface{} `json:"mount_config,omitempty"`
+}
+
+// parseBrokerVolumeMounts converts the free-form volume mounts of a bind
+// response into brokerVolumeMounts.
+func parseBrokerVolumeMounts(volumeMounts []interface{}) ([]brokerVolumeMount, error) {
+	parsed := make([]brokerVolumeMount, 0, len(volumeMounts))
+	for i, v := range volumeMounts {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("volume mount %d: %v", i, err)
+		}
+		var m brokerVolumeMount
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("volume mount %d: %v", i, err)
+		}
+		if m.Driver == "" || m.ContainerDir == "" {
+			return nil, fmt.Errorf("volume mount %d: driver and container_dir are required", i)
+		}
+		parsed = append(parsed, m)
+	}
+	return parsed, nil
+}
+
+// persistentVolumeSourceForVolumeMount returns the PersistentVolume source and
+// mount options of the device of a volume mount, or a nil source if its
+// driver is not one that can be translated. The NFS drivers of Cloud Foundry
+// describe the export as a "source" URL of the form nfs://server/path.
+func persistentVolumeSourceForVolumeMount(m brokerVolumeMount) (*corev1.PersistentVolumeSource, []string, error) {
+	switch m.Driver {
+	case "nfsdriver", "nfsv3driver":
+		source, _ := m.Device.MountConfig["source"].(string)
+		u, err := url.Parse(source)
+		if err != nil || u.Scheme != "nfs" || u.Hostname() == "" {
+			return nil, nil, fmt.Errorf("invalid NFS source %q of volume %q", source, m.Device.VolumeID)
+		}
+		path := u.Path
+		if path == "" {
+			path = "/"
+		}
+		var mountOptions []string
+		if version, ok := m.Device.MountConfig["version"].(string); ok && version != "" {
+			mountOptions = append(mountOptions, "nfsvers="+version)
+		}
+		return &corev1.PersistentVolumeSource{
+			NFS: &corev1.NFSVolumeSource{
+				Server:   u.Hostname(),
+				Path:     path,
+				ReadOnly: m.Mode == volumeMountModeReadOnly,
+			},
+		}, mountOptions, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// volumeMountPersistentVolumeName returns the name of the PersistentVolume
+// for the volume mount of the binding at the given index. PersistentVolumes
+// are not namespaced, so the name is derived from the UID of the binding.
+func volumeMountPersistentVolumeName(binding *v1beta1.ServiceBinding, index int) string {
+	return fmt.Sprintf("servicebinding-%s-%d", binding.UID, index)
+}
+
+// volumeMountClaimName returns the name of the PersistentVolumeClaim for the
+// volume mount of the binding at the given index.
+func volumeMountClaimName(binding *v1beta1.ServiceBinding, index int) string {
+	suffix := fmt.Sprintf("-volume-%d", index)
+	name := binding.Name
+	if len(name)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		name = name[:validation.DNS1123SubdomainMaxLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// injectServiceBindingVolumeMounts records the volume mounts returned by the
+// broker in the Status of the binding, which is *not* recorded in the
+// registry. If the BindingVolumeMounts feature is enabled, the volume mounts
+// whose driver can be translated get a PersistentVolume and a
+// PersistentVolumeClaim bound to it, which workloads in the namespace of the
+// binding can mount. PersistentVolumes are cluster-wide and may point anywhere,
+// so only the volume mounts returned by ClusterServiceBrokers, which cluster
+// administrators register, are translated.
+func (c *controller) injectServiceBindingVolumeMounts(binding *v1beta1.ServiceBinding, volumeMounts []interface{}) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	parsed, err := parseBrokerVolumeMounts(volumeMounts)
+	if err != nil {
+		return fmt.Errorf("Invalid volume mounts returned by the broker: %v", err)
+	}
+
+	translate := false
+	if len(parsed) > 0 && utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingVolumeMounts) {
+		instance, err := c.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
+		if err != nil {
+			return fmt.Errorf(`Unexpected error getting ServiceInstance "%s/%s": %v`, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name, err)
+		}
+		translate = instance.Spec.ClusterServiceClassSpecified()
+		if !translate {
+			glog.V(4).Info(pcb.Message(volumeMountFromServiceBrokerMessage))
+			c.recorder.Event(binding, corev1.EventTypeWarning, volumeMountNotTranslatedReason, volumeMountFromServiceBrokerMessage)
+		}
+	}
+
+	var recorded []v1beta1.ServiceBindingVolumeMount
+	for i, m := range parsed {
+		volumeMount := v1beta1.ServiceBindingVolumeMount{
+			Driver:       m.Driver,
+			ContainerDir: m.ContainerDir,
+			Mode:         m.Mode,
+			DeviceType:   m.DeviceType,
+			VolumeID:     m.Device.VolumeID,
+		}
+		if translate {
+			source, mountOptions, err := persistentVolumeSourceForVolumeMount(m)
+			if err != nil {
+				return err
+			}
+			if source == nil {
+				msg := fmt.Sprintf("Volume %q of driver %q cannot be translated into a PersistentVolume", m.Device.VolumeID, m.Driver)
+				glog.V(4).Info(pcb.Message(msg))
+				c.recorder.Event(binding, corev1.EventTypeWarning, volumeMountNotTranslatedReason, msg)
+			} else {
+				if err := c.createServiceBindingVolume(binding, i, m, source, mountOptions); err != nil {
+					return err
+				}
+				volumeMount.PersistentVolumeClaimName = volumeMountClaimName(binding, i)
+			}
+		}
+		recorded = append(recorded, volumeMount)
+	}
+
+	// Volumes of a previous bind result that are not returned anymore are
+	// removed.
+	for i := len(recorded); i < len(binding.Status.VolumeMounts); i++ {
+		if err := c.deleteServiceBindingVolume(binding, i, binding.Status.VolumeMounts[i]); err != nil {
+			return err
+		}
+	}
+
+	binding.Status.VolumeMounts = recorded
+	return nil
+}
+
+// createServiceBindingVolume creates the PersistentVolume for the volume mount
+// of the binding at the given index, and the PersistentVolumeClaim bound to
+// it. Existing objects of the binding are kept, unless the volume of the
+// broker changed, for instance after the credentials of the binding were
+// rotated; the PersistentVolume and its claim are then deleted, and an error
+// is returned so that they are created again once they are gone.
+func (c *controller) createServiceBindingVolume(binding *v1beta1.ServiceBinding, index int, m brokerVolumeMount, source *corev1.PersistentVolumeSource, mountOptions []string) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	volumeName := volumeMountPersistentVolumeName(binding, index)
+	claimName := volumeMountClaimName(binding, index)
+
+	accessMode := corev1.ReadWriteMany
+	if m.Mode == volumeMountModeReadOnly {
+		accessMode = corev1.ReadOnlyMany
+	}
+	noStorageClass := ""
+
+	glog.V(5).Info(pcb.Messagef(`Creating PersistentVolume %q and PersistentVolumeClaim "%s/%s" for volume %q`,
+		volumeName, binding.Namespace, claimName, m.Device.VolumeID,
+	))
+
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: volumeName},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: volumeMountCapacity},
+			PersistentVolumeSource:        *source,
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{accessMode},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			MountOptions:                  mountOptions,
+			ClaimRef: &corev1.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Namespace: binding.Namespace,
+				Name:      claimName,
+			},
+		},
+	}
+	_, err := c.kubeClient.CoreV1().PersistentVolumes().Create(volume)
+	if apierrors.IsAlreadyExists(err) {
+		var existingVolume *corev1.PersistentVolume
+		existingVolume, err = c.kubeClient.CoreV1().PersistentVolumes().Get(volumeName, metav1.GetOptions{})
+		if err == nil {
+			claimRef := existingVolume.Spec.ClaimRef
+			if claimRef == nil || claimRef.Namespace != binding.Namespace || claimRef.Name != claimName {
+				return fmt.Errorf(`PersistentVolume %q is not reserved for PersistentVolumeClaim "%s/%s"`, volumeName, binding.Namespace, claimName)
+			}
+			if existingVolume.DeletionTimestamp != nil {
+				return fmt.Errorf(`PersistentVolume %q of volume %q is being deleted`, volumeName, m.Device.VolumeID)
+			}
+			if !apiequality.Semantic.DeepEqual(existingVolume.Spec.PersistentVolumeSource, volume.Spec.PersistentVolumeSource) ||
+				!apiequality.Semantic.DeepEqual(existingVolume.Spec.MountOptions, volume.Spec.MountOptions) ||
+				!apiequality.Semantic.DeepEqual(existingVolume.Spec.AccessModes, volume.Spec.AccessModes) {
+				glog.V(4).Info(pcb.Messagef(`Replacing PersistentVolume %q, volume %q changed`, volumeName, m.Device.VolumeID))
+				if err := c.deleteServiceBindingVolumeObjects(binding, claimName, volumeName); err != nil {
+					return err
+				}
+				return fmt.Errorf(`PersistentVolume %q of volume %q changed and is being replaced`, volumeName, m.Device.VolumeID)
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf(`Unexpected error creating PersistentVolume %q: %v`, volumeName, err)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: volumeMountCapacity},
+			},
+			VolumeName:       volumeName,
+			StorageClassName: &noStorageClass,
+		},
+	}
+	claimClient := c.kubeClient.CoreV1().PersistentVolumeClaims(binding.Namespace)
+	if _, err := claimClient.Create(claim); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf(`Unexpected error creating PersistentVolumeClaim "%s/%s": %v`, binding.Namespace, claimName, err)
+		}
+		existingClaim, err := claimClient.Get(claimName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf(`Unexpected error getting PersistentVolumeClaim "%s/%s": %v`, binding.Namespace, claimName, err)
+		}
+		if !metav1.IsControlledBy(existingClaim, binding) {
+			controllerRef := metav1.GetControllerOf(existingClaim)
+			return fmt.Errorf(`PersistentVolumeClaim "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, claimName, controllerRef)
+		}
+	}
+	return nil
+}
+
+// ejectServiceBindingVolumeMounts deletes the PersistentVolumeClaims and
+// PersistentVolumes of all the volume mounts of the binding, and clears the
+// volume mounts in the Status, which is *not* recorded in the registry.
+func (c *controller) ejectServiceBindingVolumeMounts(binding *v1beta1.ServiceBinding) error {
+	for i, volumeMount := range binding.Status.VolumeMounts {
+		if err := c.deleteServiceBindingVolume(binding, i, volumeMount); err != nil {
+			return err
+		}
+	}
+	binding.Status.VolumeMounts = nil
+	return nil
+}
+
+// deleteServiceBindingVolume deletes the PersistentVolumeClaim and the
+// PersistentVolume of the volume mount of the binding at the given index, if
+// it was translated into them.
+func (c *controller) deleteServiceBindingVolume(binding *v1beta1.ServiceBinding, index int, volumeMount v1beta1.ServiceBindingVolumeMount) error {
+	if volumeMount.PersistentVolumeClaimName == "" {
+		return nil
+	}
+	pcb := pretty.NewBindingContextBuilder(binding)
+	volumeName := volumeMountPersistentVolumeName(binding, index)
+
+	glog.V(5).Info(pcb.Messagef(`Deleting PersistentVolumeClaim "%s/%s" and PersistentVolume %q`,
+		binding.Namespace, volumeMount.PersistentVolumeClaimName, volumeName,
+	))
+	return c.deleteServiceBindingVolumeObjects(binding, volumeMount.PersistentVolumeClaimName, volumeName)
+}
+
+// deleteServiceBindingVolumeObjects deletes the given PersistentVolumeClaim of
+// the binding and the given PersistentVolume, if they exist.
+func (c *controller) deleteServiceBindingVolumeObjects(binding *v1beta1.ServiceBinding, claimName, volumeName string) error {
+	err := c.kubeClient.CoreV1().PersistentVolumeClaims(binding.Namespace).Delete(claimName, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = c.kubeClient.CoreV1().PersistentVolumes().Delete(volumeName, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+const testServiceBindingUID = "binding-uid"
+
+func getTestNFSVolumeMount() map[string]interface{} {
+	return map[string]interface{}{
+		"driver":        "nfsv3driver",
+		"container_dir": "/data",
+		"mode":          "rw",
+		"device_type":   "shared",
+		"device": map[string]interface{}{
+			"volume_id": "nfs-volume",
+			"mount_config": map[string]interface{}{
+				"source":  "nfs://nfs.example.com/exports/data",
+				"version": "4.1",
+			},
+		},
+	}
+}
+
+func getTestSMBVolumeMount() map[string]interface{} {
+	return map[string]interface{}{
+		"driver":        "smbdriver",
+		"container_dir": "/share",
+		"mode":          "r",
+		"device_type":   "shared",
+		"device": map[string]interface{}{
+			"volume_id": "smb-volume",
+			"mount_config": map[string]interface{}{
+				"source":   "//smb.example.com/share",
+				"password": "secret",
+			},
+		},
+	}
+}
+
+// TestPersistentVolumeSourceForVolumeMount tests the translation of the
+// devices of volume mounts into PersistentVolume sources.
+func TestPersistentVolumeSourceForVolumeMount(t *testing.T) {
+	cases := []struct {
+		name                 string
+		volumeMount          map[string]interface{}
+		expectedSource       *corev1.PersistentVolumeSource
+		expectedMountOptions []string
+		expectedError        bool
+	}{
+		{
+			name:        "nfs",
+			volumeMount: getTestNFSVolumeMount(),
+			expectedSource: &corev1.PersistentVolumeSource{
+				NFS: &corev1.NFSVolumeSource{Server: "nfs.example.com", Path: "/exports/data"},
+			},
+			expectedMountOptions: []string{"nfsvers=4.1"},
+		},
+		{
+			name: "read-only nfs",
+			volumeMount: func() map[string]interface{} {
+				m := getTestNFSVolumeMount()
+				m["mode"] = "r"
+				delete(m["device"].(map[string]interface{})["mount_config"].(map[string]interface{}), "version")
+				return m
+			}(),
+			expectedSource: &corev1.PersistentVolumeSource{
+				NFS: &corev1.NFSVolumeSource{Server: "nfs.example.com", Path: "/exports/data", ReadOnly: true},
+			},
+		},
+		{
+			name: "nfs without source",
+			volumeMount: func() map[string]interface{} {
+				m := getTestNFSVolumeMount()
+				m["device"].(map[string]interface{})["mount_config"] = map[string]interface{}{}
+				return m
+			}(),
+			expectedError: true,
+		},
+		{
+			name:        "unsupported driver",
+			volumeMount: getTestSMBVolumeMount(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseBrokerVolumeMounts([]interface{}{tc.volumeMount})
+			if err != nil {
+				t.Fatalf("unexpected error parsing volume mount: %v", err)
+			}
+			source, mountOptions, err := persistentVolumeSourceForVolumeMount(parsed[0])
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedSource, source) {
+				t.Fatalf("unexpected source: %s", expectedGot(tc.expectedSource, source))
+			}
+			if !reflect.DeepEqual(tc.expectedMountOptions, mountOptions) {
+				t.Fatalf("unexpected mount options: %s", expectedGot(tc.expectedMountOptions, mountOptions))
+			}
+		})
+	}
+}
+
+// TestParseBrokerVolumeMountsInvalid tests that volume mounts without a
+// driver or container directory are rejected.
+func TestParseBrokerVolumeMountsInvalid(t *testing.T) {
+	m := getTestNFSVolumeMount()
+	delete(m, "container_dir")
+	if _, err := parseBrokerVolumeMounts([]interface{}{m}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+// TestReconcileServiceBindingWithVolumeMounts tests that the CF-specific
+// fields of a bind response are recorded in the Status of the binding, and
+// that the volume mounts that can be translated get a PersistentVolume and a
+// PersistentVolumeClaim.
+func TestReconcileServiceBindingWithVolumeMounts(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingVolumeMounts)); err != nil {
+		t.Fatalf("Failed to enable BindingVolumeMounts feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingVolumeMounts))
+
+	fakeKubeClient, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials:     map[string]interface{}{"a": "b"},
+				SyslogDrainURL:  strPtr("syslog://logs.example.com"),
+				RouteServiceURL: strPtr("https://route.example.com"),
+				VolumeMounts:    []interface{}{getTestNFSVolumeMount(), getTestSMBVolumeMount()},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingUID
+	binding.Spec.SecretName = testServiceBindingSecretName
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	binding = assertUpdateStatus(t, fakeCatalogClient.Actions()[0], binding).(*v1beta1.ServiceBinding)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue)
+
+	if e, a := "syslog://logs.example.com", updatedServiceBinding.Status.SyslogDrainURL; a == nil || *a != e {
+		t.Fatalf("unexpected syslog drain URL: %s", expectedGot(e, a))
+	}
+	if e, a := "https://route.example.com", updatedServiceBinding.Status.RouteServiceURL; a == nil || *a != e {
+		t.Fatalf("unexpected route service URL: %s", expectedGot(e, a))
+	}
+	expectedVolumeMounts := []v1beta1.ServiceBindingVolumeMount{
+		{
+			Driver:                    "nfsv3driver",
+			ContainerDir:              "/data",
+			Mode:                      "rw",
+			DeviceType:                "shared",
+			VolumeID:                  "nfs-volume",
+			PersistentVolumeClaimName: testServiceBindingName + "-volume-0",
+		},
+		{
+			Driver:       "smbdriver",
+			ContainerDir: "/share",
+			Mode:         "r",
+			DeviceType:   "shared",
+			VolumeID:     "smb-volume",
+		},
+	}
+	if !reflect.DeepEqual(expectedVolumeMounts, updatedServiceBinding.Status.VolumeMounts) {
+		t.Fatalf("unexpected volume mounts: %s", expectedGot(expectedVolumeMounts, updatedServiceBinding.Status.VolumeMounts))
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 5)
+	assertActionEquals(t, kubeActions[3], "create", "persistentvolumes")
+	assertActionEquals(t, kubeActions[4], "create", "persistentvolumeclaims")
+
+	volume := kubeActions[3].(clientgotesting.CreateAction).GetObject().(*corev1.PersistentVolume)
+	if e, a := "servicebinding-"+testServiceBindingUID+"-0", volume.Name; e != a {
+		t.Fatalf("unexpected name of PersistentVolume: %s", expectedGot(e, a))
+	}
+	if e, a := corev1.ReadWriteMany, volume.Spec.AccessModes[0]; e != a {
+		t.Fatalf("unexpected access mode of PersistentVolume: %s", expectedGot(e, a))
+	}
+	if e, a := testServiceBindingName+"-volume-0", volume.Spec.ClaimRef.Name; e != a {
+		t.Fatalf("unexpected claim of PersistentVolume: %s", expectedGot(e, a))
+	}
+	claim := kubeActions[4].(clientgotesting.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+	if e, a := volume.Name, claim.Spec.VolumeName; e != a {
+		t.Fatalf("unexpected volume of PersistentVolumeClaim: %s", expectedGot(e, a))
+	}
+	if controllerRef := metav1.GetControllerOf(claim); controllerRef == nil || controllerRef.UID != types.UID(testServiceBindingUID) {
+		t.Fatalf("PersistentVolumeClaim is not owned by the ServiceBinding: %v", controllerRef)
+	}
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 2)
+	expectedEvent := warningEventBuilder(volumeMountNotTranslatedReason).msg(`Volume "smb-volume" of driver "smbdriver" cannot be translated into a PersistentVolume`)
+	if err := checkEventPrefixes(events[:1], expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestEjectServiceBindingVolumeMounts tests that the PersistentVolumeClaims
+// and PersistentVolumes of a binding are deleted when it is ejected.
+func TestEjectServiceBindingVolumeMounts(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingUID
+	binding.Status.VolumeMounts = []v1beta1.ServiceBindingVolumeMount{
+		{Driver: "smbdriver", VolumeID: "smb-volume"},
+		{Driver: "nfsv3driver", VolumeID: "nfs-volume", PersistentVolumeClaimName: "claim"},
+	}
+	if err := testController.ejectServiceBindingVolumeMounts(binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 2)
+	assertActionEquals(t, kubeActions[0], "delete", "persistentvolumeclaims")
+	assertActionEquals(t, kubeActions[1], "delete", "persistentvolumes")
+	if e, a := "claim", kubeActions[0].(clientgotesting.DeleteAction).GetName(); e != a {
+		t.Fatalf("unexpected PersistentVolumeClaim deleted: %s", expectedGot(e, a))
+	}
+	if e, a := "servicebinding-"+testServiceBindingUID+"-1", kubeActions[1].(clientgotesting.DeleteAction).GetName(); e != a {
+		t.Fatalf("unexpected PersistentVolume deleted: %s", expectedGot(e, a))
+	}
+	if binding.Status.VolumeMounts != nil {
+		t.Fatalf("expected the volume mounts to be cleared, got %+v", binding.Status.VolumeMounts)
+	}
+}
+
+// TestInjectServiceBindingVolumeMountsFromServiceBroker tests that the volume
+// mounts returned by a namespaced ServiceBroker are recorded but not
+// translated into PersistentVolumes.
+func TestInjectServiceBindingVolumeMountsFromServiceBroker(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingVolumeMounts)); err != nil {
+		t.Fatalf("Failed to enable BindingVolumeMounts feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingVolumeMounts))
+
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithNamespacedRefs())
+
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingUID
+	if err := testController.injectServiceBindingVolumeMounts(binding, []interface{}{getTestNFSVolumeMount()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+	expectedVolumeMounts := []v1beta1.ServiceBindingVolumeMount{
+		{
+			Driver:       "nfsv3driver",
+			ContainerDir: "/data",
+			Mode:         "rw",
+			DeviceType:   "shared",
+			VolumeID:     "nfs-volume",
+		},
+	}
+	if !reflect.DeepEqual(expectedVolumeMounts, binding.Status.VolumeMounts) {
+		t.Fatalf("unexpected volume mounts: %s", expectedGot(expectedVolumeMounts, binding.Status.VolumeMounts))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(volumeMountNotTranslatedReason).msg(volumeMountFromServiceBrokerMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestInjectServiceBindingVolumeMountsReplacesChangedVolume tests that an
+// existing PersistentVolume of a binding whose volume changed, for instance
+// after its credentials were rotated, is deleted along with its claim so that
+// it is created again.
+func TestInjectServiceBindingVolumeMountsReplacesChangedVolume(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingVolumeMounts)); err != nil {
+		t.Fatalf("Failed to enable BindingVolumeMounts feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingVolumeMounts))
+
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstance())
+
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingUID
+	volumeName := "servicebinding-" + testServiceBindingUID + "-0"
+	claimName := testServiceBindingName + "-volume-0"
+	fakeKubeClient.AddReactor("create", "persistentvolumes", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewAlreadyExists(action.GetResource().GroupResource(), volumeName)
+	})
+	fakeKubeClient.AddReactor("get", "persistentvolumes", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: volumeName},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					NFS: &corev1.NFSVolumeSource{Server: "old-nfs.example.com", Path: "/exports/data"},
+				},
+				AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				MountOptions: []string{"nfsvers=4.1"},
+				ClaimRef:     &corev1.ObjectReference{Namespace: testNamespace, Name: claimName},
+			},
+		}, nil
+	})
+
+	if err := testController.injectServiceBindingVolumeMounts(binding, []interface{}{getTestNFSVolumeMount()}); err == nil {
+		t.Fatal("expected an error while the PersistentVolume is replaced")
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 4)
+	assertActionEquals(t, kubeActions[0], "create", "persistentvolumes")
+	assertActionEquals(t, kubeActions[1], "get", "persistentvolumes")
+	assertActionEquals(t, kubeActions[2], "delete", "persistentvolumeclaims")
+	assertActionEquals(t, kubeActions[3], "delete", "persistentvolumes")
+	if e, a := claimName, kubeActions[2].(clientgotesting.DeleteAction).GetName(); e != a {
+		t.Fatalf("unexpected PersistentVolumeClaim deleted: %s", expectedGot(e, a))
+	}
+	if e, a := volumeName, kubeActions[3].(clientgotesting.DeleteAction).GetName(); e != a {
+		t.Fatalf("unexpected PersistentVolume deleted: %s", expectedGot(e, a))
+	}
+}
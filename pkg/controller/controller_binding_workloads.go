@@ -0,0 +1,352 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	// injectedBindingsAnnotation records the names of the ServiceBindings
+	// whose Secrets have been injected into a workload, separated by commas,
+	// so that only what the controller injected is ever removed again.
+	injectedBindingsAnnotation = "servicecatalog.k8s.io/injected-bindings"
+
+	workloadKindDeployment  = "Deployment"
+	workloadKindStatefulSet = "StatefulSet"
+)
+
+// shouldInjectServiceBindingWorkloads returns whether the Secret of the given
+// binding is injected into workloads.
+func shouldInjectServiceBindingWorkloads(binding *v1beta1.ServiceBinding) bool {
+	return utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingWorkloadInjection) &&
+		binding.Spec.WorkloadInjection != nil
+}
+
+// reconcileServiceBindingWorkloads injects the Secret of a ready binding into
+// the workloads that have been created or labeled since it was last injected,
+// and removes it from the workloads that are no longer selected. The Status
+// is recorded in the registry if the injected workloads changed, and whether
+// it was is returned.
+func (c *controller) reconcileServiceBindingWorkloads(binding *v1beta1.ServiceBinding) (bool, error) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	toUpdate := binding.DeepCopy()
+
+	if err := c.injectServiceBindingWorkloads(toUpdate); err != nil {
+		msg := fmt.Sprintf("Error injecting Secret into workloads: %v", err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorInjectingBindResultReason, msg)
+		return false, err
+	}
+
+	if reflect.DeepEqual(binding.Status.InjectedWorkloads, toUpdate.Status.InjectedWorkloads) {
+		return false, nil
+	}
+	if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// injectServiceBindingWorkloads injects the Secret of the binding into the
+// Deployments and StatefulSets in its namespace that are selected by its
+// workload injection, and removes it from the ones it was injected into
+// before that are no longer selected. The injected workloads are set in the
+// Status, which is *not* recorded in the registry.
+func (c *controller) injectServiceBindingWorkloads(binding *v1beta1.ServiceBinding) error {
+	selector, err := metav1.LabelSelectorAsSelector(&binding.Spec.WorkloadInjection.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid workload selector: %v", err)
+	}
+	injected, err := c.syncServiceBindingWorkloads(binding, selector)
+	if err != nil {
+		return err
+	}
+	binding.Status.InjectedWorkloads = injected
+	return nil
+}
+
+// ejectServiceBindingWorkloads removes the Secret of the binding from all the
+// workloads it was injected into, and clears the injected workloads in the
+// Status, which is *not* recorded in the registry.
+func (c *controller) ejectServiceBindingWorkloads(binding *v1beta1.ServiceBinding) error {
+	if _, err := c.syncServiceBindingWorkloads(binding, labels.Nothing()); err != nil {
+		return err
+	}
+	binding.Status.InjectedWorkloads = nil
+	return nil
+}
+
+// syncServiceBindingWorkloads makes sure that the Secret of the binding is
+// injected into exactly the workloads in its namespace that match the given
+// selector, and returns them.
+func (c *controller) syncServiceBindingWorkloads(binding *v1beta1.ServiceBinding, selector labels.Selector) ([]v1beta1.ServiceBindingWorkload, error) {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	var injected []v1beta1.ServiceBindingWorkload
+
+	deployments, err := c.deploymentLister.Deployments(binding.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf(`Unexpected error listing Deployments in namespace %q: %v`, binding.Namespace, err)
+	}
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+	for _, deployment := range deployments {
+		inject := selector.Matches(labels.Set(deployment.Labels))
+		// Never mutate the shared cache of the informer.
+		toUpdate := deployment.DeepCopy()
+		if setServiceBindingWorkloadInjection(binding, &toUpdate.ObjectMeta, &toUpdate.Spec.Template.Spec, inject) {
+			glog.V(4).Info(pcb.Messagef(`Updating injection of Secret "%s/%s" into Deployment %q`, binding.Namespace, binding.Spec.SecretName, deployment.Name))
+			if _, err := c.kubeClient.AppsV1().Deployments(binding.Namespace).Update(toUpdate); err != nil {
+				return nil, fmt.Errorf(`Unexpected error updating Deployment "%s/%s": %v`, binding.Namespace, deployment.Name, err)
+			}
+		}
+		if inject {
+			injected = append(injected, v1beta1.ServiceBindingWorkload{Kind: workloadKindDeployment, Name: deployment.Name})
+		}
+	}
+
+	statefulSets, err := c.statefulSetLister.StatefulSets(binding.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf(`Unexpected error listing StatefulSets in namespace %q: %v`, binding.Namespace, err)
+	}
+	sort.Slice(statefulSets, func(i, j int) bool { return statefulSets[i].Name < statefulSets[j].Name })
+	for _, statefulSet := range statefulSets {
+		inject := selector.Matches(labels.Set(statefulSet.Labels))
+		toUpdate := statefulSet.DeepCopy()
+		if setServiceBindingWorkloadInjection(binding, &toUpdate.ObjectMeta, &toUpdate.Spec.Template.Spec, inject) {
+			glog.V(4).Info(pcb.Messagef(`Updating injection of Secret "%s/%s" into StatefulSet %q`, binding.Namespace, binding.Spec.SecretName, statefulSet.Name))
+			if _, err := c.kubeClient.AppsV1().StatefulSets(binding.Namespace).Update(toUpdate); err != nil {
+				return nil, fmt.Errorf(`Unexpected error updating StatefulSet "%s/%s": %v`, binding.Namespace, statefulSet.Name, err)
+			}
+		}
+		if inject {
+			injected = append(injected, v1beta1.ServiceBindingWorkload{Kind: workloadKindStatefulSet, Name: statefulSet.Name})
+		}
+	}
+
+	return injected, nil
+}
+
+func (c *controller) workloadAdd(obj interface{}) {
+	c.workloadChanged(obj)
+}
+
+func (c *controller) workloadUpdate(oldObj, newObj interface{}) {
+	if !isResourceVersionChanged(oldObj, newObj) {
+		return
+	}
+	c.workloadChanged(newObj)
+}
+
+func (c *controller) workloadDelete(obj interface{}) {
+	c.workloadChanged(obj)
+}
+
+// workloadChanged enqueues the ServiceBindings in the namespace of a
+// Deployment or StatefulSet that inject their Secret into it, or whose
+// Secret has been injected into it, so that workloads that are created,
+// relabeled or deleted are reconciled without waiting for a resync.
+func (c *controller) workloadChanged(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	workload, err := meta.Accessor(obj)
+	if err != nil {
+		glog.Errorf("Couldn't get object metadata of %+v: %v", obj, err)
+		return
+	}
+	namespace := workload.GetNamespace()
+
+	injectedBindings := sets.NewString()
+	if value := workload.GetAnnotations()[injectedBindingsAnnotation]; value != "" {
+		injectedBindings.Insert(strings.Split(value, ",")...)
+	}
+
+	bindings, err := c.bindingLister.ServiceBindings(namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Couldn't list the ServiceBindings of namespace %q: %v", namespace, err)
+		return
+	}
+	for _, binding := range bindings {
+		if injectedBindings.Has(binding.Name) || workloadInjectionSelects(binding, workload.GetLabels()) {
+			c.bindingAdd(binding)
+		}
+	}
+}
+
+// workloadInjectionSelects returns whether the workload injection of the
+// binding selects a workload with the given labels.
+func workloadInjectionSelects(binding *v1beta1.ServiceBinding, workloadLabels map[string]string) bool {
+	if !shouldInjectServiceBindingWorkloads(binding) {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&binding.Spec.WorkloadInjection.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(workloadLabels))
+}
+
+// setServiceBindingWorkloadInjection injects the Secret of the binding into
+// the pod template of a workload, or removes it if inject is false, and
+// returns whether the workload changed. Only Secrets that the annotation of
+// the workload records as injected are removed.
+func setServiceBindingWorkloadInjection(binding *v1beta1.ServiceBinding, workload *metav1.ObjectMeta, podSpec *corev1.PodSpec, inject bool) bool {
+	injectedBindings := sets.NewString()
+	if value := workload.Annotations[injectedBindingsAnnotation]; value != "" {
+		injectedBindings.Insert(strings.Split(value, ",")...)
+	}
+	if !inject && !injectedBindings.Has(binding.Name) {
+		return false
+	}
+
+	original := podSpec.DeepCopy()
+	setServiceBindingInPodSpec(binding, podSpec, inject)
+	if inject {
+		injectedBindings.Insert(binding.Name)
+	} else {
+		injectedBindings.Delete(binding.Name)
+	}
+
+	changed := !reflect.DeepEqual(original, podSpec)
+	value := strings.Join(injectedBindings.List(), ",")
+	if value != workload.Annotations[injectedBindingsAnnotation] {
+		changed = true
+		if value == "" {
+			delete(workload.Annotations, injectedBindingsAnnotation)
+		} else {
+			if workload.Annotations == nil {
+				workload.Annotations = map[string]string{}
+			}
+			workload.Annotations[injectedBindingsAnnotation] = value
+		}
+	}
+	return changed
+}
+
+// setServiceBindingInPodSpec adds the envFrom source or the volume and volume
+// mounts for the Secret of the binding to all containers of the pod spec, or
+// removes them if inject is false. Entries that are already injected are kept
+// in place, so that the pod template only changes when the injection does.
+func setServiceBindingInPodSpec(binding *v1beta1.ServiceBinding, podSpec *corev1.PodSpec, inject bool) {
+	secretName := binding.Spec.SecretName
+	volumeName := workloadInjectionVolumeName(binding)
+
+	var wantEnvFrom, wantVolume bool
+	var mountPath string
+	if inject {
+		switch binding.Spec.WorkloadInjection.Mode {
+		case v1beta1.ServiceBindingWorkloadInjectionModeVolume:
+			wantVolume = true
+			mountPath = binding.Spec.WorkloadInjection.MountPath
+		default:
+			wantEnvFrom = true
+		}
+	}
+
+	volumes := podSpec.Volumes[:0]
+	hasVolume := false
+	for _, v := range podSpec.Volumes {
+		if v.Name == volumeName {
+			if !wantVolume || hasVolume || v.Secret == nil || v.Secret.SecretName != secretName {
+				continue
+			}
+			hasVolume = true
+		}
+		volumes = append(volumes, v)
+	}
+	if wantVolume && !hasVolume {
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+			},
+		})
+	}
+	podSpec.Volumes = volumes
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+
+		envFrom := container.EnvFrom[:0]
+		hasEnvFrom := false
+		for _, e := range container.EnvFrom {
+			if e.SecretRef != nil && e.SecretRef.Name == secretName && e.Prefix == "" {
+				if !wantEnvFrom || hasEnvFrom {
+					continue
+				}
+				hasEnvFrom = true
+			}
+			envFrom = append(envFrom, e)
+		}
+		if wantEnvFrom && !hasEnvFrom {
+			envFrom = append(envFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				},
+			})
+		}
+		container.EnvFrom = envFrom
+
+		volumeMounts := container.VolumeMounts[:0]
+		hasVolumeMount := false
+		for _, m := range container.VolumeMounts {
+			if m.Name == volumeName {
+				if !wantVolume || hasVolumeMount || m.MountPath != mountPath || !m.ReadOnly || m.SubPath != "" {
+					continue
+				}
+				hasVolumeMount = true
+			}
+			volumeMounts = append(volumeMounts, m)
+		}
+		if wantVolume && !hasVolumeMount {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+		}
+		container.VolumeMounts = volumeMounts
+	}
+}
+
+// workloadInjectionVolumeName returns the name of the volume for the Secret
+// of the binding. Volume names must be DNS labels, while binding names are
+// DNS subdomains, so dots are replaced and the name is truncated.
+func workloadInjectionVolumeName(binding *v1beta1.ServiceBinding) string {
+	name := "servicebinding-" + strings.Replace(binding.Name, ".", "-", -1)
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength], "-")
+	}
+	return name
+}
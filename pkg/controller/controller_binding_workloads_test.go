@@ -0,0 +1,480 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+func getTestServiceBindingWithWorkloadInjection(mode v1beta1.ServiceBindingWorkloadInjectionMode) *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.WorkloadInjection = &v1beta1.ServiceBindingWorkloadInjection{
+		Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		Mode:     mode,
+	}
+	if mode == v1beta1.ServiceBindingWorkloadInjectionModeVolume {
+		binding.Spec.WorkloadInjection.MountPath = "/etc/credentials"
+	}
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+		Type:   v1beta1.ServiceBindingConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	binding.Status.ReconciledGeneration = binding.Generation
+	return binding
+}
+
+func getTestPodTemplateSpec() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "web"},
+				{
+					Name: "sidecar",
+					EnvFrom: []corev1.EnvFromSource{{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func getTestDeployment(name string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels},
+		Spec:       appsv1.DeploymentSpec{Template: getTestPodTemplateSpec()},
+	}
+}
+
+func getTestStatefulSet(name string, labels map[string]string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels},
+		Spec:       appsv1.StatefulSetSpec{Template: getTestPodTemplateSpec()},
+	}
+}
+
+// setTestWorkloadListers sets the workload listers of the controller to ones
+// listing the Deployments and StatefulSets that the fake client holds, the
+// way the informers would once they observed the changes.
+func setTestWorkloadListers(t *testing.T, testController *controller, fakeKubeClient *clientgofake.Clientset) {
+	deploymentIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	deployments, err := fakeKubeClient.AppsV1().Deployments(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range deployments.Items {
+		deploymentIndexer.Add(&deployments.Items[i])
+	}
+	testController.deploymentLister = appslisters.NewDeploymentLister(deploymentIndexer)
+
+	statefulSetIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	statefulSets, err := fakeKubeClient.AppsV1().StatefulSets(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range statefulSets.Items {
+		statefulSetIndexer.Add(&statefulSets.Items[i])
+	}
+	testController.statefulSetLister = appslisters.NewStatefulSetLister(statefulSetIndexer)
+}
+
+func secretEnvFromSource(name string) corev1.EnvFromSource {
+	return corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		},
+	}
+}
+
+// TestSetServiceBindingWorkloadInjection tests that the Secret of a binding
+// is injected into and removed from a pod template, and that reapplying an
+// injection does not change the pod template.
+func TestSetServiceBindingWorkloadInjection(t *testing.T) {
+	envFromBinding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeEnvFrom)
+	volumeBinding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeVolume)
+	volumeName := workloadInjectionVolumeName(volumeBinding)
+
+	cases := []struct {
+		name                string
+		binding             *v1beta1.ServiceBinding
+		annotations         map[string]string
+		modify              func(*corev1.PodSpec)
+		inject              bool
+		expectedChanged     bool
+		expectedAnnotations map[string]string
+		expectedPodSpec     func() corev1.PodSpec
+	}{
+		{
+			name:                "inject envFrom",
+			binding:             envFromBinding,
+			inject:              true,
+			expectedChanged:     true,
+			expectedAnnotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{secretEnvFromSource(testServiceBindingSecretName)}
+				spec.Containers[1].EnvFrom = append(spec.Containers[1].EnvFrom, secretEnvFromSource(testServiceBindingSecretName))
+				return spec
+			},
+		},
+		{
+			name:                "inject volume",
+			binding:             volumeBinding,
+			annotations:         map[string]string{injectedBindingsAnnotation: "other-binding"},
+			inject:              true,
+			expectedChanged:     true,
+			expectedAnnotations: map[string]string{injectedBindingsAnnotation: "other-binding," + testServiceBindingName},
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				spec.Volumes = []corev1.Volume{{
+					Name: volumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: testServiceBindingSecretName},
+					},
+				}}
+				for i := range spec.Containers {
+					spec.Containers[i].VolumeMounts = []corev1.VolumeMount{{Name: volumeName, MountPath: "/etc/credentials", ReadOnly: true}}
+				}
+				return spec
+			},
+		},
+		{
+			name:        "reinject envFrom after other envFrom sources",
+			binding:     envFromBinding,
+			annotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			modify: func(spec *corev1.PodSpec) {
+				for i := range spec.Containers {
+					spec.Containers[i].EnvFrom = append(spec.Containers[i].EnvFrom,
+						secretEnvFromSource(testServiceBindingSecretName), secretEnvFromSource("other-secret"))
+				}
+			},
+			inject:              true,
+			expectedChanged:     false,
+			expectedAnnotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				for i := range spec.Containers {
+					spec.Containers[i].EnvFrom = append(spec.Containers[i].EnvFrom,
+						secretEnvFromSource(testServiceBindingSecretName), secretEnvFromSource("other-secret"))
+				}
+				return spec
+			},
+		},
+		{
+			name:        "switch from envFrom to volume",
+			binding:     volumeBinding,
+			annotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			modify: func(spec *corev1.PodSpec) {
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{secretEnvFromSource(testServiceBindingSecretName)}
+			},
+			inject:              true,
+			expectedChanged:     true,
+			expectedAnnotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{}
+				spec.Volumes = []corev1.Volume{{
+					Name: volumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: testServiceBindingSecretName},
+					},
+				}}
+				for i := range spec.Containers {
+					spec.Containers[i].VolumeMounts = []corev1.VolumeMount{{Name: volumeName, MountPath: "/etc/credentials", ReadOnly: true}}
+				}
+				return spec
+			},
+		},
+		{
+			name:        "remove envFrom",
+			binding:     envFromBinding,
+			annotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			modify: func(spec *corev1.PodSpec) {
+				spec.Containers[1].EnvFrom = append(spec.Containers[1].EnvFrom, secretEnvFromSource(testServiceBindingSecretName))
+			},
+			inject:              false,
+			expectedChanged:     true,
+			expectedAnnotations: map[string]string{},
+			expectedPodSpec: func() corev1.PodSpec {
+				return getTestPodTemplateSpec().Spec
+			},
+		},
+		{
+			name:    "do not remove envFrom that was not injected",
+			binding: envFromBinding,
+			modify: func(spec *corev1.PodSpec) {
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{secretEnvFromSource(testServiceBindingSecretName)}
+			},
+			inject:          false,
+			expectedChanged: false,
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{secretEnvFromSource(testServiceBindingSecretName)}
+				return spec
+			},
+		},
+		{
+			name:    "keep envFrom with a prefix",
+			binding: envFromBinding,
+			modify: func(spec *corev1.PodSpec) {
+				source := secretEnvFromSource(testServiceBindingSecretName)
+				source.Prefix = "DB_"
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{source}
+			},
+			inject:              true,
+			expectedChanged:     true,
+			expectedAnnotations: map[string]string{injectedBindingsAnnotation: testServiceBindingName},
+			expectedPodSpec: func() corev1.PodSpec {
+				spec := getTestPodTemplateSpec().Spec
+				source := secretEnvFromSource(testServiceBindingSecretName)
+				source.Prefix = "DB_"
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{source, secretEnvFromSource(testServiceBindingSecretName)}
+				spec.Containers[1].EnvFrom = append(spec.Containers[1].EnvFrom, secretEnvFromSource(testServiceBindingSecretName))
+				return spec
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workload := &metav1.ObjectMeta{Annotations: tc.annotations}
+			podSpec := getTestPodTemplateSpec().Spec
+			if tc.modify != nil {
+				tc.modify(&podSpec)
+			}
+
+			changed := setServiceBindingWorkloadInjection(tc.binding, workload, &podSpec, tc.inject)
+			if e, a := tc.expectedChanged, changed; e != a {
+				t.Errorf("unexpected changed: expected %v, got %v", e, a)
+			}
+			if e, a := tc.expectedAnnotations, workload.Annotations; len(e) != len(a) || (len(e) > 0 && !reflect.DeepEqual(e, a)) {
+				t.Errorf("unexpected annotations: expected %v, got %v", e, a)
+			}
+			if e, a := tc.expectedPodSpec(), podSpec; !reflect.DeepEqual(e, a) {
+				t.Errorf("unexpected pod spec:\nexpected %+v\ngot      %+v", e, a)
+			}
+
+			// Applying the same injection again is a no-op.
+			if setServiceBindingWorkloadInjection(tc.binding, workload, &podSpec, tc.inject) {
+				t.Errorf("unexpected change when reapplying the injection")
+			}
+		})
+	}
+}
+
+// TestWorkloadInjectionVolumeName tests that the volume names for the Secrets
+// of bindings are valid DNS labels.
+func TestWorkloadInjectionVolumeName(t *testing.T) {
+	cases := []struct {
+		bindingName string
+		expected    string
+	}{
+		{"db", "servicebinding-db"},
+		{"db.example", "servicebinding-db-example"},
+		{fmt.Sprintf("%047d-db", 0), fmt.Sprintf("servicebinding-%047d", 0)},
+	}
+	for _, tc := range cases {
+		binding := &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: tc.bindingName}}
+		if e, a := tc.expected, workloadInjectionVolumeName(binding); e != a {
+			t.Errorf("%v: unexpected volume name: expected %q, got %q", tc.bindingName, e, a)
+		}
+	}
+}
+
+// TestReconcileServiceBindingWorkloads tests that the Secret of a ready binding
+// is injected into the selected Deployments and StatefulSets, that the
+// injected workloads are recorded in its status, and that the Secret is
+// removed from them when the binding is ejected.
+func TestReconcileServiceBindingWorkloads(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingWorkloadInjection)); err != nil {
+		t.Fatalf("Failed to enable binding workload injection feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingWorkloadInjection))
+
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	fakeKubeClient := clientgofake.NewSimpleClientset(
+		getTestDeployment("web", map[string]string{"app": "web"}),
+		getTestDeployment("worker", map[string]string{"app": "worker"}),
+		getTestStatefulSet("web-cache", map[string]string{"app": "web"}),
+	)
+	testController.kubeClient = fakeKubeClient
+	setTestWorkloadListers(t, testController, fakeKubeClient)
+
+	binding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeEnvFrom)
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	expectedWorkloads := []v1beta1.ServiceBindingWorkload{
+		{Kind: workloadKindDeployment, Name: "web"},
+		{Kind: workloadKindStatefulSet, Name: "web-cache"},
+	}
+	if e, a := expectedWorkloads, updatedServiceBinding.Status.InjectedWorkloads; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected injected workloads: expected %v, got %v", e, a)
+	}
+
+	expectedEnvFrom := secretEnvFromSource(testServiceBindingSecretName)
+	web, err := fakeKubeClient.AppsV1().Deployments(testNamespace).Get("web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []corev1.EnvFromSource{expectedEnvFrom}, web.Spec.Template.Spec.Containers[0].EnvFrom; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected envFrom of Deployment web: expected %v, got %v", e, a)
+	}
+	worker, err := fakeKubeClient.AppsV1().Deployments(testNamespace).Get("worker", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a := worker.Spec.Template.Spec.Containers[0].EnvFrom; len(a) != 0 {
+		t.Errorf("unexpected envFrom of Deployment worker: %v", a)
+	}
+	cache, err := fakeKubeClient.AppsV1().StatefulSets(testNamespace).Get("web-cache", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []corev1.EnvFromSource{expectedEnvFrom}, cache.Spec.Template.Spec.Containers[0].EnvFrom; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected envFrom of StatefulSet web-cache: expected %v, got %v", e, a)
+	}
+
+	// Reconciling the binding again with the recorded status does not update
+	// anything.
+	setTestWorkloadListers(t, testController, fakeKubeClient)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+	if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	if err := testController.ejectServiceBinding(updatedServiceBinding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a := updatedServiceBinding.Status.InjectedWorkloads; a != nil {
+		t.Errorf("unexpected injected workloads after ejection: %v", a)
+	}
+	web, err = fakeKubeClient.AppsV1().Deployments(testNamespace).Get("web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a := web.Spec.Template.Spec.Containers[0].EnvFrom; len(a) != 0 {
+		t.Errorf("unexpected envFrom of Deployment web after ejection: %v", a)
+	}
+	if _, ok := web.Annotations[injectedBindingsAnnotation]; ok {
+		t.Errorf("unexpected annotation %q on Deployment web after ejection", injectedBindingsAnnotation)
+	}
+}
+
+// TestWorkloadChanged tests that adding, relabeling or deleting a workload
+// queues the bindings in its namespace that select it or whose Secret has
+// been injected into it.
+func TestWorkloadChanged(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingWorkloadInjection)); err != nil {
+		t.Fatalf("Failed to enable binding workload injection feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingWorkloadInjection))
+
+	selectingBinding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeEnvFrom)
+	injectedBinding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeEnvFrom)
+	injectedBinding.Name = "injected-binding"
+	injectedBinding.Spec.WorkloadInjection.Selector.MatchLabels = map[string]string{"app": "other"}
+	otherNamespaceBinding := getTestServiceBindingWithWorkloadInjection(v1beta1.ServiceBindingWorkloadInjectionModeEnvFrom)
+	otherNamespaceBinding.Namespace = "other-ns"
+	plainBinding := getTestServiceBinding()
+	plainBinding.Name = "plain-binding"
+
+	webDeployment := getTestDeployment("web", map[string]string{"app": "web"})
+	injectedDeployment := getTestDeployment("worker", map[string]string{"app": "worker"})
+	injectedDeployment.Annotations = map[string]string{injectedBindingsAnnotation: injectedBinding.Name}
+	unselectedStatefulSet := getTestStatefulSet("cache", map[string]string{"app": "cache"})
+
+	cases := []struct {
+		name     string
+		change   func(c *controller)
+		expected []string
+	}{
+		{
+			name:     "add selected deployment",
+			change:   func(c *controller) { c.workloadAdd(webDeployment) },
+			expected: []string{testNamespace + "/" + testServiceBindingName},
+		},
+		{
+			name: "update injected deployment",
+			change: func(c *controller) {
+				updated := injectedDeployment.DeepCopy()
+				updated.ResourceVersion = "2"
+				c.workloadUpdate(injectedDeployment, updated)
+			},
+			expected: []string{testNamespace + "/" + injectedBinding.Name},
+		},
+		{
+			name:   "resync deployment",
+			change: func(c *controller) { c.workloadUpdate(webDeployment, webDeployment) },
+		},
+		{
+			name: "delete injected deployment",
+			change: func(c *controller) {
+				c.workloadDelete(cache.DeletedFinalStateUnknown{Key: testNamespace + "/" + injectedDeployment.Name, Obj: injectedDeployment})
+			},
+			expected: []string{testNamespace + "/" + injectedBinding.Name},
+		},
+		{
+			name:   "add unselected statefulset",
+			change: func(c *controller) { c.workloadAdd(unselectedStatefulSet) },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+			for _, binding := range []*v1beta1.ServiceBinding{selectingBinding, injectedBinding, otherNamespaceBinding, plainBinding} {
+				sharedInformers.ServiceBindings().Informer().GetStore().Add(binding)
+			}
+
+			tc.change(testController)
+
+			var queued []string
+			for testController.bindingQueue.Len() > 0 {
+				key, _ := testController.bindingQueue.Get()
+				queued = append(queued, key.(string))
+			}
+			if e, a := tc.expected, queued; !reflect.DeepEqual(e, a) {
+				t.Fatalf("Unexpected queued bindings: %v", expectedGot(e, a))
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/golang/glog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+// newBrokerClient applies the request limits of the broker with the given
+// spec to the clients of the broker and creates a client with the given
+// configuration.
+func (c *controller) newBrokerClient(clientConfig *osb.ClientConfiguration, commonSpec *v1beta1.CommonServiceBrokerSpec) (osb.Client, error) {
+	osbclientproxy.SetBrokerRequestLimits(clientConfig.Name, requestLimitsForBroker(commonSpec))
+	return c.brokerClientCreateFunc(clientConfig)
+}
+
+// requestLimitsForBroker returns the request limits set on the broker spec,
+// or nil if the broker uses the defaults of the controller.
+func requestLimitsForBroker(commonSpec *v1beta1.CommonServiceBrokerSpec) *osbclientproxy.RequestLimits {
+	limits := commonSpec.RequestLimits
+	if limits == nil {
+		return nil
+	}
+	return &osbclientproxy.RequestLimits{
+		QPS:                   float32(limits.QPS),
+		Burst:                 int(limits.Burst),
+		MaxConcurrentRequests: int(limits.MaxConcurrentRequests),
+	}
+}
+
+// requeueServiceInstanceForRequestLimit queues the instance again once the
+// broker has room for the request that exceeded its request limits. Whatever
+// the instance recorded before sending the request is kept, so the request
+// is sent again when the instance is reconciled next.
+func (c *controller) requeueServiceInstanceForRequestLimit(instance *v1beta1.ServiceInstance, limitedErr *osbclientproxy.RequestLimitedError) error {
+	key, err := cache.MetaNamespaceKeyFunc(instance)
+	if err != nil {
+		return err
+	}
+	pcb := pretty.NewInstanceContextBuilder(instance)
+	glog.V(4).Info(pcb.Messagef("Requeueing after %v: %v", limitedErr.RetryAfter, limitedErr))
+	c.instanceQueue.AddAfter(key, limitedErr.RetryAfter)
+	return nil
+}
+
+// requeueServiceBindingForRequestLimit queues the binding again once the
+// broker has room for the request that exceeded its request limits.
+func (c *controller) requeueServiceBindingForRequestLimit(binding *v1beta1.ServiceBinding, limitedErr *osbclientproxy.RequestLimitedError) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	glog.V(4).Info(pcb.Messagef("Requeueing after %v: %v", limitedErr.RetryAfter, limitedErr))
+	return c.requeueServiceBindingAfter(binding, limitedErr.RetryAfter)
+}
+
+// requeueClusterServiceBrokerForRequestLimit queues the broker again once it
+// has room for the catalog request that exceeded its request limits.
+func (c *controller) requeueClusterServiceBrokerForRequestLimit(broker *v1beta1.ClusterServiceBroker, limitedErr *osbclientproxy.RequestLimitedError) error {
+	key, err := cache.MetaNamespaceKeyFunc(broker)
+	if err != nil {
+		return err
+	}
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+	glog.V(4).Info(pcb.Messagef("Requeueing after %v: %v", limitedErr.RetryAfter, limitedErr))
+	c.clusterServiceBrokerQueue.AddAfter(key, limitedErr.RetryAfter)
+	return nil
+}
+
+// requeueServiceBrokerForRequestLimit queues the broker again once it has
+// room for the catalog request that exceeded its request limits.
+func (c *controller) requeueServiceBrokerForRequestLimit(broker *v1beta1.ServiceBroker, limitedErr *osbclientproxy.RequestLimitedError) error {
+	key, err := cache.MetaNamespaceKeyFunc(broker)
+	if err != nil {
+		return err
+	}
+	pcb := pretty.NewServiceBrokerContextBuilder(broker)
+	glog.V(4).Info(pcb.Messagef("Requeueing after %v: %v", limitedErr.RetryAfter, limitedErr))
+	c.serviceBrokerQueue.AddAfter(key, limitedErr.RetryAfter)
+	return nil
+}
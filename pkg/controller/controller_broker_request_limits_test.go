@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
+)
+
+func getTestRequestLimitedError(method string) osbclientproxy.RequestLimitedError {
+	return osbclientproxy.RequestLimitedError{
+		BrokerName: testClusterServiceBrokerName,
+		Method:     method,
+		RetryAfter: time.Minute,
+	}
+}
+
+// TestReconcileServiceInstanceRequestLimited tests that a provision request
+// that exceeds the request limits of the broker leaves the instance in
+// progress rather than failing it.
+func TestReconcileServiceInstanceRequestLimited(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: getTestRequestLimitedError("ProvisionInstance"),
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("a request over the request limits should not fail the reconciliation: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+	if events := getRecordedEvents(testController); len(events) != 0 {
+		t.Fatalf("expected no events, got %v", events)
+	}
+}
+
+// TestReconcileClusterServiceBrokerRequestLimited tests that a catalog request
+// that exceeds the request limits of the broker does not mark the broker as
+// not ready.
+func TestReconcileClusterServiceBrokerRequestLimited(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: getTestRequestLimitedError("GetCatalog"),
+		},
+	})
+
+	broker := getTestClusterServiceBroker()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("a request over the request limits should not fail the reconciliation: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertGetCatalog(t, brokerActions[0])
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+	if events := getRecordedEvents(testController); len(events) != 0 {
+		t.Fatalf("expected no events, got %v", events)
+	}
+}
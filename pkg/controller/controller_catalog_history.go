@@ -0,0 +1,217 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// maxCatalogHistory is the number of changes to the catalog of a broker that
+// are kept in its status.
+const maxCatalogHistory = 10
+
+// maxCatalogChangeEntries is the number of changed classes, and of changed
+// plans, that are listed for a single change to the catalog of a broker.
+// The remaining ones are only counted, so that brokers with large catalogs
+// do not grow their status without bounds.
+const maxCatalogChangeEntries = 50
+
+// catalogDiff collects the changes to the classes and plans of a broker
+// while its catalog is reconciled.
+type catalogDiff struct {
+	classes []v1beta1.ServiceBrokerCatalogEntryChange
+	plans   []v1beta1.ServiceBrokerCatalogEntryChange
+}
+
+func (d *catalogDiff) addClass(changeType v1beta1.ServiceBrokerCatalogChangeType, name string, spec *v1beta1.CommonServiceClassSpec, fields []string) {
+	d.classes = append(d.classes, v1beta1.ServiceBrokerCatalogEntryChange{
+		Type:         changeType,
+		Name:         name,
+		ExternalName: spec.ExternalName,
+		Fields:       fields,
+	})
+}
+
+func (d *catalogDiff) addPlan(changeType v1beta1.ServiceBrokerCatalogChangeType, name string, spec *v1beta1.CommonServicePlanSpec, classExternalName string, fields []string) {
+	d.plans = append(d.plans, v1beta1.ServiceBrokerCatalogEntryChange{
+		Type:                     changeType,
+		Name:                     name,
+		ExternalName:             spec.ExternalName,
+		ServiceClassExternalName: classExternalName,
+		Fields:                   fields,
+	})
+}
+
+func (d *catalogDiff) empty() bool {
+	return len(d.classes) == 0 && len(d.plans) == 0
+}
+
+// String summarizes the diff for events.
+func (d *catalogDiff) String() string {
+	count := func(changes []v1beta1.ServiceBrokerCatalogEntryChange, changeType v1beta1.ServiceBrokerCatalogChangeType) int {
+		n := 0
+		for _, change := range changes {
+			if change.Type == changeType {
+				n++
+			}
+		}
+		return n
+	}
+	return fmt.Sprintf(
+		"classes: %d added, %d removed, %d changed; plans: %d added, %d removed, %d changed",
+		count(d.classes, v1beta1.ServiceBrokerCatalogChangeAdded),
+		count(d.classes, v1beta1.ServiceBrokerCatalogChangeRemoved),
+		count(d.classes, v1beta1.ServiceBrokerCatalogChangeChanged),
+		count(d.plans, v1beta1.ServiceBrokerCatalogChangeAdded),
+		count(d.plans, v1beta1.ServiceBrokerCatalogChangeRemoved),
+		count(d.plans, v1beta1.ServiceBrokerCatalogChangeChanged),
+	)
+}
+
+// recordCatalogDiff appends the diff to the catalog history in the given
+// status, dropping the oldest changes beyond maxCatalogHistory. Diffs
+// without changes are not recorded, and neither is the diff of the first
+// catalog fetched for the broker, which only adds the whole catalog.
+func recordCatalogDiff(status *v1beta1.CommonServiceBrokerStatus, diff *catalogDiff, now metav1.Time) {
+	if diff.empty() || status.LastCatalogRetrievalTime == nil {
+		return
+	}
+	classes, omittedClasses := capCatalogEntryChanges(diff.classes)
+	plans, omittedPlans := capCatalogEntryChanges(diff.plans)
+	status.CatalogHistory = append(status.CatalogHistory, v1beta1.ServiceBrokerCatalogChange{
+		Time:           now,
+		Classes:        classes,
+		Plans:          plans,
+		OmittedClasses: omittedClasses,
+		OmittedPlans:   omittedPlans,
+	})
+	if n := len(status.CatalogHistory); n > maxCatalogHistory {
+		status.CatalogHistory = status.CatalogHistory[n-maxCatalogHistory:]
+	}
+}
+
+// capCatalogEntryChanges returns the first maxCatalogChangeEntries of the
+// given changes, and the number of the ones left out.
+func capCatalogEntryChanges(changes []v1beta1.ServiceBrokerCatalogEntryChange) ([]v1beta1.ServiceBrokerCatalogEntryChange, int32) {
+	if len(changes) <= maxCatalogChangeEntries {
+		return changes, 0
+	}
+	return changes[:maxCatalogChangeEntries], int32(len(changes) - maxCatalogChangeEntries)
+}
+
+// serviceClassChangedFields returns the JSON names of the fields of the spec
+// of a class that the catalog changed, out of the ones that are updated from
+// the catalog.
+func serviceClassChangedFields(existing, payload *v1beta1.CommonServiceClassSpec) []string {
+	var fields []string
+	if existing.ExternalName != payload.ExternalName {
+		fields = append(fields, "externalName")
+	}
+	if existing.Description != payload.Description {
+		fields = append(fields, "description")
+	}
+	if existing.Bindable != payload.Bindable {
+		fields = append(fields, "bindable")
+	}
+	if existing.BindingRetrievable != payload.BindingRetrievable {
+		fields = append(fields, "bindingRetrievable")
+	}
+	if existing.PlanUpdatable != payload.PlanUpdatable {
+		fields = append(fields, "planUpdatable")
+	}
+	if !rawExtensionsEqual(existing.ExternalMetadata, payload.ExternalMetadata) {
+		fields = append(fields, "externalMetadata")
+	}
+	if !stringSlicesEqual(existing.Tags, payload.Tags) {
+		fields = append(fields, "tags")
+	}
+	if !stringSlicesEqual(existing.Requires, payload.Requires) {
+		fields = append(fields, "requires")
+	}
+	return fields
+}
+
+// servicePlanChangedFields returns the JSON names of the fields of the spec
+// of a plan that the catalog changed, out of the ones that are updated from
+// the catalog.
+func servicePlanChangedFields(existing, payload *v1beta1.CommonServicePlanSpec) []string {
+	var fields []string
+	if existing.ExternalName != payload.ExternalName {
+		fields = append(fields, "externalName")
+	}
+	if existing.Description != payload.Description {
+		fields = append(fields, "description")
+	}
+	if !reflect.DeepEqual(existing.Bindable, payload.Bindable) {
+		fields = append(fields, "bindable")
+	}
+	if existing.Free != payload.Free {
+		fields = append(fields, "free")
+	}
+	if !rawExtensionsEqual(existing.ExternalMetadata, payload.ExternalMetadata) {
+		fields = append(fields, "externalMetadata")
+	}
+	if !rawExtensionsEqual(existing.ServiceInstanceCreateParameterSchema, payload.ServiceInstanceCreateParameterSchema) {
+		fields = append(fields, "instanceCreateParameterSchema")
+	}
+	if !rawExtensionsEqual(existing.ServiceInstanceUpdateParameterSchema, payload.ServiceInstanceUpdateParameterSchema) {
+		fields = append(fields, "instanceUpdateParameterSchema")
+	}
+	if !rawExtensionsEqual(existing.ServiceBindingCreateParameterSchema, payload.ServiceBindingCreateParameterSchema) {
+		fields = append(fields, "serviceBindingCreateParameterSchema")
+	}
+	return fields
+}
+
+// rawExtensionsEqual returns whether two raw extensions hold the same JSON,
+// regardless of its formatting.
+func rawExtensionsEqual(a, b *runtime.RawExtension) bool {
+	var aRaw, bRaw []byte
+	if a != nil {
+		aRaw = a.Raw
+	}
+	if b != nil {
+		bRaw = b.Raw
+	}
+	if len(aRaw) == 0 || len(bRaw) == 0 {
+		return len(aRaw) == len(bRaw)
+	}
+	var aValue, bValue interface{}
+	if json.Unmarshal(aRaw, &aValue) != nil || json.Unmarshal(bRaw, &bValue) != nil {
+		return reflect.DeepEqual(aRaw, bRaw)
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// TestReconcileClusterServiceBrokerRecordsCatalogHistory tests that the
+// classes and plans that are added to and removed from the catalog of a
+// broker are recorded in its catalog history.
+func TestReconcileClusterServiceBrokerRecordsCatalogHistory(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
+
+	testClusterServiceClass := getTestClusterServiceClass()
+	testRemovedClusterServiceClass := getTestRemovedClusterServiceClass()
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(testClusterServiceClass)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(testRemovedClusterServiceClass)
+
+	fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServiceClassList{
+			Items: []v1beta1.ClusterServiceClass{
+				*testClusterServiceClass,
+				*testRemovedClusterServiceClass,
+			},
+		}, nil
+	})
+
+	// The catalog has been fetched before, so this is not the initial
+	// population of the catalog
+	broker := getTestClusterServiceBroker()
+	lastCatalogRetrievalTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	broker.Status.LastCatalogRetrievalTime = &lastCatalogRetrievalTime
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 7)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[6], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+
+	history := updatedClusterServiceBroker.Status.CatalogHistory
+	if e, a := 1, len(history); e != a {
+		t.Fatalf("Expected %v catalog changes, got %v: %+v", e, a, history)
+	}
+	expectedClasses := []v1beta1.ServiceBrokerCatalogEntryChange{
+		{
+			Type:         v1beta1.ServiceBrokerCatalogChangeRemoved,
+			Name:         testRemovedClusterServiceClassGUID,
+			ExternalName: testRemovedClusterServiceClassName,
+		},
+	}
+	if e, a := expectedClasses, history[0].Classes; !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected class changes; expected %+v, got %+v", e, a)
+	}
+	if e, a := 2, len(history[0].Plans); e != a {
+		t.Fatalf("Expected %v plan changes, got %v: %+v", e, a, history[0].Plans)
+	}
+	for _, change := range history[0].Plans {
+		if e, a := v1beta1.ServiceBrokerCatalogChangeAdded, change.Type; e != a {
+			t.Errorf("Unexpected change type for plan %v; expected %v, got %v", change.Name, e, a)
+		}
+		if e, a := testClusterServiceClassName, change.ServiceClassExternalName; e != a {
+			t.Errorf("Unexpected class of plan %v; expected %v, got %v", change.Name, e, a)
+		}
+	}
+}
+
+// TestReconcileClusterServiceBrokerSkipsInitialCatalogHistory tests that
+// adding the whole catalog of a broker the first time it is fetched is not
+// recorded in its catalog history.
+func TestReconcileClusterServiceBrokerSkipsInitialCatalogHistory(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], getTestClusterServiceBroker()).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if history := updatedClusterServiceBroker.Status.CatalogHistory; len(history) != 0 {
+		t.Fatalf("Expected the initial catalog not to be recorded, got %+v", history)
+	}
+}
+
+func TestRecordCatalogDiff(t *testing.T) {
+	start := time.Now()
+	lastCatalogRetrievalTime := metav1.NewTime(start.Add(-time.Hour))
+	status := &v1beta1.CommonServiceBrokerStatus{}
+
+	initialDiff := &catalogDiff{}
+	initialDiff.addClass(v1beta1.ServiceBrokerCatalogChangeAdded, "class", &v1beta1.CommonServiceClassSpec{ExternalName: "class"}, nil)
+	recordCatalogDiff(status, initialDiff, metav1.NewTime(start))
+	if len(status.CatalogHistory) != 0 {
+		t.Fatalf("Expected the diff of the first catalog not to be recorded, got %+v", status.CatalogHistory)
+	}
+
+	status.LastCatalogRetrievalTime = &lastCatalogRetrievalTime
+	recordCatalogDiff(status, &catalogDiff{}, metav1.NewTime(start))
+	if len(status.CatalogHistory) != 0 {
+		t.Fatalf("Expected an empty diff not to be recorded, got %+v", status.CatalogHistory)
+	}
+
+	for i := 0; i < maxCatalogHistory+2; i++ {
+		diff := &catalogDiff{}
+		diff.addClass(v1beta1.ServiceBrokerCatalogChangeAdded, "class", &v1beta1.CommonServiceClassSpec{ExternalName: "class"}, nil)
+		recordCatalogDiff(status, diff, metav1.NewTime(start.Add(time.Duration(i)*time.Minute)))
+	}
+	if e, a := maxCatalogHistory, len(status.CatalogHistory); e != a {
+		t.Fatalf("Expected %v catalog changes, got %v", e, a)
+	}
+	if e, a := start.Add(2*time.Minute), status.CatalogHistory[0].Time.Time; !e.Equal(a) {
+		t.Errorf("Expected the oldest changes to be dropped; expected first change at %v, got %v", e, a)
+	}
+}
+
+func TestRecordCatalogDiffCapsEntries(t *testing.T) {
+	lastCatalogRetrievalTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	status := &v1beta1.CommonServiceBrokerStatus{LastCatalogRetrievalTime: &lastCatalogRetrievalTime}
+
+	diff := &catalogDiff{}
+	diff.addClass(v1beta1.ServiceBrokerCatalogChangeAdded, "class", &v1beta1.CommonServiceClassSpec{ExternalName: "class"}, nil)
+	for i := 0; i < maxCatalogChangeEntries+5; i++ {
+		name := fmt.Sprintf("plan-%d", i)
+		diff.addPlan(v1beta1.ServiceBrokerCatalogChangeAdded, name, &v1beta1.CommonServicePlanSpec{ExternalName: name}, "class", nil)
+	}
+	recordCatalogDiff(status, diff, metav1.Now())
+
+	if e, a := 1, len(status.CatalogHistory); e != a {
+		t.Fatalf("Expected %v catalog changes, got %v", e, a)
+	}
+	change := status.CatalogHistory[0]
+	if e, a := 1, len(change.Classes); e != a {
+		t.Errorf("Expected %v class changes, got %v", e, a)
+	}
+	if e, a := int32(0), change.OmittedClasses; e != a {
+		t.Errorf("Expected %v omitted class changes, got %v", e, a)
+	}
+	if e, a := maxCatalogChangeEntries, len(change.Plans); e != a {
+		t.Errorf("Expected %v plan changes, got %v", e, a)
+	}
+	if e, a := "plan-0", change.Plans[0].Name; e != a {
+		t.Errorf("Expected the first plan changes to be kept; expected %v, got %v", e, a)
+	}
+	if e, a := int32(5), change.OmittedPlans; e != a {
+		t.Errorf("Expected %v omitted plan changes, got %v", e, a)
+	}
+}
+
+func TestServiceClassChangedFields(t *testing.T) {
+	existing := &v1beta1.CommonServiceClassSpec{
+		ExternalName:     "class",
+		Description:      "a class",
+		Bindable:         true,
+		Tags:             []string{"a", "b"},
+		ExternalMetadata: &runtime.RawExtension{Raw: []byte(`{"a": 1, "b": 2}`)},
+	}
+
+	payload := existing.DeepCopy()
+	payload.ExternalMetadata = &runtime.RawExtension{Raw: []byte(`{"b":2,"a":1}`)}
+	if fields := serviceClassChangedFields(existing, payload); len(fields) != 0 {
+		t.Errorf("Expected no changed fields, got %v", fields)
+	}
+
+	payload.Description = "another class"
+	payload.PlanUpdatable = true
+	payload.Tags = []string{"a"}
+	if e, a := []string{"description", "planUpdatable", "tags"}, serviceClassChangedFields(existing, payload); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected changed fields; expected %v, got %v", e, a)
+	}
+}
+
+func TestServicePlanChangedFields(t *testing.T) {
+	existing := &v1beta1.CommonServicePlanSpec{
+		ExternalName:                         "plan",
+		Free:                                 true,
+		ServiceInstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(`{"type": "object"}`)},
+	}
+
+	payload := existing.DeepCopy()
+	if fields := servicePlanChangedFields(existing, payload); len(fields) != 0 {
+		t.Errorf("Expected no changed fields, got %v", fields)
+	}
+
+	bindable := false
+	payload.Free = false
+	payload.Bindable = &bindable
+	payload.ServiceInstanceCreateParameterSchema = nil
+	payload.ServiceBindingCreateParameterSchema = &runtime.RawExtension{Raw: []byte(`{"type": "object"}`)}
+	expected := []string{"bindable", "free", "instanceCreateParameterSchema", "serviceBindingCreateParameterSchema"}
+	if e, a := expected, servicePlanChangedFields(existing, payload); !reflect.DeepEqual(e, a) {
+		t.Errorf("Unexpected changed fields; expected %v, got %v", e, a)
+	}
+}
@@ -32,6 +32,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -58,6 +59,8 @@ const (
 	errorSyncingCatalogMessage            string = "Error syncing catalog from ClusterServiceBroker."
 	successFetchedCatalogReason           string = "FetchedCatalog"
 	successFetchedCatalogMessage          string = "Successfully fetched catalog entries from broker."
+	catalogChangedReason                  string = "CatalogChanged"
+	catalogChangedMessage                 string = "The catalog of the broker changed: "
 	errorReconciliationRetryTimeoutReason string = "ErrorReconciliationRetryTimeout"
 )
 
@@ -87,6 +90,7 @@ func (c *controller) clusterServiceBrokerDelete(obj interface{}) {
 	}
 
 	glog.V(4).Infof("Received delete event for ClusterServiceBroker %v; no further processing will occur", broker.Name)
+	osbclientproxy.RemoveBrokerRequestLimits(osbclientproxy.BrokerName(broker.Namespace, broker.Name))
 }
 
 // shouldReconcileClusterServiceBroker determines whether a broker should be reconciled; it
@@ -176,8 +180,10 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
+	// In both cases, migrate the instances of its plans as requested by its
+	// plan migration policy instead.
 	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
-		return nil
+		return c.reconcileClusterServiceBrokerPlanMigrations(broker)
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
@@ -195,7 +201,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Info(pcb.Messagef("Creating client, URL: %v", broker.Spec.URL))
-		brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err := c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 		if err != nil {
 			s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 			glog.Info(pcb.Message(s))
@@ -211,6 +217,9 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		// get the broker's catalog
 		now := metav1.Now()
 		brokerCatalog, err := brokerClient.GetCatalog()
+		if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+			return c.requeueClusterServiceBrokerForRequestLimit(broker, limitedErr)
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			glog.Warning(pcb.Message(s))
@@ -280,6 +289,17 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		existingServiceClassMap := convertClusterServiceClassListToMap(existingServiceClasses)
 		existingServicePlanMap := convertClusterServicePlanListToMap(existingServicePlans)
 
+		// record the classes and plans that are added, removed or changed
+		// in the catalog history of the broker
+		diff := &catalogDiff{}
+		classExternalNames := make(map[string]string)
+		for _, existingServiceClass := range existingServiceClasses {
+			classExternalNames[existingServiceClass.Name] = existingServiceClass.Spec.ExternalName
+		}
+		for _, payloadServiceClass := range payloadServiceClasses {
+			classExternalNames[payloadServiceClass.Name] = payloadServiceClass.Spec.ExternalName
+		}
+
 		// reconcile the serviceClasses that were part of the broker's catalog
 		// payload
 		for _, payloadServiceClass := range payloadServiceClasses {
@@ -302,6 +322,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 
 			glog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServiceClassName(payloadServiceClass)))
+
+			if existingServiceClass == nil || existingServiceClass.Status.RemovedFromBrokerCatalog {
+				diff.addClass(v1beta1.ServiceBrokerCatalogChangeAdded, payloadServiceClass.Name, &payloadServiceClass.Spec.CommonServiceClassSpec, nil)
+			} else if fields := serviceClassChangedFields(&existingServiceClass.Spec.CommonServiceClassSpec, &payloadServiceClass.Spec.CommonServiceClassSpec); len(fields) > 0 {
+				diff.addClass(v1beta1.ServiceBrokerCatalogChangeChanged, payloadServiceClass.Name, &payloadServiceClass.Spec.CommonServiceClassSpec, fields)
+			}
 		}
 
 		// handle the serviceClasses that were not in the broker's payload;
@@ -332,6 +358,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				}
 				return err
 			}
+			diff.addClass(v1beta1.ServiceBrokerCatalogChangeRemoved, existingServiceClass.Name, &existingServiceClass.Spec.CommonServiceClassSpec, nil)
 		}
 
 		// reconcile the plans that were part of the broker's catalog payload
@@ -356,6 +383,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 			glog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServicePlanName(payloadServicePlan)))
 
+			classExternalName := classExternalNames[payloadServicePlan.Spec.ClusterServiceClassRef.Name]
+			if existingServicePlan == nil || existingServicePlan.Status.RemovedFromBrokerCatalog {
+				diff.addPlan(v1beta1.ServiceBrokerCatalogChangeAdded, payloadServicePlan.Name, &payloadServicePlan.Spec.CommonServicePlanSpec, classExternalName, nil)
+			} else if fields := servicePlanChangedFields(&existingServicePlan.Spec.CommonServicePlanSpec, &payloadServicePlan.Spec.CommonServicePlanSpec); len(fields) > 0 {
+				diff.addPlan(v1beta1.ServiceBrokerCatalogChangeChanged, payloadServicePlan.Name, &payloadServicePlan.Spec.CommonServicePlanSpec, classExternalName, fields)
+			}
 		}
 
 		// handle the servicePlans that were not in the broker's payload;
@@ -387,15 +420,24 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				}
 				return err
 			}
+			classExternalName := classExternalNames[existingServicePlan.Spec.ClusterServiceClassRef.Name]
+			diff.addPlan(v1beta1.ServiceBrokerCatalogChangeRemoved, existingServicePlan.Name, &existingServicePlan.Spec.CommonServicePlanSpec, classExternalName, nil)
 		}
 
-		// everything worked correctly; update the broker's ready condition to
-		// status true
+		// everything worked correctly; record the changes to the catalog and
+		// update the broker's ready condition to status true
+		if !diff.empty() {
+			broker = broker.DeepCopy()
+			recordCatalogDiff(&broker.Status.CommonServiceBrokerStatus, diff, now)
+		}
 		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
 		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
+		if !diff.empty() {
+			c.recorder.Event(broker, corev1.EventTypeNormal, catalogChangedReason, catalogChangedMessage+diff.String())
+		}
 
 		// Update metrics with the number of serviceclass and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
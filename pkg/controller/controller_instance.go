@@ -37,6 +37,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -51,6 +52,7 @@ const (
 	successOrphanMitigationMessage string = "Orphan mitigation was completed successfully"
 
 	errorWithParameters                        string = "ErrorWithParameters"
+	errorInvalidParameters                     string = "InvalidParameters"
 	errorProvisionCallFailedReason             string = "ProvisionCallFailed"
 	errorErrorCallingProvisionReason           string = "ErrorCallingProvision"
 	errorUpdateInstanceCallFailedReason        string = "UpdateInstanceCallFailed"
@@ -174,6 +176,7 @@ func (c *controller) beginPollingServiceInstance(instance *v1beta1.ServiceInstan
 		return fmt.Errorf(s)
 	}
 
+	c.instancePollingRateLimiter.setPollingPolicy(key, c.getServiceInstanceBrokerPollingPolicy(instance))
 	c.instancePollingQueue.AddRateLimited(key)
 
 	return nil
@@ -185,6 +188,65 @@ func (c *controller) continuePollingServiceInstance(instance *v1beta1.ServiceIns
 	return c.beginPollingServiceInstance(instance)
 }
 
+// setServiceInstancePollDelay makes the next poll of the instance wait for the
+// delay the broker asked for, if it asked for one.
+func (c *controller) setServiceInstancePollDelay(instance *v1beta1.ServiceInstance, delay *time.Duration) {
+	if delay == nil {
+		return
+	}
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(instance); err == nil {
+		c.instancePollingRateLimiter.setPollDelay(key, *delay)
+	}
+}
+
+// pollLastOperation polls the last operation of an instance and returns the
+// delay the broker asked for before the next poll, if the client reports it.
+func pollLastOperation(brokerClient osb.Client, request *osb.LastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error) {
+	if client, ok := brokerClient.(osbclientproxy.PollDelayClient); ok {
+		return client.PollLastOperationWithDelay(request)
+	}
+	response, err := brokerClient.PollLastOperation(request)
+	return response, nil, err
+}
+
+// getServiceInstanceBrokerPollingPolicy returns the polling policy of the
+// broker of the instance, or nil if the broker has none or cannot be found.
+func (c *controller) getServiceInstanceBrokerPollingPolicy(instance *v1beta1.ServiceInstance) *v1beta1.ServiceBrokerPollingPolicy {
+	spec := c.getServiceInstanceBrokerSpec(instance)
+	if spec == nil {
+		return nil
+	}
+	return spec.PollingPolicy
+}
+
+// getServiceInstanceBrokerSpec returns the spec of the broker of the
+// instance, or nil if the broker cannot be found.
+func (c *controller) getServiceInstanceBrokerSpec(instance *v1beta1.ServiceInstance) *v1beta1.CommonServiceBrokerSpec {
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil:
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return nil
+		}
+		broker, err := c.clusterServiceBrokerLister.Get(class.Spec.ClusterServiceBrokerName)
+		if err != nil {
+			return nil
+		}
+		return &broker.Spec.CommonServiceBrokerSpec
+	case instance.Spec.ServiceClassRef != nil && c.serviceClassLister != nil:
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return nil
+		}
+		broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(class.Spec.ServiceBrokerName)
+		if err != nil {
+			return nil
+		}
+		return &broker.Spec.CommonServiceBrokerSpec
+	}
+	return nil
+}
+
 // finishPollingServiceInstance removes the instance's key from the controller's instance
 // polling queue.
 func (c *controller) finishPollingServiceInstance(instance *v1beta1.ServiceInstance) error {
@@ -404,6 +466,9 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 	))
 
 	response, err := brokerClient.ProvisionInstance(request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		return c.requeueServiceInstanceForRequestLimit(instance, limitedErr)
+	}
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf(
@@ -460,7 +525,13 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 	if isServiceInstanceProcessedAlready(instance) {
 		glog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
-		return nil
+		return c.requestServiceInstanceUpdateOnParametersFromChange(instance)
+	}
+
+	// Hold back the update until the maintenance window of the instance
+	// opens.
+	if deferred, err := c.deferServiceInstanceUpdate(instance); err != nil || deferred {
+		return err
 	}
 
 	instance = instance.DeepCopy()
@@ -468,6 +539,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	if instance.Status.ObservedGeneration != instance.Generation {
 		c.prepareObservedGeneration(instance)
 	}
+	removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionUpdatePending)
 
 	// Update references to ClusterServicePlan / ClusterServiceClass if necessary.
 	modified, err := c.resolveReferences(instance)
@@ -560,6 +632,9 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	}
 
 	response, err := brokerClient.UpdateInstance(request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		return c.requeueServiceInstanceForRequestLimit(instance, limitedErr)
+	}
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ServiceBroker returned a failure for update call; update will not be retried: %v", httpErr)
@@ -722,6 +797,9 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 	glog.V(4).Info(pcb.Message("Sending deprovision request to broker"))
 	response, err := brokerClient.DeprovisionInstance(request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		return c.requeueServiceInstanceForRequestLimit(instance, limitedErr)
+	}
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error deprovisioning, %s at ClusterServiceBroker %q: %v`,
@@ -780,7 +858,11 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 
 	glog.V(5).Info(pcb.Message("Polling last operation"))
 
-	response, err := brokerClient.PollLastOperation(request)
+	response, pollDelay, err := pollLastOperation(brokerClient, request)
+	if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+		c.setServiceInstancePollDelay(instance, &limitedErr.RetryAfter)
+		return c.continuePollingServiceInstance(instance)
+	}
 	if err != nil {
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec
@@ -848,6 +930,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		}
 
 		glog.V(4).Info(pcb.Message("Last operation not completed (still in progress)"))
+		c.setServiceInstancePollDelay(instance, pollDelay)
 		return c.continuePollingServiceInstance(instance)
 	case osb.StateSucceeded:
 		var err error
@@ -1455,6 +1538,20 @@ func setServiceInstanceCondition(toUpdate *v1beta1.ServiceInstance,
 	setServiceInstanceConditionInternal(toUpdate, conditionType, status, reason, message, metav1.Now())
 }
 
+// setServiceInstanceConditionFieldErrors sets the field errors of the
+// condition of the given type, which setServiceInstanceCondition clears
+// whenever it sets the condition.
+func setServiceInstanceConditionFieldErrors(toUpdate *v1beta1.ServiceInstance,
+	conditionType v1beta1.ServiceInstanceConditionType,
+	fieldErrors []v1beta1.ConditionFieldError) {
+	for i := range toUpdate.Status.Conditions {
+		if toUpdate.Status.Conditions[i].Type == conditionType {
+			toUpdate.Status.Conditions[i].FieldErrors = fieldErrors
+			return
+		}
+	}
+}
+
 // setServiceInstanceConditionInternal is setServiceInstanceCondition but allows the time to
 // be parameterized for testing.
 func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
@@ -1783,9 +1880,14 @@ func clearServiceInstanceCurrentOperation(toUpdate *v1beta1.ServiceInstance) {
 }
 
 // serviceInstanceHasExistingBindings returns true if there are any existing
-// bindings associated with the given ServiceInstance.
+// bindings associated with the given ServiceInstance. When instances may be
+// shared with other namespaces, the bindings of all namespaces are checked.
 func (c *controller) checkServiceInstanceHasExistingBindings(instance *v1beta1.ServiceInstance) error {
-	bindingLister := c.bindingLister.ServiceBindings(instance.Namespace)
+	bindingNamespace := instance.Namespace
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) {
+		bindingNamespace = metav1.NamespaceAll
+	}
+	bindingLister := c.bindingLister.ServiceBindings(bindingNamespace)
 
 	selector := labels.NewSelector()
 	bindingList, err := bindingLister.List(selector)
@@ -1797,7 +1899,7 @@ func (c *controller) checkServiceInstanceHasExistingBindings(instance *v1beta1.S
 		// Note that as we are potentially looking at a stale binding resource
 		// and cannot rely on UnbindStatus == ServiceBindingUnbindStatusNotRequired
 		// to filter out binding requests that have yet to be sent to the broker.
-		if instance.Name == binding.Spec.ServiceInstanceRef.Name {
+		if instance.Namespace == binding.GetServiceInstanceNamespace() && instance.Name == binding.Spec.ServiceInstanceRef.Name {
 			return &operationError{
 				reason:  errorDeprovisionBlockedByCredentialsReason,
 				message: "All associated ServiceBindings must be removed before this ServiceInstance can be deleted",
@@ -1851,7 +1953,8 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 
 	if setInProgressProperties {
 		parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
-			c.kubeClient,
+			clientParametersSources{c.kubeClient},
+			c.bindingLister,
 			instance.Namespace,
 			instance.Spec.Parameters,
 			instance.Spec.ParametersFrom,
@@ -1881,12 +1984,7 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 
 	// osb client handles whether or not to really send this based
 	// on the version of the client.
-	id := c.getClusterID()
-	rh.requestContext = map[string]interface{}{
-		"platform":           ContextProfilePlatformKubernetes,
-		"namespace":          instance.Namespace,
-		clusterIdentifierKey: id,
-	}
+	rh.requestContext = c.buildRequestContext(instance, ns)
 	return rh, nil
 }
 
@@ -1898,6 +1996,9 @@ func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInsta
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := validateParameters(rh.parameters, planCommon.ServiceInstanceCreateParameterSchema); err != nil {
+		return nil, nil, err
+	}
 
 	request := &osb.ProvisionRequest{
 		AcceptsIncomplete:   true,
@@ -1954,6 +2055,9 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 			} else {
 				request.Parameters = make(map[string]interface{})
 			}
+			if err := validateParameters(request.Parameters, servicePlan.Spec.ServiceInstanceUpdateParameterSchema); err != nil {
+				return nil, nil, err
+			}
 		}
 
 	} else if instance.Spec.ServiceClassSpecified() {
@@ -1989,6 +2093,9 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 			} else {
 				request.Parameters = make(map[string]interface{})
 			}
+			if err := validateParameters(request.Parameters, servicePlan.Spec.ServiceInstanceUpdateParameterSchema); err != nil {
+				return nil, nil, err
+			}
 		}
 
 	}
@@ -2160,6 +2267,7 @@ func (c *controller) handleServiceInstanceReconciliationError(instance *v1beta1.
 			status = v1beta1.ConditionUnknown
 		}
 		readyCond := newServiceInstanceReadyCondition(status, resourceErr.reason, resourceErr.message)
+		readyCond.FieldErrors = resourceErr.fieldErrors
 		return c.processServiceInstanceOperationError(instance, readyCond)
 	}
 	return err
@@ -2169,6 +2277,7 @@ func (c *controller) handleServiceInstanceReconciliationError(instance *v1beta1.
 // a ServiceInstance that hit a retryable error during reconciliation.
 func (c *controller) processServiceInstanceOperationError(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, readyCond.Status, readyCond.Reason, readyCond.Message)
+	setServiceInstanceConditionFieldErrors(instance, v1beta1.ServiceInstanceConditionReady, readyCond.FieldErrors)
 	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
 		return err
 	}
@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/maintenance"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	// maintenanceWindowScheduleAnnotation and
+	// maintenanceWindowDurationAnnotation set the maintenance window of the
+	// ServiceInstances of a namespace that have none of their own.
+	maintenanceWindowScheduleAnnotation = "servicecatalog.k8s.io/maintenance-window-schedule"
+	maintenanceWindowDurationAnnotation = "servicecatalog.k8s.io/maintenance-window-duration"
+
+	waitingForMaintenanceWindowReason  string = "WaitingForMaintenanceWindow"
+	waitingForMaintenanceWindowMessage string = "The update will be sent to the broker when the maintenance window opens at %s"
+	invalidMaintenanceWindowReason     string = "InvalidMaintenanceWindow"
+)
+
+// deferServiceInstanceUpdate returns whether the update of the instance has to
+// wait for its maintenance window to open. If so, the UpdatePending condition
+// of the instance is set and the instance is queued again for when the window
+// opens.
+func (c *controller) deferServiceInstanceUpdate(instance *v1beta1.ServiceInstance) (bool, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceWindows) ||
+		instance.Status.CurrentOperation != "" {
+		// An update that has been started is always finished.
+		return false, nil
+	}
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	window, err := c.getServiceInstanceMaintenanceWindow(instance)
+	if err != nil {
+		oe, ok := err.(*operationError)
+		if !ok {
+			return false, err
+		}
+		return true, c.setServiceInstanceUpdatePending(instance, oe.reason, oe.message)
+	}
+	now := time.Now()
+	if window == nil || window.Contains(now) {
+		return false, nil
+	}
+
+	next, ok := window.Next(now)
+	if !ok {
+		return true, c.setServiceInstanceUpdatePending(instance, invalidMaintenanceWindowReason, "The maintenance window never opens")
+	}
+	key, err := cache.MetaNamespaceKeyFunc(instance)
+	if err != nil {
+		return false, err
+	}
+	glog.V(4).Info(pcb.Messagef("Holding back update until the maintenance window opens at %v", next))
+	c.instanceQueue.AddAfter(key, next.Sub(now))
+	return true, c.setServiceInstanceUpdatePending(instance, waitingForMaintenanceWindowReason, fmt.Sprintf(waitingForMaintenanceWindowMessage, next.Format(time.RFC3339)))
+}
+
+// getServiceInstanceMaintenanceWindow returns the maintenance window of the
+// instance, or else the one of its namespace, or nil if neither has one.
+// Invalid windows are returned as an *operationError.
+func (c *controller) getServiceInstanceMaintenanceWindow(instance *v1beta1.ServiceInstance) (*maintenance.Window, error) {
+	if w := instance.Spec.MaintenanceWindow; w != nil {
+		window, err := maintenance.Parse(w.Schedule, w.Duration.Duration)
+		if err != nil {
+			return nil, &operationError{
+				reason:  invalidMaintenanceWindowReason,
+				message: fmt.Sprintf("Invalid maintenance window: %v", err),
+			}
+		}
+		return window, nil
+	}
+
+	ns, err := c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %v", instance.Namespace, err)
+	}
+	schedule := ns.Annotations[maintenanceWindowScheduleAnnotation]
+	if schedule == "" {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(ns.Annotations[maintenanceWindowDurationAnnotation])
+	if err == nil {
+		var window *maintenance.Window
+		if window, err = maintenance.Parse(schedule, duration); err == nil {
+			return window, nil
+		}
+	}
+	return nil, &operationError{
+		reason:  invalidMaintenanceWindowReason,
+		message: fmt.Sprintf("Invalid maintenance window of namespace %q: %v", instance.Namespace, err),
+	}
+}
+
+// setServiceInstanceUpdatePending records that the update of the instance is
+// held back, unless the UpdatePending condition already says so.
+func (c *controller) setServiceInstanceUpdatePending(instance *v1beta1.ServiceInstance, reason, message string) error {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionUpdatePending {
+			if cond.Status == v1beta1.ConditionTrue && cond.Reason == reason && cond.Message == message {
+				return nil
+			}
+			break
+		}
+	}
+	toUpdate := instance.DeepCopy()
+	setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpdatePending, v1beta1.ConditionTrue, reason, message)
+	_, err := c.updateServiceInstanceStatus(toUpdate)
+	return err
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+// getTestServiceInstanceWithPendingUpdate returns a provisioned instance whose
+// spec changed since it was last reconciled.
+func getTestServiceInstanceWithPendingUpdate() *v1beta1.ServiceInstance {
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	return instance
+}
+
+// closedMaintenanceWindowSchedule returns a schedule whose one minute long
+// window opens half an hour from now.
+func closedMaintenanceWindowSchedule() string {
+	return fmt.Sprintf("%d * * * *", (time.Now().UTC().Minute()+30)%60)
+}
+
+func TestReconcileServiceInstanceUpdateOutsideMaintenanceWindow(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.MaintenanceWindows)); err != nil {
+		t.Fatalf("Failed to enable maintenance windows feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.MaintenanceWindows))
+
+	cases := []struct {
+		name                  string
+		window                *v1beta1.MaintenanceWindow
+		namespaceAnnotations  map[string]string
+		expectedReason        string
+		expectedNamespaceGets int
+	}{
+		{
+			name: "instance window",
+			window: &v1beta1.MaintenanceWindow{
+				Schedule: closedMaintenanceWindowSchedule(),
+				Duration: metav1.Duration{Duration: time.Minute},
+			},
+			expectedReason: waitingForMaintenanceWindowReason,
+		},
+		{
+			name: "namespace window",
+			namespaceAnnotations: map[string]string{
+				maintenanceWindowScheduleAnnotation: closedMaintenanceWindowSchedule(),
+				maintenanceWindowDurationAnnotation: "1m",
+			},
+			expectedReason:        waitingForMaintenanceWindowReason,
+			expectedNamespaceGets: 1,
+		},
+		{
+			name: "invalid namespace window",
+			namespaceAnnotations: map[string]string{
+				maintenanceWindowScheduleAnnotation: "0 2 * *",
+				maintenanceWindowDurationAnnotation: "1h",
+			},
+			expectedReason:        invalidMaintenanceWindowReason,
+			expectedNamespaceGets: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			fakeKubeClient.PrependReactor("get", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        testNamespace,
+						Annotations: tc.namespaceAnnotations,
+					},
+				}, nil
+			})
+
+			instance := getTestServiceInstanceWithPendingUpdate()
+			instance.Spec.MaintenanceWindow = tc.window
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+			assertNumberOfActions(t, fakeKubeClient.Actions(), tc.expectedNamespaceGets)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updated := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			assertServiceInstanceCondition(t, updated, v1beta1.ServiceInstanceConditionUpdatePending, v1beta1.ConditionTrue, tc.expectedReason)
+			assertServiceInstanceCondition(t, updated, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue)
+			if e, a := int64(1), updated.Status.ObservedGeneration; e != a {
+				t.Fatalf("Unexpected observed generation: expected %v, got %v", e, a)
+			}
+
+			// The condition is only updated when it changes.
+			fakeCatalogClient.ClearActions()
+			if err := reconcileServiceInstance(t, testController, updated); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+		})
+	}
+}
+
+func TestReconcileServiceInstanceUpdateInsideMaintenanceWindow(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.MaintenanceWindows)); err != nil {
+		t.Fatalf("Failed to enable maintenance windows feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.MaintenanceWindows))
+
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+			Response: &osb.UpdateInstanceResponse{},
+		},
+	})
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	addGetNamespaceReaction(fakeKubeClient)
+
+	instance := getTestServiceInstanceWithPendingUpdate()
+	instance.Spec.MaintenanceWindow = &v1beta1.MaintenanceWindow{
+		Schedule: "* * * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	instance.Status.Conditions = append(instance.Status.Conditions, v1beta1.ServiceInstanceCondition{
+		Type:   v1beta1.ServiceInstanceConditionUpdatePending,
+		Status: v1beta1.ConditionTrue,
+		Reason: waitingForMaintenanceWindowReason,
+	})
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updated := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	if e, a := v1beta1.ServiceInstanceOperationUpdate, updated.Status.CurrentOperation; e != a {
+		t.Fatalf("Unexpected current operation: expected %v, got %v", e, a)
+	}
+	for _, cond := range updated.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionUpdatePending {
+			t.Fatalf("Unexpected UpdatePending condition once the update started: %+v", cond)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	errorServiceInstanceNotSharedReason string = "ServiceInstanceNotShared"
+)
+
+// ServiceInstanceGrant handlers
+
+func (c *controller) serviceInstanceGrantAdd(obj interface{}) {
+	grant, ok := obj.(*v1beta1.ServiceInstanceGrant)
+	if grant == nil || !ok {
+		return
+	}
+	c.serviceInstanceGranted(grant)
+}
+
+func (c *controller) serviceInstanceGrantUpdate(oldObj, newObj interface{}) {
+	if !isResourceVersionChanged(oldObj, newObj) {
+		return
+	}
+	c.serviceInstanceGrantAdd(newObj)
+}
+
+// serviceInstanceGranted queues the bindings of the namespaces listed in the
+// given grant that reference its instance, so that bindings waiting for the
+// instance to be shared with them are processed without waiting for their
+// retry. Revoking a grant does not affect the existing bindings.
+func (c *controller) serviceInstanceGranted(grant *v1beta1.ServiceInstanceGrant) {
+	for _, namespace := range grant.Spec.Namespaces {
+		bindings, err := c.bindingLister.ServiceBindings(namespace).List(labels.Everything())
+		if err != nil {
+			glog.Errorf("Couldn't list the ServiceBindings of namespace %q: %v", namespace, err)
+			continue
+		}
+		for _, binding := range bindings {
+			if binding.GetServiceInstanceNamespace() == grant.Namespace &&
+				binding.Spec.ServiceInstanceRef.Name == grant.Spec.ServiceInstanceRef.Name {
+				c.bindingAdd(binding)
+			}
+		}
+	}
+}
+
+// isServiceInstanceSharedWithServiceBinding returns whether the binding may
+// bind to its instance: either both live in the same namespace, or a
+// ServiceInstanceGrant in the namespace of the instance lists the namespace
+// of the binding.
+func (c *controller) isServiceInstanceSharedWithServiceBinding(binding *v1beta1.ServiceBinding) (bool, error) {
+	instanceNamespace := binding.GetServiceInstanceNamespace()
+	if instanceNamespace == binding.Namespace {
+		return true, nil
+	}
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) || c.serviceInstanceGrantLister == nil {
+		return false, nil
+	}
+
+	grants, err := c.serviceInstanceGrantLister.ServiceInstanceGrants(instanceNamespace).List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("Couldn't list the ServiceInstanceGrants of namespace %q: %v", instanceNamespace, err)
+	}
+	for _, grant := range grants {
+		if grant.Spec.ServiceInstanceRef.Name != binding.Spec.ServiceInstanceRef.Name {
+			continue
+		}
+		for _, namespace := range grant.Spec.Namespaces {
+			if namespace == binding.Namespace {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// checkServiceInstanceSharedWithServiceBinding returns a retryable error,
+// after setting the Ready condition of the binding to false, if the instance
+// of the binding is not shared with its namespace.
+func (c *controller) checkServiceInstanceSharedWithServiceBinding(binding *v1beta1.ServiceBinding) error {
+	shared, err := c.isServiceInstanceSharedWithServiceBinding(binding)
+	if err != nil {
+		return err
+	}
+	if shared {
+		return nil
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+	msg := fmt.Sprintf(
+		`%s "%s/%s" is not shared with namespace %q; a ServiceInstanceGrant in namespace %q must list it`,
+		pretty.ServiceInstance, binding.GetServiceInstanceNamespace(), binding.Spec.ServiceInstanceRef.Name,
+		binding.Namespace, binding.GetServiceInstanceNamespace(),
+	)
+	glog.Info(pcb.Message(msg))
+	readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorServiceInstanceNotSharedReason, msg)
+	return c.processServiceBindingOperationError(binding, readyCond)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+const testSharedServiceBindingNamespace = "team-ns"
+
+// getTestSharedServiceBinding returns a binding in another namespace than
+// the one of the test instance, referencing the test instance.
+func getTestSharedServiceBinding() *v1beta1.ServiceBinding {
+	return &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceBindingName,
+			Namespace:  testSharedServiceBindingNamespace,
+			Generation: 1,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			ServiceInstanceRef:       v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ServiceInstanceNamespace: testNamespace,
+			ExternalID:               testServiceBindingGUID,
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+		},
+	}
+}
+
+// getTestServiceInstanceGrant returns a grant sharing the test instance with
+// the given namespaces.
+func getTestServiceInstanceGrant(namespaces ...string) *v1beta1.ServiceInstanceGrant {
+	return &v1beta1.ServiceInstanceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-grant",
+			Namespace:       testNamespace,
+			ResourceVersion: "1",
+		},
+		Spec: v1beta1.ServiceInstanceGrantSpec{
+			ServiceInstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			Namespaces:         namespaces,
+		},
+	}
+}
+
+func enableServiceInstanceSharing(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceSharing))
+	if err != nil {
+		t.Fatalf("Failed to enable ServiceInstanceSharing feature: %v", err)
+	}
+}
+
+func disableServiceInstanceSharing() {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceSharing))
+}
+
+// TestReconcileServiceBindingServiceInstanceNotShared tests that a binding to
+// an instance of another namespace is not bound until a grant shares the
+// instance with the namespace of the binding.
+func TestReconcileServiceBindingServiceInstanceNotShared(t *testing.T) {
+	cases := []struct {
+		name           string
+		sharingEnabled bool
+		grant          *v1beta1.ServiceInstanceGrant
+		reason         string
+	}{
+		{
+			name:           "sharing disabled",
+			sharingEnabled: false,
+			grant:          getTestServiceInstanceGrant(testSharedServiceBindingNamespace),
+			reason:         errorServiceInstanceNotSharedReason,
+		},
+		{
+			name:           "no grant",
+			sharingEnabled: true,
+			reason:         errorServiceInstanceNotSharedReason,
+		},
+		{
+			name:           "grant for other namespaces",
+			sharingEnabled: true,
+			grant:          getTestServiceInstanceGrant("other-ns"),
+			reason:         errorServiceInstanceNotSharedReason,
+		},
+		{
+			name:           "granted",
+			sharingEnabled: true,
+			grant:          getTestServiceInstanceGrant("other-ns", testSharedServiceBindingNamespace),
+			// The binding gets past the grant check, and waits for the
+			// instance to be ready.
+			reason: errorServiceInstanceNotReadyReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.sharingEnabled {
+				enableServiceInstanceSharing(t)
+				defer disableServiceInstanceSharing()
+			}
+
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithClusterRefs())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			if tc.grant != nil {
+				sharedInformers.ServiceInstanceGrants().Informer().GetStore().Add(tc.grant)
+			}
+
+			binding := getTestSharedServiceBinding()
+			if err := reconcileServiceBinding(t, testController, binding); err == nil {
+				t.Fatal("expected the binding not to be bound")
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, 0)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+			assertServiceBindingErrorBeforeRequest(t, updatedServiceBinding, tc.reason, binding)
+
+			events := getRecordedEvents(testController)
+			assertNumEvents(t, events, 1)
+			if tc.reason != errorServiceInstanceNotSharedReason {
+				return
+			}
+			expectedEvent := warningEventBuilder(errorServiceInstanceNotSharedReason).msgf(
+				"ServiceInstance %q is not shared with namespace %q; a ServiceInstanceGrant in namespace %q must list it",
+				"test-ns/test-instance", testSharedServiceBindingNamespace, testNamespace,
+			)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestPrepareBindRequestForSharedServiceInstance tests that the app GUID of
+// a binding to an instance of another namespace is the UID of the namespace
+// of the binding, while the context still describes the instance.
+func TestPrepareBindRequestForSharedServiceInstance(t *testing.T) {
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	fakeKubeClient.PrependReactor("get", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		name := action.(clientgotesting.GetAction).GetName()
+		return true, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				UID:  types.UID(name + "-guid"),
+			},
+		}, nil
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	request, _, err := testController.prepareBindRequest(getTestSharedServiceBinding(), getTestServiceInstanceWithClusterRefs())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	appGUID := testSharedServiceBindingNamespace + "-guid"
+	if request.AppGUID == nil {
+		t.Fatal("Expected an app GUID")
+	}
+	if e, a := appGUID, *request.AppGUID; e != a {
+		t.Fatalf("Unexpected app GUID: %v", expectedGot(e, a))
+	}
+	if request.BindResource == nil || request.BindResource.AppGUID == nil {
+		t.Fatal("Expected an app GUID in the bind resource")
+	}
+	if e, a := appGUID, *request.BindResource.AppGUID; e != a {
+		t.Fatalf("Unexpected app GUID in the bind resource: %v", expectedGot(e, a))
+	}
+	if e, a := testNamespace, request.Context["namespace"]; e != a {
+		t.Fatalf("Unexpected namespace in context: %v", expectedGot(e, a))
+	}
+
+	actions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+	for i, name := range []string{testSharedServiceBindingNamespace, testNamespace} {
+		assertActionEquals(t, actions[i], "get", "namespaces")
+		if e, a := name, actions[i].(clientgotesting.GetAction).GetName(); e != a {
+			t.Fatalf("Unexpected namespace fetched: %v", expectedGot(e, a))
+		}
+	}
+}
+
+// TestServiceInstanceGrantAdd tests that adding a grant queues the bindings
+// of the granted namespaces that reference the instance of the grant.
+func TestServiceInstanceGrantAdd(t *testing.T) {
+	enableServiceInstanceSharing(t)
+	defer disableServiceInstanceSharing()
+
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedBinding := getTestSharedServiceBinding()
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(sharedBinding)
+
+	otherInstanceBinding := getTestSharedServiceBinding()
+	otherInstanceBinding.Name = "other-binding"
+	otherInstanceBinding.Spec.ServiceInstanceRef.Name = "other-instance"
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(otherInstanceBinding)
+
+	localBinding := getTestSharedServiceBinding()
+	localBinding.Name = "local-binding"
+	localBinding.Spec.ServiceInstanceNamespace = ""
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(localBinding)
+
+	testController.serviceInstanceGrantAdd(getTestServiceInstanceGrant(testSharedServiceBindingNamespace))
+
+	if e, a := 1, testController.bindingQueue.Len(); e != a {
+		t.Fatalf("Unexpected number of queued bindings: %v", expectedGot(e, a))
+	}
+	key, _ := testController.bindingQueue.Get()
+	if e, a := testSharedServiceBindingNamespace+"/"+testServiceBindingName, key; e != a {
+		t.Fatalf("Unexpected queued binding: %v", expectedGot(e, a))
+	}
+}
+
+// TestCheckServiceInstanceHasExistingBindingsInOtherNamespaces tests that the
+// deprovisioning of a shared instance is blocked by the bindings of the
+// namespaces it is shared with.
+func TestCheckServiceInstanceHasExistingBindingsInOtherNamespaces(t *testing.T) {
+	enableServiceInstanceSharing(t)
+	defer disableServiceInstanceSharing()
+
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	if err := testController.checkServiceInstanceHasExistingBindings(instance); err != nil {
+		t.Fatalf("Unexpected error without bindings: %v", err)
+	}
+
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(getTestSharedServiceBinding())
+	if err := testController.checkServiceInstanceHasExistingBindings(instance); err == nil {
+		t.Fatal("Expected the binding of another namespace to block the deprovisioning")
+	}
+}
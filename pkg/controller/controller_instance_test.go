@@ -708,6 +708,76 @@ func TestReconcileServiceInstanceWithParameters(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceWithInvalidParameters tests that provisioning
+// fails before calling the broker when the parameters, including those from
+// secrets, do not match the parameter schema of the plan.
+func TestReconcileServiceInstanceWithInvalidParameters(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ParameterSchemaValidation))
+	if err != nil {
+		t.Fatalf("Failed to enable parameter schema validation feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParameterSchemaValidation))
+
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	plan := getTestClusterServicePlan()
+	plan.Spec.ServiceInstanceCreateParameterSchema = &runtime.RawExtension{Raw: []byte(`{
+		"type": "object",
+		"required": ["size", "password"],
+		"properties": {
+			"size": {"enum": ["small", "large"]},
+			"password": {"type": "string", "minLength": 8}
+		}
+	}`)}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
+
+	fakeKubeClient.PrependReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "secret-name"},
+			Data:       map[string][]byte{"secret-key": []byte(`{"password":"letmein"}`)},
+		}, nil
+	})
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"size":"medium"}`)}
+	instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+		{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "secret-name", Key: "secret-key"}},
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("Reconcile expected to fail")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceErrorBeforeRequest(t, updatedServiceInstance, errorInvalidParameters, instance)
+
+	expectedMessage := "The parameters do not match the parameter schema of the plan, invalid fields: parameters.password, parameters.size"
+	condition := updatedServiceInstance.(*v1beta1.ServiceInstance).Status.Conditions[0]
+	if condition.Message != expectedMessage {
+		t.Fatalf("unexpected condition message; expected %q, got %q", expectedMessage, condition.Message)
+	}
+	expectedFieldErrors := []v1beta1.ConditionFieldError{
+		{Type: "FieldValueInvalid", Field: "parameters.password", Detail: "must be at least 8 characters long"},
+		{Type: "FieldValueNotSupported", Field: "parameters.size", Detail: `supported values: "small", "large"`},
+	}
+	if e, a := expectedFieldErrors, condition.FieldErrors; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected condition field errors; expected %+v, got %+v", e, a)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorInvalidParameters).msg(expectedMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestReconcileServiceInstanceResolvesReferences tests a simple successful
 // reconciliation and making sure that Service[Class|Plan]Ref are resolved
 func TestReconcileServiceInstanceResolvesReferences(t *testing.T) {
@@ -0,0 +1,260 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	parametersFromChangedReason     string = "ParametersFromChanged"
+	parametersFromChangedMessage    string = "The parameters from the sources of parametersFrom changed; requesting an update of the instance"
+	parametersDriftedMessage        string = "The parameters from the sources of parametersFrom differ from the parameters the binding was created with; recreate the binding to apply them"
+	parametersUpToDateReason        string = "ParametersUpToDate"
+	parametersUpToDateMessage       string = "The parameters from the sources of parametersFrom match the parameters the binding was created with"
+	parametersFromUnavailableLogFmt string = "Not checking the parameters from the sources of parametersFrom: %v"
+)
+
+func (c *controller) secretAdd(obj interface{}) {
+	c.parametersFromSourceChanged(obj)
+}
+
+func (c *controller) secretUpdate(oldObj, newObj interface{}) {
+	if !isResourceVersionChanged(oldObj, newObj) {
+		return
+	}
+	c.parametersFromSourceChanged(newObj)
+}
+
+func (c *controller) secretDelete(obj interface{}) {
+	c.parametersFromSourceChanged(obj)
+}
+
+func (c *controller) configMapAdd(obj interface{}) {
+	c.parametersFromSourceChanged(obj)
+}
+
+func (c *controller) configMapUpdate(oldObj, newObj interface{}) {
+	if !isResourceVersionChanged(oldObj, newObj) {
+		return
+	}
+	c.parametersFromSourceChanged(newObj)
+}
+
+func (c *controller) configMapDelete(obj interface{}) {
+	c.parametersFromSourceChanged(obj)
+}
+
+// isResourceVersionChanged returns whether an update event changed the
+// object, rather than being a resync of the informer.
+func isResourceVersionChanged(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return true
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return true
+	}
+	return oldMeta.GetResourceVersion() != newMeta.GetResourceVersion()
+}
+
+// parametersFromSourceChanged queues the instances and bindings whose
+// ParametersFrom reference the given Secret or ConfigMap, directly or, for a
+// Secret of a ServiceBinding, through the binding. Instances are only queued
+// if they opted in to being updated.
+func (c *controller) parametersFromSourceChanged(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	source, err := meta.Accessor(obj)
+	if err != nil {
+		glog.Errorf("Couldn't get object metadata of %+v: %v", obj, err)
+		return
+	}
+	_, isSecret := obj.(*corev1.Secret)
+	namespace, name := source.GetNamespace(), source.GetName()
+
+	bindings, err := c.bindingLister.ServiceBindings(namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Couldn't list the ServiceBindings of namespace %q: %v", namespace, err)
+		return
+	}
+	bindingsOfSource := sets.NewString()
+	if isSecret {
+		for _, binding := range bindings {
+			if binding.Spec.SecretName == name {
+				bindingsOfSource.Insert(binding.Name)
+			}
+		}
+	}
+
+	for _, binding := range bindings {
+		if parametersFromReferencesSource(binding.Spec.ParametersFrom, name, isSecret, bindingsOfSource) {
+			c.bindingAdd(binding)
+		}
+	}
+
+	instances, err := c.instanceLister.ServiceInstances(namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Couldn't list the ServiceInstances of namespace %q: %v", namespace, err)
+		return
+	}
+	for _, instance := range instances {
+		if instance.Spec.UpdateOnParametersFromChange &&
+			parametersFromReferencesSource(instance.Spec.ParametersFrom, name, isSecret, bindingsOfSource) {
+			c.instanceAdd(instance)
+		}
+	}
+}
+
+// parametersFromReferencesSource returns whether any of the given sources
+// reads from the Secret or ConfigMap with the given name, or from the Secret
+// of one of the given bindings.
+func parametersFromReferencesSource(parametersFrom []v1beta1.ParametersFromSource, name string, isSecret bool, bindings sets.String) bool {
+	for _, p := range parametersFrom {
+		switch {
+		case isSecret && p.SecretKeyRef != nil && p.SecretKeyRef.Name == name,
+			isSecret && p.SecretRef != nil && p.SecretRef.Name == name,
+			!isSecret && p.ConfigMapKeyRef != nil && p.ConfigMapKeyRef.Name == name,
+			!isSecret && p.ConfigMapRef != nil && p.ConfigMapRef.Name == name,
+			p.ServiceBindingKeyRef != nil && bindings.Has(p.ServiceBindingKeyRef.Name):
+			return true
+		}
+	}
+	return false
+}
+
+// parametersSourceListers returns the sources of parameters read from the
+// Secret and ConfigMap informer caches, which are only started along with
+// the WatchParametersFrom feature.
+func (c *controller) parametersSourceListers() parametersSources {
+	return listerParametersSources{
+		secretLister:    c.secretLister,
+		configMapLister: c.configMapLister,
+	}
+}
+
+// parametersDrifted returns whether the parameters built from the given
+// parameters and sources no longer match the given checksum of the
+// parameters last sent to the broker.
+func (c *controller) parametersDrifted(namespace string, parameters *runtime.RawExtension, parametersFrom []v1beta1.ParametersFromSource, checksum string) (bool, error) {
+	_, currentChecksum, _, err := prepareInProgressPropertyParameters(c.parametersSourceListers(), c.bindingLister, namespace, parameters, parametersFrom)
+	if err != nil {
+		return false, err
+	}
+	return currentChecksum != checksum, nil
+}
+
+// requestServiceInstanceUpdateOnParametersFromChange increments the
+// UpdateRequests of a ready instance that opted in to updates on
+// parametersFrom changes when the parameters from its sources no longer match
+// the parameters last sent to the broker. The update itself then follows the
+// same path as a user-requested one.
+func (c *controller) requestServiceInstanceUpdateOnParametersFromChange(instance *v1beta1.ServiceInstance) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.WatchParametersFrom) ||
+		!instance.Spec.UpdateOnParametersFromChange ||
+		len(instance.Spec.ParametersFrom) == 0 ||
+		!isServiceInstanceReady(instance) ||
+		instance.Status.ExternalProperties == nil {
+		return nil
+	}
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	drifted, err := c.parametersDrifted(instance.Namespace, instance.Spec.Parameters, instance.Spec.ParametersFrom, instance.Status.ExternalProperties.ParametersChecksum)
+	if err != nil {
+		// A source that cannot be read would fail the update as well; it is
+		// checked again when the source changes.
+		glog.V(4).Info(pcb.Messagef(parametersFromUnavailableLogFmt, err))
+		return nil
+	}
+	if !drifted {
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message(parametersFromChangedMessage))
+	toUpdate := instance.DeepCopy()
+	toUpdate.Spec.UpdateRequests++
+	if _, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).Update(toUpdate); err != nil {
+		glog.Error(pcb.Messagef("Error requesting an update: %v", err))
+		return err
+	}
+	c.recorder.Event(instance, corev1.EventTypeNormal, parametersFromChangedReason, parametersFromChangedMessage)
+	return nil
+}
+
+// reconcileServiceBindingParametersDrift sets the ParametersDrifted
+// condition of a ready binding to whether the parameters from the sources of
+// its ParametersFrom still match the parameters it was created with. Returns
+// whether the status of the binding was updated.
+func (c *controller) reconcileServiceBindingParametersDrift(binding *v1beta1.ServiceBinding) (bool, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.WatchParametersFrom) ||
+		!isServiceBindingReady(binding) ||
+		binding.Status.ExternalProperties == nil {
+		return false, nil
+	}
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	drifted := false
+	if len(binding.Spec.ParametersFrom) > 0 {
+		var err error
+		drifted, err = c.parametersDrifted(binding.Namespace, binding.Spec.Parameters, binding.Spec.ParametersFrom, binding.Status.ExternalProperties.ParametersChecksum)
+		if err != nil {
+			glog.V(4).Info(pcb.Messagef(parametersFromUnavailableLogFmt, err))
+			return false, nil
+		}
+	}
+
+	current := getServiceBindingCondition(binding, v1beta1.ServiceBindingConditionParametersDrifted)
+	toUpdate := binding.DeepCopy()
+	switch {
+	case drifted && (current == nil || current.Status != v1beta1.ConditionTrue):
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionParametersDrifted, v1beta1.ConditionTrue, parametersFromChangedReason, parametersDriftedMessage)
+		c.recorder.Event(binding, corev1.EventTypeWarning, parametersFromChangedReason, parametersDriftedMessage)
+	case !drifted && current != nil && current.Status != v1beta1.ConditionFalse:
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionParametersDrifted, v1beta1.ConditionFalse, parametersUpToDateReason, parametersUpToDateMessage)
+	default:
+		return false, nil
+	}
+
+	if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getServiceBindingCondition returns the condition of the binding with the
+// given type, or nil if the binding has none.
+func getServiceBindingCondition(binding *v1beta1.ServiceBinding, conditionType v1beta1.ServiceBindingConditionType) *v1beta1.ServiceBindingCondition {
+	for i, condition := range binding.Status.Conditions {
+		if condition.Type == conditionType {
+			return &binding.Status.Conditions[i]
+		}
+	}
+	return nil
+}
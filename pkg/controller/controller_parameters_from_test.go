@@ -0,0 +1,267 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+const testParametersSecretName = "parameters-secret"
+
+func getTestParametersFromSecret() []v1beta1.ParametersFromSource {
+	return []v1beta1.ParametersFromSource{{
+		SecretKeyRef: &v1beta1.SecretKeyReference{Name: testParametersSecretName, Key: "parameters"},
+	}}
+}
+
+func getTestParametersSecret(parameters string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: testParametersSecretName, Namespace: testNamespace},
+		Data:       map[string][]byte{"parameters": []byte(parameters)},
+	}
+}
+
+func getTestServiceInstanceWithParametersFrom(checksum string) *v1beta1.ServiceInstance {
+	instance := getTestServiceInstanceWithRefsAndExternalProperties()
+	instance.Spec.ParametersFrom = getTestParametersFromSecret()
+	instance.Spec.UpdateOnParametersFromChange = true
+	instance.Status.ExternalProperties.ParametersChecksum = checksum
+	instance.Status.ObservedGeneration = instance.Generation
+	instance.Status.ReconciledGeneration = instance.Generation
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	return instance
+}
+
+func getTestServiceBindingWithParametersFrom(checksum string) *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.Spec.ParametersFrom = getTestParametersFromSecret()
+	binding.Status.ReconciledGeneration = binding.Generation
+	binding.Status.ExternalProperties = &v1beta1.ServiceBindingPropertiesState{
+		ParametersChecksum: checksum,
+	}
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+		Type:   v1beta1.ServiceBindingConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	return binding
+}
+
+// setTestParametersSourceListers sets the Secret and ConfigMap listers of the
+// controller to ones listing the given Secrets, the way the informers would
+// once they observed them. The parameters drift checks must not read the
+// sources from the API server.
+func setTestParametersSourceListers(testController *controller, secrets ...*corev1.Secret) {
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, secret := range secrets {
+		secretIndexer.Add(secret)
+	}
+	testController.secretLister = corelisters.NewSecretLister(secretIndexer)
+	configMapIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	testController.configMapLister = corelisters.NewConfigMapLister(configMapIndexer)
+}
+
+func enableWatchParametersFrom(t *testing.T) func() {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.WatchParametersFrom)); err != nil {
+		t.Fatalf("Failed to enable WatchParametersFrom feature: %v", err)
+	}
+	return func() {
+		utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.WatchParametersFrom))
+	}
+}
+
+// TestParametersFromReferencesSource tests which sources of parametersFrom
+// are considered to reference a changed Secret or ConfigMap.
+func TestParametersFromReferencesSource(t *testing.T) {
+	cases := []struct {
+		name           string
+		parametersFrom []v1beta1.ParametersFromSource
+		isSecret       bool
+		expected       bool
+	}{
+		{
+			name:           "secret key",
+			parametersFrom: []v1beta1.ParametersFromSource{{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "source", Key: "k"}}},
+			isSecret:       true,
+			expected:       true,
+		},
+		{
+			name:           "whole secret",
+			parametersFrom: []v1beta1.ParametersFromSource{{SecretRef: &v1beta1.LocalObjectReference{Name: "source"}}},
+			isSecret:       true,
+			expected:       true,
+		},
+		{
+			name:           "secret key of a configmap with the same name",
+			parametersFrom: []v1beta1.ParametersFromSource{{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "source", Key: "k"}}},
+			isSecret:       false,
+			expected:       false,
+		},
+		{
+			name:           "configmap key",
+			parametersFrom: []v1beta1.ParametersFromSource{{ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{Name: "source", Key: "k"}}},
+			isSecret:       false,
+			expected:       true,
+		},
+		{
+			name:           "whole configmap",
+			parametersFrom: []v1beta1.ParametersFromSource{{ConfigMapRef: &v1beta1.LocalObjectReference{Name: "source"}}},
+			isSecret:       false,
+			expected:       true,
+		},
+		{
+			name:           "key of a binding of the secret",
+			parametersFrom: []v1beta1.ParametersFromSource{{ServiceBindingKeyRef: &v1beta1.ServiceBindingKeyReference{Name: "binding", Key: "k"}}},
+			isSecret:       true,
+			expected:       true,
+		},
+		{
+			name:           "other secret",
+			parametersFrom: []v1beta1.ParametersFromSource{{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "other", Key: "k"}}},
+			isSecret:       true,
+			expected:       false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := parametersFromReferencesSource(tc.parametersFrom, "source", tc.isSecret, sets.NewString("binding"))
+			if actual != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceParametersFromChanged tests that an instance
+// that opted in is updated once the parameters from its sources drift from
+// the parameters last sent to the broker.
+func TestReconcileServiceInstanceParametersFromChanged(t *testing.T) {
+	defer enableWatchParametersFrom(t)()
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	setTestParametersSourceListers(testController, getTestParametersSecret(`{"a": "2"}`))
+
+	instance := getTestServiceInstanceWithParametersFrom("stale-checksum")
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updated, ok := assertUpdate(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	if !ok {
+		t.Fatalf("expected a ServiceInstance, got %+v", actions[0])
+	}
+	if e, a := instance.Spec.UpdateRequests+1, updated.Spec.UpdateRequests; e != a {
+		t.Fatalf("unexpected UpdateRequests: expected %v, got %v", e, a)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := corev1.EventTypeNormal + " " + parametersFromChangedReason + " " + parametersFromChangedMessage
+	if err := checkEvents(events, []string{expectedEvent}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceParametersFromUnchanged tests that an instance
+// is not updated while the parameters from its sources match the parameters
+// last sent to the broker, or if it did not opt in.
+func TestReconcileServiceInstanceParametersFromUnchanged(t *testing.T) {
+	defer enableWatchParametersFrom(t)()
+
+	secret := getTestParametersSecret(`{"a": "1"}`)
+	cases := []struct {
+		name     string
+		checksum func(*controller) string
+		optIn    bool
+	}{
+		{
+			name: "parameters unchanged",
+			checksum: func(c *controller) string {
+				_, checksum, _, err := prepareInProgressPropertyParameters(c.parametersSourceListers(), c.bindingLister, testNamespace, nil, getTestParametersFromSecret())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return checksum
+			},
+			optIn: true,
+		},
+		{
+			name:     "not opted in",
+			checksum: func(*controller) string { return "stale-checksum" },
+			optIn:    false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+			setTestParametersSourceListers(testController, secret)
+
+			instance := getTestServiceInstanceWithParametersFrom(tc.checksum(testController))
+			instance.Spec.UpdateOnParametersFromChange = tc.optIn
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+		})
+	}
+}
+
+// TestReconcileServiceBindingParametersDrifted tests that a binding whose
+// parameters drifted from the parameters it was created with gets the
+// ParametersDrifted condition rather than being updated.
+func TestReconcileServiceBindingParametersDrifted(t *testing.T) {
+	defer enableWatchParametersFrom(t)()
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	setTestParametersSourceListers(testController, getTestParametersSecret(`{"a": "2"}`))
+
+	binding := getTestServiceBindingWithParametersFrom("stale-checksum")
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updated := assertUpdateStatus(t, actions[0], binding)
+	assertServiceBindingCondition(t, updated, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue)
+	assertServiceBindingCondition(t, updated, v1beta1.ServiceBindingConditionParametersDrifted, v1beta1.ConditionTrue, parametersFromChangedReason)
+
+	// Once the condition is set, a further reconciliation is a no-op.
+	fakeCatalogClient.ClearActions()
+	if err := reconcileServiceBinding(t, testController, updated.(*v1beta1.ServiceBinding)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+)
+
+const (
+	migratingServicePlanReason       string = "MigratingServicePlan"
+	migratingServicePlanMessage      string = "Migrating to plan %q as requested by the plan migration policy of ClusterServiceBroker %q"
+	errorMigratingServicePlansReason string = "ErrorMigratingServicePlans"
+)
+
+// reconcileClusterServiceBrokerPlanMigrations moves the next batch of the
+// instances of each migration of the plan migration policy of a ready broker
+// to their replacement plan, and records the progress of the migrations in
+// the status of the broker. The instance controller then sends the plan
+// changes to the broker.
+func (c *controller) reconcileClusterServiceBrokerPlanMigrations(broker *v1beta1.ClusterServiceBroker) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServicePlanMigration) {
+		return nil
+	}
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+
+	var statuses []v1beta1.PlanMigrationStatus
+	if policy := broker.Spec.PlanMigrationPolicy; policy != nil && len(policy.Migrations) > 0 {
+		var err error
+		statuses, err = c.migrateClusterServiceBrokerPlans(broker, policy)
+		if err != nil {
+			s := fmt.Sprintf("Error migrating instances to replacement plans: %v", err)
+			glog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorMigratingServicePlansReason, s)
+			return err
+		}
+	}
+
+	if reflect.DeepEqual(broker.Status.PlanMigrations, statuses) {
+		return nil
+	}
+	toUpdate := broker.DeepCopy()
+	toUpdate.Status.PlanMigrations = statuses
+	if _, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate); err != nil {
+		glog.Error(pcb.Messagef("Error updating plan migration status: %v", err))
+		return err
+	}
+	return nil
+}
+
+// migrateClusterServiceBrokerPlans applies each migration of the policy to
+// the classes of the broker it selects, and returns their progress.
+func (c *controller) migrateClusterServiceBrokerPlans(broker *v1beta1.ClusterServiceBroker, policy *v1beta1.PlanMigrationPolicy) ([]v1beta1.PlanMigrationStatus, error) {
+	allClasses, err := c.clusterServiceClassLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	allPlans, err := c.clusterServicePlanLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	instances, err := c.instanceLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var classes []*v1beta1.ClusterServiceClass
+	for _, class := range allClasses {
+		if class.Spec.ClusterServiceBrokerName == broker.Name {
+			classes = append(classes, class)
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Spec.ExternalName < classes[j].Spec.ExternalName })
+
+	var statuses []v1beta1.PlanMigrationStatus
+	for _, migration := range policy.Migrations {
+		found := false
+		for _, class := range classes {
+			if migration.ServiceClassExternalName != "" && migration.ServiceClassExternalName != class.Spec.ExternalName {
+				continue
+			}
+			found = true
+			from := findClusterServicePlan(allPlans, class, migration.FromPlanExternalName)
+			if from == nil && migration.ServiceClassExternalName == "" {
+				// Only the classes that have the plan are migrated.
+				continue
+			}
+			status := v1beta1.PlanMigrationStatus{
+				ServiceClassExternalName: class.Spec.ExternalName,
+				FromPlanExternalName:     migration.FromPlanExternalName,
+				ToPlanExternalName:       migration.ToPlanExternalName,
+			}
+			if from != nil {
+				to := findClusterServicePlan(allPlans, class, migration.ToPlanExternalName)
+				c.migrateClusterServicePlan(broker, policy, class, from, to, instances, &status)
+			}
+			statuses = append(statuses, status)
+		}
+		if !found && migration.ServiceClassExternalName != "" {
+			statuses = append(statuses, v1beta1.PlanMigrationStatus{
+				ServiceClassExternalName: migration.ServiceClassExternalName,
+				FromPlanExternalName:     migration.FromPlanExternalName,
+				ToPlanExternalName:       migration.ToPlanExternalName,
+				Message:                  fmt.Sprintf("ClusterServiceClass with external name %q does not exist", migration.ServiceClassExternalName),
+			})
+		}
+	}
+	return statuses, nil
+}
+
+// migrateClusterServicePlan moves the instances of the from plan to the to
+// plan in batches of the size of the policy, and reports the progress in the
+// given status. Failures to update single instances are reported as events
+// and retried the next time the broker is reconciled.
+func (c *controller) migrateClusterServicePlan(broker *v1beta1.ClusterServiceBroker, policy *v1beta1.PlanMigrationPolicy, class *v1beta1.ClusterServiceClass, from, to *v1beta1.ClusterServicePlan, instances []*v1beta1.ServiceInstance, status *v1beta1.PlanMigrationStatus) {
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+
+	var candidates []*v1beta1.ServiceInstance
+	for _, instance := range instances {
+		if instance.DeletionTimestamp != nil ||
+			instance.Spec.ClusterServiceClassRef == nil ||
+			instance.Spec.ClusterServiceClassRef.Name != class.Name ||
+			instance.Status.ProvisionStatus != v1beta1.ServiceInstanceProvisionStatusProvisioned ||
+			instance.Status.ExternalProperties == nil ||
+			instance.Status.ExternalProperties.ClusterServicePlanExternalID != from.Spec.ExternalID {
+			continue
+		}
+		status.RemainingInstances++
+		key := fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)
+		switch {
+		case to != nil && instanceSpecTargetsClusterServicePlan(instance, to):
+			if isServiceInstanceMigrationFailed(instance) {
+				status.FailedInstances = append(status.FailedInstances, key)
+			} else {
+				status.MigratingInstances = append(status.MigratingInstances, key)
+			}
+		case instanceSpecTargetsClusterServicePlan(instance, from):
+			candidates = append(candidates, instance)
+		}
+	}
+	sort.Strings(status.MigratingInstances)
+	sort.Strings(status.FailedInstances)
+
+	switch {
+	case to == nil:
+		status.Message = fmt.Sprintf("ClusterServicePlan with external name %q does not exist", status.ToPlanExternalName)
+		return
+	case to.Status.RemovedFromBrokerCatalog:
+		status.Message = fmt.Sprintf("ClusterServicePlan with external name %q has been removed from the catalog of the broker", status.ToPlanExternalName)
+		return
+	}
+
+	batchSize := int(policy.BatchSize)
+	if batchSize == 0 {
+		batchSize = 10
+	}
+	free := batchSize - len(status.MigratingInstances) - len(status.FailedInstances)
+	if free <= 0 || len(candidates) == 0 {
+		return
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	if len(candidates) > free {
+		candidates = candidates[:free]
+	}
+
+	for _, instance := range candidates {
+		key := fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)
+		if policy.DryRun {
+			status.PendingInstances = append(status.PendingInstances, key)
+			continue
+		}
+		toUpdate := instance.DeepCopy()
+		setInstanceSpecClusterServicePlan(toUpdate, to)
+		glog.V(4).Info(pcb.Messagef("Migrating ServiceInstance %q from plan %q to plan %q", key, from.Spec.ExternalName, to.Spec.ExternalName))
+		updated, err := c.serviceCatalogClient.ServiceInstances(instance.Namespace).Update(toUpdate)
+		if err != nil && !apierrors.IsConflict(err) {
+			s := fmt.Sprintf("Error migrating ServiceInstance %q to plan %q: %v", key, to.Spec.ExternalName, err)
+			glog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorMigratingServicePlansReason, s)
+			continue
+		}
+		// A conflict means the informer has not caught up with an earlier
+		// migration of the instance yet.
+		if err == nil {
+			c.recorder.Eventf(updated, corev1.EventTypeNormal, migratingServicePlanReason, migratingServicePlanMessage, to.Spec.ExternalName, broker.Name)
+		}
+		status.MigratingInstances = append(status.MigratingInstances, key)
+	}
+	sort.Strings(status.MigratingInstances)
+}
+
+// findClusterServicePlan returns the plan of the class with the given
+// external name, or nil if there is none.
+func findClusterServicePlan(plans []*v1beta1.ClusterServicePlan, class *v1beta1.ClusterServiceClass, externalName string) *v1beta1.ClusterServicePlan {
+	for _, plan := range plans {
+		if plan.Spec.ClusterServiceClassRef.Name == class.Name && plan.Spec.ExternalName == externalName {
+			return plan
+		}
+	}
+	return nil
+}
+
+// instanceSpecTargetsClusterServicePlan returns whether the spec of the
+// instance references the given plan, by whichever field it references its
+// plan with.
+func instanceSpecTargetsClusterServicePlan(instance *v1beta1.ServiceInstance, plan *v1beta1.ClusterServicePlan) bool {
+	switch {
+	case instance.Spec.ClusterServicePlanName != "":
+		return instance.Spec.ClusterServicePlanName == plan.Name
+	case instance.Spec.ClusterServicePlanExternalID != "":
+		return instance.Spec.ClusterServicePlanExternalID == plan.Spec.ExternalID
+	default:
+		return instance.Spec.ClusterServicePlanExternalName == plan.Spec.ExternalName
+	}
+}
+
+// setInstanceSpecClusterServicePlan references the given plan in the spec of
+// the instance, by the same field the instance references its plan with.
+func setInstanceSpecClusterServicePlan(instance *v1beta1.ServiceInstance, plan *v1beta1.ClusterServicePlan) {
+	switch {
+	case instance.Spec.ClusterServicePlanName != "":
+		instance.Spec.ClusterServicePlanName = plan.Name
+	case instance.Spec.ClusterServicePlanExternalID != "":
+		instance.Spec.ClusterServicePlanExternalID = plan.Spec.ExternalID
+	default:
+		instance.Spec.ClusterServicePlanExternalName = plan.Spec.ExternalName
+	}
+}
+
+// isServiceInstanceMigrationFailed returns whether the plan change of an
+// instance that is still provisioned on its old plan has failed, either
+// terminally or because the controller is done processing it without the
+// instance becoming ready.
+func isServiceInstanceMigrationFailed(instance *v1beta1.ServiceInstance) bool {
+	if isServiceInstanceFailed(instance) {
+		return true
+	}
+	return instance.Status.CurrentOperation == "" &&
+		instance.Status.ObservedGeneration >= instance.Generation &&
+		!isServiceInstanceReady(instance)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+// getTestServiceInstanceOnRemovedPlan returns a provisioned instance of the
+// removed plan of getTestMarkedAsRemovedClusterServicePlan().
+func getTestServiceInstanceOnRemovedPlan(name string) *v1beta1.ServiceInstance {
+	instance := getTestServiceInstance()
+	instance.Name = name
+	instance.Spec.ClusterServicePlanExternalName = testRemovedClusterServicePlanName
+	instance.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: testClusterServiceClassGUID}
+	instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: testRemovedClusterServicePlanGUID}
+	instance.Status.ObservedGeneration = instance.Generation
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testRemovedClusterServicePlanName,
+		ClusterServicePlanExternalID:   testRemovedClusterServicePlanGUID,
+	}
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	return instance
+}
+
+func TestReconcileClusterServiceBrokerPlanMigrations(t *testing.T) {
+	if err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanMigration)); err != nil {
+		t.Fatalf("Failed to enable service plan migration feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServicePlanMigration))
+
+	migrating := getTestServiceInstanceOnRemovedPlan("migrating")
+	migrating.Generation = 2
+	migrating.Spec.ClusterServicePlanExternalName = testClusterServicePlanName
+	migrating.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+
+	failed := getTestServiceInstanceOnRemovedPlan("failed")
+	failed.Generation = 2
+	failed.Status.ObservedGeneration = 2
+	failed.Spec.ClusterServicePlanExternalName = testClusterServicePlanName
+	failed.Status.Conditions[0].Status = v1beta1.ConditionFalse
+
+	cases := []struct {
+		name      string
+		instances []*v1beta1.ServiceInstance
+		toPlan    string
+		batchSize int32
+		dryRun    bool
+		updated   []string
+		status    v1beta1.PlanMigrationStatus
+	}{
+		{
+			name: "migrates the first batch",
+			instances: []*v1beta1.ServiceInstance{
+				getTestServiceInstanceOnRemovedPlan("c"),
+				getTestServiceInstanceOnRemovedPlan("a"),
+				getTestServiceInstanceOnRemovedPlan("b"),
+			},
+			toPlan:    testClusterServicePlanName,
+			batchSize: 2,
+			updated:   []string{"a", "b"},
+			status: v1beta1.PlanMigrationStatus{
+				RemainingInstances: 3,
+				MigratingInstances: []string{testNamespace + "/a", testNamespace + "/b"},
+			},
+		},
+		{
+			name: "in progress and failed migrations hold their place in the batch",
+			instances: []*v1beta1.ServiceInstance{
+				getTestServiceInstanceOnRemovedPlan("a"),
+				getTestServiceInstanceOnRemovedPlan("b"),
+				migrating,
+				failed,
+			},
+			toPlan:    testClusterServicePlanName,
+			batchSize: 3,
+			updated:   []string{"a"},
+			status: v1beta1.PlanMigrationStatus{
+				RemainingInstances: 4,
+				MigratingInstances: []string{testNamespace + "/a", testNamespace + "/migrating"},
+				FailedInstances:    []string{testNamespace + "/failed"},
+			},
+		},
+		{
+			name: "dry run",
+			instances: []*v1beta1.ServiceInstance{
+				getTestServiceInstanceOnRemovedPlan("a"),
+				getTestServiceInstanceOnRemovedPlan("b"),
+			},
+			toPlan:    testClusterServicePlanName,
+			batchSize: 1,
+			dryRun:    true,
+			status: v1beta1.PlanMigrationStatus{
+				RemainingInstances: 2,
+				PendingInstances:   []string{testNamespace + "/a"},
+			},
+		},
+		{
+			name: "replacement plan does not exist",
+			instances: []*v1beta1.ServiceInstance{
+				getTestServiceInstanceOnRemovedPlan("a"),
+			},
+			toPlan:    testNonExistentClusterServicePlanName,
+			batchSize: 1,
+			status: v1beta1.PlanMigrationStatus{
+				RemainingInstances: 1,
+				Message:            fmt.Sprintf("ClusterServicePlan with external name %q does not exist", testNonExistentClusterServicePlanName),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestMarkedAsRemovedClusterServicePlan())
+			for _, instance := range tc.instances {
+				sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+			}
+
+			broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+			broker.Spec.PlanMigrationPolicy = &v1beta1.PlanMigrationPolicy{
+				Migrations: []v1beta1.PlanMigration{{
+					FromPlanExternalName: testRemovedClusterServicePlanName,
+					ToPlanExternalName:   tc.toPlan,
+				}},
+				BatchSize: tc.batchSize,
+				DryRun:    tc.dryRun,
+			}
+
+			if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+				t.Fatalf("This should not fail: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, len(tc.updated)+1)
+			for i, name := range tc.updated {
+				updated := assertUpdate(t, actions[i], getTestServiceInstanceOnRemovedPlan(name)).(*v1beta1.ServiceInstance)
+				if e, a := testClusterServicePlanName, updated.Spec.ClusterServicePlanExternalName; e != a {
+					t.Fatalf("Unexpected plan of migrated instance: expected %v, got %v", e, a)
+				}
+			}
+
+			updatedBroker := assertUpdateStatus(t, actions[len(tc.updated)], broker).(*v1beta1.ClusterServiceBroker)
+			expected := tc.status
+			expected.ServiceClassExternalName = testClusterServiceClassName
+			expected.FromPlanExternalName = testRemovedClusterServicePlanName
+			expected.ToPlanExternalName = tc.toPlan
+			if e, a := []v1beta1.PlanMigrationStatus{expected}, updatedBroker.Status.PlanMigrations; !reflect.DeepEqual(e, a) {
+				t.Fatalf("Unexpected plan migration status: expected %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestReconcileClusterServiceBrokerPlanMigrationsDisabled(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestMarkedAsRemovedClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceOnRemovedPlan("a"))
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	broker.Spec.PlanMigrationPolicy = &v1beta1.PlanMigrationPolicy{
+		Migrations: []v1beta1.PlanMigration{{
+			FromPlanExternalName: testRemovedClusterServicePlanName,
+			ToPlanExternalName:   testClusterServicePlanName,
+		}},
+	}
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
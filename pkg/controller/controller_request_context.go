@@ -0,0 +1,87 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+const (
+	requestContextPlatformKey             string = "platform"
+	requestContextNamespaceKey            string = "namespace"
+	requestContextInstanceNameKey         string = "instance_name"
+	requestContextInstanceUIDKey          string = "instance_uid"
+	requestContextNamespaceLabelsKey      string = "namespace_labels"
+	requestContextNamespaceAnnotationsKey string = "namespace_annotations"
+)
+
+// getServiceInstanceRequestContext returns the extra fields of the context
+// of the requests about the instance selected by its broker, or else by the
+// controller. It returns nil if none are selected.
+func (c *controller) getServiceInstanceRequestContext(instance *v1beta1.ServiceInstance) *v1beta1.ServiceBrokerRequestContext {
+	if spec := c.getServiceInstanceBrokerSpec(instance); spec != nil && spec.RequestContext != nil {
+		return spec.RequestContext
+	}
+	return c.defaultRequestContext
+}
+
+// buildRequestContext returns the OSB context of the requests about the
+// given instance, whose namespace is ns. Besides the platform, the namespace
+// and the cluster ID, it holds the extra fields selected for the broker of
+// the instance.
+func (c *controller) buildRequestContext(instance *v1beta1.ServiceInstance, ns *corev1.Namespace) map[string]interface{} {
+	requestContext := map[string]interface{}{}
+
+	if selected := c.getServiceInstanceRequestContext(instance); selected != nil {
+		// The static fields go first so that they cannot replace the
+		// fields set by the controller.
+		for key, value := range selected.StaticFields {
+			requestContext[key] = value
+		}
+		if selected.Instance {
+			requestContext[requestContextInstanceNameKey] = instance.Name
+			requestContext[requestContextInstanceUIDKey] = string(instance.UID)
+		}
+		if ns != nil {
+			if labels := selectRequestContextKeys(ns.Labels, selected.NamespaceLabels); len(labels) > 0 {
+				requestContext[requestContextNamespaceLabelsKey] = labels
+			}
+			if annotations := selectRequestContextKeys(ns.Annotations, selected.NamespaceAnnotations); len(annotations) > 0 {
+				requestContext[requestContextNamespaceAnnotationsKey] = annotations
+			}
+		}
+	}
+
+	requestContext[requestContextPlatformKey] = ContextProfilePlatformKubernetes
+	requestContext[requestContextNamespaceKey] = instance.Namespace
+	requestContext[clusterIdentifierKey] = c.getClusterID()
+	return requestContext
+}
+
+// selectRequestContextKeys returns the entries of values whose keys are
+// listed in keys. Listed keys missing from values are left out.
+func selectRequestContextKeys(values map[string]string, keys []string) map[string]interface{} {
+	selected := map[string]interface{}{}
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			selected[key] = value
+		}
+	}
+	return selected
+}
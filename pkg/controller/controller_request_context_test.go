@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// TestBuildRequestContext tests that the context of the requests about an
+// instance holds the extra fields selected by the broker of the instance, or
+// else by the controller.
+func TestBuildRequestContext(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testNamespace,
+			Labels: map[string]string{
+				"cost-center": "1234",
+				"environment": "production",
+			},
+			Annotations: map[string]string{
+				"example.com/owner": "team-a",
+			},
+		},
+	}
+
+	cases := []struct {
+		name                  string
+		defaultRequestContext *v1beta1.ServiceBrokerRequestContext
+		brokerRequestContext  *v1beta1.ServiceBrokerRequestContext
+		expected              map[string]interface{}
+	}{
+		{
+			name:     "nothing selected",
+			expected: testContext,
+		},
+		{
+			name: "selected by the controller",
+			defaultRequestContext: &v1beta1.ServiceBrokerRequestContext{
+				Instance:             true,
+				NamespaceLabels:      []string{"cost-center", "missing"},
+				NamespaceAnnotations: []string{"example.com/owner"},
+				StaticFields:         map[string]string{"region": "eu-west-1"},
+			},
+			expected: map[string]interface{}{
+				"platform":           ContextProfilePlatformKubernetes,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: testClusterID,
+				"instance_name":      testServiceInstanceName,
+				"instance_uid":       "test-instance-uid",
+				"namespace_labels": map[string]interface{}{
+					"cost-center": "1234",
+				},
+				"namespace_annotations": map[string]interface{}{
+					"example.com/owner": "team-a",
+				},
+				"region": "eu-west-1",
+			},
+		},
+		{
+			name: "selected by the broker",
+			defaultRequestContext: &v1beta1.ServiceBrokerRequestContext{
+				Instance: true,
+			},
+			brokerRequestContext: &v1beta1.ServiceBrokerRequestContext{
+				NamespaceLabels: []string{"environment"},
+			},
+			expected: map[string]interface{}{
+				"platform":           ContextProfilePlatformKubernetes,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: testClusterID,
+				"namespace_labels": map[string]interface{}{
+					"environment": "production",
+				},
+			},
+		},
+		{
+			name: "static fields do not replace the fields of the controller",
+			defaultRequestContext: &v1beta1.ServiceBrokerRequestContext{
+				StaticFields: map[string]string{
+					"namespace": "other-ns",
+					"team":      "a",
+				},
+			},
+			expected: map[string]interface{}{
+				"platform":           ContextProfilePlatformKubernetes,
+				"namespace":          testNamespace,
+				clusterIdentifierKey: testClusterID,
+				"team":               "a",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.defaultRequestContext = tc.defaultRequestContext
+
+			broker := getTestClusterServiceBroker()
+			broker.Spec.RequestContext = tc.brokerRequestContext
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.UID = types.UID("test-instance-uid")
+
+			requestContext := testController.buildRequestContext(instance, ns)
+			if !reflect.DeepEqual(tc.expected, requestContext) {
+				t.Fatalf("Unexpected request context: %v", expectedGot(tc.expected, requestContext))
+			}
+		})
+	}
+}
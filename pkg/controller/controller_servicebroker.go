@@ -31,6 +31,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 )
 
@@ -76,6 +77,7 @@ func (c *controller) serviceBrokerDelete(obj interface{}) {
 	}
 
 	glog.V(4).Infof("Received delete event for ServiceBroker %v; no further processing will occur", broker.Name)
+	osbclientproxy.RemoveBrokerRequestLimits(osbclientproxy.BrokerName(broker.Namespace, broker.Name))
 }
 
 // shouldReconcileServiceBroker determines whether a broker should be reconciled; it
@@ -191,7 +193,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
 
 		glog.V(4).Info(pcb.Messagef("Creating client, URL: %v", broker.Spec.URL))
-		brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err := c.newBrokerClient(clientConfig, &broker.Spec.CommonServiceBrokerSpec)
 		if err != nil {
 			s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 			glog.Info(pcb.Message(s))
@@ -207,6 +209,9 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		// get the broker's catalog
 		now := metav1.Now()
 		brokerCatalog, err := brokerClient.GetCatalog()
+		if limitedErr, ok := osbclientproxy.IsRequestLimitedError(err); ok {
+			return c.requeueServiceBrokerForRequestLimit(broker, limitedErr)
+		}
 		if err != nil {
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			glog.Warning(pcb.Message(s))
@@ -276,6 +281,17 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		existingServiceClassMap := convertServiceClassListToMap(existingServiceClasses)
 		existingServicePlanMap := convertServicePlanListToMap(existingServicePlans)
 
+		// record the classes and plans that are added, removed or changed
+		// in the catalog history of the broker
+		diff := &catalogDiff{}
+		classExternalNames := make(map[string]string)
+		for _, existingServiceClass := range existingServiceClasses {
+			classExternalNames[existingServiceClass.Name] = existingServiceClass.Spec.ExternalName
+		}
+		for _, payloadServiceClass := range payloadServiceClasses {
+			classExternalNames[payloadServiceClass.Name] = payloadServiceClass.Spec.ExternalName
+		}
+
 		// reconcile the serviceClasses that were part of the broker's catalog
 		// payload
 		for _, payloadServiceClass := range payloadServiceClasses {
@@ -298,6 +314,12 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 
 			glog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServiceClassName(payloadServiceClass)))
+
+			if existingServiceClass == nil || existingServiceClass.Status.RemovedFromBrokerCatalog {
+				diff.addClass(v1beta1.ServiceBrokerCatalogChangeAdded, payloadServiceClass.Name, &payloadServiceClass.Spec.CommonServiceClassSpec, nil)
+			} else if fields := serviceClassChangedFields(&existingServiceClass.Spec.CommonServiceClassSpec, &payloadServiceClass.Spec.CommonServiceClassSpec); len(fields) > 0 {
+				diff.addClass(v1beta1.ServiceBrokerCatalogChangeChanged, payloadServiceClass.Name, &payloadServiceClass.Spec.CommonServiceClassSpec, fields)
+			}
 		}
 
 		// handle the serviceClasses that were not in the broker's payload;
@@ -323,6 +345,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				}
 				return err
 			}
+			diff.addClass(v1beta1.ServiceBrokerCatalogChangeRemoved, existingServiceClass.Name, &existingServiceClass.Spec.CommonServiceClassSpec, nil)
 		}
 
 		// reconcile the plans that were part of the broker's catalog payload
@@ -347,6 +370,12 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 			glog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServicePlanName(payloadServicePlan)))
 
+			classExternalName := classExternalNames[payloadServicePlan.Spec.ServiceClassRef.Name]
+			if existingServicePlan == nil || existingServicePlan.Status.RemovedFromBrokerCatalog {
+				diff.addPlan(v1beta1.ServiceBrokerCatalogChangeAdded, payloadServicePlan.Name, &payloadServicePlan.Spec.CommonServicePlanSpec, classExternalName, nil)
+			} else if fields := servicePlanChangedFields(&existingServicePlan.Spec.CommonServicePlanSpec, &payloadServicePlan.Spec.CommonServicePlanSpec); len(fields) > 0 {
+				diff.addPlan(v1beta1.ServiceBrokerCatalogChangeChanged, payloadServicePlan.Name, &payloadServicePlan.Spec.CommonServicePlanSpec, classExternalName, fields)
+			}
 		}
 
 		// handle the servicePlans that were not in the broker's payload;
@@ -372,15 +401,24 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				}
 				return err
 			}
+			classExternalName := classExternalNames[existingServicePlan.Spec.ServiceClassRef.Name]
+			diff.addPlan(v1beta1.ServiceBrokerCatalogChangeRemoved, existingServicePlan.Name, &existingServicePlan.Spec.CommonServicePlanSpec, classExternalName, nil)
 		}
 
-		// everything worked correctly; update the broker's ready condition to
-		// status true
+		// everything worked correctly; record the changes to the catalog and
+		// update the broker's ready condition to status true
+		if !diff.empty() {
+			broker = broker.DeepCopy()
+			recordCatalogDiff(&broker.Status.CommonServiceBrokerStatus, diff, now)
+		}
 		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
 		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
+		if !diff.empty() {
+			c.recorder.Event(broker, corev1.EventTypeNormal, catalogChangedReason, catalogChangedMessage+diff.String())
+		}
 
 		// Update metrics with the number of serviceclass and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
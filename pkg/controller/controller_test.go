@@ -45,6 +45,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
+	kubeinformers "k8s.io/client-go/informers"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -1990,6 +1991,9 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	// create informers
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(fakeCatalogClient, 0)
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(fakeKubeClient, 0)
+	kubeInformers := kubeInformerFactory.Core().V1()
+	kubeAppsInformers := kubeInformerFactory.Apps().V1()
 
 	fakeRecorder := record.NewFakeRecorder(5)
 
@@ -2005,12 +2009,19 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceGrants(),
+		kubeInformers.Secrets(),
+		kubeInformers.ConfigMaps(),
+		kubeAppsInformers.Deployments(),
+		kubeAppsInformers.StatefulSets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		nil,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
 	)
@@ -3748,6 +3759,18 @@ func addGetSecretNotFoundReaction(fakeKubeClient *clientgofake.Clientset) {
 	})
 }
 
+func addGetConfigMapNotFoundReaction(fakeKubeClient *clientgofake.Clientset) {
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+	})
+}
+
+func addGetConfigMapReaction(fakeKubeClient *clientgofake.Clientset, configMap *corev1.ConfigMap) {
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, configMap, nil
+	})
+}
+
 func addGetSecretReaction(fakeKubeClient *clientgofake.Clientset, secret *corev1.Secret) {
 	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
 		return true, secret, nil
@@ -20,14 +20,61 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/jsonschema"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 )
 
+// parametersSources gets the Secrets and ConfigMaps that the parameters of
+// ParametersFrom are read from.
+type parametersSources interface {
+	getSecret(namespace, name string) (*corev1.Secret, error)
+	getConfigMap(namespace, name string) (*corev1.ConfigMap, error)
+}
+
+// clientParametersSources reads the sources from the API server, for the
+// parameters sent to a broker.
+type clientParametersSources struct {
+	kubeClient kubernetes.Interface
+}
+
+func (s clientParametersSources) getSecret(namespace, name string) (*corev1.Secret, error) {
+	return s.kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+}
+
+func (s clientParametersSources) getConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	return s.kubeClient.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+}
+
+// listerParametersSources reads the sources from the informer caches, for
+// the checks of whether the parameters changed that run on every
+// reconciliation and every change of a source.
+type listerParametersSources struct {
+	secretLister    corelisters.SecretLister
+	configMapLister corelisters.ConfigMapLister
+}
+
+func (s listerParametersSources) getSecret(namespace, name string) (*corev1.Secret, error) {
+	return s.secretLister.Secrets(namespace).Get(name)
+}
+
+func (s listerParametersSources) getConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	return s.configMapLister.ConfigMaps(namespace).Get(name)
+}
+
 // buildParameters generates the parameters JSON structure to be passed
 // to the broker.
 // The first return value is a map of parameters to send to the Broker, including
@@ -35,21 +82,26 @@ import (
 // The second return value is a map of parameters with secret values redacted,
 // replaced with "<redacted>".
 // The third return value is any error that caused the function to fail.
-func buildParameters(kubeClient kubernetes.Interface, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
+func buildParameters(sources parametersSources, bindingLister listers.ServiceBindingLister, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
 	params := make(map[string]interface{})
 	paramsWithSecretsRedacted := make(map[string]interface{})
 	if parametersFrom != nil {
 		for _, p := range parametersFrom {
-			fps, err := fetchParametersFromSource(kubeClient, namespace, &p)
+			fps, err := fetchParametersFromSource(sources, bindingLister, namespace, &p)
 			if err != nil {
 				return nil, nil, err
 			}
+			secret := isSecretParametersFromSource(&p)
 			for k, v := range fps {
 				if _, ok := params[k]; ok {
 					return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
 				}
 				params[k] = v
-				paramsWithSecretsRedacted[k] = "<redacted>"
+				if secret {
+					paramsWithSecretsRedacted[k] = "<redacted>"
+				} else {
+					paramsWithSecretsRedacted[k] = v
+				}
 			}
 		}
 	}
@@ -79,21 +131,59 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 
 // fetchParametersFromSource fetches data from a specified external source and
 // represents it in the parameters map format
-func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
-	var params map[string]interface{}
-	if parametersFrom.SecretKeyRef != nil {
-		data, err := fetchSecretKeyValue(kubeClient, namespace, parametersFrom.SecretKeyRef)
+func fetchParametersFromSource(sources parametersSources, bindingLister listers.ServiceBindingLister, namespace string, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
+	switch {
+	case parametersFrom.SecretKeyRef != nil:
+		data, err := fetchSecretKeyValue(sources, namespace, parametersFrom.SecretKeyRef)
 		if err != nil {
 			return nil, err
 		}
-		p, err := unmarshalJSON(data)
+		return unmarshalJSON(data)
+	case parametersFrom.ConfigMapKeyRef != nil:
+		data, err := fetchConfigMapKeyValue(sources, namespace, parametersFrom.ConfigMapKeyRef)
 		if err != nil {
 			return nil, err
 		}
-		params = p
-
+		return unmarshalJSON([]byte(data))
+	case parametersFrom.SecretRef != nil:
+		secret, err := sources.getSecret(namespace, parametersFrom.SecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		params := make(map[string]interface{})
+		for k, v := range secret.Data {
+			params[k] = string(v)
+		}
+		return params, nil
+	case parametersFrom.ConfigMapRef != nil:
+		configMap, err := sources.getConfigMap(namespace, parametersFrom.ConfigMapRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		params := make(map[string]interface{})
+		for k, v := range configMap.Data {
+			params[k] = v
+		}
+		return params, nil
+	case parametersFrom.ServiceBindingKeyRef != nil:
+		ref := parametersFrom.ServiceBindingKeyRef
+		data, err := fetchServiceBindingKeyValue(sources, bindingLister, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		parameter := ref.Parameter
+		if parameter == "" {
+			parameter = ref.Key
+		}
+		return map[string]interface{}{parameter: string(data)}, nil
 	}
-	return params, nil
+	return nil, nil
+}
+
+// isSecretParametersFromSource returns whether the parameters of the given
+// source are read from a Secret and have to be redacted.
+func isSecretParametersFromSource(parametersFrom *v1beta1.ParametersFromSource) bool {
+	return parametersFrom.ConfigMapKeyRef == nil && parametersFrom.ConfigMapRef == nil
 }
 
 // UnmarshalRawParameters produces a map structure from a given raw YAML/JSON input
@@ -125,14 +215,46 @@ func unmarshalJSON(in []byte) (map[string]interface{}, error) {
 }
 
 // fetchSecretKeyValue requests and returns the contents of the given secret key
-func fetchSecretKeyValue(kubeClient kubernetes.Interface, namespace string, secretKeyRef *v1beta1.SecretKeyReference) ([]byte, error) {
-	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(secretKeyRef.Name, metav1.GetOptions{})
+func fetchSecretKeyValue(sources parametersSources, namespace string, secretKeyRef *v1beta1.SecretKeyReference) ([]byte, error) {
+	secret, err := sources.getSecret(namespace, secretKeyRef.Name)
 	if err != nil {
 		return nil, err
 	}
 	return secret.Data[secretKeyRef.Key], nil
 }
 
+// fetchConfigMapKeyValue requests and returns the contents of the given
+// ConfigMap key
+func fetchConfigMapKeyValue(sources parametersSources, namespace string, configMapKeyRef *v1beta1.ConfigMapKeyReference) (string, error) {
+	configMap, err := sources.getConfigMap(namespace, configMapKeyRef.Name)
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[configMapKeyRef.Key], nil
+}
+
+// fetchServiceBindingKeyValue requests and returns the contents of the given
+// key of the Secret of a ServiceBinding. The ServiceBinding has to be ready,
+// so that its Secret holds the credentials of its ServiceInstance.
+func fetchServiceBindingKeyValue(sources parametersSources, bindingLister listers.ServiceBindingLister, namespace string, ref *v1beta1.ServiceBindingKeyReference) ([]byte, error) {
+	binding, err := bindingLister.ServiceBindings(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !isServiceBindingReady(binding) {
+		return nil, fmt.Errorf("ServiceBinding %q is not ready", ref.Name)
+	}
+	secret, err := sources.getSecret(namespace, binding.Spec.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("the Secret of ServiceBinding %q has no key %q", ref.Name, ref.Key)
+	}
+	return data, nil
+}
+
 // generateChecksumOfParameters generates a checksum for the map of parameters.
 // This checksum is used to determine if parameters have changed.
 func generateChecksumOfParameters(params map[string]interface{}) (string, error) {
@@ -154,8 +276,8 @@ func generateChecksumOfParameters(params map[string]interface{}) (string, error)
 // 2 - a checksum for the map of parameters. This checksum is used to determine if parameters have changed.
 // 3 - the map of parameters marshaled into JSON as a RawExtension
 // 4 - any error that caused the function to fail.
-func prepareInProgressPropertyParameters(kubeClient kubernetes.Interface, namespace string, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
-	parameters, parametersWithSecretsRedacted, err := buildParameters(kubeClient, namespace, specParametersFrom, specParameters)
+func prepareInProgressPropertyParameters(sources parametersSources, bindingLister listers.ServiceBindingLister, namespace string, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
+	parameters, parametersWithSecretsRedacted, err := buildParameters(sources, bindingLister, namespace, specParametersFrom, specParameters)
 	if err != nil {
 		return nil, "", nil, fmt.Errorf(
 			"failed to prepare parameters %s: %s",
@@ -185,3 +307,49 @@ func prepareInProgressPropertyParameters(kubeClient kubernetes.Interface, namesp
 
 	return parameters, parametersChecksum, rawParametersWithRedaction, err
 }
+
+// validateParameters validates the parameters to send to the broker against
+// the given parameter schema of a plan, if the ParameterSchemaValidation
+// feature is enabled. Schemas that cannot be parsed or are not supported are
+// ignored, as they are provided by the broker.
+func validateParameters(parameters map[string]interface{}, schema *runtime.RawExtension) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParameterSchemaValidation) ||
+		schema == nil || len(schema.Raw) == 0 {
+		return nil
+	}
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+	errs, err := jsonschema.Validate(schema.Raw, parameters, field.NewPath("parameters"))
+	if err != nil {
+		glog.Warningf("Not validating parameters against unsupported parameter schema: %v", err)
+		return nil
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	fieldErrors := conditionFieldErrors(errs)
+	fields := sets.NewString()
+	for _, e := range fieldErrors {
+		fields.Insert(e.Field)
+	}
+	return &operationError{
+		reason:      errorInvalidParameters,
+		message:     fmt.Sprintf("The parameters do not match the parameter schema of the plan, invalid fields: %s", strings.Join(fields.List(), ", ")),
+		fieldErrors: fieldErrors,
+	}
+}
+
+// conditionFieldErrors converts the given field errors for a condition,
+// without the values of the fields, which may come from secrets.
+func conditionFieldErrors(errs field.ErrorList) []v1beta1.ConditionFieldError {
+	fieldErrors := make([]v1beta1.ConditionFieldError, 0, len(errs))
+	for _, e := range errs {
+		fieldErrors = append(fieldErrors, v1beta1.ConditionFieldError{
+			Type:   string(e.Type),
+			Field:  e.Field,
+			Detail: e.Detail,
+		})
+	}
+	return fieldErrors
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestBuildParameters(t *testing.T) {
@@ -34,12 +40,31 @@ func TestBuildParameters(t *testing.T) {
 			"string-key": []byte("textFromSecret"),
 		},
 	}
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"json-key":   "{ \"fromConfigMap\": 1 }",
+			"string-key": "textFromConfigMap",
+		},
+	}
+	readyBinding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "database-binding", Namespace: "test-ns"},
+		Spec:       v1beta1.ServiceBindingSpec{SecretName: "database-secret"},
+		Status: v1beta1.ServiceBindingStatus{
+			Conditions: []v1beta1.ServiceBindingCondition{
+				{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+			},
+		},
+	}
+	notReadyBinding := readyBinding.DeepCopy()
+	notReadyBinding.Status.Conditions = nil
 
 	cases := []struct {
 		name                                  string
 		parametersFrom                        []v1beta1.ParametersFromSource
 		parameters                            *runtime.RawExtension
 		secret                                *corev1.Secret
+		configMap                             *corev1.ConfigMap
+		binding                               *v1beta1.ServiceBinding
 		expectedParameters                    map[string]interface{}
 		expectedParametersWithSecretsRedacted map[string]interface{}
 		shouldSucceed                         bool
@@ -138,16 +163,156 @@ func TestBuildParameters(t *testing.T) {
 			secret:        secret,
 			shouldSucceed: false,
 		},
+		{
+			name: "parametersFrom: configMapKey with blob",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "configmap",
+						Key:  "json-key",
+					},
+				},
+			},
+			configMap: configMap,
+			expectedParameters: map[string]interface{}{
+				"fromConfigMap": float64(1),
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"fromConfigMap": float64(1),
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: configMapKey with invalid blob",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ConfigMapKeyRef: &v1beta1.ConfigMapKeyReference{
+						Name: "configmap",
+						Key:  "string-key",
+					},
+				},
+			},
+			configMap:     configMap,
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: whole secret",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{SecretRef: &v1beta1.LocalObjectReference{Name: "secret"}},
+			},
+			secret: secret,
+			expectedParameters: map[string]interface{}{
+				"json-key":   "{ \"json\": true }",
+				"string-key": "textFromSecret",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"json-key":   "<redacted>",
+				"string-key": "<redacted>",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: whole configMap",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{ConfigMapRef: &v1beta1.LocalObjectReference{Name: "configmap"}},
+			},
+			configMap: configMap,
+			expectedParameters: map[string]interface{}{
+				"json-key":   "{ \"fromConfigMap\": 1 }",
+				"string-key": "textFromConfigMap",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"json-key":   "{ \"fromConfigMap\": 1 }",
+				"string-key": "textFromConfigMap",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: missing configMap",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{ConfigMapRef: &v1beta1.LocalObjectReference{Name: "configmap"}},
+			},
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: serviceBindingKey",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ServiceBindingKeyRef: &v1beta1.ServiceBindingKeyReference{
+						Name:      "database-binding",
+						Key:       "string-key",
+						Parameter: "databaseEndpoint",
+					},
+				},
+			},
+			secret:  secret,
+			binding: readyBinding,
+			expectedParameters: map[string]interface{}{
+				"databaseEndpoint": "textFromSecret",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"databaseEndpoint": "<redacted>",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: serviceBindingKey defaults the parameter to the key",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ServiceBindingKeyRef: &v1beta1.ServiceBindingKeyReference{
+						Name: "database-binding",
+						Key:  "string-key",
+					},
+				},
+			},
+			secret:  secret,
+			binding: readyBinding,
+			expectedParameters: map[string]interface{}{
+				"string-key": "textFromSecret",
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"string-key": "<redacted>",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "parametersFrom: serviceBindingKey of a binding that is not ready",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ServiceBindingKeyRef: &v1beta1.ServiceBindingKeyReference{
+						Name: "database-binding",
+						Key:  "string-key",
+					},
+				},
+			},
+			secret:        secret,
+			binding:       notReadyBinding,
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: serviceBindingKey missing from the secret",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					ServiceBindingKeyRef: &v1beta1.ServiceBindingKeyReference{
+						Name: "database-binding",
+						Key:  "missing-key",
+					},
+				},
+			},
+			secret:        secret,
+			binding:       readyBinding,
+			shouldSucceed: false,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			testBuildParameters(t, tc.parametersFrom, tc.parameters, tc.secret, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
+			testBuildParameters(t, tc.parametersFrom, tc.parameters, tc.secret, tc.configMap, tc.binding, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
 		})
 	}
 }
 
-func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
+func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, configMap *corev1.ConfigMap, binding *v1beta1.ServiceBinding, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
 	// create a fake kube client
 	fakeKubeClient := &clientgofake.Clientset{}
 	if secret != nil {
@@ -155,8 +320,19 @@ func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSo
 	} else {
 		addGetSecretNotFoundReaction(fakeKubeClient)
 	}
+	if configMap != nil {
+		addGetConfigMapReaction(fakeKubeClient, configMap)
+	} else {
+		addGetConfigMapNotFoundReaction(fakeKubeClient)
+	}
+
+	bindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if binding != nil {
+		bindingIndexer.Add(binding)
+	}
+	bindingLister := listers.NewServiceBindingLister(bindingIndexer)
 
-	actual, actualWithSecretsRedacted, err := buildParameters(fakeKubeClient, "test-ns", parametersFrom, parameters)
+	actual, actualWithSecretsRedacted, err := buildParameters(clientParametersSources{fakeKubeClient}, bindingLister, "test-ns", parametersFrom, parameters)
 	if shouldSucceed {
 		if err != nil {
 			t.Fatalf("Failed to build parameters: %v", err)
@@ -227,3 +403,72 @@ func TestGenerateChecksumOfParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateParameters(t *testing.T) {
+	schema := &runtime.RawExtension{Raw: []byte(`{"type":"object","required":["size"],"properties":{"replicas":{"maximum":3}}}`)}
+	cases := []struct {
+		name                string
+		disabled            bool
+		parameters          map[string]interface{}
+		schema              *runtime.RawExtension
+		expectedError       string
+		expectedFieldErrors []v1beta1.ConditionFieldError
+	}{
+		{
+			name:       "valid",
+			parameters: map[string]interface{}{"size": "small"},
+			schema:     schema,
+		},
+		{
+			name:          "invalid",
+			parameters:    map[string]interface{}{"replicas": 5},
+			schema:        schema,
+			expectedError: "The parameters do not match the parameter schema of the plan, invalid fields: parameters.replicas, parameters.size",
+			expectedFieldErrors: []v1beta1.ConditionFieldError{
+				{Type: "FieldValueRequired", Field: "parameters.size"},
+				{Type: "FieldValueInvalid", Field: "parameters.replicas", Detail: "must be less than or equal to 3"},
+			},
+		},
+		{
+			name:     "feature disabled",
+			disabled: true,
+			schema:   schema,
+		},
+		{
+			name: "no schema",
+		},
+		{
+			name:   "invalid schema",
+			schema: &runtime.RawExtension{Raw: []byte(`{"type":`)},
+		},
+		{
+			name:   "unsupported schema",
+			schema: &runtime.RawExtension{Raw: []byte(`{"$ref":"http://example.com/schema.json"}`)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.disabled {
+				utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ParameterSchemaValidation))
+				defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParameterSchemaValidation))
+			}
+			err := validateParameters(tc.parameters, tc.schema)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			opErr, ok := err.(*operationError)
+			if !ok {
+				t.Fatalf("expected an operation error, got %v", err)
+			}
+			if opErr.reason != errorInvalidParameters || opErr.message != tc.expectedError {
+				t.Fatalf("unexpected error; expected %q, got %q: %q", tc.expectedError, opErr.reason, opErr.message)
+			}
+			if e, a := tc.expectedFieldErrors, opErr.fieldErrors; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected field errors; expected %+v, got %+v", e, a)
+			}
+		})
+	}
+}
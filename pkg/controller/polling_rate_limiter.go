@@ -0,0 +1,147 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// pollingBackoff is the backoff used to poll the last operations of a broker.
+type pollingBackoff struct {
+	initial, max time.Duration
+	// jitter is the fraction of the interval it is randomly lengthened by at
+	// most.
+	jitter float64
+}
+
+// pollingRateLimiter is a workqueue.RateLimiter for the polling queues that
+// backs off exponentially like workqueue.ItemExponentialFailureRateLimiter,
+// but with the backoff of the broker of each item, and that waits exactly as
+// long as a broker asks for with a Retry-After header instead when it did,
+// within the bounds of the backoff.
+type pollingRateLimiter struct {
+	defaultBackoff pollingBackoff
+
+	lock     sync.Mutex
+	failures map[interface{}]int
+	backoffs map[interface{}]pollingBackoff
+	delays   map[interface{}]time.Duration
+	// random returns a number in [0.0, 1.0); it is replaced in tests.
+	random func() float64
+}
+
+func newPollingRateLimiter(initial, max time.Duration) *pollingRateLimiter {
+	return &pollingRateLimiter{
+		defaultBackoff: pollingBackoff{initial: initial, max: max},
+		failures:       map[interface{}]int{},
+		backoffs:       map[interface{}]pollingBackoff{},
+		delays:         map[interface{}]time.Duration{},
+		random:         rand.Float64,
+	}
+}
+
+// When returns how long to wait before polling the item again.
+func (r *pollingRateLimiter) When(item interface{}) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	exp := r.failures[item]
+	r.failures[item] = exp + 1
+
+	backoff, ok := r.backoffs[item]
+	if !ok {
+		backoff = r.defaultBackoff
+	}
+
+	if delay, ok := r.delays[item]; ok {
+		delete(r.delays, item)
+		// A broker must neither make us poll in a busy loop nor stop us
+		// polling for good.
+		if delay < backoff.initial {
+			return backoff.initial
+		}
+		if delay > backoff.max {
+			return backoff.max
+		}
+		return delay
+	}
+
+	delay := backoff.max
+	// Doubling more than 62 times overflows a time.Duration of any length.
+	if exp < 62 {
+		if d := backoff.initial * (1 << uint(exp)); d > 0 && d < backoff.max {
+			delay = d
+		}
+	}
+	if backoff.jitter > 0 {
+		delay += time.Duration(r.random() * backoff.jitter * float64(delay))
+	}
+	return delay
+}
+
+// NumRequeues returns how many times the item has been polled.
+func (r *pollingRateLimiter) NumRequeues(item interface{}) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.failures[item]
+}
+
+// Forget resets the backoff of the item once its operation is done.
+func (r *pollingRateLimiter) Forget(item interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.failures, item)
+	delete(r.backoffs, item)
+	delete(r.delays, item)
+}
+
+// setPollingPolicy sets the backoff of the item to the given polling policy
+// of its broker, falling back to the default backoff for what the policy
+// leaves unset.
+func (r *pollingRateLimiter) setPollingPolicy(item interface{}, policy *v1beta1.ServiceBrokerPollingPolicy) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if policy == nil {
+		delete(r.backoffs, item)
+		return
+	}
+	backoff := r.defaultBackoff
+	if policy.InitialInterval != nil {
+		backoff.initial = policy.InitialInterval.Duration
+	}
+	if policy.MaxInterval != nil {
+		backoff.max = policy.MaxInterval.Duration
+	}
+	backoff.jitter = float64(policy.JitterPercent) / 100
+	r.backoffs[item] = backoff
+}
+
+// setPollDelay makes the item wait for the given delay, as asked for by the
+// broker, before it is polled next.
+func (r *pollingRateLimiter) setPollDelay(item interface{}, delay time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.delays[item] = delay
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func assertPollingDelays(t *testing.T, r *pollingRateLimiter, item string, expected ...time.Duration) {
+	for i, e := range expected {
+		if a := r.When(item); e != a {
+			t.Fatalf("Unexpected delay of poll %d: expected %v, got %v", i+1, e, a)
+		}
+	}
+}
+
+func TestPollingRateLimiterDefaultBackoff(t *testing.T) {
+	r := newPollingRateLimiter(time.Second, 5*time.Second)
+	assertPollingDelays(t, r, "a", time.Second, 2*time.Second, 4*time.Second, 5*time.Second, 5*time.Second)
+	if e, a := 5, r.NumRequeues("a"); e != a {
+		t.Fatalf("Unexpected number of requeues: expected %v, got %v", e, a)
+	}
+
+	r.Forget("a")
+	assertPollingDelays(t, r, "a", time.Second)
+	assertPollingDelays(t, r, "b", time.Second)
+}
+
+func TestPollingRateLimiterPollingPolicy(t *testing.T) {
+	r := newPollingRateLimiter(time.Second, time.Hour)
+	r.random = func() float64 { return 0.5 }
+
+	r.setPollingPolicy("a", &v1beta1.ServiceBrokerPollingPolicy{
+		InitialInterval: &metav1.Duration{Duration: time.Minute},
+		MaxInterval:     &metav1.Duration{Duration: 3 * time.Minute},
+	})
+	assertPollingDelays(t, r, "a", time.Minute, 2*time.Minute, 3*time.Minute)
+
+	// Only the maximum interval is overridden; jitter of up to 20% is
+	// lengthened by half of it with the fixed random number.
+	r.setPollingPolicy("b", &v1beta1.ServiceBrokerPollingPolicy{
+		MaxInterval:   &metav1.Duration{Duration: 2 * time.Second},
+		JitterPercent: 20,
+	})
+	assertPollingDelays(t, r, "b", 1100*time.Millisecond, 2200*time.Millisecond, 2200*time.Millisecond)
+
+	// Forgetting an item drops its policy.
+	r.Forget("a")
+	assertPollingDelays(t, r, "a", time.Second)
+}
+
+func TestPollingRateLimiterPollDelay(t *testing.T) {
+	r := newPollingRateLimiter(time.Second, time.Hour)
+	assertPollingDelays(t, r, "a", time.Second)
+
+	// The delay asked for by the broker replaces the backoff once, whether
+	// it is longer or shorter.
+	r.setPollDelay("a", 30*time.Second)
+	assertPollingDelays(t, r, "a", 30*time.Second, 4*time.Second)
+	r.setPollDelay("a", 3*time.Second)
+	assertPollingDelays(t, r, "a", 3*time.Second, 16*time.Second)
+}
+
+func TestPollingRateLimiterPollDelayClamped(t *testing.T) {
+	r := newPollingRateLimiter(time.Second, time.Hour)
+
+	// Retry-After: 0 must not make the controller poll in a busy loop.
+	r.setPollDelay("a", 0)
+	assertPollingDelays(t, r, "a", time.Second)
+
+	// A huge delay must not stop the controller polling.
+	r.setPollDelay("a", 1000*24*time.Hour)
+	assertPollingDelays(t, r, "a", time.Hour)
+
+	// The bounds are those of the polling policy of the broker.
+	r.setPollingPolicy("b", &v1beta1.ServiceBrokerPollingPolicy{
+		InitialInterval: &metav1.Duration{Duration: time.Minute},
+		MaxInterval:     &metav1.Duration{Duration: 10 * time.Minute},
+	})
+	r.setPollDelay("b", time.Second)
+	assertPollingDelays(t, r, "b", time.Minute)
+	r.setPollDelay("b", time.Hour)
+	assertPollingDelays(t, r, "b", 10*time.Minute)
+}
+
+func TestPollServiceInstanceWithBrokerPollingPolicy(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{
+				State: osb.StateInProgress,
+			},
+		},
+	})
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.PollingPolicy = &v1beta1.ServiceBrokerPollingPolicy{
+		InitialInterval: &metav1.Duration{Duration: time.Minute},
+		JitterPercent:   10,
+	}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceAsyncProvisioning(testOperation)
+	instanceKey := testNamespace + "/" + testServiceInstanceName
+
+	if err := testController.pollServiceInstance(instance); err != nil {
+		t.Fatalf("pollServiceInstance failed: %s", err)
+	}
+
+	expected := pollingBackoff{initial: time.Minute, max: 7 * 24 * time.Hour, jitter: 0.1}
+	if a := testController.instancePollingRateLimiter.backoffs[instanceKey]; expected != a {
+		t.Fatalf("Unexpected polling backoff: expected %+v, got %+v", expected, a)
+	}
+}
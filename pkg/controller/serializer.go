@@ -17,7 +17,25 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+const (
+	// credentialsJSONKey is the Secret key that the credentials are stored
+	// under in the JSON secret format.
+	credentialsJSONKey = "credentials.json"
+	// credentialsEnvKey is the Secret key that the credentials are stored
+	// under in the Env secret format.
+	credentialsEnvKey = "credentials.env"
+	// credentialsPropertiesKey is the Secret key that the credentials are
+	// stored under in the Properties secret format.
+	credentialsPropertiesKey = "credentials.properties"
 )
 
 // TODO: does this need to move to another package?
@@ -55,3 +73,120 @@ func serialize(value interface{}) ([]byte, error) {
 	}
 	return data, nil
 }
+
+// serializeCredentials lays out the given credentials as the data of a Secret
+// according to the given format.
+func serializeCredentials(format v1beta1.ServiceBindingSecretFormat, credentials map[string]interface{}) (map[string][]byte, error) {
+	switch format {
+	case v1beta1.ServiceBindingSecretFormatJSON:
+		data, err := serializeJSON(credentials)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{credentialsJSONKey: data}, nil
+	case v1beta1.ServiceBindingSecretFormatEnv:
+		data, err := serializeLines(credentials, formatEnvLine)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{credentialsEnvKey: data}, nil
+	case v1beta1.ServiceBindingSecretFormatProperties:
+		data, err := serializeLines(credentials, formatPropertiesLine)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{credentialsPropertiesKey: data}, nil
+	case "", v1beta1.ServiceBindingSecretFormatKeys:
+		secretData := make(map[string][]byte)
+		for k, v := range credentials {
+			var err error
+			secretData[k], err = serialize(v)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+			}
+		}
+		return secretData, nil
+	default:
+		return nil, fmt.Errorf("Unsupported secret format %q", format)
+	}
+}
+
+// serializeJSON converts the credentials to a single JSON object. Values
+// merged from other Secrets are raw bytes and are stored as the strings they
+// would appear as in a Secret rather than base64 encoded.
+func serializeJSON(credentials map[string]interface{}) ([]byte, error) {
+	object := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		object[k] = v
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to serialize credentials (values are intentionally not logged): %s", err)
+	}
+	return data, nil
+}
+
+// serializeLines converts the credentials to one line per key, sorted by key,
+// using formatLine to render each key and serialized value.
+func serializeLines(credentials map[string]interface{}, formatLine func(key, value string) string) ([]byte, error) {
+	keys := make([]string, 0, len(credentials))
+	for k := range credentials {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := new(bytes.Buffer)
+	for _, k := range keys {
+		value, err := serialize(credentials[k])
+		if err != nil {
+			return nil, fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+		}
+		buf.WriteString(formatLine(k, string(value)))
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+var envValueEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"$", `\$`,
+	"`", "\\`",
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// formatEnvLine renders a KEY="value" line of a .env file. Values are always
+// double quoted so that they are read back verbatim.
+func formatEnvLine(key, value string) string {
+	return key + `="` + envValueEscaper.Replace(value) + `"`
+}
+
+var propertiesEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"\f", `\f`,
+)
+
+var propertiesKeyEscaper = strings.NewReplacer(
+	"=", `\=`,
+	":", `\:`,
+	" ", `\ `,
+	"#", `\#`,
+	"!", `\!`,
+)
+
+// formatPropertiesLine renders a key=value line of a Java properties file.
+func formatPropertiesLine(key, value string) string {
+	value = propertiesEscaper.Replace(value)
+	if strings.HasPrefix(value, " ") {
+		// Leading whitespace in a value is otherwise skipped when read back
+		value = `\` + value
+	}
+	return propertiesKeyEscaper.Replace(propertiesEscaper.Replace(key)) + "=" + value
+}
@@ -24,6 +24,8 @@ import (
 	"bytes"
 	"encoding/base64"
 	"github.com/google/gofuzz"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 )
 
 // Tests in this file test this package's serialize() function by "round
@@ -147,3 +149,109 @@ func TestSerializeByteSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeCredentials(t *testing.T) {
+	credentials := map[string]interface{}{
+		"host":     "db.example.com",
+		"port":     5432,
+		"password": `p@ss "word"`,
+		"ca":       []byte("line1\nline2"),
+		"options":  map[string]interface{}{"ssl": true},
+	}
+
+	cases := []struct {
+		name     string
+		format   v1beta1.ServiceBindingSecretFormat
+		expected map[string][]byte
+	}{
+		{
+			name:   "default",
+			format: "",
+			expected: map[string][]byte{
+				"host":     []byte("db.example.com"),
+				"port":     []byte("5432"),
+				"password": []byte(`p@ss "word"`),
+				"ca":       []byte("line1\nline2"),
+				"options":  []byte(`{"ssl":true}`),
+			},
+		},
+		{
+			name:   "keys",
+			format: v1beta1.ServiceBindingSecretFormatKeys,
+			expected: map[string][]byte{
+				"host":     []byte("db.example.com"),
+				"port":     []byte("5432"),
+				"password": []byte(`p@ss "word"`),
+				"ca":       []byte("line1\nline2"),
+				"options":  []byte(`{"ssl":true}`),
+			},
+		},
+		{
+			name:   "json",
+			format: v1beta1.ServiceBindingSecretFormatJSON,
+			expected: map[string][]byte{
+				credentialsJSONKey: []byte(`{"ca":"line1\nline2","host":"db.example.com","options":{"ssl":true},"password":"p@ss \"word\"","port":5432}`),
+			},
+		},
+		{
+			name:   "env",
+			format: v1beta1.ServiceBindingSecretFormatEnv,
+			expected: map[string][]byte{
+				credentialsEnvKey: []byte(`ca="line1\nline2"
+host="db.example.com"
+options="{\"ssl\":true}"
+password="p@ss \"word\""
+port="5432"
+`),
+			},
+		},
+		{
+			name:   "properties",
+			format: v1beta1.ServiceBindingSecretFormatProperties,
+			expected: map[string][]byte{
+				credentialsPropertiesKey: []byte(`ca=line1\nline2
+host=db.example.com
+options={"ssl":true}
+password=p@ss "word"
+port=5432
+`),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		actual, err := serializeCredentials(tc.format, credentials)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(tc.expected, actual) {
+			t.Errorf("%v: unexpected secret data; expected: %q; actual: %q", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestSerializeCredentialsUnsupportedFormat(t *testing.T) {
+	if _, err := serializeCredentials("XML", map[string]interface{}{}); err == nil {
+		t.Fatal("expected an error for an unsupported secret format")
+	}
+}
+
+func TestFormatPropertiesLine(t *testing.T) {
+	cases := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{key: "a", value: "b", expected: "a=b"},
+		{key: "a key", value: "b", expected: `a\ key=b`},
+		{key: "a=b:c", value: "d", expected: `a\=b\:c=d`},
+		{key: "a", value: " leading", expected: `a=\ leading`},
+		{key: "a", value: `c:\dir`, expected: `a=c:\\dir`},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, formatPropertiesLine(tc.key, tc.value); e != a {
+			t.Errorf("unexpected properties line for %q=%q; expected: %s; actual: %s", tc.key, tc.value, e, a)
+		}
+	}
+}
@@ -71,6 +71,55 @@ const (
 	// owner: @nilebox
 	// alpha: v0.1.14
 	OriginatingIdentityLocking utilfeature.Feature = "OriginatingIdentityLocking"
+
+	// ServicePlanPolicy enables the ClusterServicePlanPolicy resource, which
+	// sets default parameters for and restricts the parameters of the
+	// ServiceInstances and ServiceBindings of service plans.
+	// alpha: v0.1.27
+	ServicePlanPolicy utilfeature.Feature = "ServicePlanPolicy"
+
+	// ParameterSchemaValidation enables the validation of the parameters of
+	// ServiceInstances and ServiceBindings against the parameter schemas of
+	// their plans before they are sent to the broker.
+	// alpha: v0.1.27
+	ParameterSchemaValidation utilfeature.Feature = "ParameterSchemaValidation"
+
+	// BindingWorkloadInjection enables the injection of the secrets of
+	// ServiceBindings into the Deployments and StatefulSets selected by their
+	// workload injection.
+	// alpha: v0.1.27
+	BindingWorkloadInjection utilfeature.Feature = "BindingWorkloadInjection"
+
+	// ServicePlanMigration enables the plan migration policies of
+	// ClusterServiceBrokers, which move the ServiceInstances of deprecated or
+	// removed plans to replacement plans.
+	// alpha: v0.1.27
+	ServicePlanMigration utilfeature.Feature = "ServicePlanMigration"
+
+	// MaintenanceWindows enables the maintenance windows of ServiceInstances
+	// and namespaces, outside of which the updates of ServiceInstances are
+	// held back.
+	// alpha: v0.1.27
+	MaintenanceWindows utilfeature.Feature = "MaintenanceWindows"
+
+	// WatchParametersFrom enables the controller to watch the Secrets and
+	// ConfigMaps referenced by the ParametersFrom of ServiceInstances and
+	// ServiceBindings, updating the instances that opt in and reporting the
+	// bindings whose parameters drifted.
+	// alpha: v0.1.27
+	WatchParametersFrom utilfeature.Feature = "WatchParametersFrom"
+
+	// BindingVolumeMounts enables the controller to translate the volume
+	// mounts returned by brokers for ServiceBindings into PersistentVolumes
+	// and PersistentVolumeClaims.
+	// alpha: v0.1.27
+	BindingVolumeMounts utilfeature.Feature = "BindingVolumeMounts"
+
+	// ServiceInstanceSharing enables the ServiceInstanceGrant resource and
+	// ServiceBindings to ServiceInstances in other namespaces that a grant
+	// shares with them.
+	// alpha: v0.1.27
+	ServiceInstanceSharing utilfeature.Feature = "ServiceInstanceSharing"
 )
 
 func init() {
@@ -88,4 +137,12 @@ var defaultServiceCatalogFeatureGates = map[utilfeature.Feature]utilfeature.Feat
 	ResponseSchema:             {Default: false, PreRelease: utilfeature.Alpha},
 	UpdateDashboardURL:         {Default: false, PreRelease: utilfeature.Alpha},
 	OriginatingIdentityLocking: {Default: true, PreRelease: utilfeature.Alpha},
+	ServicePlanPolicy:          {Default: false, PreRelease: utilfeature.Alpha},
+	ParameterSchemaValidation:  {Default: false, PreRelease: utilfeature.Alpha},
+	BindingWorkloadInjection:   {Default: false, PreRelease: utilfeature.Alpha},
+	ServicePlanMigration:       {Default: false, PreRelease: utilfeature.Alpha},
+	MaintenanceWindows:         {Default: false, PreRelease: utilfeature.Alpha},
+	WatchParametersFrom:        {Default: false, PreRelease: utilfeature.Alpha},
+	BindingVolumeMounts:        {Default: false, PreRelease: utilfeature.Alpha},
+	ServiceInstanceSharing:     {Default: false, PreRelease: utilfeature.Alpha},
 }
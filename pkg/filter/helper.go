@@ -47,3 +47,20 @@ func CreatePredicate(restrictions []string) (Predicate, error) {
 func ConvertToSelector(p Predicate) (labels.Selector, error) {
 	return labels.Parse(p.String())
 }
+
+// Keys returns the keys of the properties that the given predicate selects on.
+func Keys(p Predicate) ([]string, error) {
+	if p.Empty() {
+		return nil, nil
+	}
+	selector, err := ConvertToSelector(p)
+	if err != nil {
+		return nil, err
+	}
+	requirements, _ := selector.Requirements()
+	keys := make([]string, 0, len(requirements))
+	for _, r := range requirements {
+		keys = append(keys, r.Key())
+	}
+	return keys, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package filter
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -80,3 +81,39 @@ func TestCreatePredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestKeys(t *testing.T) {
+	cases := []struct {
+		name         string
+		restrictions []string
+		keys         []string
+	}{
+		{
+			name: "no restrictions",
+		},
+		{
+			name: "multiple restrictions",
+			restrictions: []string{
+				"spec.externalName in (Foo, Bar)",
+				"spec.tags.database",
+				"!spec.externalMetadata.deprecated",
+			},
+			keys: []string{"spec.externalMetadata.deprecated", "spec.externalName", "spec.tags.database"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			predicate, err := CreatePredicate(tc.restrictions)
+			if err != nil {
+				t.Fatalf("Unexpected error from CreatePredicate: %v", err)
+			}
+			keys, err := Keys(predicate)
+			if err != nil {
+				t.Fatalf("Unexpected error from Keys: %v", err)
+			}
+			if !reflect.DeepEqual(keys, tc.keys) {
+				t.Fatalf("Unexpected keys, \n\texpected: \t%q,\n \tgot: \t\t%q", tc.keys, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,588 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jsonschema validates values against the JSON schemas brokers
+// return for the parameters of their plans.
+//
+// It is not a complete JSON Schema implementation. It supports the following
+// validation keywords of JSON Schema draft-04, the draft used by the Open
+// Service Broker API:
+//
+//	type, enum, multipleOf, minimum, maximum, exclusiveMinimum,
+//	exclusiveMaximum, minLength, maxLength, pattern, items, additionalItems,
+//	minItems, maxItems, uniqueItems, required, properties,
+//	patternProperties, additionalProperties, minProperties, maxProperties,
+//	dependencies, allOf, anyOf, oneOf, not, definitions and $ref
+//
+// along with const and the numeric exclusiveMinimum and exclusiveMaximum of
+// draft-06. References must point into the schema itself. The annotation
+// keywords $schema, id, $id, $comment, title, description, default,
+// examples, format, readOnly and writeOnly, and extension keywords starting
+// with "x-", are ignored. Schemas that use any other keyword, an unknown
+// type or a pattern Go cannot compile are rejected, rather than only
+// partially validated.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxRefDepth limits how many references are followed while validating a
+// single value, to stop on schemas that reference themselves.
+const maxRefDepth = 100
+
+// supportedKeywords are the validation keywords that are supported.
+var supportedKeywords = sets.NewString(
+	"type",
+	"enum",
+	"const",
+	"multipleOf",
+	"minimum",
+	"maximum",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"minLength",
+	"maxLength",
+	"pattern",
+	"items",
+	"additionalItems",
+	"minItems",
+	"maxItems",
+	"uniqueItems",
+	"required",
+	"properties",
+	"patternProperties",
+	"additionalProperties",
+	"minProperties",
+	"maxProperties",
+	"dependencies",
+	"allOf",
+	"anyOf",
+	"oneOf",
+	"not",
+	"definitions",
+	"$ref",
+)
+
+// ignoredKeywords are the annotation keywords that do not affect validation.
+var ignoredKeywords = sets.NewString(
+	"$schema",
+	"id",
+	"$id",
+	"$comment",
+	"title",
+	"description",
+	"default",
+	"examples",
+	"format",
+	"readOnly",
+	"writeOnly",
+)
+
+// supportedTypes are the values of the type keyword.
+var supportedTypes = sets.NewString("object", "array", "string", "number", "integer", "boolean", "null")
+
+// Validate validates the given value against the given JSON schema. It
+// returns an error if the schema is not valid JSON or is not supported, and
+// otherwise the violations of the schema, with field paths rooted at fldPath.
+func Validate(schema []byte, value interface{}, fldPath *field.Path) (field.ErrorList, error) {
+	var root interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %v", err)
+	}
+	v := &validator{root: root}
+	if err := v.checkSchema(root, "#", map[string]bool{}); err != nil {
+		return nil, err
+	}
+	// Normalize the value to the types encoding/json produces
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %v", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value: %v", err)
+	}
+
+	return v.validate(root, normalized, fldPath, 0), nil
+}
+
+type validator struct {
+	root interface{}
+}
+
+func (v *validator) validate(schema interface{}, value interface{}, fldPath *field.Path, refDepth int) field.ErrorList {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return field.ErrorList{field.Forbidden(fldPath, "not allowed by schema")}
+		}
+		return nil
+	case map[string]interface{}:
+		if ref, ok := s["$ref"].(string); ok {
+			if refDepth >= maxRefDepth {
+				return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("too many nested references in schema"))}
+			}
+			resolved, ok := v.resolve(ref)
+			if !ok {
+				// checkSchema rejects references that cannot be resolved
+				return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("unresolvable reference %q in schema", ref))}
+			}
+			return v.validate(resolved, value, fldPath, refDepth+1)
+		}
+		return v.validateObjectSchema(s, value, fldPath, refDepth)
+	default:
+		return nil
+	}
+}
+
+func (v *validator) validateObjectSchema(s map[string]interface{}, value interface{}, fldPath *field.Path, refDepth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if types, ok := schemaTypes(s["type"]); ok && !matchesAnyType(value, types) {
+		// the remaining keywords are meaningless for a value of the wrong type
+		return append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be of type %s", strings.Join(types, " or "))))
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, value, formatValues(enum)))
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, value) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be %s", formatValue(c))))
+	}
+
+	switch val := value.(type) {
+	case string:
+		allErrs = append(allErrs, validateString(s, val, fldPath)...)
+	case float64:
+		allErrs = append(allErrs, validateNumber(s, val, fldPath)...)
+	case map[string]interface{}:
+		allErrs = append(allErrs, v.validateObject(s, val, fldPath, refDepth)...)
+	case []interface{}:
+		allErrs = append(allErrs, v.validateArray(s, val, fldPath, refDepth)...)
+	}
+
+	if allOf, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			allErrs = append(allErrs, v.validate(sub, value, fldPath, refDepth)...)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok && v.countMatches(anyOf, value, fldPath, refDepth) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must match at least one of the schemas in anyOf"))
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok && v.countMatches(oneOf, value, fldPath, refDepth) != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must match exactly one of the schemas in oneOf"))
+	}
+	if not, ok := s["not"]; ok && len(v.validate(not, value, fldPath, refDepth)) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must not match the schema in not"))
+	}
+
+	return allErrs
+}
+
+func (v *validator) countMatches(schemas []interface{}, value interface{}, fldPath *field.Path, refDepth int) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(v.validate(sub, value, fldPath, refDepth)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func validateString(s map[string]interface{}, value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	length := utf8.RuneCountInString(value)
+	if min, ok := s["minLength"].(float64); ok && float64(length) < min {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be at least %v characters long", min)))
+	}
+	if max, ok := s["maxLength"].(float64); ok && float64(length) > max {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be at most %v characters long", max)))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		// checkSchema rejects patterns Go does not support
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must match the pattern %q", pattern)))
+		}
+	}
+	return allErrs
+}
+
+func validateNumber(s map[string]interface{}, value float64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if min, ok := s["minimum"].(float64); ok {
+		if exclusive, _ := s["exclusiveMinimum"].(bool); exclusive && value <= min {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be greater than %v", min)))
+		} else if value < min {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be greater than or equal to %v", min)))
+		}
+	}
+	if min, ok := s["exclusiveMinimum"].(float64); ok && value <= min {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be greater than %v", min)))
+	}
+	if max, ok := s["maximum"].(float64); ok {
+		if exclusive, _ := s["exclusiveMaximum"].(bool); exclusive && value >= max {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be less than %v", max)))
+		} else if value > max {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be less than or equal to %v", max)))
+		}
+	}
+	if max, ok := s["exclusiveMaximum"].(float64); ok && value >= max {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be less than %v", max)))
+	}
+	if multipleOf, ok := s["multipleOf"].(float64); ok && multipleOf > 0 {
+		if q := value / multipleOf; math.Abs(q-math.Floor(q+0.5)) > 1e-9 {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be a multiple of %v", multipleOf)))
+		}
+	}
+	return allErrs
+}
+
+func (v *validator) validateObject(s map[string]interface{}, value map[string]interface{}, fldPath *field.Path, refDepth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, set := value[name]; !set {
+				allErrs = append(allErrs, field.Required(fldPath.Child(name), ""))
+			}
+		}
+	}
+	if min, ok := s["minProperties"].(float64); ok && float64(len(value)) < min {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must have at least %v properties", min)))
+	}
+	if max, ok := s["maxProperties"].(float64); ok && float64(len(value)) > max {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must have at most %v properties", max)))
+	}
+
+	if dependencies, ok := s["dependencies"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(dependencies) {
+			if _, set := value[name]; !set {
+				continue
+			}
+			required, ok := dependencies[name].([]interface{})
+			if !ok {
+				allErrs = append(allErrs, v.validate(dependencies[name], value, fldPath, refDepth)...)
+				continue
+			}
+			for _, r := range required {
+				if dependency, ok := r.(string); ok {
+					if _, set := value[dependency]; !set {
+						allErrs = append(allErrs, field.Required(fldPath.Child(dependency), fmt.Sprintf("must be set when %s is set", name)))
+					}
+				}
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	patternProperties, _ := s["patternProperties"].(map[string]interface{})
+	additionalProperties, hasAdditionalProperties := s["additionalProperties"]
+
+	for _, k := range sortedKeys(value) {
+		childPath := fldPath.Child(k)
+		matched := false
+		if sub, ok := properties[k]; ok {
+			matched = true
+			allErrs = append(allErrs, v.validate(sub, value[k], childPath, refDepth)...)
+		}
+		for pattern, sub := range patternProperties {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(k) {
+				matched = true
+				allErrs = append(allErrs, v.validate(sub, value[k], childPath, refDepth)...)
+			}
+		}
+		if matched || !hasAdditionalProperties {
+			continue
+		}
+		if allowed, ok := additionalProperties.(bool); ok {
+			if !allowed {
+				allErrs = append(allErrs, field.Forbidden(childPath, "additional properties are not allowed by schema"))
+			}
+			continue
+		}
+		allErrs = append(allErrs, v.validate(additionalProperties, value[k], childPath, refDepth)...)
+	}
+
+	return allErrs
+}
+
+func (v *validator) validateArray(s map[string]interface{}, value []interface{}, fldPath *field.Path, refDepth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if min, ok := s["minItems"].(float64); ok && float64(len(value)) < min {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must have at least %v items", min)))
+	}
+	if max, ok := s["maxItems"].(float64); ok && float64(len(value)) > max {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must have at most %v items", max)))
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range value {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), value[i]))
+					break
+				}
+			}
+		}
+	}
+
+	switch items := s["items"].(type) {
+	case []interface{}:
+		for i, sub := range items {
+			if i >= len(value) {
+				break
+			}
+			allErrs = append(allErrs, v.validate(sub, value[i], fldPath.Index(i), refDepth)...)
+		}
+		if additionalItems, ok := s["additionalItems"]; ok {
+			for i := len(items); i < len(value); i++ {
+				allErrs = append(allErrs, v.validate(additionalItems, value[i], fldPath.Index(i), refDepth)...)
+			}
+		}
+	case nil:
+	default:
+		for i := range value {
+			allErrs = append(allErrs, v.validate(items, value[i], fldPath.Index(i), refDepth)...)
+		}
+	}
+
+	return allErrs
+}
+
+// checkSchema returns an error if the given part of the schema, at the given
+// JSON pointer, or any schema it contains or references uses a keyword,
+// reference or pattern that is not supported. Checked references are
+// recorded in checkedRefs, so that recursive schemas are only checked once.
+func (v *validator) checkSchema(schema interface{}, pointer string, checkedRefs map[string]bool) error {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		if !strings.HasPrefix(ref, "#") {
+			return fmt.Errorf("%s: references to other documents are not supported: %q", pointer, ref)
+		}
+		resolved, ok := v.resolve(ref)
+		if !ok {
+			return fmt.Errorf("%s: unresolvable reference %q", pointer, ref)
+		}
+		if !checkedRefs[ref] {
+			checkedRefs[ref] = true
+			if err := v.checkSchema(resolved, ref, checkedRefs); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range sortedKeys(s) {
+		if !supportedKeywords.Has(keyword) && !ignoredKeywords.Has(keyword) && !strings.HasPrefix(keyword, "x-") {
+			return fmt.Errorf("%s: keyword %q is not supported", pointer, keyword)
+		}
+	}
+	if t, ok := s["type"]; ok {
+		types, ok := schemaTypes(t)
+		if !ok {
+			return fmt.Errorf("%s: invalid type %v", pointer, formatValue(t))
+		}
+		for _, typ := range types {
+			if !supportedTypes.Has(typ) {
+				return fmt.Errorf("%s: unknown type %q", pointer, typ)
+			}
+		}
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%s: unsupported pattern %q: %v", pointer, pattern, err)
+		}
+	}
+
+	for _, keyword := range []string{"properties", "patternProperties", "definitions", "dependencies"} {
+		subs, _ := s[keyword].(map[string]interface{})
+		for _, name := range sortedKeys(subs) {
+			if keyword == "patternProperties" {
+				if _, err := regexp.Compile(name); err != nil {
+					return fmt.Errorf("%s/patternProperties: unsupported pattern %q: %v", pointer, name, err)
+				}
+			}
+			if err := v.checkSchema(subs[name], pointer+"/"+keyword+"/"+escapePointerToken(name), checkedRefs); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range []string{"additionalProperties", "additionalItems", "items", "not"} {
+		if err := v.checkSchema(s[keyword], pointer+"/"+keyword, checkedRefs); err != nil {
+			return err
+		}
+	}
+	for _, keyword := range []string{"items", "allOf", "anyOf", "oneOf"} {
+		subs, _ := s[keyword].([]interface{})
+		for i, sub := range subs {
+			if err := v.checkSchema(sub, fmt.Sprintf("%s/%s/%d", pointer, keyword, i), checkedRefs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// escapePointerToken escapes a property name for use in a JSON pointer.
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// resolve resolves a reference to a part of the root schema, given as a
+// JSON pointer fragment like "#/definitions/size".
+func (v *validator) resolve(ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+	current := v.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return current, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch c := current.(type) {
+		case map[string]interface{}:
+			next, ok := c[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// schemaTypes returns the types allowed by the type keyword of a schema.
+func schemaTypes(t interface{}) ([]string, bool) {
+	switch typ := t.(type) {
+	case string:
+		return []string{typ}, true
+	case []interface{}:
+		types := make([]string, 0, len(typ))
+		for _, t := range typ {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types, len(types) > 0
+	default:
+		return nil, false
+	}
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, t := range types {
+		if matchesType(value, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(value interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		// checkSchema rejects unknown types
+		return true
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValues(values []interface{}) []string {
+	formatted := make([]string, 0, len(values))
+	for _, v := range values {
+		formatted = append(formatted, formatValue(v))
+	}
+	return formatted
+}
+
+// formatValue returns strings as is, and the JSON encoding of other values.
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
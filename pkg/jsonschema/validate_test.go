@@ -0,0 +1,265 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const databaseSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "required": ["size"],
+  "additionalProperties": false,
+  "properties": {
+    "size": {"$ref": "#/definitions/size"},
+    "replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+    "name": {"type": "string", "title": "Name", "pattern": "^[a-z]+$", "maxLength": 8, "x-display-order": 1},
+    "ratio": {"type": "number", "minimum": 0, "exclusiveMinimum": true, "multipleOf": 0.25},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+    "backup": {
+      "type": "object",
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "schedule": {"type": "string"}
+      },
+      "oneOf": [
+        {"properties": {"enabled": {"enum": [false]}}},
+        {"required": ["schedule"]}
+      ]
+    }
+  },
+  "definitions": {
+    "size": {"type": "string", "enum": ["small", "large"]}
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		value  interface{}
+		errors []string
+	}{
+		{
+			name:   "valid",
+			schema: databaseSchema,
+			value: map[string]interface{}{
+				"size":     "small",
+				"replicas": 3,
+				"name":     "orders",
+				"ratio":    0.75,
+				"tags":     []string{"a", "b"},
+				"backup":   map[string]interface{}{"enabled": true, "schedule": "daily"},
+			},
+		},
+		{
+			name:   "missing required property",
+			schema: databaseSchema,
+			value:  map[string]interface{}{},
+			errors: []string{"parameters.size: Required value"},
+		},
+		{
+			name:   "additional property",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "sise": "large"},
+			errors: []string{"parameters.sise: Forbidden: additional properties are not allowed by schema"},
+		},
+		{
+			name:   "referenced enum",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "medium"},
+			errors: []string{`parameters.size: Unsupported value: "medium": supported values: "small", "large"`},
+		},
+		{
+			name:   "wrong types",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": 1, "replicas": 1.5},
+			errors: []string{
+				"parameters.replicas: Invalid value: 1.5: must be of type integer",
+				"parameters.size: Invalid value: 1: must be of type string",
+			},
+		},
+		{
+			name:   "numbers out of range",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "replicas": 6, "ratio": 0},
+			errors: []string{
+				"parameters.ratio: Invalid value: 0: must be greater than 0",
+				"parameters.replicas: Invalid value: 6: must be less than or equal to 5",
+			},
+		},
+		{
+			name:   "not a multiple",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "ratio": 0.3},
+			errors: []string{"parameters.ratio: Invalid value: 0.3: must be a multiple of 0.25"},
+		},
+		{
+			name:   "invalid string",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "name": "Orders-DB"},
+			errors: []string{
+				`parameters.name: Invalid value: "Orders-DB": must be at most 8 characters long`,
+				`parameters.name: Invalid value: "Orders-DB": must match the pattern "^[a-z]+$"`,
+			},
+		},
+		{
+			name:   "invalid array",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "tags": []interface{}{"a", 1, "a", "b"}},
+			errors: []string{
+				"parameters.tags: Invalid value: []interface {}{\"a\", 1, \"a\", \"b\"}: must have at most 3 items",
+				`parameters.tags[2]: Duplicate value: "a"`,
+				"parameters.tags[1]: Invalid value: 1: must be of type string",
+			},
+		},
+		{
+			name:   "oneOf",
+			schema: databaseSchema,
+			value:  map[string]interface{}{"size": "small", "backup": map[string]interface{}{"enabled": true}},
+			errors: []string{`parameters.backup: Invalid value: map[string]interface {}{"enabled":true}: must match exactly one of the schemas in oneOf`},
+		},
+		{
+			name:   "boolean schema",
+			schema: `{"properties": {"legacy": false}}`,
+			value:  map[string]interface{}{"legacy": true},
+			errors: []string{"parameters.legacy: Forbidden: not allowed by schema"},
+		},
+		{
+			name:   "draft-06 exclusive maximum and const",
+			schema: `{"properties": {"cpu": {"exclusiveMaximum": 4}, "tier": {"const": "gold"}}}`,
+			value:  map[string]interface{}{"cpu": 4, "tier": "silver"},
+			errors: []string{
+				"parameters.cpu: Invalid value: 4: must be less than 4",
+				`parameters.tier: Invalid value: "silver": must be gold`,
+			},
+		},
+		{
+			name:   "anyOf and not",
+			schema: `{"anyOf": [{"required": ["a"]}, {"required": ["b"]}], "not": {"required": ["c"]}}`,
+			value:  map[string]interface{}{"c": 1},
+			errors: []string{
+				`parameters: Invalid value: map[string]interface {}{"c":1}: must match at least one of the schemas in anyOf`,
+				`parameters: Invalid value: map[string]interface {}{"c":1}: must not match the schema in not`,
+			},
+		},
+		{
+			name:   "dependencies",
+			schema: `{"dependencies": {"backup": ["schedule"], "replicas": {"required": ["zone"]}}}`,
+			value:  map[string]interface{}{"backup": true, "replicas": 2},
+			errors: []string{
+				"parameters.schedule: Required value: must be set when backup is set",
+				"parameters.zone: Required value",
+			},
+		},
+		{
+			name:   "self-referencing schema",
+			schema: `{"$ref": "#"}`,
+			value:  map[string]interface{}{},
+			errors: []string{"parameters: Internal error: too many nested references in schema"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs, err := Validate([]byte(tc.schema), tc.value, field.NewPath("parameters"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var actual []string
+			for _, e := range errs {
+				actual = append(actual, e.Error())
+			}
+			if !reflect.DeepEqual(actual, tc.errors) {
+				t.Fatalf("unexpected validation errors\nexpected: %q\n     got: %q", tc.errors, actual)
+			}
+		})
+	}
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		error  string
+	}{
+		{
+			name:   "invalid JSON",
+			schema: `{"type":`,
+			error:  "failed to unmarshal schema: unexpected end of JSON input",
+		},
+		{
+			name:   "reference to another document",
+			schema: `{"properties": {"a": {"$ref": "http://example.com/schema#/a"}}}`,
+			error:  `#/properties/a: references to other documents are not supported: "http://example.com/schema#/a"`,
+		},
+		{
+			name:   "unresolvable reference",
+			schema: `{"items": [{"$ref": "#/definitions/missing"}]}`,
+			error:  `#/items/0: unresolvable reference "#/definitions/missing"`,
+		},
+		{
+			name:   "unsupported keyword in referenced schema",
+			schema: `{"$ref": "#/definitions/tags", "definitions": {"tags": {"contains": {"const": "prod"}}}}`,
+			error:  `#/definitions/tags: keyword "contains" is not supported`,
+		},
+		{
+			name:   "unsupported keyword in nested schema",
+			schema: `{"properties": {"a/b": {"anyOf": [{"if": {"type": "string"}}]}}}`,
+			error:  `#/properties/a~1b/anyOf/0: keyword "if" is not supported`,
+		},
+		{
+			name:   "unknown keyword",
+			schema: `{"properties": {"a": {"type": "integer", "minimun": 1}}}`,
+			error:  `#/properties/a: keyword "minimun" is not supported`,
+		},
+		{
+			name:   "unknown type",
+			schema: `{"items": {"type": ["string", "text"]}}`,
+			error:  `#/items: unknown type "text"`,
+		},
+		{
+			name:   "invalid type",
+			schema: `{"type": 1}`,
+			error:  `#: invalid type 1`,
+		},
+		{
+			name:   "unsupported pattern",
+			schema: `{"additionalProperties": {"pattern": "^(?!admin)"}}`,
+			error:  "#/additionalProperties: unsupported pattern \"^(?!admin)\": error parsing regexp: invalid or unsupported Perl syntax: `(?!`",
+		},
+		{
+			name:   "unsupported pattern property",
+			schema: `{"patternProperties": {"^(?=x)": {}}}`,
+			error:  "#/patternProperties: unsupported pattern \"^(?=x)\": error parsing regexp: invalid or unsupported Perl syntax: `(?=`",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Validate([]byte(tc.schema), map[string]interface{}{}, field.NewPath("parameters"))
+			if err == nil {
+				t.Fatal("expected an error for an unsupported schema")
+			}
+			if err.Error() != tc.error {
+				t.Fatalf("unexpected error\nexpected: %s\n     got: %s", tc.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenance implements recurring maintenance windows, whose start
+// times are given by cron expressions.
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds the search for the next start of a window, for schedules
+// like "0 0 30 2 *" that never match.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// field describes one of the five fields of a cron expression.
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Window is a recurring period of time of a fixed duration that starts at the
+// times matched by a cron expression, in UTC.
+type Window struct {
+	minutes, hours, days, months, weekdays uint64
+	// Whether the day of month and day of week fields are restricted; if
+	// both are, a day matches if either matches.
+	daysRestricted, weekdaysRestricted bool
+	duration                           time.Duration
+}
+
+// Parse parses a cron expression with five fields (minute, hour, day of month,
+// month and day of week) into a Window of the given duration. Each field is a
+// comma separated list of "*", values, ranges like "1-5" and steps like "*/15"
+// or "1-5/2". Day of week 0 and 7 are both Sunday.
+func Parse(schedule string, duration time.Duration) (*Window, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("the duration of a maintenance window must be positive")
+	}
+	parts := strings.Fields(schedule)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("expected %d fields in schedule %q, found %d", len(fields), schedule, len(parts))
+	}
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	w := &Window{
+		minutes:            sets[0],
+		hours:              sets[1],
+		days:               sets[2],
+		months:             sets[3],
+		weekdays:           sets[4],
+		daysRestricted:     parts[2] != "*",
+		weekdaysRestricted: parts[4] != "*",
+		duration:           duration,
+	}
+	if w.weekdays&(1<<7) != 0 {
+		w.weekdays |= 1
+	}
+	return w, nil
+}
+
+// ValidateSchedule returns an error if the cron expression cannot be parsed
+// by Parse.
+func ValidateSchedule(schedule string) error {
+	_, err := Parse(schedule, time.Minute)
+	return err
+}
+
+// parseField returns the set of values of a field of a cron expression as a
+// bit set.
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rng = item[:i]
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			step = n
+		}
+		low, high := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid %s field %q", f.name, item)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid %s field %q", f.name, item)
+				}
+			} else if step > 1 {
+				high = f.max
+			}
+			if low < f.min || high > f.max || low > high {
+				return 0, fmt.Errorf("%s field %q is out of range %d-%d", f.name, item, f.min, f.max)
+			}
+		}
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// Duration returns the duration of the window.
+func (w *Window) Duration() time.Duration {
+	return w.duration
+}
+
+// Contains returns whether t is inside the window.
+func (w *Window) Contains(t time.Time) bool {
+	start, ok := w.Next(t.Add(-w.duration))
+	return ok && !start.After(t)
+}
+
+// Next returns the first start of the window strictly after t, at minute
+// granularity in UTC, and false if the schedule never matches.
+func (w *Window) Next(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		switch {
+		case w.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !w.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case w.hours&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case w.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (w *Window) matchesDay(t time.Time) bool {
+	day := w.days&(1<<uint(t.Day())) != 0
+	weekday := w.weekdays&(1<<uint(t.Weekday())) != 0
+	if w.daysRestricted && w.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	tm, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		schedule string
+		valid    bool
+	}{
+		{"* * * * *", true},
+		{"0 2 * * 6", true},
+		{"*/15 1-5/2 1,15 * 1-5", true},
+		{"0 0 * * 7", true},
+		{"0 2 * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"5-1 * * * *", false},
+		{"*/0 * * * *", false},
+		{"a * * * *", false},
+	}
+	for _, tc := range cases {
+		_, err := Parse(tc.schedule, time.Hour)
+		if tc.valid && err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.schedule, err)
+		} else if !tc.valid && err == nil {
+			t.Errorf("%q: expected an error", tc.schedule)
+		}
+	}
+	if _, err := Parse("* * * * *", 0); err == nil {
+		t.Errorf("expected an error for a window without duration")
+	}
+}
+
+func TestNext(t *testing.T) {
+	cases := []struct {
+		schedule string
+		from     string
+		next     string
+	}{
+		// Saturday 02:00
+		{"0 2 * * 6", "2018-06-04T10:00:00Z", "2018-06-09T02:00:00Z"},
+		{"0 2 * * 6", "2018-06-09T02:00:00Z", "2018-06-16T02:00:00Z"},
+		{"*/15 * * * *", "2018-06-04T10:07:30Z", "2018-06-04T10:15:00Z"},
+		{"30 23 31 12 *", "2018-06-04T10:00:00Z", "2018-12-31T23:30:00Z"},
+		// Sunday as 7
+		{"0 0 * * 7", "2018-06-04T10:00:00Z", "2018-06-10T00:00:00Z"},
+		// day of month or day of week
+		{"0 0 1 * 3", "2018-06-02T00:00:00Z", "2018-06-06T00:00:00Z"},
+		// 29th of February
+		{"0 0 29 2 *", "2018-03-01T00:00:00Z", "2020-02-29T00:00:00Z"},
+	}
+	for _, tc := range cases {
+		w, err := Parse(tc.schedule, time.Hour)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.schedule, err)
+		}
+		next, ok := w.Next(mustTime(t, tc.from))
+		if e := mustTime(t, tc.next); !ok || !next.Equal(e) {
+			t.Errorf("%q from %v: expected %v, got %v", tc.schedule, tc.from, e, next)
+		}
+	}
+
+	w, err := Parse("0 0 30 2 *", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next, ok := w.Next(mustTime(t, "2018-06-04T10:00:00Z")); ok {
+		t.Errorf("expected no start for a schedule that never matches, got %v", next)
+	}
+}
+
+func TestContains(t *testing.T) {
+	w, err := Parse("0 22 * * *", 4*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		t        string
+		contains bool
+	}{
+		{"2018-06-04T21:59:00Z", false},
+		{"2018-06-04T22:00:00Z", true},
+		{"2018-06-05T01:59:59Z", true},
+		{"2018-06-05T02:00:00Z", false},
+		{"2018-06-05T12:00:00Z", false},
+	}
+	for _, tc := range cases {
+		if a := w.Contains(mustTime(t, tc.t)); a != tc.contains {
+			t.Errorf("%v: expected %v, got %v", tc.t, tc.contains, a)
+		}
+	}
+}
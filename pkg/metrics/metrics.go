@@ -72,6 +72,29 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// OSBRequestsInFlight exposes the number of requests to Open Service
+	// Brokers that are waiting for a response, by broker name.
+	OSBRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_requests_in_flight",
+			Help:      "Number of requests from the OSB Client to the specified Service Broker that are waiting for a response.",
+		},
+		[]string{"broker"},
+	)
+
+	// OSBRequestsQueued exposes the number of requests to Open Service
+	// Brokers that were held back by the request limits of the broker and
+	// are queued to be retried, by broker name.
+	OSBRequestsQueued = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_requests_queued",
+			Help:      "Number of requests from the OSB Client to the specified Service Broker that exceeded the request limits of the broker and are queued to be retried.",
+		},
+		[]string{"broker"},
+	)
 )
 
 func register(registry *prometheus.Registry) {
@@ -79,6 +102,8 @@ func register(registry *prometheus.Registry) {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(OSBRequestsInFlight)
+		registry.MustRegister(OSBRequestsQueued)
 	})
 }
 
@@ -0,0 +1,235 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+)
+
+// concurrencyRetryDelay is how long a request that exceeds the concurrency
+// limit of its broker waits before it is retried, before jitter.
+const concurrencyRetryDelay = time.Second
+
+// RequestLimits limits the requests that the clients send to a broker. A
+// zero value of a limit means that the limit is not enforced.
+type RequestLimits struct {
+	// QPS is the maximum average number of requests per second.
+	QPS float32
+	// Burst is the maximum number of requests sent at once above QPS.
+	// Defaults to QPS, rounded up.
+	Burst int
+	// MaxConcurrentRequests is the maximum number of requests in flight at
+	// the same time.
+	MaxConcurrentRequests int
+}
+
+// RequestLimitedError is returned by the clients for a request that was not
+// sent to the broker because it would exceed the request limits of the
+// broker. The request should be retried after RetryAfter.
+type RequestLimitedError struct {
+	BrokerName string
+	Method     string
+	RetryAfter time.Duration
+}
+
+func (e RequestLimitedError) Error() string {
+	return fmt.Sprintf("%s request to broker %q exceeds the request limits of the broker; retry after %v", e.Method, e.BrokerName, e.RetryAfter)
+}
+
+// IsRequestLimitedError returns whether the error is a RequestLimitedError
+// and returns the RequestLimitedError if it is.
+func IsRequestLimitedError(err error) (*RequestLimitedError, bool) {
+	limitedErr, ok := err.(RequestLimitedError)
+	if !ok {
+		return nil, false
+	}
+	return &limitedErr, true
+}
+
+// BrokerName returns the name that identifies a broker to the proxy: the
+// name of a ClusterServiceBroker, or the namespace and name of a
+// ServiceBroker. Names cannot contain a "/", so a ServiceBroker never has the
+// name of a ClusterServiceBroker, even if both have the same name. It is the
+// name the clients of the broker must be configured with.
+func BrokerName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// brokerLimiters holds the limiter of every broker, by BrokerName, shared by
+// all the clients of the broker.
+var brokerLimiters = struct {
+	sync.Mutex
+	defaults RequestLimits
+	limiters map[string]*limiter
+}{limiters: map[string]*limiter{}}
+
+// SetDefaultRequestLimits sets the limits of the brokers that have no limits
+// of their own. It only affects brokers whose limits are set afterwards, so
+// it should be called before any client is created.
+func SetDefaultRequestLimits(limits RequestLimits) {
+	brokerLimiters.Lock()
+	defer brokerLimiters.Unlock()
+	brokerLimiters.defaults = limits
+}
+
+// SetBrokerRequestLimits sets the limits of the requests to the broker with
+// the given BrokerName. Limits that are zero, or all the limits if limits is nil,
+// fall back to the defaults.
+func SetBrokerRequestLimits(brokerName string, limits *RequestLimits) {
+	brokerLimiters.Lock()
+	defer brokerLimiters.Unlock()
+
+	effective := brokerLimiters.defaults
+	if limits != nil {
+		if limits.QPS > 0 {
+			effective.QPS = limits.QPS
+			effective.Burst = limits.Burst
+		}
+		if limits.Burst > 0 {
+			effective.Burst = limits.Burst
+		}
+		if limits.MaxConcurrentRequests > 0 {
+			effective.MaxConcurrentRequests = limits.MaxConcurrentRequests
+		}
+	}
+	getLimiterLocked(brokerName).setLimits(effective)
+}
+
+// RemoveBrokerRequestLimits forgets the limiter of the broker with the given
+// BrokerName once the broker is deleted. Clients that are still in use keep
+// the limiter they were created with.
+func RemoveBrokerRequestLimits(brokerName string) {
+	brokerLimiters.Lock()
+	defer brokerLimiters.Unlock()
+
+	if _, ok := brokerLimiters.limiters[brokerName]; !ok {
+		return
+	}
+	delete(brokerLimiters.limiters, brokerName)
+	metrics.OSBRequestsInFlight.DeleteLabelValues(brokerName)
+	metrics.OSBRequestsQueued.DeleteLabelValues(brokerName)
+}
+
+// getLimiter returns the limiter of the broker with the given name, creating
+// it with the default limits if the broker has none yet.
+func getLimiter(brokerName string) *limiter {
+	brokerLimiters.Lock()
+	defer brokerLimiters.Unlock()
+	return getLimiterLocked(brokerName)
+}
+
+func getLimiterLocked(brokerName string) *limiter {
+	l, ok := brokerLimiters.limiters[brokerName]
+	if !ok {
+		l = &limiter{brokerName: brokerName}
+		l.setLimits(brokerLimiters.defaults)
+		brokerLimiters.limiters[brokerName] = l
+	}
+	return l
+}
+
+// limiter enforces the request limits of a single broker.
+type limiter struct {
+	brokerName string
+
+	mutex    sync.Mutex
+	limits   RequestLimits
+	rate     *rate.Limiter
+	inFlight int
+}
+
+func (l *limiter) setLimits(limits RequestLimits) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if limits == l.limits && (l.rate != nil) == (limits.QPS > 0) {
+		return
+	}
+	l.limits = limits
+	if limits.QPS <= 0 {
+		l.rate = nil
+		return
+	}
+	burst := limits.Burst
+	if burst <= 0 {
+		burst = int(limits.QPS)
+		if float32(burst) < limits.QPS {
+			burst++
+		}
+	}
+	l.rate = rate.NewLimiter(rate.Limit(limits.QPS), burst)
+}
+
+// acquire takes a slot for a request of the given method, or returns a
+// RequestLimitedError if the request would exceed the limits of the broker.
+// Every successful acquire must be followed by a release once the request
+// completes.
+func (l *limiter) acquire(method string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.limits.MaxConcurrentRequests > 0 && l.inFlight >= l.limits.MaxConcurrentRequests {
+		return l.limited(method, wait.Jitter(concurrencyRetryDelay, 1.0))
+	}
+	if l.rate != nil {
+		now := time.Now()
+		reservation := l.rate.ReserveN(now, 1)
+		if !reservation.OK() {
+			return l.limited(method, wait.Jitter(concurrencyRetryDelay, 1.0))
+		}
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return l.limited(method, delay)
+		}
+	}
+
+	l.inFlight++
+	metrics.OSBRequestsInFlight.WithLabelValues(l.brokerName).Inc()
+	return nil
+}
+
+// release frees the slot taken by acquire.
+func (l *limiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inFlight--
+	metrics.OSBRequestsInFlight.WithLabelValues(l.brokerName).Dec()
+}
+
+// limited returns the error for a request that exceeds the limits of the
+// broker and counts the request as queued until it is due to be retried.
+func (l *limiter) limited(method string, retryAfter time.Duration) error {
+	queued := metrics.OSBRequestsQueued.WithLabelValues(l.brokerName)
+	queued.Inc()
+	time.AfterFunc(retryAfter, queued.Dec)
+	return RequestLimitedError{
+		BrokerName: l.brokerName,
+		Method:     method,
+		RetryAfter: retryAfter,
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterMaxConcurrentRequests(t *testing.T) {
+	l := &limiter{brokerName: "concurrency-broker"}
+	l.setLimits(RequestLimits{MaxConcurrentRequests: 2})
+
+	for i := 0; i < 2; i++ {
+		if err := l.acquire(bind); err != nil {
+			t.Fatalf("unexpected error acquiring request %d: %v", i, err)
+		}
+	}
+
+	err := l.acquire(bind)
+	limitedErr, ok := IsRequestLimitedError(err)
+	if !ok {
+		t.Fatalf("expected a RequestLimitedError, got %v", err)
+	}
+	if limitedErr.BrokerName != "concurrency-broker" || limitedErr.Method != bind {
+		t.Fatalf("unexpected error: %+v", limitedErr)
+	}
+	if limitedErr.RetryAfter < concurrencyRetryDelay {
+		t.Fatalf("expected a retry after at least %v, got %v", concurrencyRetryDelay, limitedErr.RetryAfter)
+	}
+
+	l.release()
+	if err := l.acquire(bind); err != nil {
+		t.Fatalf("unexpected error after release: %v", err)
+	}
+}
+
+func TestLimiterQPS(t *testing.T) {
+	l := &limiter{brokerName: "qps-broker"}
+	l.setLimits(RequestLimits{QPS: 1, Burst: 2})
+
+	for i := 0; i < 2; i++ {
+		if err := l.acquire(getCatalog); err != nil {
+			t.Fatalf("unexpected error acquiring request %d: %v", i, err)
+		}
+		l.release()
+	}
+
+	limitedErr, ok := IsRequestLimitedError(l.acquire(getCatalog))
+	if !ok {
+		t.Fatal("expected the request over the burst to be limited")
+	}
+	if limitedErr.RetryAfter <= 0 || limitedErr.RetryAfter > time.Second {
+		t.Fatalf("expected a retry after of at most one second, got %v", limitedErr.RetryAfter)
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := &limiter{brokerName: "unlimited-broker"}
+	l.setLimits(RequestLimits{})
+
+	for i := 0; i < 100; i++ {
+		if err := l.acquire(provisionInstance); err != nil {
+			t.Fatalf("unexpected error acquiring request %d: %v", i, err)
+		}
+	}
+}
+
+func TestSetBrokerRequestLimits(t *testing.T) {
+	SetDefaultRequestLimits(RequestLimits{QPS: 10, Burst: 20, MaxConcurrentRequests: 5})
+	defer SetDefaultRequestLimits(RequestLimits{})
+
+	cases := []struct {
+		name     string
+		limits   *RequestLimits
+		expected RequestLimits
+	}{
+		{
+			name:     "no broker limits",
+			expected: RequestLimits{QPS: 10, Burst: 20, MaxConcurrentRequests: 5},
+		},
+		{
+			name:     "broker qps without burst",
+			limits:   &RequestLimits{QPS: 2},
+			expected: RequestLimits{QPS: 2, MaxConcurrentRequests: 5},
+		},
+		{
+			name:     "broker concurrency only",
+			limits:   &RequestLimits{MaxConcurrentRequests: 1},
+			expected: RequestLimits{QPS: 10, Burst: 20, MaxConcurrentRequests: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		brokerName := "broker-" + tc.name
+		SetBrokerRequestLimits(brokerName, tc.limits)
+		if e, a := tc.expected, getLimiter(brokerName).limits; e != a {
+			t.Errorf("%v: unexpected limits; expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
+
+func TestBrokerRequestLimitsOfBrokersWithTheSameName(t *testing.T) {
+	clusterBroker := BrokerName("", "same-name-broker")
+	namespacedBroker := BrokerName("test-ns", "same-name-broker")
+	defer RemoveBrokerRequestLimits(clusterBroker)
+	defer RemoveBrokerRequestLimits(namespacedBroker)
+
+	SetBrokerRequestLimits(clusterBroker, &RequestLimits{MaxConcurrentRequests: 1})
+	SetBrokerRequestLimits(namespacedBroker, &RequestLimits{MaxConcurrentRequests: 2})
+
+	if e, a := 1, getLimiter(clusterBroker).limits.MaxConcurrentRequests; e != a {
+		t.Fatalf("unexpected concurrency limit of the ClusterServiceBroker; expected %v, got %v", e, a)
+	}
+	if e, a := 2, getLimiter(namespacedBroker).limits.MaxConcurrentRequests; e != a {
+		t.Fatalf("unexpected concurrency limit of the ServiceBroker; expected %v, got %v", e, a)
+	}
+
+	// A request in flight to one broker does not count against the other.
+	if err := getLimiter(clusterBroker).acquire(bind); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer getLimiter(clusterBroker).release()
+	if _, ok := IsRequestLimitedError(getLimiter(clusterBroker).acquire(bind)); !ok {
+		t.Fatal("expected the second request to the ClusterServiceBroker to be limited")
+	}
+	if err := getLimiter(namespacedBroker).acquire(bind); err != nil {
+		t.Fatalf("unexpected error acquiring a request to the ServiceBroker: %v", err)
+	}
+	getLimiter(namespacedBroker).release()
+}
+
+func TestRemoveBrokerRequestLimits(t *testing.T) {
+	brokerName := BrokerName("test-ns", "deleted-broker")
+	SetBrokerRequestLimits(brokerName, &RequestLimits{MaxConcurrentRequests: 1})
+	RemoveBrokerRequestLimits(brokerName)
+
+	brokerLimiters.Lock()
+	_, ok := brokerLimiters.limiters[brokerName]
+	brokerLimiters.Unlock()
+	if ok {
+		t.Fatal("expected the limiter of the deleted broker to be removed")
+	}
+
+	// A broker created again with the same name starts from the defaults.
+	if e, a := (RequestLimits{}), getLimiter(brokerName).limits; e != a {
+		t.Fatalf("unexpected limits; expected %+v, got %+v", e, a)
+	}
+	RemoveBrokerRequestLimits(brokerName)
+}
@@ -15,11 +15,12 @@ limitations under the License.
 */
 
 // Package osbclientproxy proxies the OSB Client Library enabling
-// metrics instrumentation
+// metrics instrumentation and per-broker request limits
 package osbclientproxy
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
@@ -31,6 +32,9 @@ import (
 type proxyclient struct {
 	brokerName    string
 	realOSBClient osb.Client
+	limiter       *limiter
+	config        *osb.ClientConfiguration
+	httpClient    *http.Client
 }
 
 // NewClient is a CreateFunc for creating a new functional Client and
@@ -41,7 +45,10 @@ func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
 		return nil, err
 	}
 	proxy := proxyclient{realOSBClient: osbClient}
+	proxy.config = config
+	proxy.httpClient = newHTTPClient(config)
 	proxy.brokerName = config.Name
+	proxy.limiter = getLimiter(config.Name)
 	return proxy, nil
 }
 
@@ -64,6 +71,10 @@ const (
 // metrics.
 func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 	glog.V(9).Info("OSBClientProxy getCatalog()")
+	if err := pc.limiter.acquire(getCatalog); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.GetCatalog()
 	pc.updateMetrics(getCatalog, err)
 	return response, err
@@ -74,6 +85,10 @@ func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
 	glog.V(9).Info("OSBClientProxy ProvisionInstance()")
+	if err := pc.limiter.acquire(provisionInstance); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.ProvisionInstance(r)
 	pc.updateMetrics(provisionInstance, err)
 	return response, err
@@ -85,6 +100,10 @@ func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.Provision
 // to the underlying implementation and capturing request metrics.
 func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
 	glog.V(9).Info("OSBClientProxy UpdateInstance()")
+	if err := pc.limiter.acquire(updateInstance); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.UpdateInstance(r)
 	pc.updateMetrics(updateInstance, err)
 	return response, err
@@ -95,6 +114,10 @@ func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateI
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
 	glog.V(9).Info("OSBClientProxy DeprovisionInstance()")
+	if err := pc.limiter.acquire(deprovisionInstance); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.DeprovisionInstance(r)
 	pc.updateMetrics(deprovisionInstance, err)
 	return response, err
@@ -105,6 +128,10 @@ func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.Depro
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
 	glog.V(9).Info("OSBClientProxy PollLastOperation()")
+	if err := pc.limiter.acquire(pollLastOperation); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.PollLastOperation(r)
 	pc.updateMetrics(pollLastOperation, err)
 	return response, err
@@ -115,6 +142,10 @@ func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastO
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
 	glog.V(9).Info("OSBClientProxy PollBindingLastOperation()")
+	if err := pc.limiter.acquire(pollBindingLastOperation); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.PollBindingLastOperation(r)
 	pc.updateMetrics(pollBindingLastOperation, err)
 	return response, err
@@ -124,6 +155,10 @@ func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationReques
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 	glog.V(9).Info("OSBClientProxy Bind().")
+	if err := pc.limiter.acquire(bind); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.Bind(r)
 	pc.updateMetrics(bind, err)
 	return response, err
@@ -133,6 +168,10 @@ func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
 	glog.V(9).Info("OSBClientProxy Unbind()")
+	if err := pc.limiter.acquire(unbind); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.Unbind(r)
 	pc.updateMetrics(unbind, err)
 	return response, err
@@ -143,6 +182,10 @@ func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error)
 // metrics.
 func (pc proxyclient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
 	glog.V(9).Info("OSBClientProxy GetBinding()")
+	if err := pc.limiter.acquire(getBinding); err != nil {
+		return nil, err
+	}
+	defer pc.limiter.release()
 	response, err := pc.realOSBClient.GetBinding(r)
 	pc.updateMetrics(getBinding, err)
 	return response, err
@@ -0,0 +1,228 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// retryAfterHeader is the header a broker uses to tell clients how long to
+// wait before polling the last operation again.
+const retryAfterHeader = "Retry-After"
+
+// PollDelayClient is implemented by the clients of the proxy. Its methods
+// poll the last operation like the osb.Client methods do, and also return the
+// delay the broker asked for with a Retry-After header before the operation
+// is polled again, or nil if the broker asked for none.
+type PollDelayClient interface {
+	PollLastOperationWithDelay(r *osb.LastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error)
+	PollBindingLastOperationWithDelay(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error)
+}
+
+var _ PollDelayClient = proxyclient{}
+
+// PollLastOperationWithDelay implements PollDelayClient. The OSB client
+// library does not expose the headers of the response, so the proxy sends the
+// last operation request itself.
+func (pc proxyclient) PollLastOperationWithDelay(r *osb.LastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error) {
+	if r.InstanceID == "" {
+		// let the library report the invalid request
+		response, err := pc.PollLastOperation(r)
+		return response, nil, err
+	}
+	if err := pc.limiter.acquire(pollLastOperation); err != nil {
+		return nil, nil, err
+	}
+	defer pc.limiter.release()
+	url := fmt.Sprintf("%s/v2/service_instances/%s/last_operation", pc.brokerURL(), r.InstanceID)
+	response, delay, err := pc.pollLastOperationURL(url, r.ServiceID, r.PlanID, r.OperationKey, r.OriginatingIdentity)
+	pc.updateMetrics(pollLastOperation, err)
+	return response, delay, err
+}
+
+// PollBindingLastOperationWithDelay implements PollDelayClient. The OSB
+// client library does not expose the headers of the response, so the proxy
+// sends the last operation request itself.
+func (pc proxyclient) PollBindingLastOperationWithDelay(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, *time.Duration, error) {
+	if !pc.config.EnableAlphaFeatures || r.InstanceID == "" || r.BindingID == "" {
+		// let the library report the request that it does not allow
+		response, err := pc.PollBindingLastOperation(r)
+		return response, nil, err
+	}
+	if err := pc.limiter.acquire(pollBindingLastOperation); err != nil {
+		return nil, nil, err
+	}
+	defer pc.limiter.release()
+	url := fmt.Sprintf("%s/v2/service_instances/%s/service_bindings/%s/last_operation", pc.brokerURL(), r.InstanceID, r.BindingID)
+	response, delay, err := pc.pollLastOperationURL(url, r.ServiceID, r.PlanID, r.OperationKey, r.OriginatingIdentity)
+	pc.updateMetrics(pollBindingLastOperation, err)
+	return response, delay, err
+}
+
+func (pc proxyclient) brokerURL() string {
+	return strings.TrimRight(pc.config.URL, "/")
+}
+
+// pollLastOperationURL sends a last operation request to the given URL the
+// same way the OSB client library does, and returns the response along with
+// the delay of its Retry-After header.
+func (pc proxyclient) pollLastOperationURL(url string, serviceID, planID *string, operationKey *osb.OperationKey, identity *osb.OriginatingIdentity) (*osb.LastOperationResponse, *time.Duration, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set(osb.APIVersionHeader, pc.config.APIVersion.HeaderValue())
+	if auth := pc.config.AuthConfig; auth != nil {
+		if auth.BasicAuthConfig != nil {
+			request.SetBasicAuth(auth.BasicAuthConfig.Username, auth.BasicAuthConfig.Password)
+		} else if auth.BearerConfig != nil {
+			request.Header.Set("Authorization", "Bearer "+auth.BearerConfig.Token)
+		}
+	}
+	if pc.config.APIVersion.AtLeast(osb.Version2_13()) && identity != nil {
+		headerValue, err := originatingIdentityHeaderValue(identity)
+		if err != nil {
+			return nil, nil, err
+		}
+		request.Header.Set(osb.OriginatingIdentityHeader, headerValue)
+	}
+
+	q := request.URL.Query()
+	if serviceID != nil {
+		q.Set(osb.VarKeyServiceID, *serviceID)
+	}
+	if planID != nil {
+		q.Set(osb.VarKeyPlanID, *planID)
+	}
+	if operationKey != nil {
+		q.Set(osb.VarKeyOperation, string(*operationKey))
+	}
+	request.URL.RawQuery = q.Encode()
+
+	response, err := pc.httpClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, failureResponseError(response.StatusCode, body)
+	}
+	userResponse := &osb.LastOperationResponse{}
+	if err := json.Unmarshal(body, userResponse); err != nil {
+		return nil, nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+	return userResponse, parseRetryAfter(response.Header.Get(retryAfterHeader)), nil
+}
+
+// failureResponseError returns the error for a response of the broker with
+// a status other than 200, as the OSB client library does.
+func failureResponseError(statusCode int, body []byte) error {
+	httpErr := osb.HTTPStatusCodeError{
+		StatusCode: statusCode,
+	}
+
+	brokerResponse := make(map[string]interface{})
+	if err := json.Unmarshal(body, &brokerResponse); err != nil {
+		httpErr.ResponseError = err
+		return httpErr
+	}
+	if errorMessage, ok := brokerResponse["error"].(string); ok {
+		httpErr.ErrorMessage = &errorMessage
+	}
+	if description, ok := brokerResponse["description"].(string); ok {
+		httpErr.Description = &description
+	}
+	return httpErr
+}
+
+func originatingIdentityHeaderValue(i *osb.OriginatingIdentity) (string, error) {
+	if i.Platform == "" {
+		return "", errors.New("originating identity platform must not be empty")
+	}
+	if i.Value == "" {
+		return "", errors.New("originating identity value must not be empty")
+	}
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(i.Value), &js); err != nil {
+		return "", fmt.Errorf("originating identity value must be valid JSON: %v", err)
+	}
+	return fmt.Sprintf("%v %v", i.Platform, base64.StdEncoding.EncodeToString([]byte(i.Value))), nil
+}
+
+// newHTTPClient returns an HTTP client for the broker configured the same
+// way as the one of the OSB client library.
+func newHTTPClient(config *osb.ClientConfiguration) *http.Client {
+	transport := &http.Transport{}
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig
+	} else {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if config.Insecure {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if len(config.CAData) != 0 {
+		if transport.TLSClientConfig.RootCAs == nil {
+			transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+		}
+		transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(config.CAData)
+	}
+	return &http.Client{
+		Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
+		Transport: transport,
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number
+// of seconds or an HTTP date. It returns nil if the header is missing or
+// invalid.
+func parseRetryAfter(value string) *time.Duration {
+	if value == "" {
+		return nil
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		delay = time.Until(t)
+	} else {
+		return nil
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return &delay
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+func newTestPollDelayClient(t *testing.T, handler http.HandlerFunc) (PollDelayClient, func()) {
+	server := httptest.NewServer(handler)
+	config := osb.DefaultClientConfiguration()
+	config.Name = "poll-delay-broker"
+	config.URL = server.URL
+	config.EnableAlphaFeatures = true
+	config.AuthConfig = &osb.AuthConfig{
+		BasicAuthConfig: &osb.BasicAuthConfig{Username: "user", Password: "pass"},
+	}
+	client, err := NewClient(config)
+	if err != nil {
+		server.Close()
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return client.(PollDelayClient), server.Close
+}
+
+func TestPollLastOperationWithDelay(t *testing.T) {
+	operation := osb.OperationKey("op")
+	client, closeServer := newTestPollDelayClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/v2/service_instances/instance/last_operation", r.URL.Path; e != a {
+			t.Errorf("unexpected path: expected %q, got %q", e, a)
+		}
+		if e, a := "op", r.URL.Query().Get(osb.VarKeyOperation); e != a {
+			t.Errorf("unexpected operation: expected %q, got %q", e, a)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			t.Errorf("unexpected basic auth: %q %q", user, pass)
+		}
+		if r.Header.Get(osb.APIVersionHeader) == "" {
+			t.Errorf("missing %s header", osb.APIVersionHeader)
+		}
+		w.Header().Set("Retry-After", "30")
+		w.Write([]byte(`{"state":"in progress"}`))
+	})
+	defer closeServer()
+
+	response, delay, err := client.PollLastOperationWithDelay(&osb.LastOperationRequest{
+		InstanceID:   "instance",
+		OperationKey: &operation,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := osb.StateInProgress, response.State; e != a {
+		t.Fatalf("unexpected state: expected %v, got %v", e, a)
+	}
+	if delay == nil || *delay != 30*time.Second {
+		t.Fatalf("unexpected delay: expected 30s, got %v", delay)
+	}
+}
+
+func TestPollBindingLastOperationWithDelay(t *testing.T) {
+	client, closeServer := newTestPollDelayClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/v2/service_instances/instance/service_bindings/binding/last_operation", r.URL.Path; e != a {
+			t.Errorf("unexpected path: expected %q, got %q", e, a)
+		}
+		w.Write([]byte(`{"state":"succeeded"}`))
+	})
+	defer closeServer()
+
+	response, delay, err := client.PollBindingLastOperationWithDelay(&osb.BindingLastOperationRequest{
+		InstanceID: "instance",
+		BindingID:  "binding",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := osb.StateSucceeded, response.State; e != a {
+		t.Fatalf("unexpected state: expected %v, got %v", e, a)
+	}
+	if delay != nil {
+		t.Fatalf("unexpected delay: %v", *delay)
+	}
+}
+
+func TestPollLastOperationWithDelayFailure(t *testing.T) {
+	client, closeServer := newTestPollDelayClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(`{"description":"gone"}`))
+	})
+	defer closeServer()
+
+	_, _, err := client.PollLastOperationWithDelay(&osb.LastOperationRequest{InstanceID: "instance"})
+	if !osb.IsGoneError(err) {
+		t.Fatalf("expected a gone error, got %v", err)
+	}
+	httpErr, _ := osb.IsHTTPError(err)
+	if httpErr.Description == nil || *httpErr.Description != "gone" {
+		t.Fatalf("unexpected description: %+v", httpErr)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected *time.Duration
+	}{
+		{value: ""},
+		{value: "soon"},
+		{value: "0", expected: durationPtr(0)},
+		{value: "120", expected: durationPtr(2 * time.Minute)},
+		{value: "-5", expected: durationPtr(0)},
+		{value: "Mon, 02 Jan 2006 15:04:05 GMT", expected: durationPtr(0)},
+	}
+	for _, tc := range cases {
+		actual := parseRetryAfter(tc.value)
+		if (tc.expected == nil) != (actual == nil) || (actual != nil && *tc.expected != *actual) {
+			t.Errorf("%q: expected %v, got %v", tc.value, tc.expected, actual)
+		}
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -140,18 +140,32 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	}
 	newServiceBinding.Status = oldServiceBinding.Status
 
-	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
+	// TODO: The only change to the spec that we currently handle in the
+	// reconciler is a credential rotation request. Once we handle other
+	// changes, this needs to be removed and proper validation of allowed
+	// changes needs to be implemented in ValidateUpdate.
+	rotationRequests := newServiceBinding.Spec.RotationRequests
 	newServiceBinding.Spec = oldServiceBinding.Spec
 
+	// Ignore the RotationRequests field when it is the default value
+	if rotationRequests != 0 {
+		newServiceBinding.Spec.RotationRequests = rotationRequests
+	}
+
+	// A credential rotation binds under a new ExternalID. The previous
+	// ExternalID is retired so that the reconciler can unbind it once the
+	// new credentials are in place.
+	if newServiceBinding.Spec.RotationRequests > oldServiceBinding.Spec.RotationRequests {
+		retired := make([]sc.ServiceBindingRetiredCredentials, len(oldServiceBinding.Status.RetiredCredentials), len(oldServiceBinding.Status.RetiredCredentials)+1)
+		copy(retired, oldServiceBinding.Status.RetiredCredentials)
+		newServiceBinding.Status.RetiredCredentials = append(retired, sc.ServiceBindingRetiredCredentials{
+			ExternalID: oldServiceBinding.Spec.ExternalID,
+		})
+		newServiceBinding.Spec.ExternalID = string(uuid.NewUUID())
+	}
+
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
-	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(ctx, newServiceBinding)
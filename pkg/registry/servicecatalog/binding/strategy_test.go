@@ -59,9 +59,6 @@ func contextWithUserName(userName string) context.Context {
 	return genericapirequest.WithUser(ctx, userInfo)
 }
 
-// TODO: Un-comment "spec-change" test case when there is a field
-// in the spec to which the reconciler allows a change.
-
 // TestInstanceCredentialUpdate tests that generation is incremented correctly when the
 // spec of a ServiceBinding is updated.
 func TestInstanceCredentialUpdate(t *testing.T) {
@@ -76,18 +73,27 @@ func TestInstanceCredentialUpdate(t *testing.T) {
 			older: getTestInstanceCredential(),
 			newer: getTestInstanceCredential(),
 		},
-		//		{
-		//			name:  "spec change",
-		//			older: getTestInstanceCredential(),
-		//			newer: func() *v1beta1.ServiceBinding {
-		//				ic := getTestInstanceCredential()
-		//				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
-		//					Name: "new-string",
-		//				}
-		//				return ic
-		//			},
-		//			shouldGenerationIncrement: true,
-		//		},
+		{
+			name:  "ignored spec change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
+					Name: "new-string",
+				}
+				return ic
+			}(),
+		},
+		{
+			name:  "rotation requested",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.RotationRequests = 1
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
 	}
 	for _, tc := range cases {
 		bindingRESTStrategies.PrepareForUpdate(nil, tc.newer, tc.older)
@@ -118,17 +124,15 @@ func TestInstanceCredentialUserInfo(t *testing.T) {
 		t.Errorf("unexpected user info in created spec: expected %q, got %q", e, a)
 	}
 
-	// TODO: Un-comment the following portion of this test when there is a field
-	// in the spec to which the reconciler allows a change.
+	updaterUserName := "updater"
+	updatedInstanceCredential := getTestInstanceCredential()
+	updatedInstanceCredential.Spec.RotationRequests = 1
+	updateContext := contextWithUserName(updaterUserName)
+	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
 
-	//  updaterUserName := "updater"
-	//	updatedInstanceCredential := getTestInstanceCredential()
-	//	updateContext := contextWithUserName(updaterUserName)
-	//	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
-
-	//	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
-	//		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
-	//	}
+	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
+		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
+	}
 
 	deleterUserName := "deleter"
 	deletedInstanceCredential := getTestInstanceCredential()
@@ -161,3 +165,51 @@ func TestExternalIDUserProvided(t *testing.T) {
 		t.Errorf("Modified user provided ExternalID to %q", createdInstanceCredential.Spec.ExternalID)
 	}
 }
+
+// TestRotationRequestRetiresExternalID checks that requesting a credential
+// rotation assigns a new ExternalID and retires the previous one.
+func TestRotationRequestRetiresExternalID(t *testing.T) {
+	older := getTestInstanceCredential()
+	older.Spec.ExternalID = "old-id"
+	older.Status.RetiredCredentials = []servicecatalog.ServiceBindingRetiredCredentials{
+		{ExternalID: "older-id"},
+	}
+
+	newer := getTestInstanceCredential()
+	newer.Spec.RotationRequests = 1
+	bindingRESTStrategies.PrepareForUpdate(nil, newer, older)
+
+	if newer.Spec.ExternalID == "" || newer.Spec.ExternalID == older.Spec.ExternalID {
+		t.Errorf("Expected a new ExternalID to be set, got %q", newer.Spec.ExternalID)
+	}
+	if e, a := 2, len(newer.Status.RetiredCredentials); e != a {
+		t.Fatalf("unexpected number of retired credentials: expected %v, got %v", e, a)
+	}
+	if e, a := "old-id", newer.Status.RetiredCredentials[1].ExternalID; e != a {
+		t.Errorf("unexpected retired ExternalID: expected %q, got %q", e, a)
+	}
+	if e, a := 1, len(older.Status.RetiredCredentials); e != a {
+		t.Errorf("older binding's retired credentials were modified: expected %v, got %v", e, a)
+	}
+}
+
+// TestRotationRequestsIgnoredWhenUnset checks that an update that does not
+// set the RotationRequests field keeps the existing value.
+func TestRotationRequestsIgnoredWhenUnset(t *testing.T) {
+	older := getTestInstanceCredential()
+	older.Spec.ExternalID = "old-id"
+	older.Spec.RotationRequests = 3
+
+	newer := getTestInstanceCredential()
+	bindingRESTStrategies.PrepareForUpdate(nil, newer, older)
+
+	if e, a := int64(3), newer.Spec.RotationRequests; e != a {
+		t.Errorf("unexpected rotation requests: expected %v, got %v", e, a)
+	}
+	if e, a := "old-id", newer.Spec.ExternalID; e != a {
+		t.Errorf("unexpected ExternalID: expected %q, got %q", e, a)
+	}
+	if e, a := older.Generation, newer.Generation; e != a {
+		t.Errorf("unexpected generation: expected %v, got %v", e, a)
+	}
+}
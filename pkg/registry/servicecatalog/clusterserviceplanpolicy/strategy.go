@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterserviceplanpolicy
+
+import (
+	"context"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/api"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/golang/glog"
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scv "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/validation"
+)
+
+// NewScopeStrategy returns a new NamespaceScopedStrategy for service plan
+// policies
+func NewScopeStrategy() rest.NamespaceScopedStrategy {
+	return clusterServicePlanPolicyRESTStrategies
+}
+
+// implements interfaces RESTCreateStrategy, RESTUpdateStrategy, RESTDeleteStrategy,
+// NamespaceScopedStrategy
+type clusterServicePlanPolicyRESTStrategy struct {
+	runtime.ObjectTyper // inherit ObjectKinds method
+	names.NameGenerator // GenerateName method for CreateStrategy
+}
+
+var (
+	clusterServicePlanPolicyRESTStrategies = clusterServicePlanPolicyRESTStrategy{
+		ObjectTyper:   api.Scheme,
+		NameGenerator: names.SimpleNameGenerator,
+	}
+	_ rest.RESTCreateStrategy = clusterServicePlanPolicyRESTStrategies
+	_ rest.RESTUpdateStrategy = clusterServicePlanPolicyRESTStrategies
+	_ rest.RESTDeleteStrategy = clusterServicePlanPolicyRESTStrategies
+)
+
+// Canonicalize does not transform a service plan policy.
+func (clusterServicePlanPolicyRESTStrategy) Canonicalize(obj runtime.Object) {
+	_, ok := obj.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to create")
+	}
+}
+
+// NamespaceScoped returns false as clusterserviceplanpolicies are not scoped
+// to a namespace.
+func (clusterServicePlanPolicyRESTStrategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate receives the incoming ClusterServicePlanPolicy and sets
+// its generation.
+func (clusterServicePlanPolicyRESTStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	policy, ok := obj.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to create")
+	}
+	policy.Generation = 1
+}
+
+func (clusterServicePlanPolicyRESTStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	return scv.ValidateClusterServicePlanPolicy(obj.(*sc.ClusterServicePlanPolicy))
+}
+
+func (clusterServicePlanPolicyRESTStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (clusterServicePlanPolicyRESTStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (clusterServicePlanPolicyRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtime.Object) {
+	newPolicy, ok := new.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to update to")
+	}
+	oldPolicy, ok := old.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to update from")
+	}
+
+	// Spec updates bump the generation so that we can distinguish between
+	// spec changes and other changes to the object.
+	if !apiequality.Semantic.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+		newPolicy.Generation = oldPolicy.Generation + 1
+	}
+}
+
+func (clusterServicePlanPolicyRESTStrategy) ValidateUpdate(ctx context.Context, new, old runtime.Object) field.ErrorList {
+	newPolicy, ok := new.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to validate to")
+	}
+	oldPolicy, ok := old.(*sc.ClusterServicePlanPolicy)
+	if !ok {
+		glog.Fatal("received a non-clusterserviceplanpolicy object to validate from")
+	}
+
+	return scv.ValidateClusterServicePlanPolicyUpdate(newPolicy, oldPolicy)
+}
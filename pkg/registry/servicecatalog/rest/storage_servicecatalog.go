@@ -24,10 +24,12 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterservicebroker"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterserviceclass"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterserviceplan"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterserviceplanpolicy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/instance"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/servicebroker"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceclass"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceinstancegrant"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceplan"
 	"github.com/kubernetes-incubator/service-catalog/pkg/storage/etcd"
 	"k8s.io/apiserver/pkg/registry/generic"
@@ -249,6 +251,50 @@ func (p StorageProvider) v1beta1Storage(
 		storageMap["servicebrokers/status"] = serviceBrokerStatusStorage
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServicePlanPolicy) {
+		clusterServicePlanPolicyRESTOptions, err := restOptionsGetter.GetRESTOptions(servicecatalog.Resource("clusterserviceplanpolicies"))
+		if err != nil {
+			return nil, err
+		}
+
+		clusterServicePlanPolicyOpts := server.NewOptions(
+			etcd.Options{
+				RESTOptions:   clusterServicePlanPolicyRESTOptions,
+				Capacity:      1000,
+				ObjectType:    clusterserviceplanpolicy.EmptyObject(),
+				ScopeStrategy: clusterserviceplanpolicy.NewScopeStrategy(),
+				NewListFunc:   clusterserviceplanpolicy.NewList,
+				GetAttrsFunc:  clusterserviceplanpolicy.GetAttrs,
+				Trigger:       storage.NoTriggerPublisher,
+			},
+			p.StorageType,
+		)
+
+		storageMap["clusterserviceplanpolicies"] = clusterserviceplanpolicy.NewStorage(*clusterServicePlanPolicyOpts)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) {
+		serviceInstanceGrantRESTOptions, err := restOptionsGetter.GetRESTOptions(servicecatalog.Resource("serviceinstancegrants"))
+		if err != nil {
+			return nil, err
+		}
+
+		serviceInstanceGrantOpts := server.NewOptions(
+			etcd.Options{
+				RESTOptions:   serviceInstanceGrantRESTOptions,
+				Capacity:      1000,
+				ObjectType:    serviceinstancegrant.EmptyObject(),
+				ScopeStrategy: serviceinstancegrant.NewScopeStrategy(),
+				NewListFunc:   serviceinstancegrant.NewList,
+				GetAttrsFunc:  serviceinstancegrant.GetAttrs,
+				Trigger:       storage.NoTriggerPublisher,
+			},
+			p.StorageType,
+		)
+
+		storageMap["serviceinstancegrants"] = serviceinstancegrant.NewStorage(*serviceInstanceGrantOpts)
+	}
+
 	return storageMap, nil
 }
 
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceinstancegrant
+
+import (
+	"context"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/api"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/golang/glog"
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scv "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/validation"
+)
+
+// NewScopeStrategy returns a new NamespaceScopedStrategy for service instance
+// grants
+func NewScopeStrategy() rest.NamespaceScopedStrategy {
+	return serviceInstanceGrantRESTStrategies
+}
+
+// implements interfaces RESTCreateStrategy, RESTUpdateStrategy, RESTDeleteStrategy,
+// NamespaceScopedStrategy
+type serviceInstanceGrantRESTStrategy struct {
+	runtime.ObjectTyper // inherit ObjectKinds method
+	names.NameGenerator // GenerateName method for CreateStrategy
+}
+
+var (
+	serviceInstanceGrantRESTStrategies = serviceInstanceGrantRESTStrategy{
+		ObjectTyper:   api.Scheme,
+		NameGenerator: names.SimpleNameGenerator,
+	}
+	_ rest.RESTCreateStrategy = serviceInstanceGrantRESTStrategies
+	_ rest.RESTUpdateStrategy = serviceInstanceGrantRESTStrategies
+	_ rest.RESTDeleteStrategy = serviceInstanceGrantRESTStrategies
+)
+
+// Canonicalize does not transform a service instance grant.
+func (serviceInstanceGrantRESTStrategy) Canonicalize(obj runtime.Object) {
+	_, ok := obj.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to create")
+	}
+}
+
+// NamespaceScoped returns true as serviceinstancegrants are scoped
+// to a namespace.
+func (serviceInstanceGrantRESTStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// PrepareForCreate receives the incoming ServiceInstanceGrant and sets
+// its generation.
+func (serviceInstanceGrantRESTStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	grant, ok := obj.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to create")
+	}
+	grant.Generation = 1
+}
+
+func (serviceInstanceGrantRESTStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	return scv.ValidateServiceInstanceGrant(obj.(*sc.ServiceInstanceGrant))
+}
+
+func (serviceInstanceGrantRESTStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (serviceInstanceGrantRESTStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (serviceInstanceGrantRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtime.Object) {
+	newGrant, ok := new.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to update to")
+	}
+	oldGrant, ok := old.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to update from")
+	}
+
+	// Spec updates bump the generation so that we can distinguish between
+	// spec changes and other changes to the object.
+	if !apiequality.Semantic.DeepEqual(oldGrant.Spec, newGrant.Spec) {
+		newGrant.Generation = oldGrant.Generation + 1
+	}
+}
+
+func (serviceInstanceGrantRESTStrategy) ValidateUpdate(ctx context.Context, new, old runtime.Object) field.ErrorList {
+	newGrant, ok := new.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to validate to")
+	}
+	oldGrant, ok := old.(*sc.ServiceInstanceGrant)
+	if !ok {
+		glog.Fatal("received a non-serviceinstancegrant object to validate from")
+	}
+
+	return scv.ValidateServiceInstanceGrantUpdate(newGrant, oldGrant)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -50,18 +51,25 @@ func (sdk *SDK) RetrieveBinding(ns, name string) (*v1beta1.ServiceBinding, error
 	return binding, nil
 }
 
-// RetrieveBindingsByInstance gets all child bindings for an instance.
+// RetrieveBindingsByInstance gets all child bindings for an instance,
+// including the bindings of other namespaces the instance is shared with.
+// Bindings are only searched in the namespace of the instance when the user
+// may not list them in all namespaces.
 func (sdk *SDK) RetrieveBindingsByInstance(instance *v1beta1.ServiceInstance,
 ) ([]v1beta1.ServiceBinding, error) {
 	// Not using a filtered list operation because it's not supported yet.
-	results, err := sdk.ServiceCatalog().ServiceBindings(instance.Namespace).List(v1.ListOptions{})
+	results, err := sdk.ServiceCatalog().ServiceBindings("").List(v1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		results, err = sdk.ServiceCatalog().ServiceBindings(instance.Namespace).List(v1.ListOptions{})
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to search bindings")
 	}
 
 	var bindings []v1beta1.ServiceBinding
 	for _, binding := range results.Items {
-		if binding.Spec.ServiceInstanceRef.Name == instance.Name {
+		if binding.GetServiceInstanceNamespace() == instance.Namespace &&
+			binding.Spec.ServiceInstanceRef.Name == instance.Name {
 			bindings = append(bindings, binding)
 		}
 	}
@@ -204,6 +212,23 @@ func (sdk *SDK) BindingParentHierarchy(binding *v1beta1.ServiceBinding,
 	return instance, class, plan, broker, nil
 }
 
+// ScopedBindingParentHierarchy retrieves all ancestor resources of a binding,
+// whether its instance was provisioned from a cluster or namespace scoped plan.
+func (sdk *SDK) ScopedBindingParentHierarchy(binding *v1beta1.ServiceBinding,
+) (*v1beta1.ServiceInstance, Class, Plan, Broker, error) {
+	instance, err := sdk.RetrieveInstanceByBinding(binding)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	class, plan, broker, err := sdk.ScopedInstanceParentHierarchy(instance)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return instance, class, plan, broker, nil
+}
+
 // GetBindingStatusCondition returns the last condition on a binding status.
 // When no conditions exist, an empty condition is returned.
 func GetBindingStatusCondition(status v1beta1.ServiceBindingStatus) v1beta1.ServiceBindingCondition {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -99,10 +100,38 @@ var _ = Describe("Binding", func() {
 	})
 
 	Describe("RetrieveBindingsByInstance", func() {
-		It("Calls the generated v1beta1 List method on the provided instance's namespace", func() {
+		It("Calls the generated v1beta1 List method in all namespaces", func() {
+			si := &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "apple_instance", Namespace: sb.Namespace}}
+			sb.Spec.ServiceInstanceRef.Name = si.Name
+			sharedBinding := &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "team_namespace"}}
+			sharedBinding.Spec.ServiceInstanceRef.Name = si.Name
+			sharedBinding.Spec.ServiceInstanceNamespace = si.Namespace
+			otherBinding := &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team_namespace"}}
+			otherBinding.Spec.ServiceInstanceRef.Name = si.Name
+			svcCatClient = fake.NewSimpleClientset(sb, sb2, sharedBinding, otherBinding)
+			sdk = &SDK{
+				ServiceCatalogClient: svcCatClient,
+			}
+
+			bindings, err := sdk.RetrieveBindingsByInstance(si)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(bindings).To(ConsistOf(*sb, *sharedBinding))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "servicebindings")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).Namespace).To(Equal(""))
+		})
+
+		It("Falls back to the instance's namespace when listing all namespaces is forbidden", func() {
 			si := &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "apple_instance", Namespace: sb.Namespace}}
 			sb.Spec.ServiceInstanceRef.Name = si.Name
 			svcCatClient = fake.NewSimpleClientset(sb, sb2)
+			svcCatClient.PrependReactor("list", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				if action.GetNamespace() != "" {
+					return false, nil, nil
+				}
+				return true, nil, apierrors.NewForbidden(v1beta1.Resource("servicebindings"), "", fmt.Errorf("not allowed"))
+			})
 			sdk = &SDK{
 				ServiceCatalogClient: svcCatClient,
 			}
@@ -112,8 +141,9 @@ var _ = Describe("Binding", func() {
 
 			Expect(bindings).To(ConsistOf(*sb))
 			actions := svcCatClient.Actions()
-			Expect(actions[0].Matches("list", "servicebindings")).To(BeTrue())
-			Expect(actions[0].(testing.ListActionImpl).Namespace).To(Equal(si.Namespace))
+			Expect(actions).To(HaveLen(2))
+			Expect(actions[1].Matches("list", "servicebindings")).To(BeTrue())
+			Expect(actions[1].(testing.ListActionImpl).Namespace).To(Equal(si.Namespace))
 		})
 
 		It("Bubbles up errors", func() {
@@ -18,15 +18,79 @@ package servicecatalog
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
-// Deregister deletes a broker
+// Broker provides a unifying layer of cluster and namespace scoped broker resources.
+type Broker interface {
+
+	// GetName returns the broker's name.
+	GetName() string
+
+	// GetNamespace returns the broker's namespace, or "" if it's cluster-scoped.
+	GetNamespace() string
+
+	// GetURL returns the broker's URL.
+	GetURL() string
+
+	// GetSpec returns the broker's spec.
+	GetSpec() v1beta1.CommonServiceBrokerSpec
+
+	// GetStatus returns the broker's status.
+	GetStatus() v1beta1.CommonServiceBrokerStatus
+}
+
+// RegisterOptions allows specifying additional broker registration options.
+type RegisterOptions struct {
+	// BasicSecret is the name of a secret containing basic auth credentials
+	// for the broker. For cluster-scoped brokers, the secret is looked up in
+	// the namespace of the scope options.
+	BasicSecret string
+
+	// BearerSecret is the name of a secret containing a bearer token for the
+	// broker. For cluster-scoped brokers, the secret is looked up in the
+	// namespace of the scope options.
+	BearerSecret string
+
+	// CAFile is the path to a PEM encoded CA bundle used to validate the
+	// broker's serving certificate.
+	CAFile string
+
+	// ClassRestrictions are the catalog restrictions applied to the broker's classes.
+	ClassRestrictions []string
+
+	// PlanRestrictions are the catalog restrictions applied to the broker's plans.
+	PlanRestrictions []string
+
+	// RelistBehavior is the relist behavior for the broker's catalog.
+	RelistBehavior v1beta1.ServiceBrokerRelistBehavior
+
+	// RelistDuration is the frequency of relists when the behavior is Duration.
+	RelistDuration *v1.Duration
+
+	// SkipTLS disables TLS certificate verification when communicating with the broker.
+	SkipTLS bool
+}
+
+// Deregister deletes a cluster-scoped broker
 func (sdk *SDK) Deregister(brokerName string) error {
-	err := sdk.ServiceCatalog().ClusterServiceBrokers().Delete(brokerName, &v1.DeleteOptions{})
+	return sdk.DeregisterScopedBroker(brokerName, ScopeOptions{Scope: ClusterScope})
+}
+
+// DeregisterScopedBroker deletes a broker, which is namespace-scoped if the
+// scope options have the namespace scope, and otherwise cluster-scoped.
+func (sdk *SDK) DeregisterScopedBroker(brokerName string, scopeOpts ScopeOptions) error {
+	var err error
+	if scopeOpts.Scope == NamespaceScope {
+		err = sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Delete(brokerName, &v1.DeleteOptions{})
+	} else {
+		err = sdk.ServiceCatalog().ClusterServiceBrokers().Delete(brokerName, &v1.DeleteOptions{})
+	}
 	if err != nil {
 		return fmt.Errorf("deregister request failed (%s)", err)
 	}
@@ -65,40 +129,132 @@ func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
 	return broker, nil
 }
 
-// Register creates a broker
+// Register creates a cluster-scoped broker
 func (sdk *SDK) Register(brokerName string, url string) (*v1beta1.ClusterServiceBroker, error) {
+	broker, err := sdk.RegisterScopedBroker(brokerName, url, nil, ScopeOptions{Scope: ClusterScope})
+	if err != nil {
+		return nil, err
+	}
+	return broker.(*v1beta1.ClusterServiceBroker), nil
+}
+
+// RegisterScopedBroker creates a broker with the given options, which is
+// namespace-scoped if the scope options have the namespace scope, and
+// otherwise cluster-scoped.
+func (sdk *SDK) RegisterScopedBroker(brokerName string, url string, opts *RegisterOptions, scopeOpts ScopeOptions) (Broker, error) {
+	if opts == nil {
+		opts = &RegisterOptions{}
+	}
+
+	spec := v1beta1.CommonServiceBrokerSpec{
+		URL:                   url,
+		InsecureSkipTLSVerify: opts.SkipTLS,
+		RelistBehavior:        opts.RelistBehavior,
+		RelistDuration:        opts.RelistDuration,
+	}
+	if opts.CAFile != "" {
+		caBytes, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file '%s' (%s)", opts.CAFile, err)
+		}
+		spec.CABundle = caBytes
+	}
+	if len(opts.ClassRestrictions) > 0 || len(opts.PlanRestrictions) > 0 {
+		spec.CatalogRestrictions = &v1beta1.CatalogRestrictions{
+			ServiceClass: opts.ClassRestrictions,
+			ServicePlan:  opts.PlanRestrictions,
+		}
+	}
+
+	if scopeOpts.Scope == NamespaceScope {
+		request := &v1beta1.ServiceBroker{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      brokerName,
+				Namespace: scopeOpts.Namespace,
+			},
+			Spec: v1beta1.ServiceBrokerSpec{
+				CommonServiceBrokerSpec: spec,
+			},
+		}
+		if opts.BasicSecret != "" {
+			request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+				Basic: &v1beta1.BasicAuthConfig{
+					SecretRef: &v1beta1.LocalObjectReference{Name: opts.BasicSecret},
+				},
+			}
+		} else if opts.BearerSecret != "" {
+			request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+				Bearer: &v1beta1.BearerTokenAuthConfig{
+					SecretRef: &v1beta1.LocalObjectReference{Name: opts.BearerSecret},
+				},
+			}
+		}
+
+		result, err := sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Create(request)
+		if err != nil {
+			return nil, fmt.Errorf("register request failed (%s)", err)
+		}
+		return result, nil
+	}
+
 	request := &v1beta1.ClusterServiceBroker{
 		ObjectMeta: v1.ObjectMeta{
 			Name: brokerName,
 		},
 		Spec: v1beta1.ClusterServiceBrokerSpec{
-			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
-				URL: url,
-			},
+			CommonServiceBrokerSpec: spec,
 		},
 	}
+	if opts.BasicSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
+			Basic: &v1beta1.ClusterBasicAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Name:      opts.BasicSecret,
+					Namespace: scopeOpts.Namespace,
+				},
+			},
+		}
+	} else if opts.BearerSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
+			Bearer: &v1beta1.ClusterBearerTokenAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Name:      opts.BearerSecret,
+					Namespace: scopeOpts.Namespace,
+				},
+			},
+		}
+	}
+
 	result, err := sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
 	if err != nil {
 		return nil, fmt.Errorf("register request failed (%s)", err)
 	}
-
 	return result, nil
 }
 
-// Sync or relist a broker to refresh its catalog metadata.
+// Sync or relist a cluster-scoped broker to refresh its catalog metadata.
 func (sdk *SDK) Sync(name string, retries int) error {
+	return sdk.SyncScopedBroker(name, ScopeOptions{Scope: ClusterScope}, retries)
+}
+
+// SyncScopedBroker syncs or relists a broker to refresh its catalog
+// metadata. The broker is namespace-scoped if the scope options have the
+// namespace scope, and otherwise cluster-scoped. Updates that conflict with
+// another change to the broker are retried up to the given number of times.
+func (sdk *SDK) SyncScopedBroker(name string, scopeOpts ScopeOptions, retries int) error {
 	for j := 0; j < retries; j++ {
-		catalog, err := sdk.RetrieveBroker(name)
-		if err != nil {
-			return err
+		var err error
+		if scopeOpts.Scope == NamespaceScope {
+			err = sdk.syncNamespacedBroker(name, scopeOpts.Namespace)
+		} else {
+			err = sdk.syncClusterBroker(name)
 		}
-
-		catalog.Spec.RelistRequests = catalog.Spec.RelistRequests + 1
-
-		_, err = sdk.ServiceCatalog().ClusterServiceBrokers().Update(catalog)
 		if err == nil {
 			return nil
 		}
+		if errors.IsNotFound(err) {
+			return err
+		}
 		if !errors.IsConflict(err) {
 			return fmt.Errorf("could not sync service broker (%s)", err)
 		}
@@ -106,3 +262,123 @@ func (sdk *SDK) Sync(name string, retries int) error {
 
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
+
+func (sdk *SDK) syncClusterBroker(name string) error {
+	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	broker.Spec.RelistRequests = broker.Spec.RelistRequests + 1
+
+	_, err = sdk.ServiceCatalog().ClusterServiceBrokers().Update(broker)
+	return err
+}
+
+func (sdk *SDK) syncNamespacedBroker(name, namespace string) error {
+	broker, err := sdk.ServiceCatalog().ServiceBrokers(namespace).Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	broker.Spec.RelistRequests = broker.Spec.RelistRequests + 1
+
+	_, err = sdk.ServiceCatalog().ServiceBrokers(namespace).Update(broker)
+	return err
+}
+
+// RetrieveScopedBrokers lists the brokers included by the scope options.
+func (sdk *SDK) RetrieveScopedBrokers(opts ScopeOptions) ([]Broker, error) {
+	var brokers []Broker
+
+	if opts.Scope.IncludesCluster() {
+		csb, err := sdk.ServiceCatalog().ClusterServiceBrokers().List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list cluster-scoped brokers (%s)", err)
+		}
+		for i := range csb.Items {
+			brokers = append(brokers, &csb.Items[i])
+		}
+	}
+
+	if opts.Scope.IncludesNamespace() {
+		sb, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).List(v1.ListOptions{})
+		if err != nil {
+			// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
+			if errors.IsNotFound(err) {
+				return brokers, nil
+			}
+			return nil, fmt.Errorf("unable to list brokers in %q (%s)", opts.Namespace, err)
+		}
+		for i := range sb.Items {
+			brokers = append(brokers, &sb.Items[i])
+		}
+	}
+
+	return brokers, nil
+}
+
+// RetrieveScopedBroker gets a broker by its name, searching the scopes
+// included by the scope options.
+func (sdk *SDK) RetrieveScopedBroker(name string, opts ScopeOptions) (Broker, error) {
+	var brokers []Broker
+
+	if opts.Scope.IncludesCluster() {
+		csb, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+		if err == nil {
+			brokers = append(brokers, csb)
+		} else if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to get broker '%s' (%s)", name, err)
+		}
+	}
+
+	if opts.Scope.IncludesNamespace() && opts.Namespace != "" {
+		sb, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		if err == nil {
+			brokers = append(brokers, sb)
+		} else if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to get broker '%s.%s' (%s)", opts.Namespace, name, err)
+		}
+	} else if opts.Scope.IncludesNamespace() {
+		// Without a namespace, the brokers of all namespaces are searched,
+		// as they are for classes and plans.
+		listOpts := v1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+		}
+		sbs, err := sdk.ServiceCatalog().ServiceBrokers("").List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search brokers by name (%s)", err)
+		}
+		for i := range sbs.Items {
+			if sbs.Items[i].Name == name {
+				brokers = append(brokers, &sbs.Items[i])
+			}
+		}
+	}
+
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("broker '%s' not found", name)
+	}
+	if len(brokers) > 1 {
+		return nil, fmt.Errorf("more than one matching broker found for '%s', specify a scope or namespace", name)
+	}
+	return brokers[0], nil
+}
+
+// RetrieveScopedBrokerByClass gets the parent broker of a cluster or namespace scoped class.
+func (sdk *SDK) RetrieveScopedBrokerByClass(class Class) (Broker, error) {
+	brokerName := class.GetServiceBrokerName()
+	if class.GetNamespace() == "" {
+		broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(brokerName, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return broker, nil
+	}
+
+	broker, err := sdk.ServiceCatalog().ServiceBrokers(class.GetNamespace()).Get(brokerName, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return broker, nil
+}
@@ -18,9 +18,13 @@ package servicecatalog_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/testing"
@@ -180,6 +184,63 @@ var _ = Describe("Broker", func() {
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
 		})
 	})
+	Describe("RegisterScopedBroker", func() {
+		It("creates a namespaced broker with auth, CA and relist options", func() {
+			caFile, err := ioutil.TempFile("", "ca")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.Remove(caFile.Name())
+			caFile.WriteString("my-ca")
+			caFile.Close()
+
+			opts := &RegisterOptions{
+				BasicSecret:       "potato-auth",
+				CAFile:            caFile.Name(),
+				ClassRestrictions: []string{"spec.externalName in (potato)"},
+				RelistBehavior:    v1beta1.ServiceBrokerRelistBehaviorDuration,
+				RelistDuration:    &metav1.Duration{Duration: 10 * time.Minute},
+			}
+			broker, err := sdk.RegisterScopedBroker("potato_broker", "https://potato.com", opts, ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.GetNamespace()).To(Equal("default"))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.Namespace).To(Equal("default"))
+			Expect(objectFromRequest.Spec.AuthInfo.Basic.SecretRef.Name).To(Equal("potato-auth"))
+			Expect(objectFromRequest.Spec.AuthInfo.Bearer).To(BeNil())
+			Expect(string(objectFromRequest.Spec.CABundle)).To(Equal("my-ca"))
+			Expect(objectFromRequest.Spec.CatalogRestrictions.ServiceClass).To(ConsistOf("spec.externalName in (potato)"))
+			Expect(objectFromRequest.Spec.CatalogRestrictions.ServicePlan).To(BeEmpty())
+			Expect(objectFromRequest.Spec.RelistBehavior).To(Equal(v1beta1.ServiceBrokerRelistBehaviorDuration))
+			Expect(objectFromRequest.Spec.RelistDuration.Duration).To(Equal(10 * time.Minute))
+		})
+		It("references the bearer secret in the scope namespace for cluster-scoped brokers", func() {
+			opts := &RegisterOptions{
+				BearerSecret: "potato-token",
+				SkipTLS:      true,
+			}
+			_, err := sdk.RegisterScopedBroker("potato_broker", "https://potato.com", opts, ScopeOptions{Scope: ClusterScope, Namespace: "potato-system"})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal("potato-token"))
+			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Namespace).To(Equal("potato-system"))
+			Expect(objectFromRequest.Spec.InsecureSkipTLSVerify).To(BeTrue())
+			Expect(objectFromRequest.Spec.CatalogRestrictions).To(BeNil())
+		})
+		It("Bubbles up errors reading the CA file", func() {
+			opts := &RegisterOptions{CAFile: "/does/not/exist.pem"}
+			_, err := sdk.RegisterScopedBroker("potato_broker", "https://potato.com", opts, ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to read CA file"))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+	})
 	Describe("Sync", func() {
 		It("Useds the generated v1beta1 Retrieve method to get the broker, and then updates it with a new RelistRequests", func() {
 			err := sdk.Sync(sb.Name, 3)
@@ -193,5 +254,165 @@ var _ = Describe("Broker", func() {
 			Expect(actions[1].Matches("update", "clusterservicebrokers")).To(BeTrue())
 			Expect(actions[1].(testing.UpdateActionImpl).Object.(*v1beta1.ClusterServiceBroker).Spec.RelistRequests).Should(BeNumerically(">", 0))
 		})
+		It("Returns NotFound errors without retrying", func() {
+			err := sdk.Sync("potato_broker", 3)
+
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("get", "clusterservicebrokers")).To(BeTrue())
+		})
+	})
+	Describe("RetrieveScopedBrokers", func() {
+		var nsb *v1beta1.ServiceBroker
+
+		BeforeEach(func() {
+			nsb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sb, sb2, nsb)
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+
+		It("Only lists cluster-scoped brokers when the scope is cluster", func() {
+			brokers, err := sdk.RetrieveScopedBrokers(ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).Should(ConsistOf(sb, sb2))
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Matches("list", "clusterservicebrokers")).To(BeTrue())
+		})
+		It("Only lists namespaced brokers when the scope is namespace", func() {
+			brokers, err := sdk.RetrieveScopedBrokers(ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).Should(ConsistOf(nsb))
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Matches("list", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("default"))
+		})
+		It("Lists both kinds of brokers when the scope is all", func() {
+			brokers, err := sdk.RetrieveScopedBrokers(ScopeOptions{Scope: AllScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).Should(ConsistOf(sb, sb2, nsb))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error retrieving list"
+			badClient.AddReactor("list", "servicebrokers", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+			_, err := sdk.RetrieveScopedBrokers(ScopeOptions{Scope: NamespaceScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
+	Describe("RetrieveScopedBroker", func() {
+		var nsb *v1beta1.ServiceBroker
+
+		BeforeEach(func() {
+			nsb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sb, sb2, nsb)
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+
+		It("Gets a namespaced broker", func() {
+			broker, err := sdk.RetrieveScopedBroker(nsb.Name, ScopeOptions{Scope: NamespaceScope, Namespace: nsb.Namespace})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(nsb))
+		})
+		It("Gets a cluster-scoped broker", func() {
+			broker, err := sdk.RetrieveScopedBroker(sb2.Name, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(sb2))
+		})
+		It("Returns an error when the name is ambiguous", func() {
+			_, err := sdk.RetrieveScopedBroker(sb.Name, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("more than one matching broker"))
+		})
+		It("Searches all namespaces when no namespace is specified", func() {
+			broker, err := sdk.RetrieveScopedBroker(nsb.Name, ScopeOptions{Scope: NamespaceScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(BeEmpty())
+		})
+		It("Returns an error when the name is ambiguous across namespaces", func() {
+			_, err := sdk.RetrieveScopedBroker(sb.Name, ScopeOptions{Scope: AllScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("more than one matching broker"))
+		})
+		It("Returns an error when the broker doesn't exist", func() {
+			broker, err := sdk.RetrieveScopedBroker("banana", ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not found"))
+		})
+	})
+	Describe("RetrieveScopedBrokerByClass", func() {
+		It("Gets the parent broker of a namespaced class", func() {
+			nsb := &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sb, nsb)
+			sdk.ServiceCatalogClient = svcCatClient
+			sc := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       v1beta1.ServiceClassSpec{ServiceBrokerName: nsb.Name},
+			}
+
+			broker, err := sdk.RetrieveScopedBrokerByClass(sc)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+		})
+		It("Bubbles up errors", func() {
+			sc := &v1beta1.ClusterServiceClass{Spec: v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: "banana"}}
+			broker, err := sdk.RetrieveScopedBrokerByClass(sc)
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not found"))
+		})
+	})
+	Describe("DeregisterScopedBroker and SyncScopedBroker", func() {
+		var nsb *v1beta1.ServiceBroker
+
+		BeforeEach(func() {
+			nsb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sb, nsb)
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+
+		It("deletes a namespaced broker", func() {
+			err := sdk.DeregisterScopedBroker(nsb.Name, ScopeOptions{Scope: NamespaceScope, Namespace: nsb.Namespace})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(nsb.Namespace))
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(nsb.Name))
+		})
+		It("relists a namespaced broker", func() {
+			err := sdk.SyncScopedBroker(nsb.Name, ScopeOptions{Scope: NamespaceScope, Namespace: nsb.Namespace}, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+			Expect(actions[1].Matches("update", "servicebrokers")).To(BeTrue())
+			Expect(actions[1].(testing.UpdateActionImpl).Object.(*v1beta1.ServiceBroker).Spec.RelistRequests).Should(BeNumerically(">", 0))
+		})
 	})
 })
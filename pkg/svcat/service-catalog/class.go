@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -35,11 +36,20 @@ type Class interface {
 	// GetName returns the class's name.
 	GetName() string
 
+	// GetNamespace returns the class's namespace, or "" if it's cluster-scoped.
+	GetNamespace() string
+
 	// GetExternalName returns the class's external name.
 	GetExternalName() string
 
 	// GetDescription returns the class description.
 	GetDescription() string
+
+	// GetServiceBrokerName returns the name of the class's broker.
+	GetServiceBrokerName() string
+
+	// GetSpec returns the class's spec.
+	GetSpec() v1beta1.CommonServiceClassSpec
 }
 
 // RetrieveClasses lists all classes defined in the cluster.
@@ -90,3 +100,91 @@ func (sdk *SDK) RetrieveClassByPlan(plan *v1beta1.ClusterServicePlan,
 
 	return class, nil
 }
+
+// RetrieveScopedClasses lists the classes included by the scope options.
+func (sdk *SDK) RetrieveScopedClasses(opts ScopeOptions) ([]Class, error) {
+	var classes []Class
+
+	if opts.Scope.IncludesCluster() {
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list cluster-scoped classes (%s)", err)
+		}
+		for i := range csc.Items {
+			classes = append(classes, &csc.Items[i])
+		}
+	}
+
+	if opts.Scope.IncludesNamespace() {
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(v1.ListOptions{})
+		if err != nil {
+			// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
+			if errors.IsNotFound(err) {
+				return classes, nil
+			}
+			return nil, fmt.Errorf("unable to list classes in %q (%s)", opts.Namespace, err)
+		}
+		for i := range sc.Items {
+			classes = append(classes, &sc.Items[i])
+		}
+	}
+
+	return classes, nil
+}
+
+// RetrieveScopedClassByName gets a class by its external name, searching the
+// scopes included by the scope options.
+func (sdk *SDK) RetrieveScopedClassByName(name string, opts ScopeOptions) (Class, error) {
+	var classes []Class
+
+	listOpts := v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(FieldExternalClassName, name).String(),
+	}
+
+	if opts.Scope.IncludesCluster() {
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+		}
+		for i := range csc.Items {
+			classes = append(classes, &csc.Items[i])
+		}
+	}
+
+	if opts.Scope.IncludesNamespace() {
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(listOpts)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+		}
+		if sc != nil {
+			for i := range sc.Items {
+				classes = append(classes, &sc.Items[i])
+			}
+		}
+	}
+
+	if len(classes) == 0 {
+		return nil, fmt.Errorf("class '%s' not found", name)
+	}
+	if len(classes) > 1 {
+		return nil, fmt.Errorf("more than one matching class found for '%s'", name)
+	}
+	return classes[0], nil
+}
+
+// RetrieveScopedClassByPlan gets the class associated to a cluster or namespace scoped plan.
+func (sdk *SDK) RetrieveScopedClassByPlan(plan Plan) (Class, error) {
+	if plan.GetNamespace() == "" {
+		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(plan.GetClassID(), v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get class (%s)", err)
+		}
+		return class, nil
+	}
+
+	class, err := sdk.ServiceCatalog().ServiceClasses(plan.GetNamespace()).Get(plan.GetClassID(), v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get class (%s)", err)
+	}
+	return class, nil
+}
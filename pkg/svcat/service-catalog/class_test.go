@@ -207,4 +207,90 @@ var _ = Describe("Class", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(fakeClassName))
 		})
 	})
+	Describe("RetrieveScopedClasses", func() {
+		It("Lists classes from both scopes when the scope is all", func() {
+			nsc := &v1beta1.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sc, sc2, nsc)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			classes, err := sdk.RetrieveScopedClasses(ScopeOptions{Scope: AllScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(sc, sc2, nsc))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(actions[1].Matches("list", "serviceclasses")).To(BeTrue())
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error retrieving list"
+			badClient.AddReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.RetrieveScopedClasses(ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
+	Describe("RetrieveScopedClassByName", func() {
+		It("Searches namespaced classes by their external name", func() {
+			nsc := &v1beta1.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			realClient := &fake.Clientset{}
+			realClient.AddReactor("list", "serviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServiceClassList{Items: []v1beta1.ServiceClass{*nsc}}, nil
+			})
+			sdk.ServiceCatalogClient = realClient
+
+			class, err := sdk.RetrieveScopedClassByName("foobar", ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class).To(Equal(nsc))
+			actions := realClient.Actions()
+			Expect(actions[0].Matches("list", "serviceclasses")).To(BeTrue())
+			requirements := actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Requirements()
+			Expect(requirements).ShouldNot(BeEmpty())
+			Expect(requirements[0].Field).To(Equal("spec.externalName"))
+			Expect(requirements[0].Value).To(Equal("foobar"))
+		})
+		It("Returns an error when the class exists in both scopes", func() {
+			realClient := &fake.Clientset{}
+			realClient.AddReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ClusterServiceClassList{Items: []v1beta1.ClusterServiceClass{*sc}}, nil
+			})
+			realClient.AddReactor("list", "serviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServiceClassList{Items: []v1beta1.ServiceClass{{}}}, nil
+			})
+			sdk.ServiceCatalogClient = realClient
+
+			class, err := sdk.RetrieveScopedClassByName("foobar", ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(class).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("more than one matching class"))
+		})
+	})
+	Describe("RetrieveScopedClassByPlan", func() {
+		It("Gets the namespaced class of a namespaced plan", func() {
+			nsc := &v1beta1.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			svcCatClient = fake.NewSimpleClientset(sc, nsc)
+			sdk.ServiceCatalogClient = svcCatClient
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan", Namespace: "default"},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{Name: nsc.Name},
+				},
+			}
+
+			class, err := sdk.RetrieveScopedClassByPlan(plan)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class).To(Equal(nsc))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "serviceclasses")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("default"))
+		})
+	})
 })
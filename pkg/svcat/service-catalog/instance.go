@@ -72,10 +72,11 @@ func (sdk *SDK) RetrieveInstance(ns, name string) (*v1beta1.ServiceInstance, err
 	return instance, nil
 }
 
-// RetrieveInstanceByBinding retrieves the parent instance for a binding.
+// RetrieveInstanceByBinding retrieves the parent instance for a binding,
+// which may live in another namespace than the binding.
 func (sdk *SDK) RetrieveInstanceByBinding(b *v1beta1.ServiceBinding,
 ) (*v1beta1.ServiceInstance, error) {
-	ns := b.Namespace
+	ns := b.GetServiceInstanceNamespace()
 	instName := b.Spec.ServiceInstanceRef.Name
 	inst, err := sdk.ServiceCatalog().ServiceInstances(ns).Get(instName, v1.GetOptions{})
 	if err != nil {
@@ -164,6 +165,52 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	}
 }
 
+// ScopedInstanceParentHierarchy retrieves all ancestor resources of an
+// instance, whether it was provisioned from a cluster or namespace scoped plan.
+func (sdk *SDK) ScopedInstanceParentHierarchy(instance *v1beta1.ServiceInstance,
+) (Class, Plan, Broker, error) {
+	class, plan, err := sdk.InstanceToScopedClassAndPlan(instance)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	broker, err := sdk.RetrieveScopedBrokerByClass(class)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return class, plan, broker, nil
+}
+
+// InstanceToScopedClassAndPlan retrieves the parent class and plan for an
+// instance, whether it was provisioned from a cluster or namespace scoped plan.
+func (sdk *SDK) InstanceToScopedClassAndPlan(instance *v1beta1.ServiceInstance,
+) (Class, Plan, error) {
+	if instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil {
+		class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		return class, plan, nil
+	}
+
+	if instance.Spec.ServiceClassRef == nil || instance.Spec.ServicePlanRef == nil {
+		return nil, nil, fmt.Errorf("instance '%s.%s' has not been resolved to a class and plan", instance.Namespace, instance.Name)
+	}
+
+	class, err := sdk.ServiceCatalog().ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name, v1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := sdk.ServiceCatalog().ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name, v1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return class, plan, nil
+}
+
 // Provision creates an instance of a service class and plan.
 func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planName string,
 	params interface{}, secrets map[string]string) (*v1beta1.ServiceInstance, error) {
@@ -120,6 +120,19 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(instanceName))
 			Expect(actions[0].(testing.GetActionImpl).Namespace).To(Equal(namespace))
 		})
+		It("Calls the generated v1beta1 Get method with the namespace of the instance of a binding of another namespace", func() {
+			sb := &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "banana_binding", Namespace: "team_namespace"}}
+			sb.Spec.ServiceInstanceRef.Name = si.Name
+			sb.Spec.ServiceInstanceNamespace = si.Namespace
+			instance, err := sdk.RetrieveInstanceByBinding(sb)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(Equal(si))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(si.Name))
+			Expect(actions[0].(testing.GetActionImpl).Namespace).To(Equal(si.Namespace))
+		})
 		It("Bubbles up errors", func() {
 			namespace := si.Namespace
 			instanceName := "not_real_instance"
@@ -447,6 +460,67 @@ var _ = Describe("Instances", func() {
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
 		})
 	})
+	Describe("ScopedInstanceParentHierarchy", func() {
+		It("builds the heirarchy of an instance provisioned from a namespaced plan", func() {
+			broker := &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker", Namespace: "foobar_namespace"}}
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_class", Namespace: "foobar_namespace"},
+				Spec:       v1beta1.ServiceClassSpec{ServiceBrokerName: broker.Name},
+			}
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan", Namespace: "foobar_namespace"},
+			}
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ServiceClassRef: &v1beta1.LocalObjectReference{Name: class.Name},
+					ServicePlanRef:  &v1beta1.LocalObjectReference{Name: plan.Name},
+				},
+			}
+			linkedClient := fake.NewSimpleClientset(si, class, plan, broker)
+			sdk.ServiceCatalogClient = linkedClient
+
+			retClass, retPlan, retBroker, err := sdk.ScopedInstanceParentHierarchy(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retClass).To(Equal(class))
+			Expect(retPlan).To(Equal(plan))
+			Expect(retBroker).To(Equal(broker))
+			actions := linkedClient.Actions()
+			Expect(actions[0].Matches("get", "serviceclasses")).To(BeTrue())
+			Expect(actions[1].Matches("get", "serviceplans")).To(BeTrue())
+			Expect(actions[2].Matches("get", "servicebrokers")).To(BeTrue())
+		})
+		It("builds the heirarchy of an instance provisioned from a cluster-scoped plan", func() {
+			broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker"}}
+			class := &v1beta1.ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_class"},
+				Spec:       v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: broker.Name},
+			}
+			plan := &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan"}}
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: class.Name},
+					ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: plan.Name},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(si, class, plan, broker)
+
+			retClass, retPlan, retBroker, err := sdk.ScopedInstanceParentHierarchy(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retClass).To(Equal(class))
+			Expect(retPlan).To(Equal(plan))
+			Expect(retBroker).To(Equal(broker))
+		})
+		It("returns an error when the instance has not been resolved", func() {
+			_, _, _, err := sdk.ScopedInstanceParentHierarchy(si)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has not been resolved"))
+		})
+	})
 	Describe("Provision", func() {
 		It("Calls the v1beta1 Create method with the passed in arguements", func() {
 			namespace := "cherry_namespace"
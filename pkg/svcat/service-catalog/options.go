@@ -16,7 +16,57 @@ limitations under the License.
 
 package servicecatalog
 
+import (
+	"fmt"
+	"strings"
+)
+
 // FilterOptions allows for optional filtering fields to be passed to `Retrieve` methods.
 type FilterOptions struct {
 	ClassID string
 }
+
+// Scope is an enum that represents filtering objects by their scope.
+type Scope string
+
+const (
+	// AllScope combines cluster-scoped and namespace-scoped resources.
+	AllScope Scope = "all"
+
+	// ClusterScope restricts results to cluster-scoped resources.
+	ClusterScope Scope = "cluster"
+
+	// NamespaceScope restricts results to namespace-scoped resources.
+	NamespaceScope Scope = "namespace"
+)
+
+// ScopeOptions allows for filtering results by their scope, and the namespace
+// used when looking up namespace-scoped resources.
+type ScopeOptions struct {
+	// Namespace to search for namespace-scoped resources.
+	// When empty, namespace-scoped resources from all namespaces are included.
+	Namespace string
+
+	// Scope of the resources to include.
+	Scope Scope
+}
+
+// IncludesCluster determines if cluster-scoped resources should be retrieved.
+func (s Scope) IncludesCluster() bool {
+	return s == AllScope || s == ClusterScope
+}
+
+// IncludesNamespace determines if namespace-scoped resources should be retrieved.
+func (s Scope) IncludesNamespace() bool {
+	return s == AllScope || s == NamespaceScope
+}
+
+// ParseScope converts a user-specified scope into a Scope, returning an error
+// if it is not a recognized value.
+func ParseScope(scope string) (Scope, error) {
+	switch s := Scope(strings.ToLower(scope)); s {
+	case AllScope, ClusterScope, NamespaceScope:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid scope '%s', allowed values are: %s, %s, %s", scope, AllScope, ClusterScope, NamespaceScope)
+}
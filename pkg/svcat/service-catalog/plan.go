@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -30,6 +31,9 @@ const (
 
 	// FieldServiceClassRef is the jsonpath to a plan's associated class name.
 	FieldServiceClassRef = "spec.clusterServiceClassRef.name"
+
+	// FieldNamespacedServiceClassRef is the jsonpath to a namespaced plan's associated class name.
+	FieldNamespacedServiceClassRef = "spec.serviceClassRef.name"
 )
 
 // Plan provides a unifying layer of cluster and namespace scoped plan resources.
@@ -38,11 +42,23 @@ type Plan interface {
 	// GetName returns the plan's name.
 	GetName() string
 
+	// GetNamespace returns the plan's namespace, or "" if it's cluster-scoped.
+	GetNamespace() string
+
 	// GetExternalName returns the plan's external name.
 	GetExternalName() string
 
 	// GetDescription returns the plan description.
 	GetDescription() string
+
+	// GetClassID returns the name of the plan's class.
+	GetClassID() string
+
+	// GetServiceBrokerName returns the name of the plan's broker.
+	GetServiceBrokerName() string
+
+	// GetSpec returns the plan's spec.
+	GetSpec() v1beta1.CommonServicePlanSpec
 }
 
 // RetrievePlans lists all plans defined in the cluster.
@@ -133,3 +149,74 @@ func (sdk *SDK) RetrievePlanByClassAndPlanNames(className, planName string,
 	}
 	return &searchResults.Items[0], nil
 }
+
+// RetrieveScopedPlans lists the plans included by the scope options,
+// optionally filtered by their class.
+func (sdk *SDK) RetrieveScopedPlans(filter *FilterOptions, opts ScopeOptions) ([]Plan, error) {
+	var plans []Plan
+
+	if opts.Scope.IncludesCluster() {
+		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list cluster-scoped plans (%s)", err)
+		}
+		for i := range csp.Items {
+			plans = append(plans, &csp.Items[i])
+		}
+	}
+
+	if opts.Scope.IncludesNamespace() {
+		sp, err := sdk.ServiceCatalog().ServicePlans(opts.Namespace).List(v1.ListOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to list plans in %q (%s)", opts.Namespace, err)
+		}
+		if sp != nil {
+			for i := range sp.Items {
+				plans = append(plans, &sp.Items[i])
+			}
+		}
+	}
+
+	if filter != nil && filter.ClassID != "" {
+		plansFiltered := make([]Plan, 0)
+		for _, p := range plans {
+			if p.GetClassID() == filter.ClassID {
+				plansFiltered = append(plansFiltered, p)
+			}
+		}
+		return plansFiltered, nil
+	}
+
+	return plans, nil
+}
+
+// RetrieveScopedPlansByClass retrieves all plans for a cluster or namespace scoped class.
+func (sdk *SDK) RetrieveScopedPlansByClass(class Class) ([]Plan, error) {
+	var plans []Plan
+
+	if class.GetNamespace() == "" {
+		planOpts := v1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(FieldServiceClassRef, class.GetName()).String(),
+		}
+		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(planOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list plans (%s)", err)
+		}
+		for i := range csp.Items {
+			plans = append(plans, &csp.Items[i])
+		}
+		return plans, nil
+	}
+
+	planOpts := v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(FieldNamespacedServiceClassRef, class.GetName()).String(),
+	}
+	sp, err := sdk.ServiceCatalog().ServicePlans(class.GetNamespace()).List(planOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list plans (%s)", err)
+	}
+	for i := range sp.Items {
+		plans = append(plans, &sp.Items[i])
+	}
+	return plans, nil
+}
@@ -197,4 +197,65 @@ var _ = Describe("Plan", func() {
 			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
 		})
 	})
+	Describe("RetrieveScopedPlans", func() {
+		It("Lists plans from both scopes, filtered by class", func() {
+			csp := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster_plan"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "durian_class"},
+				},
+			}
+			sp := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "namespaced_plan", Namespace: "default"},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{Name: "durian_class"},
+				},
+			}
+			otherPlan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "other_plan", Namespace: "default"},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{Name: "other_class"},
+				},
+			}
+			svcCatClient = fake.NewSimpleClientset(csp, sp, otherPlan)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			plans, err := sdk.RetrieveScopedPlans(&FilterOptions{ClassID: "durian_class"}, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).To(ConsistOf(csp, sp))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "clusterserviceplans")).To(BeTrue())
+			Expect(actions[1].Matches("list", "serviceplans")).To(BeTrue())
+		})
+	})
+	Describe("RetrieveScopedPlansByClass", func() {
+		It("Lists namespaced plans with a field selector on the class name", func() {
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "durian_class", Namespace: "default"},
+			}
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "durian", Namespace: "default"},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{Name: class.Name},
+				},
+			}
+			linkedClient := &fake.Clientset{}
+			linkedClient.AddReactor("list", "serviceplans", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServicePlanList{Items: []v1beta1.ServicePlan{*plan}}, nil
+			})
+			sdk.ServiceCatalogClient = linkedClient
+
+			plans, err := sdk.RetrieveScopedPlansByClass(class)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).To(ConsistOf(plan))
+			actions := linkedClient.Actions()
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Matches("list", "serviceplans")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(class.Namespace))
+			opts := fields.Set{"spec.serviceClassRef.name": class.Name}
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
+		})
+	})
 })
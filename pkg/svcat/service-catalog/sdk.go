@@ -41,24 +41,36 @@ type SvcatClient interface {
 	RetrieveBinding(string, string) (*apiv1beta1.ServiceBinding, error)
 	RetrieveBindings(string) (*apiv1beta1.ServiceBindingList, error)
 	RetrieveBindingsByInstance(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
+	ScopedBindingParentHierarchy(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, Class, Plan, Broker, error)
 	Unbind(string, string) ([]types.NamespacedName, error)
 	WaitForBinding(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
 
 	Deregister(string) error
+	DeregisterScopedBroker(string, ScopeOptions) error
 	RetrieveBrokers() ([]apiv1beta1.ClusterServiceBroker, error)
 	RetrieveBroker(string) (*apiv1beta1.ClusterServiceBroker, error)
 	RetrieveBrokerByClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveScopedBrokers(ScopeOptions) ([]Broker, error)
+	RetrieveScopedBroker(string, ScopeOptions) (Broker, error)
+	RetrieveScopedBrokerByClass(Class) (Broker, error)
 	Register(string, string) (*apiv1beta1.ClusterServiceBroker, error)
+	RegisterScopedBroker(string, string, *RegisterOptions, ScopeOptions) (Broker, error)
 	Sync(string, int) error
+	SyncScopedBroker(string, ScopeOptions, int) error
 
 	RetrieveClasses() ([]apiv1beta1.ClusterServiceClass, error)
 	RetrieveClassByName(string) (*apiv1beta1.ClusterServiceClass, error)
 	RetrieveClassByID(string) (*apiv1beta1.ClusterServiceClass, error)
 	RetrieveClassByPlan(*apiv1beta1.ClusterServicePlan) (*apiv1beta1.ClusterServiceClass, error)
+	RetrieveScopedClasses(ScopeOptions) ([]Class, error)
+	RetrieveScopedClassByName(string, ScopeOptions) (Class, error)
+	RetrieveScopedClassByPlan(Plan) (Class, error)
 
 	Deprovision(string, string) error
 	InstanceParentHierarchy(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, *apiv1beta1.ClusterServiceBroker, error)
 	InstanceToServiceClassAndPlan(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, error)
+	InstanceToScopedClassAndPlan(*apiv1beta1.ServiceInstance) (Class, Plan, error)
+	ScopedInstanceParentHierarchy(*apiv1beta1.ServiceInstance) (Class, Plan, Broker, error)
 	IsInstanceFailed(*apiv1beta1.ServiceInstance) bool
 	IsInstanceReady(*apiv1beta1.ServiceInstance) bool
 	Provision(string, string, string, string, string, interface{}, map[string]string) (*apiv1beta1.ServiceInstance, error)
@@ -74,6 +86,8 @@ type SvcatClient interface {
 	RetrievePlanByID(string) (*apiv1beta1.ClusterServicePlan, error)
 	RetrievePlansByClass(*apiv1beta1.ClusterServiceClass) ([]apiv1beta1.ClusterServicePlan, error)
 	RetrievePlanByClassAndPlanNames(string, string) (*apiv1beta1.ClusterServicePlan, error)
+	RetrieveScopedPlans(*FilterOptions, ScopeOptions) ([]Plan, error)
+	RetrieveScopedPlansByClass(Class) ([]Plan, error)
 
 	RetrieveSecretByBinding(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 
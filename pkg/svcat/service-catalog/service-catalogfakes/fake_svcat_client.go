@@ -154,6 +154,25 @@ type FakeSvcatClient struct {
 		result1 []apiv1beta1.ServiceBinding
 		result2 error
 	}
+	ScopedBindingParentHierarchyStub        func(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error)
+	scopedBindingParentHierarchyMutex       sync.RWMutex
+	scopedBindingParentHierarchyArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceBinding
+	}
+	scopedBindingParentHierarchyReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
+		result5 error
+	}
+	scopedBindingParentHierarchyReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
+		result5 error
+	}
 	UnbindStub        func(string, string) ([]types.NamespacedName, error)
 	unbindMutex       sync.RWMutex
 	unbindArgsForCall []struct {
@@ -195,6 +214,18 @@ type FakeSvcatClient struct {
 	deregisterReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeregisterScopedBrokerStub        func(string, servicecatalog.ScopeOptions) error
+	deregisterScopedBrokerMutex       sync.RWMutex
+	deregisterScopedBrokerArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	deregisterScopedBrokerReturns struct {
+		result1 error
+	}
+	deregisterScopedBrokerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RetrieveBrokersStub        func() ([]apiv1beta1.ClusterServiceBroker, error)
 	retrieveBrokersMutex       sync.RWMutex
 	retrieveBrokersArgsForCall []struct{}
@@ -232,6 +263,46 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceBroker
 		result2 error
 	}
+	RetrieveScopedBrokersStub        func(servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error)
+	retrieveScopedBrokersMutex       sync.RWMutex
+	retrieveScopedBrokersArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveScopedBrokersReturns struct {
+		result1 []servicecatalog.Broker
+		result2 error
+	}
+	retrieveScopedBrokersReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Broker
+		result2 error
+	}
+	RetrieveScopedBrokerStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
+	retrieveScopedBrokerMutex       sync.RWMutex
+	retrieveScopedBrokerArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveScopedBrokerReturns struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	retrieveScopedBrokerReturnsOnCall map[int]struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	RetrieveScopedBrokerByClassStub        func(servicecatalog.Class) (servicecatalog.Broker, error)
+	retrieveScopedBrokerByClassMutex       sync.RWMutex
+	retrieveScopedBrokerByClassArgsForCall []struct {
+		arg1 servicecatalog.Class
+	}
+	retrieveScopedBrokerByClassReturns struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	retrieveScopedBrokerByClassReturnsOnCall map[int]struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
 	RegisterStub        func(string, string) (*apiv1beta1.ClusterServiceBroker, error)
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
@@ -246,6 +317,22 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceBroker
 		result2 error
 	}
+	RegisterScopedBrokerStub        func(string, string, *servicecatalog.RegisterOptions, servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
+	registerScopedBrokerMutex       sync.RWMutex
+	registerScopedBrokerArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.RegisterOptions
+		arg4 servicecatalog.ScopeOptions
+	}
+	registerScopedBrokerReturns struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	registerScopedBrokerReturnsOnCall map[int]struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
 	SyncStub        func(string, int) error
 	syncMutex       sync.RWMutex
 	syncArgsForCall []struct {
@@ -258,6 +345,19 @@ type FakeSvcatClient struct {
 	syncReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SyncScopedBrokerStub        func(string, servicecatalog.ScopeOptions, int) error
+	syncScopedBrokerMutex       sync.RWMutex
+	syncScopedBrokerArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+		arg3 int
+	}
+	syncScopedBrokerReturns struct {
+		result1 error
+	}
+	syncScopedBrokerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RetrieveClassesStub        func() ([]apiv1beta1.ClusterServiceClass, error)
 	retrieveClassesMutex       sync.RWMutex
 	retrieveClassesArgsForCall []struct{}
@@ -308,6 +408,46 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceClass
 		result2 error
 	}
+	RetrieveScopedClassesStub        func(servicecatalog.ScopeOptions) ([]servicecatalog.Class, error)
+	retrieveScopedClassesMutex       sync.RWMutex
+	retrieveScopedClassesArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveScopedClassesReturns struct {
+		result1 []servicecatalog.Class
+		result2 error
+	}
+	retrieveScopedClassesReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Class
+		result2 error
+	}
+	RetrieveScopedClassByNameStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Class, error)
+	retrieveScopedClassByNameMutex       sync.RWMutex
+	retrieveScopedClassByNameArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveScopedClassByNameReturns struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
+	retrieveScopedClassByNameReturnsOnCall map[int]struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
+	RetrieveScopedClassByPlanStub        func(servicecatalog.Plan) (servicecatalog.Class, error)
+	retrieveScopedClassByPlanMutex       sync.RWMutex
+	retrieveScopedClassByPlanArgsForCall []struct {
+		arg1 servicecatalog.Plan
+	}
+	retrieveScopedClassByPlanReturns struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
+	retrieveScopedClassByPlanReturnsOnCall map[int]struct {
+		result1 servicecatalog.Class
+		result2 error
+	}
 	DeprovisionStub        func(string, string) error
 	deprovisionMutex       sync.RWMutex
 	deprovisionArgsForCall []struct {
@@ -352,6 +492,38 @@ type FakeSvcatClient struct {
 		result2 *apiv1beta1.ClusterServicePlan
 		result3 error
 	}
+	InstanceToScopedClassAndPlanStub        func(*apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, error)
+	instanceToScopedClassAndPlanMutex       sync.RWMutex
+	instanceToScopedClassAndPlanArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	instanceToScopedClassAndPlanReturns struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 error
+	}
+	instanceToScopedClassAndPlanReturnsOnCall map[int]struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 error
+	}
+	ScopedInstanceParentHierarchyStub        func(*apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error)
+	scopedInstanceParentHierarchyMutex       sync.RWMutex
+	scopedInstanceParentHierarchyArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	scopedInstanceParentHierarchyReturns struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
+		result4 error
+	}
+	scopedInstanceParentHierarchyReturnsOnCall map[int]struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
+		result4 error
+	}
 	IsInstanceFailedStub        func(*apiv1beta1.ServiceInstance) bool
 	isInstanceFailedMutex       sync.RWMutex
 	isInstanceFailedArgsForCall []struct {
@@ -543,6 +715,33 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServicePlan
 		result2 error
 	}
+	RetrieveScopedPlansStub        func(*servicecatalog.FilterOptions, servicecatalog.ScopeOptions) ([]servicecatalog.Plan, error)
+	retrieveScopedPlansMutex       sync.RWMutex
+	retrieveScopedPlansArgsForCall []struct {
+		arg1 *servicecatalog.FilterOptions
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveScopedPlansReturns struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}
+	retrieveScopedPlansReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}
+	RetrieveScopedPlansByClassStub        func(servicecatalog.Class) ([]servicecatalog.Plan, error)
+	retrieveScopedPlansByClassMutex       sync.RWMutex
+	retrieveScopedPlansByClassArgsForCall []struct {
+		arg1 servicecatalog.Class
+	}
+	retrieveScopedPlansByClassReturns struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}
+	retrieveScopedPlansByClassReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}
 	RetrieveSecretByBindingStub        func(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 	retrieveSecretByBindingMutex       sync.RWMutex
 	retrieveSecretByBindingArgsForCall []struct {
@@ -1043,6 +1242,66 @@ func (fake *FakeSvcatClient) RetrieveBindingsByInstanceReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) ScopedBindingParentHierarchy(arg1 *apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error) {
+	fake.scopedBindingParentHierarchyMutex.Lock()
+	ret, specificReturn := fake.scopedBindingParentHierarchyReturnsOnCall[len(fake.scopedBindingParentHierarchyArgsForCall)]
+	fake.scopedBindingParentHierarchyArgsForCall = append(fake.scopedBindingParentHierarchyArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceBinding
+	}{arg1})
+	fake.recordInvocation("ScopedBindingParentHierarchy", []interface{}{arg1})
+	fake.scopedBindingParentHierarchyMutex.Unlock()
+	if fake.ScopedBindingParentHierarchyStub != nil {
+		return fake.ScopedBindingParentHierarchyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
+	}
+	return fake.scopedBindingParentHierarchyReturns.result1, fake.scopedBindingParentHierarchyReturns.result2, fake.scopedBindingParentHierarchyReturns.result3, fake.scopedBindingParentHierarchyReturns.result4, fake.scopedBindingParentHierarchyReturns.result5
+}
+
+func (fake *FakeSvcatClient) ScopedBindingParentHierarchyCallCount() int {
+	fake.scopedBindingParentHierarchyMutex.RLock()
+	defer fake.scopedBindingParentHierarchyMutex.RUnlock()
+	return len(fake.scopedBindingParentHierarchyArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ScopedBindingParentHierarchyArgsForCall(i int) *apiv1beta1.ServiceBinding {
+	fake.scopedBindingParentHierarchyMutex.RLock()
+	defer fake.scopedBindingParentHierarchyMutex.RUnlock()
+	return fake.scopedBindingParentHierarchyArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) ScopedBindingParentHierarchyReturns(result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.Class, result3 servicecatalog.Plan, result4 servicecatalog.Broker, result5 error) {
+	fake.ScopedBindingParentHierarchyStub = nil
+	fake.scopedBindingParentHierarchyReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
+func (fake *FakeSvcatClient) ScopedBindingParentHierarchyReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 servicecatalog.Class, result3 servicecatalog.Plan, result4 servicecatalog.Broker, result5 error) {
+	fake.ScopedBindingParentHierarchyStub = nil
+	if fake.scopedBindingParentHierarchyReturnsOnCall == nil {
+		fake.scopedBindingParentHierarchyReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 servicecatalog.Class
+			result3 servicecatalog.Plan
+			result4 servicecatalog.Broker
+			result5 error
+		})
+	}
+	fake.scopedBindingParentHierarchyReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 servicecatalog.Class
+		result3 servicecatalog.Plan
+		result4 servicecatalog.Broker
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
 func (fake *FakeSvcatClient) Unbind(arg1 string, arg2 string) ([]types.NamespacedName, error) {
 	fake.unbindMutex.Lock()
 	ret, specificReturn := fake.unbindReturnsOnCall[len(fake.unbindArgsForCall)]
@@ -1197,6 +1456,55 @@ func (fake *FakeSvcatClient) DeregisterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) DeregisterScopedBroker(arg1 string, arg2 servicecatalog.ScopeOptions) error {
+	fake.deregisterScopedBrokerMutex.Lock()
+	ret, specificReturn := fake.deregisterScopedBrokerReturnsOnCall[len(fake.deregisterScopedBrokerArgsForCall)]
+	fake.deregisterScopedBrokerArgsForCall = append(fake.deregisterScopedBrokerArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("DeregisterScopedBroker", []interface{}{arg1, arg2})
+	fake.deregisterScopedBrokerMutex.Unlock()
+	if fake.DeregisterScopedBrokerStub != nil {
+		return fake.DeregisterScopedBrokerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deregisterScopedBrokerReturns.result1
+}
+
+func (fake *FakeSvcatClient) DeregisterScopedBrokerCallCount() int {
+	fake.deregisterScopedBrokerMutex.RLock()
+	defer fake.deregisterScopedBrokerMutex.RUnlock()
+	return len(fake.deregisterScopedBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DeregisterScopedBrokerArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.deregisterScopedBrokerMutex.RLock()
+	defer fake.deregisterScopedBrokerMutex.RUnlock()
+	return fake.deregisterScopedBrokerArgsForCall[i].arg1, fake.deregisterScopedBrokerArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) DeregisterScopedBrokerReturns(result1 error) {
+	fake.DeregisterScopedBrokerStub = nil
+	fake.deregisterScopedBrokerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) DeregisterScopedBrokerReturnsOnCall(i int, result1 error) {
+	fake.DeregisterScopedBrokerStub = nil
+	if fake.deregisterScopedBrokerReturnsOnCall == nil {
+		fake.deregisterScopedBrokerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deregisterScopedBrokerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSvcatClient) RetrieveBrokers() ([]apiv1beta1.ClusterServiceBroker, error) {
 	fake.retrieveBrokersMutex.Lock()
 	ret, specificReturn := fake.retrieveBrokersReturnsOnCall[len(fake.retrieveBrokersArgsForCall)]
@@ -1342,6 +1650,160 @@ func (fake *FakeSvcatClient) RetrieveBrokerByClassReturnsOnCall(i int, result1 *
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveScopedBrokers(arg1 servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error) {
+	fake.retrieveScopedBrokersMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedBrokersReturnsOnCall[len(fake.retrieveScopedBrokersArgsForCall)]
+	fake.retrieveScopedBrokersArgsForCall = append(fake.retrieveScopedBrokersArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveScopedBrokers", []interface{}{arg1})
+	fake.retrieveScopedBrokersMutex.Unlock()
+	if fake.RetrieveScopedBrokersStub != nil {
+		return fake.RetrieveScopedBrokersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedBrokersReturns.result1, fake.retrieveScopedBrokersReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokersCallCount() int {
+	fake.retrieveScopedBrokersMutex.RLock()
+	defer fake.retrieveScopedBrokersMutex.RUnlock()
+	return len(fake.retrieveScopedBrokersArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokersArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveScopedBrokersMutex.RLock()
+	defer fake.retrieveScopedBrokersMutex.RUnlock()
+	return fake.retrieveScopedBrokersArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokersReturns(result1 []servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokersStub = nil
+	fake.retrieveScopedBrokersReturns = struct {
+		result1 []servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokersReturnsOnCall(i int, result1 []servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokersStub = nil
+	if fake.retrieveScopedBrokersReturnsOnCall == nil {
+		fake.retrieveScopedBrokersReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.retrieveScopedBrokersReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBroker(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
+	fake.retrieveScopedBrokerMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedBrokerReturnsOnCall[len(fake.retrieveScopedBrokerArgsForCall)]
+	fake.retrieveScopedBrokerArgsForCall = append(fake.retrieveScopedBrokerArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveScopedBroker", []interface{}{arg1, arg2})
+	fake.retrieveScopedBrokerMutex.Unlock()
+	if fake.RetrieveScopedBrokerStub != nil {
+		return fake.RetrieveScopedBrokerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedBrokerReturns.result1, fake.retrieveScopedBrokerReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerCallCount() int {
+	fake.retrieveScopedBrokerMutex.RLock()
+	defer fake.retrieveScopedBrokerMutex.RUnlock()
+	return len(fake.retrieveScopedBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrieveScopedBrokerMutex.RLock()
+	defer fake.retrieveScopedBrokerMutex.RUnlock()
+	return fake.retrieveScopedBrokerArgsForCall[i].arg1, fake.retrieveScopedBrokerArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokerStub = nil
+	fake.retrieveScopedBrokerReturns = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokerStub = nil
+	if fake.retrieveScopedBrokerReturnsOnCall == nil {
+		fake.retrieveScopedBrokerReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.retrieveScopedBrokerReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerByClass(arg1 servicecatalog.Class) (servicecatalog.Broker, error) {
+	fake.retrieveScopedBrokerByClassMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedBrokerByClassReturnsOnCall[len(fake.retrieveScopedBrokerByClassArgsForCall)]
+	fake.retrieveScopedBrokerByClassArgsForCall = append(fake.retrieveScopedBrokerByClassArgsForCall, struct {
+		arg1 servicecatalog.Class
+	}{arg1})
+	fake.recordInvocation("RetrieveScopedBrokerByClass", []interface{}{arg1})
+	fake.retrieveScopedBrokerByClassMutex.Unlock()
+	if fake.RetrieveScopedBrokerByClassStub != nil {
+		return fake.RetrieveScopedBrokerByClassStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedBrokerByClassReturns.result1, fake.retrieveScopedBrokerByClassReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerByClassCallCount() int {
+	fake.retrieveScopedBrokerByClassMutex.RLock()
+	defer fake.retrieveScopedBrokerByClassMutex.RUnlock()
+	return len(fake.retrieveScopedBrokerByClassArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerByClassArgsForCall(i int) servicecatalog.Class {
+	fake.retrieveScopedBrokerByClassMutex.RLock()
+	defer fake.retrieveScopedBrokerByClassMutex.RUnlock()
+	return fake.retrieveScopedBrokerByClassArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerByClassReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokerByClassStub = nil
+	fake.retrieveScopedBrokerByClassReturns = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedBrokerByClassReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveScopedBrokerByClassStub = nil
+	if fake.retrieveScopedBrokerByClassReturnsOnCall == nil {
+		fake.retrieveScopedBrokerByClassReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.retrieveScopedBrokerByClassReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Register(arg1 string, arg2 string) (*apiv1beta1.ClusterServiceBroker, error) {
 	fake.registerMutex.Lock()
 	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
@@ -1394,6 +1856,60 @@ func (fake *FakeSvcatClient) RegisterReturnsOnCall(i int, result1 *apiv1beta1.Cl
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RegisterScopedBroker(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions, arg4 servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
+	fake.registerScopedBrokerMutex.Lock()
+	ret, specificReturn := fake.registerScopedBrokerReturnsOnCall[len(fake.registerScopedBrokerArgsForCall)]
+	fake.registerScopedBrokerArgsForCall = append(fake.registerScopedBrokerArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.RegisterOptions
+		arg4 servicecatalog.ScopeOptions
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RegisterScopedBroker", []interface{}{arg1, arg2, arg3, arg4})
+	fake.registerScopedBrokerMutex.Unlock()
+	if fake.RegisterScopedBrokerStub != nil {
+		return fake.RegisterScopedBrokerStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.registerScopedBrokerReturns.result1, fake.registerScopedBrokerReturns.result2
+}
+
+func (fake *FakeSvcatClient) RegisterScopedBrokerCallCount() int {
+	fake.registerScopedBrokerMutex.RLock()
+	defer fake.registerScopedBrokerMutex.RUnlock()
+	return len(fake.registerScopedBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RegisterScopedBrokerArgsForCall(i int) (string, string, *servicecatalog.RegisterOptions, servicecatalog.ScopeOptions) {
+	fake.registerScopedBrokerMutex.RLock()
+	defer fake.registerScopedBrokerMutex.RUnlock()
+	return fake.registerScopedBrokerArgsForCall[i].arg1, fake.registerScopedBrokerArgsForCall[i].arg2, fake.registerScopedBrokerArgsForCall[i].arg3, fake.registerScopedBrokerArgsForCall[i].arg4
+}
+
+func (fake *FakeSvcatClient) RegisterScopedBrokerReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RegisterScopedBrokerStub = nil
+	fake.registerScopedBrokerReturns = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RegisterScopedBrokerReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RegisterScopedBrokerStub = nil
+	if fake.registerScopedBrokerReturnsOnCall == nil {
+		fake.registerScopedBrokerReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.registerScopedBrokerReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Sync(arg1 string, arg2 int) error {
 	fake.syncMutex.Lock()
 	ret, specificReturn := fake.syncReturnsOnCall[len(fake.syncArgsForCall)]
@@ -1443,6 +1959,56 @@ func (fake *FakeSvcatClient) SyncReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) SyncScopedBroker(arg1 string, arg2 servicecatalog.ScopeOptions, arg3 int) error {
+	fake.syncScopedBrokerMutex.Lock()
+	ret, specificReturn := fake.syncScopedBrokerReturnsOnCall[len(fake.syncScopedBrokerArgsForCall)]
+	fake.syncScopedBrokerArgsForCall = append(fake.syncScopedBrokerArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SyncScopedBroker", []interface{}{arg1, arg2, arg3})
+	fake.syncScopedBrokerMutex.Unlock()
+	if fake.SyncScopedBrokerStub != nil {
+		return fake.SyncScopedBrokerStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.syncScopedBrokerReturns.result1
+}
+
+func (fake *FakeSvcatClient) SyncScopedBrokerCallCount() int {
+	fake.syncScopedBrokerMutex.RLock()
+	defer fake.syncScopedBrokerMutex.RUnlock()
+	return len(fake.syncScopedBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) SyncScopedBrokerArgsForCall(i int) (string, servicecatalog.ScopeOptions, int) {
+	fake.syncScopedBrokerMutex.RLock()
+	defer fake.syncScopedBrokerMutex.RUnlock()
+	return fake.syncScopedBrokerArgsForCall[i].arg1, fake.syncScopedBrokerArgsForCall[i].arg2, fake.syncScopedBrokerArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) SyncScopedBrokerReturns(result1 error) {
+	fake.SyncScopedBrokerStub = nil
+	fake.syncScopedBrokerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) SyncScopedBrokerReturnsOnCall(i int, result1 error) {
+	fake.SyncScopedBrokerStub = nil
+	if fake.syncScopedBrokerReturnsOnCall == nil {
+		fake.syncScopedBrokerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.syncScopedBrokerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSvcatClient) RetrieveClasses() ([]apiv1beta1.ClusterServiceClass, error) {
 	fake.retrieveClassesMutex.Lock()
 	ret, specificReturn := fake.retrieveClassesReturnsOnCall[len(fake.retrieveClassesArgsForCall)]
@@ -1639,6 +2205,160 @@ func (fake *FakeSvcatClient) RetrieveClassByPlanReturnsOnCall(i int, result1 *ap
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveScopedClasses(arg1 servicecatalog.ScopeOptions) ([]servicecatalog.Class, error) {
+	fake.retrieveScopedClassesMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedClassesReturnsOnCall[len(fake.retrieveScopedClassesArgsForCall)]
+	fake.retrieveScopedClassesArgsForCall = append(fake.retrieveScopedClassesArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveScopedClasses", []interface{}{arg1})
+	fake.retrieveScopedClassesMutex.Unlock()
+	if fake.RetrieveScopedClassesStub != nil {
+		return fake.RetrieveScopedClassesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedClassesReturns.result1, fake.retrieveScopedClassesReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassesCallCount() int {
+	fake.retrieveScopedClassesMutex.RLock()
+	defer fake.retrieveScopedClassesMutex.RUnlock()
+	return len(fake.retrieveScopedClassesArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassesArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveScopedClassesMutex.RLock()
+	defer fake.retrieveScopedClassesMutex.RUnlock()
+	return fake.retrieveScopedClassesArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassesReturns(result1 []servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassesStub = nil
+	fake.retrieveScopedClassesReturns = struct {
+		result1 []servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassesReturnsOnCall(i int, result1 []servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassesStub = nil
+	if fake.retrieveScopedClassesReturnsOnCall == nil {
+		fake.retrieveScopedClassesReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Class
+			result2 error
+		})
+	}
+	fake.retrieveScopedClassesReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByName(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Class, error) {
+	fake.retrieveScopedClassByNameMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedClassByNameReturnsOnCall[len(fake.retrieveScopedClassByNameArgsForCall)]
+	fake.retrieveScopedClassByNameArgsForCall = append(fake.retrieveScopedClassByNameArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveScopedClassByName", []interface{}{arg1, arg2})
+	fake.retrieveScopedClassByNameMutex.Unlock()
+	if fake.RetrieveScopedClassByNameStub != nil {
+		return fake.RetrieveScopedClassByNameStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedClassByNameReturns.result1, fake.retrieveScopedClassByNameReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByNameCallCount() int {
+	fake.retrieveScopedClassByNameMutex.RLock()
+	defer fake.retrieveScopedClassByNameMutex.RUnlock()
+	return len(fake.retrieveScopedClassByNameArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByNameArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrieveScopedClassByNameMutex.RLock()
+	defer fake.retrieveScopedClassByNameMutex.RUnlock()
+	return fake.retrieveScopedClassByNameArgsForCall[i].arg1, fake.retrieveScopedClassByNameArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByNameReturns(result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassByNameStub = nil
+	fake.retrieveScopedClassByNameReturns = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByNameReturnsOnCall(i int, result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassByNameStub = nil
+	if fake.retrieveScopedClassByNameReturnsOnCall == nil {
+		fake.retrieveScopedClassByNameReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Class
+			result2 error
+		})
+	}
+	fake.retrieveScopedClassByNameReturnsOnCall[i] = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByPlan(arg1 servicecatalog.Plan) (servicecatalog.Class, error) {
+	fake.retrieveScopedClassByPlanMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedClassByPlanReturnsOnCall[len(fake.retrieveScopedClassByPlanArgsForCall)]
+	fake.retrieveScopedClassByPlanArgsForCall = append(fake.retrieveScopedClassByPlanArgsForCall, struct {
+		arg1 servicecatalog.Plan
+	}{arg1})
+	fake.recordInvocation("RetrieveScopedClassByPlan", []interface{}{arg1})
+	fake.retrieveScopedClassByPlanMutex.Unlock()
+	if fake.RetrieveScopedClassByPlanStub != nil {
+		return fake.RetrieveScopedClassByPlanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedClassByPlanReturns.result1, fake.retrieveScopedClassByPlanReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByPlanCallCount() int {
+	fake.retrieveScopedClassByPlanMutex.RLock()
+	defer fake.retrieveScopedClassByPlanMutex.RUnlock()
+	return len(fake.retrieveScopedClassByPlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByPlanArgsForCall(i int) servicecatalog.Plan {
+	fake.retrieveScopedClassByPlanMutex.RLock()
+	defer fake.retrieveScopedClassByPlanMutex.RUnlock()
+	return fake.retrieveScopedClassByPlanArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByPlanReturns(result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassByPlanStub = nil
+	fake.retrieveScopedClassByPlanReturns = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedClassByPlanReturnsOnCall(i int, result1 servicecatalog.Class, result2 error) {
+	fake.RetrieveScopedClassByPlanStub = nil
+	if fake.retrieveScopedClassByPlanReturnsOnCall == nil {
+		fake.retrieveScopedClassByPlanReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Class
+			result2 error
+		})
+	}
+	fake.retrieveScopedClassByPlanReturnsOnCall[i] = struct {
+		result1 servicecatalog.Class
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Deprovision(arg1 string, arg2 string) error {
 	fake.deprovisionMutex.Lock()
 	ret, specificReturn := fake.deprovisionReturnsOnCall[len(fake.deprovisionArgsForCall)]
@@ -1799,6 +2519,117 @@ func (fake *FakeSvcatClient) InstanceToServiceClassAndPlanReturnsOnCall(i int, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeSvcatClient) InstanceToScopedClassAndPlan(arg1 *apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, error) {
+	fake.instanceToScopedClassAndPlanMutex.Lock()
+	ret, specificReturn := fake.instanceToScopedClassAndPlanReturnsOnCall[len(fake.instanceToScopedClassAndPlanArgsForCall)]
+	fake.instanceToScopedClassAndPlanArgsForCall = append(fake.instanceToScopedClassAndPlanArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("InstanceToScopedClassAndPlan", []interface{}{arg1})
+	fake.instanceToScopedClassAndPlanMutex.Unlock()
+	if fake.InstanceToScopedClassAndPlanStub != nil {
+		return fake.InstanceToScopedClassAndPlanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.instanceToScopedClassAndPlanReturns.result1, fake.instanceToScopedClassAndPlanReturns.result2, fake.instanceToScopedClassAndPlanReturns.result3
+}
+
+func (fake *FakeSvcatClient) InstanceToScopedClassAndPlanCallCount() int {
+	fake.instanceToScopedClassAndPlanMutex.RLock()
+	defer fake.instanceToScopedClassAndPlanMutex.RUnlock()
+	return len(fake.instanceToScopedClassAndPlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) InstanceToScopedClassAndPlanArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.instanceToScopedClassAndPlanMutex.RLock()
+	defer fake.instanceToScopedClassAndPlanMutex.RUnlock()
+	return fake.instanceToScopedClassAndPlanArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) InstanceToScopedClassAndPlanReturns(result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 error) {
+	fake.InstanceToScopedClassAndPlanStub = nil
+	fake.instanceToScopedClassAndPlanReturns = struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) InstanceToScopedClassAndPlanReturnsOnCall(i int, result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 error) {
+	fake.InstanceToScopedClassAndPlanStub = nil
+	if fake.instanceToScopedClassAndPlanReturnsOnCall == nil {
+		fake.instanceToScopedClassAndPlanReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Class
+			result2 servicecatalog.Plan
+			result3 error
+		})
+	}
+	fake.instanceToScopedClassAndPlanReturnsOnCall[i] = struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) ScopedInstanceParentHierarchy(arg1 *apiv1beta1.ServiceInstance) (servicecatalog.Class, servicecatalog.Plan, servicecatalog.Broker, error) {
+	fake.scopedInstanceParentHierarchyMutex.Lock()
+	ret, specificReturn := fake.scopedInstanceParentHierarchyReturnsOnCall[len(fake.scopedInstanceParentHierarchyArgsForCall)]
+	fake.scopedInstanceParentHierarchyArgsForCall = append(fake.scopedInstanceParentHierarchyArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("ScopedInstanceParentHierarchy", []interface{}{arg1})
+	fake.scopedInstanceParentHierarchyMutex.Unlock()
+	if fake.ScopedInstanceParentHierarchyStub != nil {
+		return fake.ScopedInstanceParentHierarchyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fake.scopedInstanceParentHierarchyReturns.result1, fake.scopedInstanceParentHierarchyReturns.result2, fake.scopedInstanceParentHierarchyReturns.result3, fake.scopedInstanceParentHierarchyReturns.result4
+}
+
+func (fake *FakeSvcatClient) ScopedInstanceParentHierarchyCallCount() int {
+	fake.scopedInstanceParentHierarchyMutex.RLock()
+	defer fake.scopedInstanceParentHierarchyMutex.RUnlock()
+	return len(fake.scopedInstanceParentHierarchyArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ScopedInstanceParentHierarchyArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.scopedInstanceParentHierarchyMutex.RLock()
+	defer fake.scopedInstanceParentHierarchyMutex.RUnlock()
+	return fake.scopedInstanceParentHierarchyArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) ScopedInstanceParentHierarchyReturns(result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 servicecatalog.Broker, result4 error) {
+	fake.ScopedInstanceParentHierarchyStub = nil
+	fake.scopedInstanceParentHierarchyReturns = struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeSvcatClient) ScopedInstanceParentHierarchyReturnsOnCall(i int, result1 servicecatalog.Class, result2 servicecatalog.Plan, result3 servicecatalog.Broker, result4 error) {
+	fake.ScopedInstanceParentHierarchyStub = nil
+	if fake.scopedInstanceParentHierarchyReturnsOnCall == nil {
+		fake.scopedInstanceParentHierarchyReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Class
+			result2 servicecatalog.Plan
+			result3 servicecatalog.Broker
+			result4 error
+		})
+	}
+	fake.scopedInstanceParentHierarchyReturnsOnCall[i] = struct {
+		result1 servicecatalog.Class
+		result2 servicecatalog.Plan
+		result3 servicecatalog.Broker
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeSvcatClient) IsInstanceFailed(arg1 *apiv1beta1.ServiceInstance) bool {
 	fake.isInstanceFailedMutex.Lock()
 	ret, specificReturn := fake.isInstanceFailedReturnsOnCall[len(fake.isInstanceFailedArgsForCall)]
@@ -2519,6 +3350,109 @@ func (fake *FakeSvcatClient) RetrievePlanByClassAndPlanNamesReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveScopedPlans(arg1 *servicecatalog.FilterOptions, arg2 servicecatalog.ScopeOptions) ([]servicecatalog.Plan, error) {
+	fake.retrieveScopedPlansMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedPlansReturnsOnCall[len(fake.retrieveScopedPlansArgsForCall)]
+	fake.retrieveScopedPlansArgsForCall = append(fake.retrieveScopedPlansArgsForCall, struct {
+		arg1 *servicecatalog.FilterOptions
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveScopedPlans", []interface{}{arg1, arg2})
+	fake.retrieveScopedPlansMutex.Unlock()
+	if fake.RetrieveScopedPlansStub != nil {
+		return fake.RetrieveScopedPlansStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedPlansReturns.result1, fake.retrieveScopedPlansReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansCallCount() int {
+	fake.retrieveScopedPlansMutex.RLock()
+	defer fake.retrieveScopedPlansMutex.RUnlock()
+	return len(fake.retrieveScopedPlansArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansArgsForCall(i int) (*servicecatalog.FilterOptions, servicecatalog.ScopeOptions) {
+	fake.retrieveScopedPlansMutex.RLock()
+	defer fake.retrieveScopedPlansMutex.RUnlock()
+	return fake.retrieveScopedPlansArgsForCall[i].arg1, fake.retrieveScopedPlansArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansReturns(result1 []servicecatalog.Plan, result2 error) {
+	fake.RetrieveScopedPlansStub = nil
+	fake.retrieveScopedPlansReturns = struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansReturnsOnCall(i int, result1 []servicecatalog.Plan, result2 error) {
+	fake.RetrieveScopedPlansStub = nil
+	if fake.retrieveScopedPlansReturnsOnCall == nil {
+		fake.retrieveScopedPlansReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Plan
+			result2 error
+		})
+	}
+	fake.retrieveScopedPlansReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansByClass(arg1 servicecatalog.Class) ([]servicecatalog.Plan, error) {
+	fake.retrieveScopedPlansByClassMutex.Lock()
+	ret, specificReturn := fake.retrieveScopedPlansByClassReturnsOnCall[len(fake.retrieveScopedPlansByClassArgsForCall)]
+	fake.retrieveScopedPlansByClassArgsForCall = append(fake.retrieveScopedPlansByClassArgsForCall, struct {
+		arg1 servicecatalog.Class
+	}{arg1})
+	fake.recordInvocation("RetrieveScopedPlansByClass", []interface{}{arg1})
+	fake.retrieveScopedPlansByClassMutex.Unlock()
+	if fake.RetrieveScopedPlansByClassStub != nil {
+		return fake.RetrieveScopedPlansByClassStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveScopedPlansByClassReturns.result1, fake.retrieveScopedPlansByClassReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansByClassCallCount() int {
+	fake.retrieveScopedPlansByClassMutex.RLock()
+	defer fake.retrieveScopedPlansByClassMutex.RUnlock()
+	return len(fake.retrieveScopedPlansByClassArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansByClassArgsForCall(i int) servicecatalog.Class {
+	fake.retrieveScopedPlansByClassMutex.RLock()
+	defer fake.retrieveScopedPlansByClassMutex.RUnlock()
+	return fake.retrieveScopedPlansByClassArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansByClassReturns(result1 []servicecatalog.Plan, result2 error) {
+	fake.RetrieveScopedPlansByClassStub = nil
+	fake.retrieveScopedPlansByClassReturns = struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveScopedPlansByClassReturnsOnCall(i int, result1 []servicecatalog.Plan, result2 error) {
+	fake.RetrieveScopedPlansByClassStub = nil
+	if fake.retrieveScopedPlansByClassReturnsOnCall == nil {
+		fake.retrieveScopedPlansByClassReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Plan
+			result2 error
+		})
+	}
+	fake.retrieveScopedPlansByClassReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveSecretByBinding(arg1 *apiv1beta1.ServiceBinding) (*apicorev1.Secret, error) {
 	fake.retrieveSecretByBindingMutex.Lock()
 	ret, specificReturn := fake.retrieveSecretByBindingReturnsOnCall[len(fake.retrieveSecretByBindingArgsForCall)]
@@ -2634,22 +3568,36 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBindingsMutex.RUnlock()
 	fake.retrieveBindingsByInstanceMutex.RLock()
 	defer fake.retrieveBindingsByInstanceMutex.RUnlock()
+	fake.scopedBindingParentHierarchyMutex.RLock()
+	defer fake.scopedBindingParentHierarchyMutex.RUnlock()
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
 	fake.waitForBindingMutex.RLock()
 	defer fake.waitForBindingMutex.RUnlock()
 	fake.deregisterMutex.RLock()
 	defer fake.deregisterMutex.RUnlock()
+	fake.deregisterScopedBrokerMutex.RLock()
+	defer fake.deregisterScopedBrokerMutex.RUnlock()
 	fake.retrieveBrokersMutex.RLock()
 	defer fake.retrieveBrokersMutex.RUnlock()
 	fake.retrieveBrokerMutex.RLock()
 	defer fake.retrieveBrokerMutex.RUnlock()
 	fake.retrieveBrokerByClassMutex.RLock()
 	defer fake.retrieveBrokerByClassMutex.RUnlock()
+	fake.retrieveScopedBrokersMutex.RLock()
+	defer fake.retrieveScopedBrokersMutex.RUnlock()
+	fake.retrieveScopedBrokerMutex.RLock()
+	defer fake.retrieveScopedBrokerMutex.RUnlock()
+	fake.retrieveScopedBrokerByClassMutex.RLock()
+	defer fake.retrieveScopedBrokerByClassMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
+	fake.registerScopedBrokerMutex.RLock()
+	defer fake.registerScopedBrokerMutex.RUnlock()
 	fake.syncMutex.RLock()
 	defer fake.syncMutex.RUnlock()
+	fake.syncScopedBrokerMutex.RLock()
+	defer fake.syncScopedBrokerMutex.RUnlock()
 	fake.retrieveClassesMutex.RLock()
 	defer fake.retrieveClassesMutex.RUnlock()
 	fake.retrieveClassByNameMutex.RLock()
@@ -2658,12 +3606,22 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveClassByIDMutex.RUnlock()
 	fake.retrieveClassByPlanMutex.RLock()
 	defer fake.retrieveClassByPlanMutex.RUnlock()
+	fake.retrieveScopedClassesMutex.RLock()
+	defer fake.retrieveScopedClassesMutex.RUnlock()
+	fake.retrieveScopedClassByNameMutex.RLock()
+	defer fake.retrieveScopedClassByNameMutex.RUnlock()
+	fake.retrieveScopedClassByPlanMutex.RLock()
+	defer fake.retrieveScopedClassByPlanMutex.RUnlock()
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
 	fake.instanceParentHierarchyMutex.RLock()
 	defer fake.instanceParentHierarchyMutex.RUnlock()
 	fake.instanceToServiceClassAndPlanMutex.RLock()
 	defer fake.instanceToServiceClassAndPlanMutex.RUnlock()
+	fake.instanceToScopedClassAndPlanMutex.RLock()
+	defer fake.instanceToScopedClassAndPlanMutex.RUnlock()
+	fake.scopedInstanceParentHierarchyMutex.RLock()
+	defer fake.scopedInstanceParentHierarchyMutex.RUnlock()
 	fake.isInstanceFailedMutex.RLock()
 	defer fake.isInstanceFailedMutex.RUnlock()
 	fake.isInstanceReadyMutex.RLock()
@@ -2692,6 +3650,10 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrievePlansByClassMutex.RUnlock()
 	fake.retrievePlanByClassAndPlanNamesMutex.RLock()
 	defer fake.retrievePlanByClassAndPlanNamesMutex.RUnlock()
+	fake.retrieveScopedPlansMutex.RLock()
+	defer fake.retrieveScopedPlansMutex.RUnlock()
+	fake.retrieveScopedPlansByClassMutex.RLock()
+	defer fake.retrieveScopedPlansByClassMutex.RUnlock()
 	fake.retrieveSecretByBindingMutex.RLock()
 	defer fake.retrieveSecretByBindingMutex.RUnlock()
 	fake.serverVersionMutex.RLock()
@@ -0,0 +1,224 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+)
+
+// safeToApplyPodPresetsOnPod determines if there is any conflict in
+// information injected by the given PodPresets into the pod.
+func safeToApplyPodPresetsOnPod(pod *corev1.Pod, podPresets []*settingsv1alpha1.PodPreset) error {
+	var errs []error
+
+	if _, err := mergeVolumes(pod.Spec.Volumes, podPresets); err != nil {
+		errs = append(errs, err)
+	}
+	for _, ctr := range pod.Spec.InitContainers {
+		if err := safeToApplyPodPresetsOnContainer(&ctr, podPresets); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, ctr := range pod.Spec.Containers {
+		if err := safeToApplyPodPresetsOnContainer(&ctr, podPresets); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// safeToApplyPodPresetsOnContainer determines if there is any conflict in
+// information injected by the given PodPresets into the container.
+func safeToApplyPodPresetsOnContainer(ctr *corev1.Container, podPresets []*settingsv1alpha1.PodPreset) error {
+	var errs []error
+	// check if it is safe to merge env vars and volume mounts from the
+	// presets; env from sources are appended and never conflict.
+	if _, err := mergeEnv(ctr.Env, podPresets); err != nil {
+		errs = append(errs, fmt.Errorf("container %q: %v", ctr.Name, err))
+	}
+	if _, err := mergeVolumeMounts(ctr.VolumeMounts, podPresets); err != nil {
+		errs = append(errs, fmt.Errorf("container %q: %v", ctr.Name, err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// mergeEnv merges the env vars of the PodPresets into the given list. An env
+// var of a PodPreset conflicts with an existing env var of the same name
+// unless both are identical.
+func mergeEnv(envVars []corev1.EnvVar, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.EnvVar, error) {
+	origEnv := map[string]corev1.EnvVar{}
+	for _, v := range envVars {
+		origEnv[v.Name] = v
+	}
+
+	mergedEnv := make([]corev1.EnvVar, len(envVars))
+	copy(mergedEnv, envVars)
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.Env {
+			found, ok := origEnv[v.Name]
+			if !ok {
+				origEnv[v.Name] = v
+				mergedEnv = append(mergedEnv, v)
+				continue
+			}
+			if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging env for PodPreset %q has a conflict on env var %q", pp.Name, v.Name))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return mergedEnv, nil
+}
+
+// mergeEnvFrom appends the env from sources of the PodPresets to the given
+// list.
+func mergeEnvFrom(envSources []corev1.EnvFromSource, podPresets []*settingsv1alpha1.PodPreset) []corev1.EnvFromSource {
+	var mergedEnvFrom []corev1.EnvFromSource
+	mergedEnvFrom = append(mergedEnvFrom, envSources...)
+	for _, pp := range podPresets {
+		mergedEnvFrom = append(mergedEnvFrom, pp.Spec.EnvFrom...)
+	}
+	return mergedEnvFrom
+}
+
+// mergeVolumeMounts merges the volume mounts of the PodPresets into the given
+// list. A volume mount of a PodPreset conflicts with an existing volume mount
+// of the same name or mount path unless both are identical.
+func mergeVolumeMounts(volumeMounts []corev1.VolumeMount, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.VolumeMount, error) {
+	origVolumeMounts := map[string]corev1.VolumeMount{}
+	volumeMountsByPath := map[string]corev1.VolumeMount{}
+	for _, v := range volumeMounts {
+		origVolumeMounts[v.Name] = v
+		volumeMountsByPath[v.MountPath] = v
+	}
+
+	mergedVolumeMounts := make([]corev1.VolumeMount, len(volumeMounts))
+	copy(mergedVolumeMounts, volumeMounts)
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.VolumeMounts {
+			found, ok := origVolumeMounts[v.Name]
+			if !ok {
+				origVolumeMounts[v.Name] = v
+				mergedVolumeMounts = append(mergedVolumeMounts, v)
+			} else if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging volume mounts for PodPreset %q has a conflict on volume mount %q", pp.Name, v.Name))
+			}
+
+			found, ok = volumeMountsByPath[v.MountPath]
+			if !ok {
+				volumeMountsByPath[v.MountPath] = v
+			} else if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging volume mounts for PodPreset %q has a conflict on mount path %q", pp.Name, v.MountPath))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return mergedVolumeMounts, nil
+}
+
+// mergeVolumes merges the volumes of the PodPresets into the given list. A
+// volume of a PodPreset conflicts with an existing volume of the same name
+// unless both are identical.
+func mergeVolumes(volumes []corev1.Volume, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.Volume, error) {
+	origVolumes := map[string]corev1.Volume{}
+	for _, v := range volumes {
+		origVolumes[v.Name] = v
+	}
+
+	mergedVolumes := make([]corev1.Volume, len(volumes))
+	copy(mergedVolumes, volumes)
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.Volumes {
+			found, ok := origVolumes[v.Name]
+			if !ok {
+				origVolumes[v.Name] = v
+				mergedVolumes = append(mergedVolumes, v)
+				continue
+			}
+			if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging volumes for PodPreset %q has a conflict on volume %q", pp.Name, v.Name))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return mergedVolumes, nil
+}
+
+// applyPodPresetsOnPod updates the pod with the merged information from the
+// PodPresets and records the applied PodPresets in its annotations. The
+// PodPresets must have been checked with safeToApplyPodPresetsOnPod first.
+func applyPodPresetsOnPod(pod *corev1.Pod, podPresets []*settingsv1alpha1.PodPreset) {
+	if len(podPresets) == 0 {
+		return
+	}
+
+	volumes, _ := mergeVolumes(pod.Spec.Volumes, podPresets)
+	pod.Spec.Volumes = volumes
+
+	for i := range pod.Spec.InitContainers {
+		applyPodPresetsOnContainer(&pod.Spec.InitContainers[i], podPresets)
+	}
+	for i := range pod.Spec.Containers {
+		applyPodPresetsOnContainer(&pod.Spec.Containers[i], podPresets)
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	for _, pp := range podPresets {
+		pod.Annotations[podPresetAnnotationKey(pp.Name)] = pp.ResourceVersion
+	}
+}
+
+// applyPodPresetsOnContainer injects the env vars, env from sources and
+// volume mounts of the PodPresets into the container.
+func applyPodPresetsOnContainer(ctr *corev1.Container, podPresets []*settingsv1alpha1.PodPreset) {
+	envVars, _ := mergeEnv(ctr.Env, podPresets)
+	ctr.Env = envVars
+
+	volumeMounts, _ := mergeVolumeMounts(ctr.VolumeMounts, podPresets)
+	ctr.VolumeMounts = volumeMounts
+
+	ctr.EnvFrom = mergeEnvFrom(ctr.EnvFrom, podPresets)
+}
+
+// podPresetAnnotationKey returns the key of the annotation that records that
+// the PodPreset with the given name was applied to a pod.
+func podPresetAnnotationKey(name string) string {
+	return fmt.Sprintf("%s/podpreset-%s", annotationPrefix, name)
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podpreset implements a mutating admission webhook that injects the
+// env vars, env from sources, volumes and volume mounts of matching
+// PodPresets into pods when they are created.
+package podpreset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+)
+
+const (
+	annotationPrefix = "podpreset.servicecatalog.k8s.io"
+
+	// ExcludeAnnotation is the annotation that a pod sets to "true" to opt
+	// out of PodPresets.
+	ExcludeAnnotation = annotationPrefix + "/exclude"
+
+	// mirrorPodAnnotation marks the static pods that the kubelet mirrors to
+	// the API server, these cannot be modified.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	errorPodPresetConflictReason = "PodPresetConflict"
+)
+
+var podResource = metav1.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// patchOperation is a single RFC 6902 JSON patch operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Webhook serves the AdmissionReviews of the API server for pods, and
+// patches the pods with the PodPresets that select them.
+type Webhook struct {
+	podPresetLister settingslisters.PodPresetLister
+	recorder        record.EventRecorder
+}
+
+// NewWebhook returns a Webhook that reads the PodPresets from the given
+// lister and reports conflicts between PodPresets and pods to the recorder.
+func NewWebhook(podPresetLister settingslisters.PodPresetLister, recorder record.EventRecorder) *Webhook {
+	return &Webhook{
+		podPresetLister: podPresetLister,
+		recorder:        recorder,
+	}
+}
+
+// ServeHTTP decodes the AdmissionReview in the request, and responds with an
+// AdmissionReview holding the result of Admit.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+		http.Error(rw, fmt.Sprintf("unsupported content type %q, expected application/json", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("could not read request: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(rw, fmt.Sprintf("could not decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(rw, "AdmissionReview does not contain a request", http.StatusBadRequest)
+		return
+	}
+
+	response := w.Admit(review.Request)
+	response.UID = review.Request.UID
+	review.Response = response
+	review.Request = nil
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("could not encode AdmissionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write(data)
+}
+
+// Admit applies the PodPresets selecting the pod that is being created to it.
+// Requests for other resources and operations are allowed unchanged.
+func (w *Webhook) Admit(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	// Ignore all calls to subresources or resources other than pods.
+	// Ignore all operations other than CREATE.
+	if request.Resource != podResource || request.SubResource != "" || request.Operation != admissionv1beta1.Create {
+		return allowed()
+	}
+
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(request.Object.Raw, pod); err != nil {
+		return denied(metav1.StatusReasonBadRequest, fmt.Sprintf("could not decode pod: %v", err))
+	}
+
+	if _, isMirrorPod := pod.Annotations[mirrorPodAnnotation]; isMirrorPod {
+		return allowed()
+	}
+	if pod.Annotations[ExcludeAnnotation] == "true" {
+		glog.V(5).Infof("Pod %q in namespace %q is excluded from PodPresets", podName(pod), request.Namespace)
+		return allowed()
+	}
+
+	list, err := w.podPresetLister.PodPresets(request.Namespace).List(labels.Everything())
+	if err != nil {
+		return denied(metav1.StatusReasonInternalError, fmt.Sprintf("could not list PodPresets: %v", err))
+	}
+	matchingPPs, err := filterPodPresets(list, pod)
+	if err != nil {
+		return denied(metav1.StatusReasonInternalError, fmt.Sprintf("could not filter PodPresets: %v", err))
+	}
+	if len(matchingPPs) == 0 {
+		return allowed()
+	}
+
+	presetNames := make([]string, len(matchingPPs))
+	for i, pp := range matchingPPs {
+		presetNames[i] = pp.Name
+	}
+
+	// Detect merge conflicts. A conflicting pod is admitted without any of
+	// the PodPresets, and the conflict is reported on the PodPresets, since
+	// the pod does not exist yet.
+	if err := safeToApplyPodPresetsOnPod(pod, matchingPPs); err != nil {
+		glog.Warningf("Conflict occurred while applying PodPresets %s on pod %q in namespace %q: %v", strings.Join(presetNames, ","), podName(pod), request.Namespace, err)
+		for _, pp := range matchingPPs {
+			w.recorder.Eventf(pp, corev1.EventTypeWarning, errorPodPresetConflictReason,
+				"PodPresets %s were not applied to pod %q: %v", strings.Join(presetNames, ","), podName(pod), err)
+		}
+		return allowed()
+	}
+
+	modified := pod.DeepCopy()
+	applyPodPresetsOnPod(modified, matchingPPs)
+
+	patch, err := json.Marshal(createPatch(pod, modified))
+	if err != nil {
+		return denied(metav1.StatusReasonInternalError, fmt.Sprintf("could not encode patch: %v", err))
+	}
+
+	glog.V(4).Infof("Applying PodPresets %s on pod %q in namespace %q", strings.Join(presetNames, ","), podName(pod), request.Namespace)
+
+	response := allowed()
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	response.Patch = patch
+	response.PatchType = &patchType
+	return response
+}
+
+// filterPodPresets returns the PodPresets whose selector matches the labels
+// of the pod, sorted by name.
+func filterPodPresets(list []*settingsv1alpha1.PodPreset, pod *corev1.Pod) ([]*settingsv1alpha1.PodPreset, error) {
+	var matchingPPs []*settingsv1alpha1.PodPreset
+
+	for _, pp := range list {
+		selector, err := metav1.LabelSelectorAsSelector(&pp.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("label selector conversion failed for PodPreset %q: %v", pp.Name, err)
+		}
+
+		// check if the pod labels match the selector
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		glog.V(5).Infof("PodPreset %q matches pod %q labels", pp.Name, podName(pod))
+		matchingPPs = append(matchingPPs, pp)
+	}
+
+	sort.Slice(matchingPPs, func(i, j int) bool {
+		return matchingPPs[i].Name < matchingPPs[j].Name
+	})
+	return matchingPPs, nil
+}
+
+// createPatch returns the JSON patch that turns the original pod into the
+// modified pod. Only the fields that applying PodPresets changes are
+// compared, and since these are only ever appended to, comparing their
+// lengths is enough. The patch replaces the changed fields, rather than the
+// whole spec, so that fields of the pod this webhook does not know about are
+// kept.
+func createPatch(original, modified *corev1.Pod) []patchOperation {
+	var patch []patchOperation
+
+	if len(original.Spec.Volumes) != len(modified.Spec.Volumes) {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/volumes", Value: modified.Spec.Volumes})
+	}
+	patch = append(patch, createContainersPatch("/spec/initContainers", original.Spec.InitContainers, modified.Spec.InitContainers)...)
+	patch = append(patch, createContainersPatch("/spec/containers", original.Spec.Containers, modified.Spec.Containers)...)
+
+	if original.Annotations == nil {
+		patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations", Value: modified.Annotations})
+	} else {
+		keys := make([]string, 0, len(modified.Annotations))
+		for key, value := range modified.Annotations {
+			if originalValue, ok := original.Annotations[key]; !ok || originalValue != value {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations/" + escapeJSONPointer(key), Value: modified.Annotations[key]})
+		}
+	}
+
+	return patch
+}
+
+// createContainersPatch returns the JSON patch operations for the changed
+// fields of the containers at the given path.
+func createContainersPatch(path string, original, modified []corev1.Container) []patchOperation {
+	var patch []patchOperation
+	for i := range modified {
+		containerPath := fmt.Sprintf("%s/%d", path, i)
+		if len(original[i].Env) != len(modified[i].Env) {
+			patch = append(patch, patchOperation{Op: "add", Path: containerPath + "/env", Value: modified[i].Env})
+		}
+		if len(original[i].EnvFrom) != len(modified[i].EnvFrom) {
+			patch = append(patch, patchOperation{Op: "add", Path: containerPath + "/envFrom", Value: modified[i].EnvFrom})
+		}
+		if len(original[i].VolumeMounts) != len(modified[i].VolumeMounts) {
+			patch = append(patch, patchOperation{Op: "add", Path: containerPath + "/volumeMounts", Value: modified[i].VolumeMounts})
+		}
+	}
+	return patch
+}
+
+// escapeJSONPointer escapes a key for use in a JSON pointer, as described in
+// RFC 6901.
+func escapeJSONPointer(key string) string {
+	return strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1)
+}
+
+// podName returns the name of the pod, or its generate name if the name is
+// not set yet.
+func podName(pod *corev1.Pod) string {
+	if pod.Name != "" {
+		return pod.Name
+	}
+	return pod.GenerateName
+}
+
+func allowed() *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
+
+func denied(reason metav1.StatusReason, message string) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
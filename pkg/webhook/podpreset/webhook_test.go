@@ -0,0 +1,400 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+)
+
+const testNamespace = "test-ns"
+
+func newPodPresetLister(t *testing.T, podPresets ...*settingsv1alpha1.PodPreset) settingslisters.PodPresetLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pp := range podPresets {
+		if err := indexer.Add(pp); err != nil {
+			t.Fatalf("unexpected error adding PodPreset: %v", err)
+		}
+	}
+	return settingslisters.NewPodPresetLister(indexer)
+}
+
+func newPodPreset(name string, matchLabels map[string]string) *settingsv1alpha1.PodPreset {
+	return &settingsv1alpha1.PodPreset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       testNamespace,
+			ResourceVersion: "1",
+		},
+		Spec: settingsv1alpha1.PodPresetSpec{
+			Selector: metav1.LabelSelector{MatchLabels: matchLabels},
+		},
+	}
+}
+
+func newDatabasePodPreset() *settingsv1alpha1.PodPreset {
+	pp := newPodPreset("database", map[string]string{"role": "frontend"})
+	pp.Spec.Env = []corev1.EnvVar{{Name: "DB_PORT", Value: "6379"}}
+	pp.Spec.EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "database-binding"}}},
+	}
+	pp.Spec.Volumes = []corev1.Volume{
+		{Name: "credentials", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "database-binding"}}},
+	}
+	pp.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "credentials", MountPath: "/etc/database"}}
+	return pp
+}
+
+func newPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"role": "frontend"},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+			Containers: []corev1.Container{
+				{
+					Name:  "web",
+					Image: "nginx",
+					Env:   []corev1.EnvVar{{Name: "DB_NAME", Value: "orders"}},
+				},
+			},
+		},
+	}
+}
+
+func newPodRequest(t *testing.T, pod *corev1.Pod) *admissionv1beta1.AdmissionRequest {
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("unexpected error encoding pod: %v", err)
+	}
+	return &admissionv1beta1.AdmissionRequest{
+		UID:       "test-uid",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Resource:  podResource,
+		Namespace: testNamespace,
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+// applyPatch applies the patch of the response to the pod and returns the
+// patched pod.
+func applyPatch(t *testing.T, pod *corev1.Pod, response *admissionv1beta1.AdmissionResponse) *corev1.Pod {
+	if response.PatchType == nil || *response.PatchType != admissionv1beta1.PatchTypeJSONPatch {
+		t.Fatalf("expected a JSON patch, got %v", response.PatchType)
+	}
+	patch, err := jsonpatch.DecodePatch(response.Patch)
+	if err != nil {
+		t.Fatalf("unexpected error decoding patch: %v", err)
+	}
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("unexpected error encoding pod: %v", err)
+	}
+	patched, err := patch.Apply(raw)
+	if err != nil {
+		t.Fatalf("unexpected error applying patch %s: %v", response.Patch, err)
+	}
+	patchedPod := &corev1.Pod{}
+	if err := json.Unmarshal(patched, patchedPod); err != nil {
+		t.Fatalf("unexpected error decoding patched pod: %v", err)
+	}
+	return patchedPod
+}
+
+func TestAdmitAppliesPodPresets(t *testing.T) {
+	pp := newDatabasePodPreset()
+	other := newPodPreset("cache", map[string]string{"role": "frontend"})
+	other.ResourceVersion = "7"
+	other.Spec.Env = []corev1.EnvVar{{Name: "CACHE_HOST", Value: "redis"}}
+	recorder := record.NewFakeRecorder(5)
+	webhook := NewWebhook(newPodPresetLister(t, pp, other), recorder)
+
+	pod := newPod()
+	pod.Annotations = map[string]string{"existing": "annotation"}
+	response := webhook.Admit(newPodRequest(t, pod))
+	if !response.Allowed {
+		t.Fatalf("expected the pod to be allowed, got %v", response.Result)
+	}
+	patched := applyPatch(t, pod, response)
+
+	expectedVolumes := pp.Spec.Volumes
+	if !reflect.DeepEqual(patched.Spec.Volumes, expectedVolumes) {
+		t.Errorf("unexpected volumes\nexpected: %+v\n     got: %+v", expectedVolumes, patched.Spec.Volumes)
+	}
+	expectedEnv := []corev1.EnvVar{
+		{Name: "DB_NAME", Value: "orders"},
+		{Name: "CACHE_HOST", Value: "redis"},
+		{Name: "DB_PORT", Value: "6379"},
+	}
+	if !reflect.DeepEqual(patched.Spec.Containers[0].Env, expectedEnv) {
+		t.Errorf("unexpected env\nexpected: %+v\n     got: %+v", expectedEnv, patched.Spec.Containers[0].Env)
+	}
+	for _, ctr := range append(patched.Spec.InitContainers, patched.Spec.Containers...) {
+		if !reflect.DeepEqual(ctr.EnvFrom, pp.Spec.EnvFrom) {
+			t.Errorf("unexpected env from sources of container %q\nexpected: %+v\n     got: %+v", ctr.Name, pp.Spec.EnvFrom, ctr.EnvFrom)
+		}
+		if !reflect.DeepEqual(ctr.VolumeMounts, pp.Spec.VolumeMounts) {
+			t.Errorf("unexpected volume mounts of container %q\nexpected: %+v\n     got: %+v", ctr.Name, pp.Spec.VolumeMounts, ctr.VolumeMounts)
+		}
+	}
+	expectedAnnotations := map[string]string{
+		"existing": "annotation",
+		"podpreset.servicecatalog.k8s.io/podpreset-cache":    "7",
+		"podpreset.servicecatalog.k8s.io/podpreset-database": "1",
+	}
+	if !reflect.DeepEqual(patched.Annotations, expectedAnnotations) {
+		t.Errorf("unexpected annotations\nexpected: %v\n     got: %v", expectedAnnotations, patched.Annotations)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(recorder.Events))
+	}
+}
+
+func TestAdmitAddsAnnotations(t *testing.T) {
+	webhook := NewWebhook(newPodPresetLister(t, newDatabasePodPreset()), record.NewFakeRecorder(5))
+
+	pod := newPod()
+	response := webhook.Admit(newPodRequest(t, pod))
+	patched := applyPatch(t, pod, response)
+
+	expectedAnnotations := map[string]string{"podpreset.servicecatalog.k8s.io/podpreset-database": "1"}
+	if !reflect.DeepEqual(patched.Annotations, expectedAnnotations) {
+		t.Errorf("unexpected annotations\nexpected: %v\n     got: %v", expectedAnnotations, patched.Annotations)
+	}
+}
+
+func TestAdmitConflict(t *testing.T) {
+	cases := []struct {
+		name          string
+		modify        func(pp *settingsv1alpha1.PodPreset)
+		expectedError string
+	}{
+		{
+			name: "env var",
+			modify: func(pp *settingsv1alpha1.PodPreset) {
+				pp.Spec.Env = []corev1.EnvVar{{Name: "DB_NAME", Value: "users"}}
+			},
+			expectedError: `container "web": merging env for PodPreset "database" has a conflict on env var "DB_NAME"`,
+		},
+		{
+			name: "volume",
+			modify: func(pp *settingsv1alpha1.PodPreset) {
+				pp.Spec.Volumes[0].Name = "data"
+			},
+			expectedError: `merging volumes for PodPreset "database" has a conflict on volume "data"`,
+		},
+		{
+			name: "mount path",
+			modify: func(pp *settingsv1alpha1.PodPreset) {
+				pp.Spec.VolumeMounts[0].MountPath = "/var/lib/data"
+			},
+			expectedError: `merging volume mounts for PodPreset "database" has a conflict on mount path "/var/lib/data"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pp := newDatabasePodPreset()
+			tc.modify(pp)
+			recorder := record.NewFakeRecorder(5)
+			webhook := NewWebhook(newPodPresetLister(t, pp), recorder)
+
+			pod := newPod()
+			pod.Spec.Volumes = []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+			pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/data"}}
+
+			response := webhook.Admit(newPodRequest(t, pod))
+			if !response.Allowed {
+				t.Fatalf("expected the pod to be allowed, got %v", response.Result)
+			}
+			if response.Patch != nil {
+				t.Fatalf("expected no patch, got %s", response.Patch)
+			}
+			if len(recorder.Events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(recorder.Events))
+			}
+			event := <-recorder.Events
+			if !strings.HasPrefix(event, corev1.EventTypeWarning+" "+errorPodPresetConflictReason+" ") || !strings.Contains(event, tc.expectedError) {
+				t.Fatalf("unexpected event %q, expected a warning containing %q", event, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestAdmitSkipped(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod)
+	}{
+		{
+			name: "selector does not match",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				pod.Labels["role"] = "backend"
+			},
+		},
+		{
+			name: "other namespace",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				request.Namespace = "other-ns"
+			},
+		},
+		{
+			name: "excluded",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				pod.Annotations = map[string]string{ExcludeAnnotation: "true"}
+			},
+		},
+		{
+			name: "mirror pod",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				pod.Annotations = map[string]string{mirrorPodAnnotation: "mirror"}
+			},
+		},
+		{
+			name: "update",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				request.Operation = admissionv1beta1.Update
+			},
+		},
+		{
+			name: "subresource",
+			modify: func(request *admissionv1beta1.AdmissionRequest, pod *corev1.Pod) {
+				request.SubResource = "status"
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			webhook := NewWebhook(newPodPresetLister(t, newDatabasePodPreset()), record.NewFakeRecorder(5))
+
+			pod := newPod()
+			request := newPodRequest(t, pod)
+			tc.modify(request, pod)
+			raw, err := json.Marshal(pod)
+			if err != nil {
+				t.Fatalf("unexpected error encoding pod: %v", err)
+			}
+			request.Object.Raw = raw
+
+			response := webhook.Admit(request)
+			if !response.Allowed {
+				t.Fatalf("expected the pod to be allowed, got %v", response.Result)
+			}
+			if response.Patch != nil {
+				t.Fatalf("expected no patch, got %s", response.Patch)
+			}
+		})
+	}
+}
+
+func TestAdmitInvalidPod(t *testing.T) {
+	webhook := NewWebhook(newPodPresetLister(t), record.NewFakeRecorder(5))
+
+	request := newPodRequest(t, newPod())
+	request.Object.Raw = []byte(`{"spec": []}`)
+	response := webhook.Admit(request)
+	if response.Allowed {
+		t.Fatal("expected the pod to be denied")
+	}
+	if response.Result.Reason != metav1.StatusReasonBadRequest {
+		t.Fatalf("unexpected reason %q", response.Result.Reason)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8"} {
+		t.Run(contentType, func(t *testing.T) {
+			webhook := NewWebhook(newPodPresetLister(t, newDatabasePodPreset()), record.NewFakeRecorder(5))
+
+			pod := newPod()
+			body, err := json.Marshal(&admissionv1beta1.AdmissionReview{Request: newPodRequest(t, pod)})
+			if err != nil {
+				t.Fatalf("unexpected error encoding AdmissionReview: %v", err)
+			}
+			request := httptest.NewRequest(http.MethodPost, "/podpresets", bytes.NewReader(body))
+			request.Header.Set("Content-Type", contentType)
+			recorder := httptest.NewRecorder()
+			webhook.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("unexpected status code %d: %s", recorder.Code, recorder.Body)
+			}
+			review := &admissionv1beta1.AdmissionReview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), review); err != nil {
+				t.Fatalf("unexpected error decoding AdmissionReview: %v", err)
+			}
+			if review.Response == nil {
+				t.Fatal("expected a response")
+			}
+			if review.Response.UID != "test-uid" {
+				t.Errorf("unexpected UID %q", review.Response.UID)
+			}
+			patched := applyPatch(t, pod, review.Response)
+			if len(patched.Spec.Volumes) != 1 {
+				t.Errorf("expected the PodPreset to be applied, got %+v", patched.Spec)
+			}
+		})
+	}
+}
+
+func TestServeHTTPInvalidRequest(t *testing.T) {
+	webhook := NewWebhook(newPodPresetLister(t), record.NewFakeRecorder(5))
+
+	cases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		code        int
+	}{
+		{name: "method", method: http.MethodGet, contentType: "application/json", body: "{}", code: http.StatusMethodNotAllowed},
+		{name: "content type", method: http.MethodPost, contentType: "text/plain", body: "{}", code: http.StatusUnsupportedMediaType},
+		{name: "invalid content type", method: http.MethodPost, contentType: "application/json; charset", body: "{}", code: http.StatusUnsupportedMediaType},
+		{name: "body", method: http.MethodPost, contentType: "application/json", body: "{", code: http.StatusBadRequest},
+		{name: "no request", method: http.MethodPost, contentType: "application/json", body: "{}", code: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, "/podpresets", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+			recorder := httptest.NewRecorder()
+			webhook.ServeHTTP(recorder, request)
+			if recorder.Code != tc.code {
+				t.Fatalf("unexpected status code %d, expected %d", recorder.Code, tc.code)
+			}
+		})
+	}
+}
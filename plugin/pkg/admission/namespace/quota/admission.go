@@ -0,0 +1,404 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceCatalogQuota"
+
+	// ConfigMapName is the name of the ConfigMap that holds the quota of
+	// the namespace it is created in. Namespaces without it are not limited.
+	ConfigMapName = "service-catalog-quota"
+
+	// ServiceInstancesKey limits the number of ServiceInstances in a namespace.
+	// Appending ".class.<external name>" or ".plan.<external name>" limits the
+	// number of ServiceInstances of a single class or plan.
+	ServiceInstancesKey = "serviceinstances"
+	// ServiceBindingsKey limits the number of ServiceBindings in a namespace.
+	// Appending ".class.<external name>" or ".plan.<external name>" limits the
+	// number of ServiceBindings to instances of a single class or plan.
+	ServiceBindingsKey = "servicebindings"
+	// CostKey limits the total cost of the ServiceInstances in a namespace.
+	CostKey = "cost"
+	// CostMetadataKeyKey names the key of the plan external metadata that
+	// holds the cost of a single instance of the plan. It defaults to
+	// DefaultCostMetadataKey. Plans without it cost nothing.
+	CostMetadataKeyKey = "cost.metadataKey"
+	// DefaultCostMetadataKey is the default plan external metadata key
+	// holding the cost of a single instance of the plan.
+	DefaultCostMetadataKey = "cost"
+
+	classKeyInfix = ".class."
+	planKeyInfix  = ".plan."
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewQuota()
+	})
+}
+
+// quota is an implementation of admission.Interface.
+// It rejects the creation of ServiceInstances and ServiceBindings, and
+// ServiceInstance plan changes, that would exceed the limits set in the
+// ConfigMap of the namespace.
+type quota struct {
+	*admission.Handler
+	kubeClient     kubeclientset.Interface
+	planResolver   *resolver.Resolver
+	instanceLister internalversion.ServiceInstanceLister
+	bindingLister  internalversion.ServiceBindingLister
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&quota{})
+var _ = scadmission.WantsKubeClientSet(&quota{})
+
+// limits are the limits of a namespace, keyed by the quota key they apply to.
+type limits struct {
+	hard            map[string]float64
+	costMetadataKey string
+}
+
+// usage is the usage of a set of resources, keyed by the quota key it
+// counts towards.
+type usage map[string]float64
+
+func (u usage) add(other usage) {
+	for k, v := range other {
+		u[k] += v
+	}
+}
+
+func (q *quota) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !q.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// We only care about the spec of instances and bindings
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetSubresource() != "" {
+		return nil
+	}
+	var isInstance bool
+	switch a.GetResource().GroupResource() {
+	case servicecatalog.Resource("serviceinstances"):
+		isInstance = true
+	case servicecatalog.Resource("servicebindings"):
+		if a.GetOperation() != admission.Create {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	l, err := q.getLimits(a.GetNamespace())
+	if err != nil {
+		glog.Error(err)
+		return admission.NewForbidden(a, err)
+	}
+	if l == nil {
+		return nil
+	}
+
+	var requested, used usage
+	if isInstance {
+		instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		if a.GetOperation() == admission.Update {
+			oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+			if !ok {
+				return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+			}
+			requested = increased(q.instanceUsage(oldInstance, oldInstance, l), q.instanceUsage(instance, oldInstance, l))
+		} else {
+			requested = q.instanceUsage(instance, nil, l)
+		}
+		used, err = q.namespaceInstanceUsage(a.GetNamespace(), a.GetName(), l)
+	} else {
+		binding, ok := a.GetObject().(*servicecatalog.ServiceBinding)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceBinding but was unable to be converted")
+		}
+		requested = q.bindingUsage(binding)
+		used, err = q.namespaceBindingUsage(a.GetNamespace(), a.GetName())
+	}
+	if err != nil {
+		glog.Error(err)
+		return admission.NewForbidden(a, err)
+	}
+
+	if err := checkLimits(l, requested, used); err != nil {
+		glog.V(4).Infof("Rejecting %v %s/%s: %v", a.GetKind().Kind, a.GetNamespace(), a.GetName(), err)
+		return admission.NewForbidden(a, err)
+	}
+	return nil
+}
+
+// NewQuota creates a new admission control handler that enforces the
+// ServiceInstance and ServiceBinding quota of namespaces
+func NewQuota() (admission.Interface, error) {
+	return &quota{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (q *quota) SetKubeClientSet(client kubeclientset.Interface) {
+	q.kubeClient = client
+}
+
+func (q *quota) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	bindingInformer := f.Servicecatalog().InternalVersion().ServiceBindings()
+	q.planResolver = resolver.New(f)
+	q.instanceLister = instanceInformer.Lister()
+	q.bindingLister = bindingInformer.Lister()
+
+	readyFunc := func() bool {
+		return q.planResolver.HasSynced() &&
+			instanceInformer.Informer().HasSynced() && bindingInformer.Informer().HasSynced()
+	}
+
+	q.SetReadyFunc(readyFunc)
+}
+
+func (q *quota) ValidateInitialization() error {
+	if q.kubeClient == nil {
+		return errors.New("missing kubeClient")
+	}
+	if q.planResolver == nil {
+		return errors.New("missing plan resolver")
+	}
+	if q.instanceLister == nil {
+		return errors.New("missing instance lister")
+	}
+	if q.bindingLister == nil {
+		return errors.New("missing binding lister")
+	}
+	return nil
+}
+
+// getLimits returns the limits of the given namespace, or nil if the
+// namespace has no quota ConfigMap.
+func (q *quota) getLimits(namespace string) (*limits, error) {
+	configMap, err := q.kubeClient.CoreV1().ConfigMaps(namespace).Get(ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting quota of namespace %q: %v", namespace, err)
+	}
+	return parseLimits(configMap)
+}
+
+func parseLimits(configMap *corev1.ConfigMap) (*limits, error) {
+	l := &limits{
+		hard:            make(map[string]float64),
+		costMetadataKey: DefaultCostMetadataKey,
+	}
+	for key, value := range configMap.Data {
+		if key == CostMetadataKeyKey {
+			l.costMetadataKey = value
+			continue
+		}
+		if !isQuotaKey(key) {
+			return nil, fmt.Errorf("invalid quota %s/%s: unsupported key %q", configMap.Namespace, configMap.Name, key)
+		}
+		hard, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || hard < 0 {
+			return nil, fmt.Errorf("invalid quota %s/%s: %q must be a non-negative number, got %q", configMap.Namespace, configMap.Name, key, value)
+		}
+		l.hard[key] = hard
+	}
+	return l, nil
+}
+
+func isQuotaKey(key string) bool {
+	if key == ServiceInstancesKey || key == ServiceBindingsKey || key == CostKey {
+		return true
+	}
+	for _, prefix := range []string{ServiceInstancesKey, ServiceBindingsKey} {
+		for _, infix := range []string{classKeyInfix, planKeyInfix} {
+			if strings.HasPrefix(key, prefix+infix) && len(key) > len(prefix+infix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkLimits returns an error if adding the requested usage to the used
+// usage exceeds any of the limits.
+func checkLimits(l *limits, requested, used usage) error {
+	var exceeded []string
+	for key, value := range requested {
+		hard, ok := l.hard[key]
+		if !ok || value <= 0 {
+			continue
+		}
+		if used[key]+value > hard {
+			exceeded = append(exceeded, key)
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	sort.Strings(exceeded)
+
+	var requestedMsg, usedMsg, limitedMsg []string
+	for _, key := range exceeded {
+		requestedMsg = append(requestedMsg, fmt.Sprintf("%s=%s", key, formatQuantity(requested[key])))
+		usedMsg = append(usedMsg, fmt.Sprintf("%s=%s", key, formatQuantity(used[key])))
+		limitedMsg = append(limitedMsg, fmt.Sprintf("%s=%s", key, formatQuantity(l.hard[key])))
+	}
+	return fmt.Errorf("exceeded quota: %s, requested: %s, used: %s, limited: %s",
+		ConfigMapName, strings.Join(requestedMsg, ","), strings.Join(usedMsg, ","), strings.Join(limitedMsg, ","))
+}
+
+func formatQuantity(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// increased returns the new usage of the keys whose usage increased from the
+// old usage. An update is checked against the quota with its full new usage,
+// as the usage of the namespace excludes the updated object, but only for the
+// keys it increases, so that an update that does not increase any usage is
+// allowed in a namespace that is over quota.
+func increased(old, new usage) usage {
+	u := usage{}
+	for key, value := range new {
+		if value > old[key] {
+			u[key] = value
+		}
+	}
+	return u
+}
+
+// instanceUsage returns the usage of the instance. stored is the instance as
+// it is stored, whose class and plan references are used if the plan of the
+// instance is the same, or nil on create.
+func (q *quota) instanceUsage(instance, stored *servicecatalog.ServiceInstance, l *limits) usage {
+	// If the class or plan is not found, the instance counts towards the
+	// limits of the external names specified by the user.
+	plan, _ := q.planResolver.Resolve(instance, stored)
+	u := planUsage(ServiceInstancesKey, plan)
+	u[CostKey] = planCost(plan.ExternalMetadata, l.costMetadataKey)
+	return u
+}
+
+func (q *quota) bindingUsage(binding *servicecatalog.ServiceBinding) usage {
+	instance, err := q.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		// Count the binding towards the overall limit only.
+		return usage{ServiceBindingsKey: 1}
+	}
+	plan, _ := q.planResolver.Resolve(instance, instance)
+	return planUsage(ServiceBindingsKey, plan)
+}
+
+// namespaceInstanceUsage returns the usage of the ServiceInstances in the
+// namespace, except for the named one.
+func (q *quota) namespaceInstanceUsage(namespace, exclude string, l *limits) (usage, error) {
+	instances, err := q.instanceLister.ServiceInstances(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing ServiceInstances in namespace %q: %v", namespace, err)
+	}
+	u := usage{}
+	for _, instance := range instances {
+		if instance.Name != exclude {
+			u.add(q.instanceUsage(instance, instance, l))
+		}
+	}
+	return u, nil
+}
+
+// namespaceBindingUsage returns the usage of the ServiceBindings in the
+// namespace, except for the named one.
+func (q *quota) namespaceBindingUsage(namespace, exclude string) (usage, error) {
+	bindings, err := q.bindingLister.ServiceBindings(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing ServiceBindings in namespace %q: %v", namespace, err)
+	}
+	u := usage{}
+	for _, binding := range bindings {
+		if binding.Name != exclude {
+			u.add(q.bindingUsage(binding))
+		}
+	}
+	return u, nil
+}
+
+func planUsage(resource string, plan *resolver.Plan) usage {
+	u := usage{resource: 1}
+	if plan.ClassExternalName != "" {
+		u[resource+classKeyInfix+plan.ClassExternalName] = 1
+	}
+	if plan.PlanExternalName != "" {
+		u[resource+planKeyInfix+plan.PlanExternalName] = 1
+	}
+	return u
+}
+
+// planCost returns the cost of a single instance of a plan, read from the
+// given key of its external metadata.
+func planCost(externalMetadata *runtime.RawExtension, key string) float64 {
+	if externalMetadata == nil || len(externalMetadata.Raw) == 0 {
+		return 0
+	}
+	metadata := make(map[string]interface{})
+	if err := json.Unmarshal(externalMetadata.Raw, &metadata); err != nil {
+		return 0
+	}
+	switch cost := metadata[key].(type) {
+	case float64:
+		return cost
+	case string:
+		if f, err := strconv.ParseFloat(cost, 64); err == nil {
+			return f
+		}
+	}
+	return 0
+}
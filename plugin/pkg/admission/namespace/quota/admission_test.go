@@ -0,0 +1,362 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	sptesting "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver/testing"
+)
+
+const testNamespace = "test-ns"
+
+// newHandlerForTest returns a configured handler for testing, backed by
+// the given service catalog and kube objects.
+func newHandlerForTest(t *testing.T, scObjects []runtime.Object, kubeObjects []runtime.Object) admission.Interface {
+	internalClient := fake.NewSimpleClientset(scObjects...)
+	kubeClient := kubefake.NewSimpleClientset(kubeObjects...)
+	handler, err := NewQuota()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	if err := sptesting.InitializeHandler(handler, internalClient, kubeClient); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	return handler
+}
+
+func newQuotaConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: testNamespace},
+		Data:       data,
+	}
+}
+
+// withExternalMetadata sets the external metadata of the plan to the given JSON.
+func withExternalMetadata(plan *servicecatalog.ClusterServicePlan, metadata string) *servicecatalog.ClusterServicePlan {
+	plan.Spec.ExternalMetadata = &runtime.RawExtension{Raw: []byte(metadata)}
+	return plan
+}
+
+// newServiceInstance returns an instance of the given class and plan
+// external names, with its references resolved if resolved is true.
+func newServiceInstance(name, className, planName string, resolved bool) *servicecatalog.ServiceInstance {
+	instance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: className,
+				ClusterServicePlanExternalName:  planName,
+			},
+		},
+	}
+	if resolved {
+		instance.Spec.ClusterServiceClassRef = &servicecatalog.ClusterObjectReference{Name: className + "-id"}
+		instance.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: className + "-" + planName + "-id"}
+	}
+	return instance
+}
+
+// withPlan returns the instance with its plan changed to the given external
+// name as the user changes it: the references of the old plan are kept
+// until the apiserver clears them after admission.
+func withPlan(instance *servicecatalog.ServiceInstance, planName string) *servicecatalog.ServiceInstance {
+	instance = instance.DeepCopy()
+	instance.Spec.ClusterServicePlanExternalName = planName
+	return instance
+}
+
+// withRefs returns the instance with the class and plan references of other.
+func withRefs(instance, other *servicecatalog.ServiceInstance) *servicecatalog.ServiceInstance {
+	instance.Spec.ClusterServiceClassRef = other.Spec.ClusterServiceClassRef
+	instance.Spec.ClusterServicePlanRef = other.Spec.ClusterServicePlanRef
+	return instance
+}
+
+func newServiceBinding(name, instanceName string) *servicecatalog.ServiceBinding {
+	return &servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: servicecatalog.ServiceBindingSpec{
+			ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: instanceName},
+		},
+	}
+}
+
+// catalog returns the classes and plans used by the tests: a "mysql" class
+// with "small" and "large" plans costing 1 and 10, and a "redis" class with
+// a "small" plan that has no cost.
+func catalog() []runtime.Object {
+	return []runtime.Object{
+		sptesting.NewClusterServiceClass("mysql-id", "mysql"),
+		withExternalMetadata(sptesting.NewClusterServicePlan("mysql-small-id", "small", "mysql-id"), `{"cost":1}`),
+		withExternalMetadata(sptesting.NewClusterServicePlan("mysql-large-id", "large", "mysql-id"), `{"cost":10,"price":"25.5"}`),
+		sptesting.NewClusterServiceClass("redis-id", "redis"),
+		sptesting.NewClusterServicePlan("redis-small-id", "small", "redis-id"),
+	}
+}
+
+func TestAdmitServiceInstance(t *testing.T) {
+	cases := []struct {
+		name      string
+		existing  []runtime.Object
+		quota     map[string]string
+		instance  *servicecatalog.ServiceInstance
+		old       *servicecatalog.ServiceInstance
+		operation admission.Operation
+		exceeded  string
+	}{
+		{
+			name:      "no quota",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			instance:  newServiceInstance("new", "mysql", "large", false),
+			operation: admission.Create,
+		},
+		{
+			name:      "within instance limit",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"serviceinstances": "2"},
+			instance:  newServiceInstance("new", "mysql", "small", false),
+			operation: admission.Create,
+		},
+		{
+			name:      "instance limit exceeded",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"serviceinstances": "1"},
+			instance:  newServiceInstance("new", "redis", "small", false),
+			operation: admission.Create,
+			exceeded:  "serviceinstances=1",
+		},
+		{
+			name:      "class limit exceeded",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"serviceinstances.class.mysql": "1"},
+			instance:  newServiceInstance("new", "mysql", "large", false),
+			operation: admission.Create,
+			exceeded:  "serviceinstances.class.mysql=1",
+		},
+		{
+			name:      "other class not limited",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"serviceinstances.class.mysql": "1"},
+			instance:  newServiceInstance("new", "redis", "small", false),
+			operation: admission.Create,
+		},
+		{
+			name:      "plan limit counts plans of every class",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"serviceinstances.plan.small": "1"},
+			instance:  newServiceInstance("new", "redis", "small", false),
+			operation: admission.Create,
+			exceeded:  "serviceinstances.plan.small=1",
+		},
+		{
+			name:      "cost limit exceeded",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			quota:     map[string]string{"cost": "15"},
+			instance:  newServiceInstance("new", "mysql", "large", false),
+			operation: admission.Create,
+			exceeded:  "requested: cost=10, used: cost=10, limited: cost=15",
+		},
+		{
+			name:      "within cost limit",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			quota:     map[string]string{"cost": "15"},
+			instance:  newServiceInstance("new", "mysql", "small", false),
+			operation: admission.Create,
+		},
+		{
+			name:      "plans without a cost are free",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			quota:     map[string]string{"cost": "10"},
+			instance:  newServiceInstance("new", "redis", "small", false),
+			operation: admission.Create,
+		},
+		{
+			name:      "custom cost metadata key",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			quota:     map[string]string{"cost": "50", "cost.metadataKey": "price"},
+			instance:  newServiceInstance("new", "mysql", "large", false),
+			operation: admission.Create,
+			exceeded:  "requested: cost=25.5, used: cost=25.5, limited: cost=50",
+		},
+		{
+			name:      "plan change exceeding plan limit",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "large", true)},
+			quota:     map[string]string{"serviceinstances.plan.large": "1"},
+			instance:  withPlan(newServiceInstance("new", "mysql", "small", true), "large"),
+			old:       newServiceInstance("new", "mysql", "small", true),
+			operation: admission.Update,
+			exceeded:  "serviceinstances.plan.large=1",
+		},
+		{
+			name:      "plan change exceeding cost limit",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"cost": "10"},
+			instance:  withPlan(newServiceInstance("new", "mysql", "small", true), "large"),
+			old:       newServiceInstance("new", "mysql", "small", true),
+			operation: admission.Update,
+			exceeded:  "requested: cost=10, used: cost=1, limited: cost=10",
+		},
+		{
+			name:      "plan change within cost limit",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"cost": "11"},
+			instance:  withPlan(newServiceInstance("new", "mysql", "small", true), "large"),
+			old:       newServiceInstance("new", "mysql", "small", true),
+			operation: admission.Update,
+		},
+		{
+			name:      "plan change with the references of the old plan set by the user",
+			existing:  []runtime.Object{newServiceInstance("existing", "mysql", "small", true)},
+			quota:     map[string]string{"cost": "10"},
+			instance:  withRefs(newServiceInstance("new", "mysql", "large", false), newServiceInstance("new", "mysql", "small", true)),
+			old:       newServiceInstance("new", "mysql", "small", true),
+			operation: admission.Update,
+			exceeded:  "requested: cost=10, used: cost=1, limited: cost=10",
+		},
+		{
+			name: "update without plan change in a namespace over quota",
+			existing: []runtime.Object{
+				newServiceInstance("existing", "mysql", "large", true),
+				newServiceInstance("new", "mysql", "large", true),
+			},
+			quota:     map[string]string{"serviceinstances": "1", "cost": "1"},
+			instance:  newServiceInstance("new", "mysql", "large", true),
+			old:       newServiceInstance("new", "mysql", "large", true),
+			operation: admission.Update,
+		},
+		{
+			name:      "invalid quota",
+			quota:     map[string]string{"serviceinstances": "many"},
+			instance:  newServiceInstance("new", "mysql", "small", false),
+			operation: admission.Create,
+			exceeded:  `"serviceinstances" must be a non-negative number`,
+		},
+		{
+			name:      "unsupported quota key",
+			quota:     map[string]string{"serviceclasses": "1"},
+			instance:  newServiceInstance("new", "mysql", "small", false),
+			operation: admission.Create,
+			exceeded:  `unsupported key "serviceclasses"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var kubeObjects []runtime.Object
+			if tc.quota != nil {
+				kubeObjects = append(kubeObjects, newQuotaConfigMap(tc.quota))
+			}
+			handler := newHandlerForTest(t, append(catalog(), tc.existing...), kubeObjects)
+
+			var old runtime.Object
+			if tc.old != nil {
+				old = tc.old
+			}
+			err := handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(tc.instance, old, servicecatalog.Kind("ServiceInstance").WithVersion("version"), tc.instance.Namespace, tc.instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", tc.operation, nil))
+			if tc.exceeded == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tc.exceeded)
+			}
+			if !strings.Contains(err.Error(), tc.exceeded) {
+				t.Fatalf("expected an error containing %q, got %q", tc.exceeded, err)
+			}
+		})
+	}
+}
+
+func TestAdmitServiceBinding(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		quota    map[string]string
+		binding  *servicecatalog.ServiceBinding
+		exceeded string
+	}{
+		{
+			name: "within binding limit",
+			existing: []runtime.Object{
+				newServiceInstance("mysql", "mysql", "small", true),
+				newServiceBinding("existing", "mysql"),
+			},
+			quota:   map[string]string{"servicebindings": "2"},
+			binding: newServiceBinding("new", "mysql"),
+		},
+		{
+			name: "binding limit exceeded",
+			existing: []runtime.Object{
+				newServiceInstance("mysql", "mysql", "small", true),
+				newServiceBinding("existing", "mysql"),
+			},
+			quota:    map[string]string{"servicebindings": "1"},
+			binding:  newServiceBinding("new", "missing-instance"),
+			exceeded: "servicebindings=1",
+		},
+		{
+			name: "class binding limit exceeded",
+			existing: []runtime.Object{
+				newServiceInstance("mysql", "mysql", "small", true),
+				newServiceBinding("existing", "mysql"),
+			},
+			quota:    map[string]string{"servicebindings.class.mysql": "1"},
+			binding:  newServiceBinding("new", "mysql"),
+			exceeded: "servicebindings.class.mysql=1",
+		},
+		{
+			name: "other class binding not limited",
+			existing: []runtime.Object{
+				newServiceInstance("mysql", "mysql", "small", true),
+				newServiceInstance("redis", "redis", "small", true),
+				newServiceBinding("existing", "mysql"),
+			},
+			quota:   map[string]string{"servicebindings.class.mysql": "1"},
+			binding: newServiceBinding("new", "redis"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, append(catalog(), tc.existing...), []runtime.Object{newQuotaConfigMap(tc.quota)})
+
+			err := handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(tc.binding, nil, servicecatalog.Kind("ServiceBinding").WithVersion("version"), tc.binding.Namespace, tc.binding.Name, servicecatalog.Resource("servicebindings").WithVersion("version"), "", admission.Create, nil))
+			if tc.exceeded == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tc.exceeded)
+			}
+			if !strings.Contains(err.Error(), tc.exceeded) {
+				t.Fatalf("expected an error containing %q, got %q", tc.exceeded, err)
+			}
+		})
+	}
+}
@@ -26,9 +26,12 @@ import (
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
 	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 const (
@@ -46,10 +49,12 @@ func Register(plugins *admission.Plugins) {
 // enforceNoNewCredentialsForDeletedInstance is an implementation of admission.Interface.
 // If creating new ServiceBindings or updating an existing
 // set of credentials, fail the operation if the ServiceInstance is
-// marked for deletion
+// marked for deletion, or if the ServiceInstance lives in another namespace
+// that has not granted access to it
 type enforceNoNewCredentialsForDeletedInstance struct {
 	*admission.Handler
 	instanceLister internalversion.ServiceInstanceLister
+	grantLister    internalversion.ServiceInstanceGrantLister
 }
 
 var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&enforceNoNewCredentialsForDeletedInstance{})
@@ -77,26 +82,77 @@ func (b *enforceNoNewCredentialsForDeletedInstance) Admit(a admission.Attributes
 	}
 
 	instanceRef := credentials.Spec.ServiceInstanceRef
-	instance, err := b.instanceLister.ServiceInstances(credentials.Namespace).Get(instanceRef.Name)
+	instanceNamespace := credentials.GetServiceInstanceNamespace()
+	instance, err := b.instanceLister.ServiceInstances(instanceNamespace).Get(instanceRef.Name)
 
 	// block the credentials operation if the ServiceInstance is being deleted
 	if err == nil && instance.DeletionTimestamp != nil {
 		warning := fmt.Sprintf("ServiceBinding %s/%s references a ServiceInstance that is being deleted: %s/%s",
 			credentials.Namespace,
 			credentials.Name,
-			credentials.Namespace,
+			instanceNamespace,
 			instanceRef.Name)
 		glog.Info(warning, err)
 		return admission.NewForbidden(a, fmt.Errorf(warning))
 	}
 
+	// block the credentials operation if the ServiceInstance lives in
+	// another namespace that has not granted access to it
+	if instanceNamespace != credentials.Namespace {
+		granted, err := b.isServiceInstanceGranted(instanceNamespace, instanceRef.Name, credentials.Namespace)
+		if err != nil {
+			return admission.NewForbidden(a, err)
+		}
+		if !granted {
+			warning := fmt.Sprintf("ServiceBinding %s/%s references a ServiceInstance that is not shared with namespace %q: %s/%s",
+				credentials.Namespace,
+				credentials.Name,
+				credentials.Namespace,
+				instanceNamespace,
+				instanceRef.Name)
+			glog.Info(warning)
+			return admission.NewForbidden(a, fmt.Errorf(warning))
+		}
+	}
+
 	return nil
 }
 
+// isServiceInstanceGranted returns whether a ServiceInstanceGrant in the
+// namespace of the instance allows the given namespace to bind to it.
+func (b *enforceNoNewCredentialsForDeletedInstance) isServiceInstanceGranted(instanceNamespace, instanceName, namespace string) (bool, error) {
+	if b.grantLister == nil {
+		return false, nil
+	}
+	grants, err := b.grantLister.ServiceInstanceGrants(instanceNamespace).List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list ServiceInstanceGrants in namespace %q: %v", instanceNamespace, err)
+	}
+	for _, grant := range grants {
+		if grant.Spec.ServiceInstanceRef.Name != instanceName {
+			continue
+		}
+		for _, ns := range grant.Spec.Namespaces {
+			if ns == namespace {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func (b *enforceNoNewCredentialsForDeletedInstance) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
 	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
 	b.instanceLister = instanceInformer.Lister()
-	b.SetReadyFunc(instanceInformer.Informer().HasSynced)
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceSharing) {
+		b.SetReadyFunc(instanceInformer.Informer().HasSynced)
+		return
+	}
+	grantInformer := f.Servicecatalog().InternalVersion().ServiceInstanceGrants()
+	b.grantLister = grantInformer.Lister()
+	b.SetReadyFunc(func() bool {
+		return instanceInformer.Informer().HasSynced() && grantInformer.Informer().HasSynced()
+	})
 }
 
 func (b *enforceNoNewCredentialsForDeletedInstance) ValidateInitialization() error {
@@ -108,7 +164,7 @@ func (b *enforceNoNewCredentialsForDeletedInstance) ValidateInitialization() err
 
 // NewCredentialsBlocker creates a new admission control handler that
 // blocks creation of a ServiceBinding if the instance
-// is being deleted
+// is being deleted or is not shared with the namespace of the binding
 func NewCredentialsBlocker() (admission.Interface, error) {
 	return &enforceNoNewCredentialsForDeletedInstance{
 		Handler: admission.NewHandler(admission.Create),
@@ -17,6 +17,7 @@ limitations under the License.
 package lifecycle
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	core "k8s.io/client-go/testing"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
@@ -31,6 +33,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 // newHandlerForTest returns a configured handler for testing.
@@ -124,3 +127,101 @@ func TestAllowNewCredentialsForNonDeletedInstance(t *testing.T) {
 		t.Errorf("Error, admission controller should not block this test")
 	}
 }
+
+// newSharedServiceBinding returns a new Service Binding in the "team-ns"
+// namespace that references the "test-instance" service instance of the
+// "test-ns" namespace.
+func newSharedServiceBinding() servicecatalog.ServiceBinding {
+	credential := newServiceBinding()
+	credential.Namespace = "team-ns"
+	credential.Spec.ServiceInstanceNamespace = "test-ns"
+	return credential
+}
+
+// TestCredentialsForSharedInstance validates the admission controller
+// only allows a Service Binding to reference a Service Instance of another
+// namespace when a Service Instance Grant shares it with the namespace of
+// the binding.
+func TestCredentialsForSharedInstance(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceSharing))
+	if err != nil {
+		t.Fatalf("Failed to enable ServiceInstanceSharing feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceSharing))
+
+	cases := []struct {
+		name       string
+		grantName  string
+		namespaces []string
+		allowed    bool
+	}{
+		{
+			name:    "no grant",
+			allowed: false,
+		},
+		{
+			name:       "grant for another instance",
+			grantName:  "other-instance",
+			namespaces: []string{"team-ns"},
+			allowed:    false,
+		},
+		{
+			name:       "grant for other namespaces",
+			grantName:  "test-instance",
+			namespaces: []string{"other-ns"},
+			allowed:    false,
+		},
+		{
+			name:       "granted",
+			grantName:  "test-instance",
+			namespaces: []string{"other-ns", "team-ns"},
+			allowed:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		fakeClient := &fake.Clientset{}
+		handler, informerFactory, err := newHandlerForTest(fakeClient)
+		if err != nil {
+			t.Errorf("%v: unexpected error initializing handler: %v", tc.name, err)
+			continue
+		}
+
+		grantList := &servicecatalog.ServiceInstanceGrantList{
+			ListMeta: metav1.ListMeta{
+				ResourceVersion: "1",
+			}}
+		if tc.grantName != "" {
+			grantList.Items = append(grantList.Items, servicecatalog.ServiceInstanceGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-grant", Namespace: "test-ns"},
+				Spec: servicecatalog.ServiceInstanceGrantSpec{
+					ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: tc.grantName},
+					Namespaces:         tc.namespaces,
+				},
+			})
+		}
+		fakeClient.AddReactor("list", "serviceinstancegrants", func(action core.Action) (bool, runtime.Object, error) {
+			return true, grantList, nil
+		})
+
+		informerFactory.Start(wait.NeverStop)
+
+		credential := newSharedServiceBinding()
+		err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(&credential, nil, servicecatalog.Kind("ServiceBindings").WithVersion("version"),
+			"team-ns", "test-cred", servicecatalog.Resource("servicebindings").WithVersion("version"), "", admission.Create, nil))
+		if tc.allowed {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%v: expected the admission controller to block the request", tc.name)
+			continue
+		}
+		expected := "servicebindings.servicecatalog.k8s.io \"test-cred\" is forbidden: ServiceBinding team-ns/test-cred references a ServiceInstance that is not shared with namespace \"team-ns\": test-ns/test-instance"
+		if err.Error() != expected {
+			t.Errorf("%v: admission controller blocked the request but not with expected error, expected %q, got %q", tc.name, expected, err.Error())
+		}
+	}
+}
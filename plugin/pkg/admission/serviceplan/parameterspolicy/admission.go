@@ -0,0 +1,428 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameterspolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeinformers "k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServicePlanPolicy"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewParametersPolicy()
+	})
+}
+
+// parametersPolicy is an implementation of admission.Interface.
+// It applies the ClusterServicePlanPolicies selecting the plan of a
+// ServiceInstance to the parameters of the ServiceInstance and of its
+// ServiceBindings: default parameters are added, and parameters that are
+// locked or not allowed are rejected.
+type parametersPolicy struct {
+	*admission.Handler
+	namespaceLister corelisters.NamespaceLister
+	planResolver    *resolver.Resolver
+	instanceLister  internalversion.ServiceInstanceLister
+	policyLister    internalversion.ClusterServicePlanPolicyLister
+	readyFuncs      []admission.ReadyFunc
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&parametersPolicy{})
+var _ = scadmission.WantsKubeInformerFactory(&parametersPolicy{})
+
+func (p *parametersPolicy) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !p.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+	// policies are only served when the feature is enabled
+	if p.policyLister == nil {
+		return nil
+	}
+
+	// We only care about the spec of instances and bindings
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetSubresource() != "" {
+		return nil
+	}
+	switch a.GetResource().GroupResource() {
+	case servicecatalog.Resource("serviceinstances"):
+		return p.admitServiceInstance(a)
+	case servicecatalog.Resource("servicebindings"):
+		if a.GetOperation() != admission.Create {
+			return nil
+		}
+		return p.admitServiceBinding(a)
+	default:
+		return nil
+	}
+}
+
+func (p *parametersPolicy) admitServiceInstance(a admission.Attributes) error {
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+	if a.GetOperation() == admission.Update {
+		oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		// Only enforce the policies when the plan or the parameters change,
+		// so that a new policy does not change the parameters of existing
+		// instances on unrelated updates.
+		if instance.DeletionTimestamp != nil ||
+			(instance.Spec.PlanReference == oldInstance.Spec.PlanReference &&
+				reflect.DeepEqual(instance.Spec.Parameters, oldInstance.Spec.Parameters) &&
+				reflect.DeepEqual(instance.Spec.ParametersFrom, oldInstance.Spec.ParametersFrom)) {
+			return nil
+		}
+	}
+
+	var stored *servicecatalog.ServiceInstance
+	if a.GetOperation() == admission.Update {
+		stored = a.GetOldObject().(*servicecatalog.ServiceInstance)
+	}
+	// If the class or plan is not found, the policies of the external names
+	// specified by the user apply.
+	plan, _ := p.planResolver.Resolve(instance, stored)
+	policies, err := p.getPolicies(instance.Namespace, plan)
+	if err != nil {
+		glog.Error(err)
+		return admission.NewForbidden(a, err)
+	}
+	var parametersPolicies []namedParametersPolicy
+	for _, policy := range policies {
+		if policy.Spec.InstanceParameters != nil {
+			parametersPolicies = append(parametersPolicies, namedParametersPolicy{policy.Name, policy.Spec.InstanceParameters})
+		}
+	}
+
+	if err := checkParametersFrom(len(instance.Spec.ParametersFrom) > 0, parametersPolicies); err != nil {
+		glog.V(4).Infof(`Rejecting ServiceInstance "%s/%s": %v`, instance.Namespace, instance.Name, err)
+		return admission.NewForbidden(a, err)
+	}
+	parameters, err := applyPolicies(instance.Spec.Parameters, parametersPolicies)
+	if err != nil {
+		glog.V(4).Infof(`Rejecting ServiceInstance "%s/%s": %v`, instance.Namespace, instance.Name, err)
+		return admission.NewForbidden(a, err)
+	}
+	instance.Spec.Parameters = parameters
+	return nil
+}
+
+func (p *parametersPolicy) admitServiceBinding(a admission.Attributes) error {
+	binding, ok := a.GetObject().(*servicecatalog.ServiceBinding)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceBinding but was unable to be converted")
+	}
+
+	// Bindings to instances that do not exist yet are only subject to the
+	// policies that do not select a class or plan.
+	plan := &resolver.Plan{}
+	instance, err := p.instanceLister.ServiceInstances(binding.GetServiceInstanceNamespace()).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err == nil {
+		plan, _ = p.planResolver.Resolve(instance, instance)
+	}
+
+	policies, err := p.getPolicies(binding.Namespace, plan)
+	if err != nil {
+		glog.Error(err)
+		return admission.NewForbidden(a, err)
+	}
+	var parametersPolicies []namedParametersPolicy
+	for _, policy := range policies {
+		if policy.Spec.BindingParameters != nil {
+			parametersPolicies = append(parametersPolicies, namedParametersPolicy{policy.Name, policy.Spec.BindingParameters})
+		}
+	}
+
+	if err := checkParametersFrom(len(binding.Spec.ParametersFrom) > 0, parametersPolicies); err != nil {
+		glog.V(4).Infof(`Rejecting ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+		return admission.NewForbidden(a, err)
+	}
+	parameters, err := applyPolicies(binding.Spec.Parameters, parametersPolicies)
+	if err != nil {
+		glog.V(4).Infof(`Rejecting ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+		return admission.NewForbidden(a, err)
+	}
+	binding.Spec.Parameters = parameters
+	return nil
+}
+
+// NewParametersPolicy creates a new admission control handler that applies
+// the ClusterServicePlanPolicies to the parameters of ServiceInstances and
+// ServiceBindings
+func NewParametersPolicy() (admission.Interface, error) {
+	return &parametersPolicy{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (p *parametersPolicy) SetKubeInformerFactory(f kubeinformers.SharedInformerFactory) {
+	namespaceInformer := f.Core().V1().Namespaces()
+	p.namespaceLister = namespaceInformer.Lister()
+	p.addReadyFunc(namespaceInformer.Informer().HasSynced)
+}
+
+func (p *parametersPolicy) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	p.planResolver = resolver.New(f)
+	p.instanceLister = instanceInformer.Lister()
+	p.addReadyFunc(p.planResolver.HasSynced)
+	p.addReadyFunc(instanceInformer.Informer().HasSynced)
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServicePlanPolicy) {
+		policyInformer := f.Servicecatalog().InternalVersion().ClusterServicePlanPolicies()
+		p.policyLister = policyInformer.Lister()
+		p.addReadyFunc(policyInformer.Informer().HasSynced)
+	}
+}
+
+// addReadyFunc adds a function that has to return true for the handler to
+// be ready, as the handler is initialized with two informer factories.
+func (p *parametersPolicy) addReadyFunc(readyFunc admission.ReadyFunc) {
+	p.readyFuncs = append(p.readyFuncs, readyFunc)
+	p.SetReadyFunc(func() bool {
+		for _, f := range p.readyFuncs {
+			if !f() {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (p *parametersPolicy) ValidateInitialization() error {
+	if p.namespaceLister == nil {
+		return errors.New("missing namespace lister")
+	}
+	if p.planResolver == nil {
+		return errors.New("missing plan resolver")
+	}
+	if p.instanceLister == nil {
+		return errors.New("missing instance lister")
+	}
+	return nil
+}
+
+// getPolicies returns the policies that apply to the given plan in the
+// given namespace, sorted by name.
+func (p *parametersPolicy) getPolicies(namespace string, plan *resolver.Plan) ([]*servicecatalog.ClusterServicePlanPolicy, error) {
+	policies, err := p.policyLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing service plan policies: %v", err)
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	ns, err := p.namespaceLister.Get(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error getting namespace %q: %v", namespace, err)
+	}
+
+	var matching []*servicecatalog.ClusterServicePlanPolicy
+	for _, policy := range policies {
+		spec := &policy.Spec
+		if spec.ServiceClassExternalName != "" && spec.ServiceClassExternalName != plan.ClassExternalName {
+			continue
+		}
+		if spec.ServicePlanExternalName != "" && spec.ServicePlanExternalName != plan.PlanExternalName {
+			continue
+		}
+		if spec.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace selector of ClusterServicePlanPolicy %q: %v", policy.Name, err)
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		matching = append(matching, policy)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching, nil
+}
+
+// namedParametersPolicy is a ParametersPolicy and the name of the
+// ClusterServicePlanPolicy it belongs to.
+type namedParametersPolicy struct {
+	name   string
+	policy *servicecatalog.ParametersPolicy
+}
+
+// checkParametersFrom returns an error if parameters are taken from other
+// resources while a policy locks or restricts parameters. The admission
+// controller cannot read those resources, so it could not enforce the policy
+// on the parameters they hold.
+func checkParametersFrom(hasParametersFrom bool, policies []namedParametersPolicy) error {
+	if !hasParametersFrom {
+		return nil
+	}
+	for _, p := range policies {
+		if len(p.policy.LockedKeys) > 0 || len(p.policy.AllowedValues) > 0 {
+			return fmt.Errorf("parametersFrom may not be used, as ClusterServicePlanPolicy %q locks or restricts parameters", p.name)
+		}
+	}
+	return nil
+}
+
+// applyPolicies applies the given policies in order to the given
+// parameters. It returns the parameters with the defaults of the policies
+// added, or an error if the parameters violate a policy. The defaults of
+// locked keys take precedence over other defaults, and a default of an
+// earlier policy takes precedence over the defaults of later ones.
+func applyPolicies(in *runtime.RawExtension, policies []namedParametersPolicy) (*runtime.RawExtension, error) {
+	if len(policies) == 0 {
+		return in, nil
+	}
+	var raw []byte
+	if in != nil {
+		raw = in.Raw
+	}
+	parameters, err := unmarshalParameters(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %v", err)
+	}
+
+	defaults := make([]map[string]interface{}, len(policies))
+	for i, p := range policies {
+		if p.policy.Defaults != nil {
+			defaults[i], err = unmarshalParameters(p.policy.Defaults.Raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid defaults of ClusterServicePlanPolicy %q: %v", p.name, err)
+			}
+		}
+		for _, key := range p.policy.LockedKeys {
+			value, set := parameters[key]
+			if !set {
+				continue
+			}
+			if defaultValue, ok := defaults[i][key]; !ok || !reflect.DeepEqual(value, defaultValue) {
+				return nil, fmt.Errorf("parameter %q is locked by ClusterServicePlanPolicy %q", key, p.name)
+			}
+		}
+	}
+
+	changed := false
+	setDefault := func(key string, value interface{}) {
+		if _, set := parameters[key]; !set {
+			parameters[key] = value
+			changed = true
+		}
+	}
+	for i, p := range policies {
+		for _, key := range p.policy.LockedKeys {
+			if value, ok := defaults[i][key]; ok {
+				setDefault(key, value)
+			}
+		}
+	}
+	for i := range policies {
+		for key, value := range defaults[i] {
+			setDefault(key, value)
+		}
+	}
+
+	for _, p := range policies {
+		for _, allowed := range p.policy.AllowedValues {
+			value, set := parameters[allowed.Key]
+			if !set {
+				continue
+			}
+			if !isAllowed(value, allowed.Values) {
+				return nil, fmt.Errorf("parameter %q may not be set to %s by ClusterServicePlanPolicy %q, allowed values: %v", allowed.Key, formatValue(value), p.name, allowed.Values)
+			}
+		}
+	}
+
+	if !changed {
+		return in, nil
+	}
+	raw, err = json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal parameters: %v", err)
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+// unmarshalParameters unmarshals the given raw parameters into a map.
+func unmarshalParameters(raw []byte) (map[string]interface{}, error) {
+	parameters := make(map[string]interface{})
+	if len(raw) > 0 {
+		if err := yaml.Unmarshal(raw, &parameters); err != nil {
+			return nil, err
+		}
+	}
+	return parameters, nil
+}
+
+// isAllowed returns whether the given parameter value is one of the
+// allowed values.
+func isAllowed(value interface{}, allowed []string) bool {
+	formatted := formatValue(value)
+	for _, a := range allowed {
+		if formatted == a {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue returns strings as is, and the JSON encoding of other values.
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
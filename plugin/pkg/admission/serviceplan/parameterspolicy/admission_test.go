@@ -0,0 +1,401 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameterspolicy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	sptesting "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver/testing"
+)
+
+const (
+	productionNamespace  = "prod"
+	developmentNamespace = "dev"
+)
+
+// newHandlerForTest returns a configured handler for testing with the given
+// service catalog objects.
+func newHandlerForTest(t *testing.T, objects ...runtime.Object) admission.Interface {
+	objects = append(objects,
+		sptesting.NewClusterServiceClass("mysql-id", "mysql"),
+		sptesting.NewClusterServicePlan("mysql-small-id", "small", "mysql-id"),
+		sptesting.NewClusterServicePlan("mysql-premium-id", "premium", "mysql-id"),
+	)
+	internalClient := fake.NewSimpleClientset(objects...)
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: productionNamespace, Labels: map[string]string{"environment": "production"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: developmentNamespace}},
+	)
+
+	handler, err := NewParametersPolicy()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	if err := sptesting.InitializeHandler(handler, internalClient, kubeClient); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	return handler
+}
+
+func newServiceInstance(namespace, planExternalName, parameters string) *servicecatalog.ServiceInstance {
+	instance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "mysql",
+				ClusterServicePlanExternalName:  planExternalName,
+			},
+		},
+	}
+	if parameters != "" {
+		instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return instance
+}
+
+// parametersFromSecret is a ParametersFrom that takes the parameters from a
+// Secret, which the admission controller cannot read.
+var parametersFromSecret = []servicecatalog.ParametersFromSource{
+	{SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "parameters", Key: "parameters"}},
+}
+
+func newServiceBinding(namespace, parameters string) *servicecatalog.ServiceBinding {
+	binding := &servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: namespace},
+		Spec: servicecatalog.ServiceBindingSpec{
+			ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: "instance"},
+		},
+	}
+	if parameters != "" {
+		binding.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return binding
+}
+
+// newProductionPolicy returns a policy that forces backups and limits the
+// number of replicas of premium instances in production namespaces, and
+// defaults the role of their bindings.
+func newProductionPolicy() *servicecatalog.ClusterServicePlanPolicy {
+	return &servicecatalog.ClusterServicePlanPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: servicecatalog.ClusterServicePlanPolicySpec{
+			ServiceClassExternalName: "mysql",
+			ServicePlanExternalName:  "premium",
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			},
+			InstanceParameters: &servicecatalog.ParametersPolicy{
+				Defaults:   &runtime.RawExtension{Raw: []byte(`{"backupEnabled":true,"replicas":3}`)},
+				LockedKeys: []string{"backupEnabled", "debug"},
+				AllowedValues: []servicecatalog.AllowedParameterValues{
+					{Key: "replicas", Values: []string{"3", "5"}},
+				},
+			},
+			BindingParameters: &servicecatalog.ParametersPolicy{
+				Defaults: &runtime.RawExtension{Raw: []byte(`{"role":"read-only"}`)},
+				AllowedValues: []servicecatalog.AllowedParameterValues{
+					{Key: "role", Values: []string{"read-only", "read-write"}},
+				},
+			},
+		},
+	}
+}
+
+func admit(handler admission.Interface, obj, oldObj runtime.Object, resource, namespace string, operation admission.Operation) error {
+	kind := "ServiceInstance"
+	if resource == "servicebindings" {
+		kind = "ServiceBinding"
+	}
+	return handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(obj, oldObj, servicecatalog.Kind(kind).WithVersion("version"), namespace, "name", servicecatalog.Resource(resource).WithVersion("version"), "", operation, nil))
+}
+
+func checkResult(t *testing.T, err error, expectedErr string, parameters *runtime.RawExtension, expectedParameters string) {
+	if expectedErr != "" {
+		if err == nil {
+			t.Fatalf("expected an error containing %q", expectedErr)
+		}
+		if !strings.Contains(err.Error(), expectedErr) {
+			t.Fatalf("expected an error containing %q, got %q", expectedErr, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actual string
+	if parameters != nil {
+		actual = string(parameters.Raw)
+	}
+	if actual != expectedParameters {
+		t.Fatalf("unexpected parameters; expected %s, got %s", expectedParameters, actual)
+	}
+}
+
+func TestAdmitServiceInstance(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanPolicy))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServicePlanPolicy))
+
+	cases := []struct {
+		name               string
+		namespace          string
+		plan               string
+		parameters         string
+		parametersFrom     bool
+		oldParameters      *string
+		expectedParameters string
+		err                string
+	}{
+		{
+			name:               "defaults added",
+			namespace:          productionNamespace,
+			plan:               "premium",
+			expectedParameters: `{"backupEnabled":true,"replicas":3}`,
+		},
+		{
+			name:               "defaults merged",
+			namespace:          productionNamespace,
+			plan:               "premium",
+			parameters:         `{"replicas":5,"size":"10Gi"}`,
+			expectedParameters: `{"backupEnabled":true,"replicas":5,"size":"10Gi"}`,
+		},
+		{
+			name:               "locked key set to default",
+			namespace:          productionNamespace,
+			plan:               "premium",
+			parameters:         `{"backupEnabled":true}`,
+			expectedParameters: `{"backupEnabled":true,"replicas":3}`,
+		},
+		{
+			name:       "locked key changed",
+			namespace:  productionNamespace,
+			plan:       "premium",
+			parameters: `{"backupEnabled":false}`,
+			err:        `parameter "backupEnabled" is locked by ClusterServicePlanPolicy "production"`,
+		},
+		{
+			name:       "locked key without default",
+			namespace:  productionNamespace,
+			plan:       "premium",
+			parameters: `{"debug":true}`,
+			err:        `parameter "debug" is locked by ClusterServicePlanPolicy "production"`,
+		},
+		{
+			name:       "value not allowed",
+			namespace:  productionNamespace,
+			plan:       "premium",
+			parameters: `{"replicas":1}`,
+			err:        `parameter "replicas" may not be set to 1 by ClusterServicePlanPolicy "production"`,
+		},
+		{
+			name:               "other plan",
+			namespace:          productionNamespace,
+			plan:               "small",
+			parameters:         `{"backupEnabled":false}`,
+			expectedParameters: `{"backupEnabled":false}`,
+		},
+		{
+			name:               "other namespace",
+			namespace:          developmentNamespace,
+			plan:               "premium",
+			parameters:         `{"backupEnabled":false}`,
+			expectedParameters: `{"backupEnabled":false}`,
+		},
+		{
+			name:               "update changing parameters",
+			namespace:          productionNamespace,
+			plan:               "premium",
+			parameters:         `{"size":"10Gi"}`,
+			oldParameters:      strPtr(""),
+			expectedParameters: `{"backupEnabled":true,"replicas":3,"size":"10Gi"}`,
+		},
+		{
+			name:           "parametersFrom with locked keys",
+			namespace:      productionNamespace,
+			plan:           "premium",
+			parametersFrom: true,
+			err:            `parametersFrom may not be used, as ClusterServicePlanPolicy "production" locks or restricts parameters`,
+		},
+		{
+			name:           "parametersFrom of other plan",
+			namespace:      productionNamespace,
+			plan:           "small",
+			parametersFrom: true,
+		},
+		{
+			name:           "update adding parametersFrom",
+			namespace:      productionNamespace,
+			plan:           "premium",
+			parameters:     `{"replicas":5}`,
+			parametersFrom: true,
+			oldParameters:  strPtr(`{"replicas":5}`),
+			err:            `parametersFrom may not be used, as ClusterServicePlanPolicy "production" locks or restricts parameters`,
+		},
+		{
+			name:               "update without changes",
+			namespace:          productionNamespace,
+			plan:               "premium",
+			parameters:         `{"backupEnabled":false}`,
+			oldParameters:      strPtr(`{"backupEnabled":false}`),
+			expectedParameters: `{"backupEnabled":false}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, newProductionPolicy())
+
+			instance := newServiceInstance(tc.namespace, tc.plan, tc.parameters)
+			if tc.parametersFrom {
+				instance.Spec.ParametersFrom = parametersFromSecret
+			}
+			var oldInstance runtime.Object
+			operation := admission.Create
+			if tc.oldParameters != nil {
+				oldInstance = newServiceInstance(tc.namespace, tc.plan, *tc.oldParameters)
+				operation = admission.Update
+			}
+			err := admit(handler, instance, oldInstance, "serviceinstances", tc.namespace, operation)
+			checkResult(t, err, tc.err, instance.Spec.Parameters, tc.expectedParameters)
+		})
+	}
+}
+
+func TestAdmitServiceBinding(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanPolicy))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServicePlanPolicy))
+
+	cases := []struct {
+		name               string
+		plan               string
+		parameters         string
+		parametersFrom     bool
+		expectedParameters string
+		err                string
+	}{
+		{
+			name:               "defaults added",
+			plan:               "premium",
+			expectedParameters: `{"role":"read-only"}`,
+		},
+		{
+			name:           "parametersFrom with restricted values",
+			plan:           "premium",
+			parametersFrom: true,
+			err:            `parametersFrom may not be used, as ClusterServicePlanPolicy "production" locks or restricts parameters`,
+		},
+		{
+			name:               "allowed value",
+			plan:               "premium",
+			parameters:         `{"role":"read-write"}`,
+			expectedParameters: `{"role":"read-write"}`,
+		},
+		{
+			name:       "value not allowed",
+			plan:       "premium",
+			parameters: `{"role":"admin"}`,
+			err:        `parameter "role" may not be set to admin by ClusterServicePlanPolicy "production"`,
+		},
+		{
+			name:               "instance of other plan",
+			plan:               "small",
+			parameters:         `{"role":"admin"}`,
+			expectedParameters: `{"role":"admin"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, newProductionPolicy(), newServiceInstance(productionNamespace, tc.plan, ""))
+
+			binding := newServiceBinding(productionNamespace, tc.parameters)
+			if tc.parametersFrom {
+				binding.Spec.ParametersFrom = parametersFromSecret
+			}
+			err := admit(handler, binding, nil, "servicebindings", productionNamespace, admission.Create)
+			checkResult(t, err, tc.err, binding.Spec.Parameters, tc.expectedParameters)
+		})
+	}
+}
+
+// TestAdmitPolicyOrder checks that the defaults of locked keys take
+// precedence over other defaults, and that the defaults of a policy take
+// precedence over the defaults of policies whose names sort after it.
+func TestAdmitPolicyOrder(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanPolicy))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServicePlanPolicy))
+
+	general := &servicecatalog.ClusterServicePlanPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "general"},
+		Spec: servicecatalog.ClusterServicePlanPolicySpec{
+			InstanceParameters: &servicecatalog.ParametersPolicy{
+				Defaults: &runtime.RawExtension{Raw: []byte(`{"backupEnabled":false,"region":"eu","replicas":5}`)},
+			},
+		},
+	}
+	handler := newHandlerForTest(t, newProductionPolicy(), general)
+
+	instance := newServiceInstance(productionNamespace, "premium", "")
+	err := admit(handler, instance, nil, "serviceinstances", productionNamespace, admission.Create)
+	checkResult(t, err, "", instance.Spec.Parameters, `{"backupEnabled":true,"region":"eu","replicas":5}`)
+
+	instance = newServiceInstance(developmentNamespace, "small", "")
+	err = admit(handler, instance, nil, "serviceinstances", developmentNamespace, admission.Create)
+	checkResult(t, err, "", instance.Spec.Parameters, `{"backupEnabled":false,"region":"eu","replicas":5}`)
+}
+
+// TestAdmitPlanChange checks that the policies of the new plan apply when the
+// plan of an instance changes, even though the updated instance still has the
+// references of the old plan.
+func TestAdmitPlanChange(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServicePlanPolicy))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServicePlanPolicy))
+
+	handler := newHandlerForTest(t, newProductionPolicy())
+
+	oldInstance := newServiceInstance(productionNamespace, "small", `{"backupEnabled":false}`)
+	oldInstance.Spec.ClusterServiceClassRef = &servicecatalog.ClusterObjectReference{Name: "mysql-id"}
+	oldInstance.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: "mysql-small-id"}
+	instance := oldInstance.DeepCopy()
+	instance.Spec.ClusterServicePlanExternalName = "premium"
+
+	err := admit(handler, instance, oldInstance, "serviceinstances", productionNamespace, admission.Update)
+	checkResult(t, err, `parameter "backupEnabled" is locked by ClusterServicePlanPolicy "production"`, nil, "")
+}
+
+// TestAdmitFeatureDisabled checks that no policies are applied when the
+// ServicePlanPolicy feature is disabled.
+func TestAdmitFeatureDisabled(t *testing.T) {
+	handler := newHandlerForTest(t, newProductionPolicy())
+
+	instance := newServiceInstance(productionNamespace, "premium", `{"backupEnabled":false}`)
+	err := admit(handler, instance, nil, "serviceinstances", productionNamespace, admission.Create)
+	checkResult(t, err, "", instance.Spec.Parameters, `{"backupEnabled":false}`)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
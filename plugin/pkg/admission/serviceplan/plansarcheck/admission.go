@@ -0,0 +1,216 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plansarcheck
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServicePlanSarCheck"
+
+	// ProvisionVerb is the verb a user must be allowed on a plan, or on the
+	// class of the plan, to create a ServiceInstance of the plan.
+	ProvisionVerb = "provision"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewPlanSARCheck()
+	})
+}
+
+// planSARCheck is an implementation of admission.Interface.
+// It enforces that the creator of a Service Instance, or the user changing
+// its plan, is allowed to provision the plan or the class of the plan.
+type planSARCheck struct {
+	*admission.Handler
+	client       kubeclientset.Interface
+	planResolver *resolver.Resolver
+}
+
+var _ = scadmission.WantsKubeClientSet(&planSARCheck{})
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&planSARCheck{})
+
+func convertToSARExtra(extra map[string][]string) map[string]authorizationapi.ExtraValue {
+	if extra == nil {
+		return nil
+	}
+
+	ret := map[string]authorizationapi.ExtraValue{}
+	for k, v := range extra {
+		ret[k] = authorizationapi.ExtraValue(v)
+	}
+
+	return ret
+}
+
+func (p *planSARCheck) Admit(a admission.Attributes) error {
+	// need to wait for our caches to warm
+	if !p.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+	// only care about the spec of service instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") || a.GetSubresource() != "" {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+
+	if a.GetOperation() == admission.Update {
+		oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		if instance.Spec.PlanReference == oldInstance.Spec.PlanReference {
+			// the plan has not changed
+			return nil
+		}
+	}
+
+	if instance.Spec.ServiceClassSpecified() && !p.planResolver.ServesNamespacedPlans() {
+		return nil
+	}
+	planAttributes, classAttributes, err := p.getResourceAttributes(instance)
+	if err != nil {
+		glog.V(4).Infof(`ServiceInstance "%s/%s": %v`, instance.Namespace, instance.Name, err)
+		return admission.NewForbidden(a, err)
+	}
+	if planAttributes == nil {
+		return nil
+	}
+
+	userInfo := a.GetUserInfo()
+	allowed, reason, err := p.isAllowed(userInfo, planAttributes)
+	if err != nil {
+		return err
+	}
+	if !allowed && classAttributes != nil {
+		allowed, reason, err = p.isAllowed(userInfo, classAttributes)
+		if err != nil {
+			return err
+		}
+	}
+	if !allowed {
+		return admission.NewForbidden(a, fmt.Errorf("user %q cannot %s %s %q: Reason: %s", userInfo.GetName(), ProvisionVerb, planAttributes.Resource, planAttributes.Name, reason))
+	}
+	return nil
+}
+
+// isAllowed runs a SubjectAccessReview for the given user and resource and
+// returns whether the user is allowed, and the reason if not.
+func (p *planSARCheck) isAllowed(userInfo user.Info, attributes *authorizationapi.ResourceAttributes) (bool, string, error) {
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               userInfo.GetName(),
+			Groups:             userInfo.GetGroups(),
+			Extra:              convertToSARExtra(userInfo.GetExtra()),
+			UID:                userInfo.GetUID(),
+		},
+	}
+	sar, err := p.client.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return false, "", err
+	}
+	if sar.Status.EvaluationError != "" {
+		return sar.Status.Allowed, fmt.Sprintf("%s, EvaluationError: %s", sar.Status.Reason, sar.Status.EvaluationError), nil
+	}
+	return sar.Status.Allowed, sar.Status.Reason, nil
+}
+
+func newResourceAttributes(namespace, resource, name string) *authorizationapi.ResourceAttributes {
+	return &authorizationapi.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      ProvisionVerb,
+		Group:     servicecatalog.GroupName,
+		Resource:  resource,
+		Name:      name,
+	}
+}
+
+// getResourceAttributes returns the attributes of the plan and of the class
+// the instance refers to, or nil if it does not refer to a plan. The class
+// attributes are nil if the plan is specified by its Kubernetes name and is
+// not found.
+func (p *planSARCheck) getResourceAttributes(instance *servicecatalog.ServiceInstance) (*authorizationapi.ResourceAttributes, *authorizationapi.ResourceAttributes, error) {
+	planResource, classResource := "clusterserviceplans", "clusterserviceclasses"
+	if !instance.Spec.ClusterServiceClassSpecified() {
+		if !instance.Spec.ServiceClassSpecified() {
+			return nil, nil, nil
+		}
+		planResource, classResource = "serviceplans", "serviceclasses"
+	}
+
+	// The plan is checked whenever it changes, so the references of the
+	// stored instance are never used.
+	plan, err := p.planResolver.Resolve(instance, nil)
+	if err != nil {
+		if plan.PlanName == "" {
+			return nil, nil, fmt.Errorf("%v, can not check access to it", err)
+		}
+		return newResourceAttributes(plan.Namespace, planResource, plan.PlanName), nil, nil
+	}
+	return newResourceAttributes(plan.Namespace, planResource, plan.PlanName), newResourceAttributes(plan.Namespace, classResource, plan.ClassName), nil
+}
+
+// NewPlanSARCheck creates a new admission control handler that checks
+// whether a user may provision the plan of a Service Instance
+func NewPlanSARCheck() (admission.Interface, error) {
+	return &planSARCheck{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (p *planSARCheck) SetKubeClientSet(client kubeclientset.Interface) {
+	p.client = client
+}
+
+func (p *planSARCheck) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	p.planResolver = resolver.New(f)
+	p.SetReadyFunc(p.planResolver.HasSynced)
+}
+
+func (p *planSARCheck) ValidateInitialization() error {
+	if p.client == nil {
+		return errors.New("missing client")
+	}
+	if p.planResolver == nil {
+		return errors.New("missing plan resolver")
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plansarcheck
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	sptesting "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver/testing"
+)
+
+const testNamespace = "test-ns"
+
+// newHandlerForTest returns a configured handler for testing. Only the
+// "<resource>/<name>" pairs in allowed are allowed by the SAR checks, which
+// are recorded in reviews.
+func newHandlerForTest(t *testing.T, allowed []string, reviews *[]string) admission.Interface {
+	internalClient := fake.NewSimpleClientset(
+		sptesting.NewClusterServiceClass("mysql-id", "mysql"),
+		sptesting.NewClusterServicePlan("mysql-small-id", "small", "mysql-id"),
+		sptesting.NewClusterServicePlan("mysql-premium-id", "premium", "mysql-id"),
+		sptesting.NewServiceClass(testNamespace, "redis-id", "redis"),
+		sptesting.NewServicePlan(testNamespace, "redis-premium-id", "premium", "redis-id"),
+	)
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+		sar := action.(core.CreateAction).GetObject().(*authorizationapi.SubjectAccessReview)
+		attributes := sar.Spec.ResourceAttributes
+		if attributes.Verb != ProvisionVerb || attributes.Group != servicecatalog.GroupName {
+			t.Errorf("unexpected SAR resource attributes: %+v", attributes)
+		}
+		review := attributes.Resource + "/" + attributes.Name
+		*reviews = append(*reviews, review)
+		result := &authorizationapi.SubjectAccessReview{}
+		for _, a := range allowed {
+			if a == review {
+				result.Status.Allowed = true
+			}
+		}
+		return true, result, nil
+	})
+
+	handler, err := NewPlanSARCheck()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	if err := sptesting.InitializeHandler(handler, internalClient, kubeClient); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	return handler
+}
+
+func newServiceInstance(ref servicecatalog.PlanReference) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: ref,
+		},
+	}
+}
+
+func TestAdmissionServiceInstance(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	premiumByExternalName := servicecatalog.PlanReference{
+		ClusterServiceClassExternalName: "mysql",
+		ClusterServicePlanExternalName:  "premium",
+	}
+	smallByExternalName := servicecatalog.PlanReference{
+		ClusterServiceClassExternalName: "mysql",
+		ClusterServicePlanExternalName:  "small",
+	}
+
+	cases := []struct {
+		name        string
+		ref         servicecatalog.PlanReference
+		oldRef      *servicecatalog.PlanReference
+		subresource string
+		allowed     []string
+		reviews     []string
+		err         string
+	}{
+		{
+			name:    "plan allowed",
+			ref:     premiumByExternalName,
+			allowed: []string{"clusterserviceplans/mysql-premium-id"},
+			reviews: []string{"clusterserviceplans/mysql-premium-id"},
+		},
+		{
+			name:    "class allowed",
+			ref:     premiumByExternalName,
+			allowed: []string{"clusterserviceclasses/mysql-id"},
+			reviews: []string{"clusterserviceplans/mysql-premium-id", "clusterserviceclasses/mysql-id"},
+		},
+		{
+			name:    "forbidden",
+			ref:     premiumByExternalName,
+			allowed: []string{"clusterserviceplans/mysql-small-id"},
+			reviews: []string{"clusterserviceplans/mysql-premium-id", "clusterserviceclasses/mysql-id"},
+			err:     `user "user" cannot provision clusterserviceplans "mysql-premium-id"`,
+		},
+		{
+			name: "plan specified by external ID",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassExternalID: "mysql-id",
+				ClusterServicePlanExternalID:  "mysql-premium-id",
+			},
+			allowed: []string{"clusterserviceplans/mysql-premium-id"},
+			reviews: []string{"clusterserviceplans/mysql-premium-id"},
+		},
+		{
+			name: "plan specified by name",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassName: "mysql-id",
+				ClusterServicePlanName:  "mysql-premium-id",
+			},
+			reviews: []string{"clusterserviceplans/mysql-premium-id", "clusterserviceclasses/mysql-id"},
+			err:     `user "user" cannot provision clusterserviceplans "mysql-premium-id"`,
+		},
+		{
+			name: "unknown plan",
+			ref: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "mysql",
+				ClusterServicePlanExternalName:  "gold",
+			},
+			err: "can not check access",
+		},
+		{
+			name: "namespaced plan allowed",
+			ref: servicecatalog.PlanReference{
+				ServiceClassExternalName: "redis",
+				ServicePlanExternalName:  "premium",
+			},
+			allowed: []string{"serviceplans/redis-premium-id"},
+			reviews: []string{"serviceplans/redis-premium-id"},
+		},
+		{
+			name: "namespaced plan forbidden",
+			ref: servicecatalog.PlanReference{
+				ServiceClassExternalName: "redis",
+				ServicePlanExternalName:  "premium",
+			},
+			reviews: []string{"serviceplans/redis-premium-id", "serviceclasses/redis-id"},
+			err:     `user "user" cannot provision serviceplans "redis-premium-id"`,
+		},
+		{
+			name:    "plan change forbidden",
+			ref:     premiumByExternalName,
+			oldRef:  &smallByExternalName,
+			reviews: []string{"clusterserviceplans/mysql-premium-id", "clusterserviceclasses/mysql-id"},
+			err:     `user "user" cannot provision clusterserviceplans "mysql-premium-id"`,
+		},
+		{
+			name:   "update without plan change",
+			ref:    premiumByExternalName,
+			oldRef: &premiumByExternalName,
+		},
+		{
+			name:        "status update",
+			ref:         premiumByExternalName,
+			oldRef:      &smallByExternalName,
+			subresource: "status",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reviews []string
+			handler := newHandlerForTest(t, tc.allowed, &reviews)
+
+			instance := newServiceInstance(tc.ref)
+			var oldInstance runtime.Object
+			operation := admission.Create
+			if tc.oldRef != nil {
+				oldInstance = newServiceInstance(*tc.oldRef)
+				operation = admission.Update
+			}
+			userInfo := &user.DefaultInfo{Name: "user", Groups: []string{"developers"}}
+			err := handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(instance, oldInstance, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), tc.subresource, operation, userInfo))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected an error containing %q", tc.err)
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %q", tc.err, err)
+				}
+			}
+			if !reflect.DeepEqual(reviews, tc.reviews) {
+				t.Fatalf("unexpected SAR checks; expected %v, got %v", tc.reviews, reviews)
+			}
+		})
+	}
+}
+
+// TestAdmissionNamespacedBrokersDisabled checks that instances of namespaced
+// plans are not checked when namespaced brokers are disabled, as their
+// classes and plans are not served.
+func TestAdmissionNamespacedBrokersDisabled(t *testing.T) {
+	var reviews []string
+	handler := newHandlerForTest(t, nil, &reviews)
+
+	instance := newServiceInstance(servicecatalog.PlanReference{
+		ServiceClassExternalName: "redis",
+		ServicePlanExternalName:  "premium",
+	})
+	userInfo := &user.DefaultInfo{Name: "user"}
+	err := handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, userInfo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reviews) != 0 {
+		t.Fatalf("unexpected SAR checks: %v", reviews)
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolver looks up the class and plan of a ServiceInstance for the
+// admission plugins that act on the plan of an instance.
+package resolver
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+// Plan is the class and plan a ServiceInstance refers to. The names are
+// empty if they can not be determined.
+type Plan struct {
+	// Namespace is the namespace of a ServiceClass and ServicePlan, and
+	// empty for a ClusterServiceClass and ClusterServicePlan.
+	Namespace string
+
+	// ClassName and PlanName are the Kubernetes names of the class and plan.
+	ClassName string
+	PlanName  string
+
+	// ClassExternalName and PlanExternalName are the external names of the
+	// class and plan.
+	ClassExternalName string
+	PlanExternalName  string
+
+	// ExternalMetadata is the external metadata of the plan.
+	ExternalMetadata *runtime.RawExtension
+}
+
+// Resolver looks up the class and plan of ServiceInstances in the informer
+// caches.
+type Resolver struct {
+	cscLister internalversion.ClusterServiceClassLister
+	cspLister internalversion.ClusterServicePlanLister
+	scLister  internalversion.ServiceClassLister
+	spLister  internalversion.ServicePlanLister
+	hasSynced func() bool
+}
+
+// New creates a new Resolver that uses the class and plan informers of the
+// given factory. ServiceClasses and ServicePlans are only looked up when
+// namespaced brokers are enabled.
+func New(f informers.SharedInformerFactory) *Resolver {
+	cscInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	cspInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	r := &Resolver{
+		cscLister: cscInformer.Lister(),
+		cspLister: cspInformer.Lister(),
+	}
+
+	namespacedSynced := func() bool { return true }
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		scInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+		spInformer := f.Servicecatalog().InternalVersion().ServicePlans()
+		r.scLister = scInformer.Lister()
+		r.spLister = spInformer.Lister()
+		namespacedSynced = func() bool {
+			return scInformer.Informer().HasSynced() && spInformer.Informer().HasSynced()
+		}
+	}
+
+	r.hasSynced = func() bool {
+		return cscInformer.Informer().HasSynced() && cspInformer.Informer().HasSynced() && namespacedSynced()
+	}
+	return r
+}
+
+// HasSynced returns whether the caches of the resolver have synced.
+func (r *Resolver) HasSynced() bool {
+	return r.hasSynced()
+}
+
+// ServesNamespacedPlans returns whether ServiceClasses and ServicePlans are
+// looked up, which they are only when namespaced brokers are enabled.
+func (r *Resolver) ServesNamespacedPlans() bool {
+	return r.scLister != nil
+}
+
+// Resolve returns the class and plan that the PlanReference of the given
+// instance refers to. The class and plan references of stored, the instance
+// as it is stored, are only used if its PlanReference is the same as that of
+// the instance: the apiserver clears the plan reference when the plan
+// changes only after admission, and the references of a new or updated
+// object are set by the user. stored is nil on create, and the instance
+// itself if it is read from the cache.
+//
+// Resolve returns an error if the class or plan is not found, along with
+// what is known of the plan: the names specified by the user and, if the
+// class was found, its names.
+func (r *Resolver) Resolve(instance, stored *servicecatalog.ServiceInstance) (*Plan, error) {
+	var refs *servicecatalog.ServiceInstanceSpec
+	if stored != nil && stored.Spec.PlanReference == instance.Spec.PlanReference {
+		refs = &stored.Spec
+	}
+	if instance.Spec.ClusterServiceClassSpecified() {
+		return r.resolveCluster(&instance.Spec.PlanReference, refs)
+	}
+	if instance.Spec.ServiceClassSpecified() {
+		return r.resolveNamespaced(instance.Namespace, &instance.Spec.PlanReference, refs)
+	}
+	return &Plan{}, nil
+}
+
+func (r *Resolver) resolveCluster(ref *servicecatalog.PlanReference, refs *servicecatalog.ServiceInstanceSpec) (*Plan, error) {
+	p := &Plan{
+		ClassName:         ref.ClusterServiceClassName,
+		PlanName:          ref.ClusterServicePlanName,
+		ClassExternalName: ref.ClusterServiceClassExternalName,
+		PlanExternalName:  ref.ClusterServicePlanExternalName,
+	}
+	if refs != nil && refs.ClusterServiceClassRef != nil {
+		p.ClassName = refs.ClusterServiceClassRef.Name
+	}
+	if refs != nil && refs.ClusterServicePlanRef != nil {
+		p.PlanName = refs.ClusterServicePlanRef.Name
+	}
+
+	var class *servicecatalog.ClusterServiceClass
+	if p.ClassName != "" {
+		class, _ = r.cscLister.Get(p.ClassName)
+	} else {
+		classes, err := r.cscLister.List(labels.Everything())
+		if err != nil {
+			return p, err
+		}
+		for _, c := range classes {
+			if (ref.ClusterServiceClassExternalName != "" && c.Spec.ExternalName == ref.ClusterServiceClassExternalName) ||
+				(ref.ClusterServiceClassExternalID != "" && c.Spec.ExternalID == ref.ClusterServiceClassExternalID) {
+				class = c
+				break
+			}
+		}
+	}
+	if class == nil {
+		return p, fmt.Errorf("ClusterServiceClass %c not found", ref)
+	}
+	p.ClassName = class.Name
+	p.ClassExternalName = class.Spec.ExternalName
+
+	var plan *servicecatalog.ClusterServicePlan
+	if p.PlanName != "" {
+		plan, _ = r.cspLister.Get(p.PlanName)
+	} else {
+		plans, err := r.cspLister.List(labels.Everything())
+		if err != nil {
+			return p, err
+		}
+		for _, pl := range plans {
+			if pl.Spec.ClusterServiceClassRef.Name != class.Name {
+				continue
+			}
+			if (ref.ClusterServicePlanExternalName != "" && pl.Spec.ExternalName == ref.ClusterServicePlanExternalName) ||
+				(ref.ClusterServicePlanExternalID != "" && pl.Spec.ExternalID == ref.ClusterServicePlanExternalID) {
+				plan = pl
+				break
+			}
+		}
+	}
+	if plan == nil {
+		return p, fmt.Errorf("ClusterServicePlan %c not found", ref)
+	}
+	p.PlanName = plan.Name
+	p.PlanExternalName = plan.Spec.ExternalName
+	p.ExternalMetadata = plan.Spec.ExternalMetadata
+	return p, nil
+}
+
+func (r *Resolver) resolveNamespaced(namespace string, ref *servicecatalog.PlanReference, refs *servicecatalog.ServiceInstanceSpec) (*Plan, error) {
+	p := &Plan{
+		Namespace:         namespace,
+		ClassName:         ref.ServiceClassName,
+		PlanName:          ref.ServicePlanName,
+		ClassExternalName: ref.ServiceClassExternalName,
+		PlanExternalName:  ref.ServicePlanExternalName,
+	}
+	if r.scLister == nil {
+		return p, fmt.Errorf("ServiceClass %c not found, namespaced brokers are not enabled", ref)
+	}
+	if refs != nil && refs.ServiceClassRef != nil {
+		p.ClassName = refs.ServiceClassRef.Name
+	}
+	if refs != nil && refs.ServicePlanRef != nil {
+		p.PlanName = refs.ServicePlanRef.Name
+	}
+
+	scLister := r.scLister.ServiceClasses(namespace)
+	var class *servicecatalog.ServiceClass
+	if p.ClassName != "" {
+		class, _ = scLister.Get(p.ClassName)
+	} else {
+		classes, err := scLister.List(labels.Everything())
+		if err != nil {
+			return p, err
+		}
+		for _, c := range classes {
+			if (ref.ServiceClassExternalName != "" && c.Spec.ExternalName == ref.ServiceClassExternalName) ||
+				(ref.ServiceClassExternalID != "" && c.Spec.ExternalID == ref.ServiceClassExternalID) {
+				class = c
+				break
+			}
+		}
+	}
+	if class == nil {
+		return p, fmt.Errorf("ServiceClass %c not found", ref)
+	}
+	p.ClassName = class.Name
+	p.ClassExternalName = class.Spec.ExternalName
+
+	spLister := r.spLister.ServicePlans(namespace)
+	var plan *servicecatalog.ServicePlan
+	if p.PlanName != "" {
+		plan, _ = spLister.Get(p.PlanName)
+	} else {
+		plans, err := spLister.List(labels.Everything())
+		if err != nil {
+			return p, err
+		}
+		for _, pl := range plans {
+			if pl.Spec.ServiceClassRef.Name != class.Name {
+				continue
+			}
+			if (ref.ServicePlanExternalName != "" && pl.Spec.ExternalName == ref.ServicePlanExternalName) ||
+				(ref.ServicePlanExternalID != "" && pl.Spec.ExternalID == ref.ServicePlanExternalID) {
+				plan = pl
+				break
+			}
+		}
+	}
+	if plan == nil {
+		return p, fmt.Errorf("ServicePlan %c not found", ref)
+	}
+	p.PlanName = plan.Name
+	p.PlanExternalName = plan.Spec.ExternalName
+	p.ExternalMetadata = plan.Spec.ExternalMetadata
+	return p, nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	sptesting "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/resolver/testing"
+)
+
+const testNamespace = "test-ns"
+
+func newResolverForTest(t *testing.T) *Resolver {
+	internalClient := fake.NewSimpleClientset(
+		sptesting.NewClusterServiceClass("mysql-id", "mysql"),
+		sptesting.NewClusterServicePlan("mysql-small-id", "small", "mysql-id"),
+		sptesting.NewClusterServicePlan("mysql-premium-id", "premium", "mysql-id"),
+		sptesting.NewServiceClass(testNamespace, "redis-id", "redis"),
+		sptesting.NewServicePlan(testNamespace, "redis-premium-id", "premium", "redis-id"),
+	)
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	r := New(f)
+	f.Start(wait.NeverStop)
+	if !cache.WaitForCacheSync(wait.NeverStop, r.HasSynced) {
+		t.Fatalf("caches did not sync")
+	}
+	return r
+}
+
+func newServiceInstance(ref servicecatalog.PlanReference) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: ref,
+		},
+	}
+}
+
+// newResolvedServiceInstance returns an instance of the small mysql plan
+// whose references were resolved by the controller.
+func newResolvedServiceInstance() *servicecatalog.ServiceInstance {
+	instance := newServiceInstance(servicecatalog.PlanReference{
+		ClusterServiceClassExternalName: "mysql",
+		ClusterServicePlanExternalName:  "small",
+	})
+	instance.Spec.ClusterServiceClassRef = &servicecatalog.ClusterObjectReference{Name: "mysql-id"}
+	instance.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: "mysql-small-id"}
+	return instance
+}
+
+func TestResolve(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	premium := &Plan{
+		ClassName:         "mysql-id",
+		PlanName:          "mysql-premium-id",
+		ClassExternalName: "mysql",
+		PlanExternalName:  "premium",
+	}
+	small := &Plan{
+		ClassName:         "mysql-id",
+		PlanName:          "mysql-small-id",
+		ClassExternalName: "mysql",
+		PlanExternalName:  "small",
+	}
+
+	// planChange is a resolved instance whose plan the user changed to the
+	// premium plan, which keeps the references of the small plan
+	planChange := newResolvedServiceInstance()
+	planChange.Spec.ClusterServicePlanExternalName = "premium"
+
+	// staleRefs is an instance of the small plan with the references of
+	// the premium plan set by the user
+	staleRefs := newResolvedServiceInstance()
+	staleRefs.Spec.ClusterServicePlanRef = &servicecatalog.ClusterObjectReference{Name: "mysql-premium-id"}
+
+	cases := []struct {
+		name     string
+		instance *servicecatalog.ServiceInstance
+		stored   *servicecatalog.ServiceInstance
+		expected *Plan
+		err      string
+	}{
+		{
+			name: "external names",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "mysql",
+				ClusterServicePlanExternalName:  "premium",
+			}),
+			expected: premium,
+		},
+		{
+			name: "external IDs",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ClusterServiceClassExternalID: "mysql-id",
+				ClusterServicePlanExternalID:  "mysql-premium-id",
+			}),
+			expected: premium,
+		},
+		{
+			name: "names",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ClusterServiceClassName: "mysql-id",
+				ClusterServicePlanName:  "mysql-premium-id",
+			}),
+			expected: premium,
+		},
+		{
+			name:     "references of the stored instance",
+			instance: newResolvedServiceInstance(),
+			stored:   newResolvedServiceInstance(),
+			expected: small,
+		},
+		{
+			name:     "references ignored on plan change",
+			instance: planChange,
+			stored:   newResolvedServiceInstance(),
+			expected: premium,
+		},
+		{
+			name:     "references of the instance ignored",
+			instance: staleRefs,
+			expected: small,
+		},
+		{
+			name: "unknown plan",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "mysql",
+				ClusterServicePlanExternalName:  "gold",
+			}),
+			expected: &Plan{
+				ClassName:         "mysql-id",
+				ClassExternalName: "mysql",
+				PlanExternalName:  "gold",
+			},
+			err: "ClusterServicePlan",
+		},
+		{
+			name: "unknown class",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "postgres",
+				ClusterServicePlanExternalName:  "small",
+			}),
+			expected: &Plan{
+				ClassExternalName: "postgres",
+				PlanExternalName:  "small",
+			},
+			err: "ClusterServiceClass",
+		},
+		{
+			name: "namespaced plan",
+			instance: newServiceInstance(servicecatalog.PlanReference{
+				ServiceClassExternalName: "redis",
+				ServicePlanExternalName:  "premium",
+			}),
+			expected: &Plan{
+				Namespace:         testNamespace,
+				ClassName:         "redis-id",
+				PlanName:          "redis-premium-id",
+				ClassExternalName: "redis",
+				PlanExternalName:  "premium",
+			},
+		},
+		{
+			name:     "no plan",
+			instance: newServiceInstance(servicecatalog.PlanReference{}),
+			expected: &Plan{},
+		},
+	}
+
+	r := newResolverForTest(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := r.Resolve(tc.instance, tc.stored)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+			}
+			if *plan != *tc.expected {
+				t.Fatalf("unexpected plan; expected %+v, got %+v", tc.expected, plan)
+			}
+		})
+	}
+}
+
+func TestResolveNamespacedBrokersDisabled(t *testing.T) {
+	r := newResolverForTest(t)
+	if r.ServesNamespacedPlans() {
+		t.Fatalf("expected namespaced plans not to be served")
+	}
+
+	plan, err := r.Resolve(newServiceInstance(servicecatalog.PlanReference{
+		ServiceClassExternalName: "redis",
+		ServicePlanExternalName:  "premium",
+	}), nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	expected := Plan{Namespace: testNamespace, ClassExternalName: "redis", PlanExternalName: "premium"}
+	if *plan != expected {
+		t.Fatalf("unexpected plan; expected %+v, got %+v", expected, *plan)
+	}
+}
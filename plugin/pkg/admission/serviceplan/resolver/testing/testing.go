@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing has the fixtures shared by the tests of the admission
+// plugins that look up the plan of a ServiceInstance.
+package testing
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	kubeinformers "k8s.io/client-go/informers"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+// InitializeHandler initializes the given handler with informers of the
+// given clients, validates its initialization and starts the informers.
+func InitializeHandler(handler admission.Interface, internalClient internalclientset.Interface, kubeClient kubeclientset.Interface) error {
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	kf := kubeinformers.NewSharedInformerFactory(kubeClient, 5*time.Minute)
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubeClient, kf)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		return err
+	}
+	f.Start(wait.NeverStop)
+	kf.Start(wait.NeverStop)
+	return nil
+}
+
+// NewClusterServiceClass returns a ClusterServiceClass with the given
+// external name, whose external ID is its name.
+func NewClusterServiceClass(name, externalName string) *servicecatalog.ClusterServiceClass {
+	return &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: externalName,
+				ExternalID:   name,
+			},
+		},
+	}
+}
+
+// NewClusterServicePlan returns a ClusterServicePlan of the named class with
+// the given external name, whose external ID is its name.
+func NewClusterServicePlan(name, externalName, className string) *servicecatalog.ClusterServicePlan {
+	return &servicecatalog.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: servicecatalog.ClusterServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName: externalName,
+				ExternalID:   name,
+			},
+			ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: className},
+		},
+	}
+}
+
+// NewServiceClass returns a ServiceClass in the given namespace with the
+// given external name, whose external ID is its name.
+func NewServiceClass(namespace, name, externalName string) *servicecatalog.ServiceClass {
+	return &servicecatalog.ServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: servicecatalog.ServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: externalName,
+				ExternalID:   name,
+			},
+		},
+	}
+}
+
+// NewServicePlan returns a ServicePlan of the named class in the given
+// namespace with the given external name, whose external ID is its name.
+func NewServicePlan(namespace, name, externalName, className string) *servicecatalog.ServicePlan {
+	return &servicecatalog.ServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: servicecatalog.ServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName: externalName,
+				ExternalID:   name,
+			},
+			ServiceClassRef: servicecatalog.LocalObjectReference{Name: className},
+		},
+	}
+}
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	restclient "k8s.io/client-go/rest"
 	clientgotesting "k8s.io/client-go/testing"
@@ -762,6 +763,10 @@ func newControllerTestTestController(ct *controllerTest) (
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(fakeKubeClient, 0)
+	kubeInformers := kubeInformerFactory.Core().V1()
+	kubeAppsInformers := kubeInformerFactory.Apps().V1()
+
 	// WARNING: Should you try to record more events than the buffer size
 	// passed here, the recording function will hang indefinitely.
 	fakeRecorder := record.NewFakeRecorder(50)
@@ -778,12 +783,19 @@ func newControllerTestTestController(ct *controllerTest) (
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceGrants(),
+		kubeInformers.Secrets(),
+		kubeInformers.ConfigMaps(),
+		kubeAppsInformers.Deployments(),
+		kubeAppsInformers.StatefulSets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		nil,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)
@@ -922,6 +934,10 @@ func newTestController(t *testing.T) (
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(fakeKubeClient, 0)
+	kubeInformers := kubeInformerFactory.Core().V1()
+	kubeAppsInformers := kubeInformerFactory.Apps().V1()
+
 	// WARNING: Should you try to record more events than the buffer size
 	// passed here, the recording function will hang indefinitely.
 	fakeRecorder := record.NewFakeRecorder(50)
@@ -938,12 +954,19 @@ func newTestController(t *testing.T) (
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceGrants(),
+		kubeInformers.Secrets(),
+		kubeInformers.ConfigMaps(),
+		kubeAppsInformers.Deployments(),
+		kubeAppsInformers.StatefulSets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		nil,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)